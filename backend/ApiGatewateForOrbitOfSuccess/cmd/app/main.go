@@ -8,6 +8,7 @@ import (
 
 	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/app"
 	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/config"
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/lib/tracing"
 )
 
 const (
@@ -51,5 +52,6 @@ func setupLogger(env string) *slog.Logger {
 		)
 	}
 
-	return log
+	// Records logged with a context get its trace and span ids
+	return slog.New(tracing.NewLogHandler(log.Handler()))
 }
@@ -15,7 +15,70 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/activate_account": {
+        "/v1/auth/accounts/{id}/role": {
+            "put": {
+                "description": "Change the role of the account. Only admins may do it, not on their own account. The role comes into effect with the next access token of the account.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Set account role",
+                "operationId": "Set account role",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "account id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "role",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.SetRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/authv1.SetRoleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/v1/auth/activate_account": {
             "post": {
                 "description": "Activate account",
                 "consumes": [
@@ -61,7 +124,135 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/change_password": {
+        "/v1/auth/api_tokens": {
+            "get": {
+                "description": "List personal API tokens without their secrets",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "List API tokens",
+                "operationId": "List API tokens",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/authv1.ListAPITokensResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            },
+            "post": {
+                "description": "Create personal API token. The secret is returned only once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Create API token",
+                "operationId": "Create API token",
+                "parameters": [
+                    {
+                        "description": "token",
+                        "name": "token",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/entities.CreateAPITokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/authv1.CreateAPITokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/v1/auth/api_tokens/{id}": {
+            "delete": {
+                "description": "Revoke personal API token",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Revoke API token",
+                "operationId": "Revoke API token",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "token id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/authv1.RevokeAPITokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/v1/auth/change_password": {
             "post": {
                 "description": "Change password",
                 "consumes": [
@@ -110,7 +301,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/login": {
+        "/v1/auth/login": {
             "post": {
                 "description": "Login",
                 "consumes": [
@@ -159,7 +350,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/logout": {
+        "/v1/auth/logout": {
             "post": {
                 "description": "Logout",
                 "consumes": [
@@ -205,7 +396,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/refresh": {
+        "/v1/auth/refresh": {
             "post": {
                 "description": "Refresh token",
                 "consumes": [
@@ -254,7 +445,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/register": {
+        "/v1/auth/register": {
             "post": {
                 "description": "Register",
                 "consumes": [
@@ -300,7 +491,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/send_password_link": {
+        "/v1/auth/send_password_link": {
             "post": {
                 "description": "Send password link",
                 "consumes": [
@@ -349,9 +540,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/docs/create": {
+        "/v1/docs/create": {
             "post": {
-                "description": "Create",
+                "description": "Create. Refused with 409 and the list of similar themes unless force is set.\nEnforced workload limits refuse it with 409 and conflicts, otherwise they come as workload_warnings.",
                 "consumes": [
                     "application/json"
                 ],
@@ -386,6 +577,12 @@ const docTemplate = `{
                     "404": {
                         "description": "Not Found"
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/entities.SimilarThemesResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     },
@@ -395,7 +592,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/docs/delete": {
+        "/v1/docs/delete": {
             "post": {
                 "description": "Delete",
                 "consumes": [
@@ -441,7 +638,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/docs/filtered": {
+        "/v1/docs/filtered": {
             "post": {
                 "description": "Get filtererd",
                 "consumes": [
@@ -463,6 +660,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/entities.GetFilteredRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the response the client has",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -472,6 +675,9 @@ const docTemplate = `{
                             "$ref": "#/definitions/entities.GetResponse"
                         }
                     },
+                    "304": {
+                        "description": "not modified"
+                    },
                     "400": {
                         "description": "Bad Request"
                     },
@@ -487,7 +693,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/docs/search": {
+        "/v1/docs/search": {
             "post": {
                 "description": "Search",
                 "consumes": [
@@ -509,6 +715,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/entities.SearchRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the response the client has",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -518,6 +730,9 @@ const docTemplate = `{
                             "$ref": "#/definitions/entities.GetResponse"
                         }
                     },
+                    "304": {
+                        "description": "not modified"
+                    },
                     "400": {
                         "description": "Bad Request"
                     },
@@ -533,9 +748,150 @@ const docTemplate = `{
                 }
             }
         },
-        "/docs/update": {
+        "/v1/docs/similar": {
+            "post": {
+                "description": "Find docs whose themes share most words with the given one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Docs"
+                ],
+                "summary": "Similar themes",
+                "operationId": "Similar themes",
+                "parameters": [
+                    {
+                        "description": "theme",
+                        "name": "similar",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.FindSimilarThemesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.FindSimilarThemesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/v1/docs/stats": {
+            "get": {
+                "description": "Count docs grouped by year, type, group, director, reviewer or discipline.\nWith top only the largest groups are returned, with compare the year before is counted too.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Docs"
+                ],
+                "summary": "Stats",
+                "operationId": "Stats",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Also count the year before the given one",
+                        "name": "compare",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "director",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "discipline",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "name": "group_by",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "reviewer",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 0,
+                        "type": "integer",
+                        "description": "Only the largest groups, 0 means all",
+                        "name": "top",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "year",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Stats"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/v1/docs/update": {
             "post": {
-                "description": "Update",
+                "description": "Update the fields present in the body. The version of the doc is required, a stale one is refused with 409.",
                 "consumes": [
                     "application/json"
                 ],
@@ -561,7 +917,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entities.SuccessResponse"
+                            "$ref": "#/definitions/entities.UpdateResponse"
                         }
                     },
                     "400": {
@@ -570,6 +926,9 @@ const docTemplate = `{
                     "404": {
                         "description": "Not Found"
                     },
+                    "409": {
+                        "description": "Conflict"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     },
@@ -578,188 +937,3341 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "authv1.ActivateAccountResponse": {
-            "type": "object",
-            "properties": {
-                "success": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "authv1.ChangePasswordResponse": {
-            "type": "object",
-            "properties": {
-                "success": {
-                    "type": "boolean"
-                }
-            }
         },
-        "authv1.LoginResponse": {
+        "/v2/commissions": {
+            "get": {
+                "description": "List defense commissions with their members",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule v2"
+                ],
+                "summary": "List commissions",
+                "operationId": "List commissions",
+                "parameters": [
+                    {
+                        "maximum": 2100,
+                        "minimum": 2000,
+                        "type": "integer",
+                        "name": "year",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ListCommissionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            },
+            "post": {
+                "description": "Create defense commission. It must have one chair and at most one secretary.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule v2"
+                ],
+                "summary": "Create commission",
+                "operationId": "Create commission",
+                "parameters": [
+                    {
+                        "description": "commission",
+                        "name": "commission",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.CreateCommissionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Commission"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/v2/docs": {
+            "get": {
+                "description": "List docs matching query-string filters",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Docs v2"
+                ],
+                "summary": "List docs",
+                "operationId": "List docs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "director",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "discipline",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "fio",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name of a participant in any role",
+                        "name": "participant",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "reviewer",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "theme",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the response the client has",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ListDocsResponse"
+                        }
+                    },
+                    "304": {
+                        "description": "not modified"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            },
+            "post": {
+                "description": "Create doc. Location header points to the new doc.\nRefused with 409 and the list of similar themes unless force is set.\nExceeded workload limits of the supervisors, co-supervisors and reviewers refuse it with 409 and conflicts\nwhen enforced, otherwise they are returned in workload_warnings.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Docs v2"
+                ],
+                "summary": "Create doc",
+                "operationId": "Create doc",
+                "parameters": [
+                    {
+                        "description": "doc",
+                        "name": "doc",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.CreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entities.SavedDocResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/entities.SimilarThemesResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/v2/docs/reviewer-assignments": {
+            "get": {
+                "description": "Propose a reviewer for every doc of the year without one, spreading the workload.\nNothing is saved, apply the proposals by patching the docs.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Docs v2"
+                ],
+                "summary": "Suggest reviewer assignments",
+                "operationId": "Suggest reviewer assignments",
+                "parameters": [
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "description": "The current year by default",
+                        "name": "year",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.SuggestReviewerAssignmentsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/v2/docs/search": {
+            "get": {
+                "description": "Search the doc fields (metadata), the text of attached PDF, DOCX and ODT files (content) or both.\nContent matches tell the page or paragraph of the file they were found in.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Docs v2"
+                ],
+                "summary": "Search docs",
+                "operationId": "Search docs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "metadata",
+                            "content",
+                            "both"
+                        ],
+                        "type": "string",
+                        "name": "scope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the response the client has",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.SearchDocsResponse"
+                        }
+                    },
+                    "304": {
+                        "description": "not modified"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/v2/docs/similar": {
+            "get": {
+                "description": "Find docs whose themes share most words with the given one",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Docs v2"
+                ],
+                "summary": "Similar themes",
+                "operationId": "Similar themes v2",
+                "parameters": [
+                    {
+                        "maximum": 50,
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "maxLength": 250,
+                        "type": "string",
+                        "name": "theme",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maximum": 1,
+                        "minimum": 0,
+                        "type": "number",
+                        "name": "threshold",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.FindSimilarThemesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/v2/docs/{id}": {
+            "get": {
+                "description": "Get doc by id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Docs v2"
+                ],
+                "summary": "Get doc",
+                "operationId": "Get doc",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "doc id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the response the client has",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Doc"
+                        }
+                    },
+                    "304": {
+                        "description": "not modified"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete doc by id",
+                "tags": [
+                    "Docs v2"
+                ],
+                "summary": "Delete doc",
+                "operationId": "Delete doc",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "doc id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            },
+            "patch": {
+                "description": "Change only the fields present in the body. If-Match must hold the version from ETag.\nA new supervisor, co-supervisor, reviewer or year is checked against the workload limits like on create.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Docs v2"
+                ],
+                "summary": "Patch doc",
+                "operationId": "Patch doc",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "doc id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "doc version",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "changed fields",
+                        "name": "doc",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.PatchDocRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.SavedDocResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "428": {
+                        "description": "Precondition Required"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/v2/docs/{id}/assessment": {
+            "get": {
+                "description": "Review, supervisor feedback and defense protocol submitted so far. Missing parts are null.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Docs v2"
+                ],
+                "summary": "Get doc assessment",
+                "operationId": "Get doc assessment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "doc id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Assessment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/v2/docs/{id}/attachments": {
+            "get": {
+                "description": "Files attached to the doc with the state of their text indexing",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Docs v2"
+                ],
+                "summary": "List attachments",
+                "operationId": "List attachments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "doc id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.GetAttachmentsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            },
+            "post": {
+                "description": "Attach a file to the doc. Text of PDF, DOCX and ODT files is indexed for content search.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Docs v2"
+                ],
+                "summary": "Upload attachment",
+                "operationId": "Upload attachment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "doc id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "file, up to 20 MiB",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Attachment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/v2/docs/{id}/attachments/{attachment_id}": {
+            "get": {
+                "description": "Download the attached file",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Docs v2"
+                ],
+                "summary": "Get attachment file",
+                "operationId": "Get attachment file",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "doc id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "attachment id",
+                        "name": "attachment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete the attached file with its indexed text. Allowed to the uploader, secretaries and admins.",
+                "tags": [
+                    "Docs v2"
+                ],
+                "summary": "Delete attachment",
+                "operationId": "Delete attachment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "doc id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "attachment id",
+                        "name": "attachment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/v2/docs/{id}/feedback": {
+            "put": {
+                "description": "Submit the supervisor's feedback. Only the accounts linked to the doc's supervisors may do it, as well as secretaries and admins.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Docs v2"
+                ],
+                "summary": "Submit supervisor feedback",
+                "operationId": "Submit supervisor feedback",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "doc id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "feedback",
+                        "name": "feedback",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.SubmitFeedbackRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Feedback"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/v2/docs/{id}/protocol": {
+            "put": {
+                "description": "Submit the commission's final grade. Only the accounts linked to the members of the commission the doc is defended before may do it, as well as secretaries and admins.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Docs v2"
+                ],
+                "summary": "Submit defense protocol",
+                "operationId": "Submit defense protocol",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "doc id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "protocol",
+                        "name": "protocol",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.SubmitProtocolRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Protocol"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/v2/docs/{id}/protocol.pdf": {
+            "get": {
+                "description": "Protocol of defense filled from the doc, its review, feedback and the commission",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Docs v2"
+                ],
+                "summary": "Get defense protocol PDF",
+                "operationId": "Get defense protocol PDF",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "doc id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/v2/docs/{id}/review": {
+            "put": {
+                "description": "Submit the reviewer's grade with a text, a file or both. Only the account linked to the doc's reviewer may do it, as well as secretaries and admins.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Docs v2"
+                ],
+                "summary": "Submit review",
+                "operationId": "Submit review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "doc id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "grade 2..5",
+                        "name": "grade",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "review text",
+                        "name": "text",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "review file, up to 3 MiB",
+                        "name": "file",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Review"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/v2/docs/{id}/review/file": {
+            "get": {
+                "description": "Download the file attached to the review",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Docs v2"
+                ],
+                "summary": "Get review file",
+                "operationId": "Get review file",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "doc id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/v2/docs/{id}/reviewer-suggestions": {
+            "get": {
+                "description": "Rank candidate reviewers of the doc among people who reviewed docs before.\nDiscipline and theme matches raise the score, the workload of the doc's year lowers it.\nThe supervisor and former supervisors of the student are left out.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Docs v2"
+                ],
+                "summary": "Suggest reviewers",
+                "operationId": "Suggest reviewers",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "doc id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maximum": 50,
+                        "minimum": 0,
+                        "type": "integer",
+                        "description": "0 means the default of 5",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.SuggestReviewersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/v2/docs/{id}/transitions": {
+            "get": {
+                "description": "Status history of the doc, oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Docs v2"
+                ],
+                "summary": "List doc transitions",
+                "operationId": "List doc transitions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "doc id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ListTransitionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            },
+            "post": {
+                "description": "Move doc to another status. Allowed moves depend on the current status. Students and supervisors may only move docs whose participants are linked to their account.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Docs v2"
+                ],
+                "summary": "Transition doc",
+                "operationId": "Transition doc",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "doc id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "transition",
+                        "name": "transition",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.TransitionDocRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.TransitionDocResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/v2/orders": {
+            "get": {
+                "description": "Orders of the academic year, of one type or covering one doc, by date",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders v2"
+                ],
+                "summary": "List orders",
+                "operationId": "List orders",
+                "parameters": [
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "doc_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "theme_approval",
+                            "reviewer_assignment",
+                            "commission"
+                        ],
+                        "type": "string",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "year",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ListOrdersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            },
+            "post": {
+                "description": "Record a university order. The signed file is optional. Secretaries and admins only.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders v2"
+                ],
+                "summary": "Create order",
+                "operationId": "Create order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "date",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "maxLength": 100,
+                        "type": "string",
+                        "name": "number",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "maxLength": 500,
+                        "type": "string",
+                        "name": "title",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "theme_approval",
+                            "reviewer_assignment",
+                            "commission"
+                        ],
+                        "type": "string",
+                        "name": "type",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Academic year of the docs the order covers",
+                        "name": "year",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "signed order, up to 20 MiB",
+                        "name": "file",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/v2/orders/{id}": {
+            "get": {
+                "description": "Get the order with the docs it covers",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders v2"
+                ],
+                "summary": "Get order",
+                "operationId": "Get order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "order id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/v2/orders/{id}/docs": {
+            "post": {
+                "description": "Link docs of the order's year to it in one go. Nothing is linked if any doc is missing or of another year.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders v2"
+                ],
+                "summary": "Attach docs to order",
+                "operationId": "Attach docs to order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "order id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "doc ids",
+                        "name": "docs",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.OrderDocsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.OrderDocsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            },
+            "delete": {
+                "description": "Unlink the docs from the order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders v2"
+                ],
+                "summary": "Detach docs from order",
+                "operationId": "Detach docs from order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "order id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "doc ids",
+                        "name": "docs",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.OrderDocsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.OrderDocsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/v2/orders/{id}/file": {
+            "get": {
+                "description": "Download the signed order",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Orders v2"
+                ],
+                "summary": "Get order file",
+                "operationId": "Get order file",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "order id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/v2/sessions": {
+            "get": {
+                "description": "List defense sessions overlapping the period",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule v2"
+                ],
+                "summary": "List sessions",
+                "operationId": "List sessions",
+                "parameters": [
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ListSessionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            },
+            "post": {
+                "description": "Create defense session split into ordered slots. Conflicting room or commission bookings are returned with 409.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule v2"
+                ],
+                "summary": "Create session",
+                "operationId": "Create session",
+                "parameters": [
+                    {
+                        "description": "session",
+                        "name": "session",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.CreateSessionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Session"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/v2/sessions/{id}": {
+            "get": {
+                "description": "Get defense session with its commission and slots",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule v2"
+                ],
+                "summary": "Get session",
+                "operationId": "Get session",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "session id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Session"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete defense session with its slots",
+                "tags": [
+                    "Schedule v2"
+                ],
+                "summary": "Delete session",
+                "operationId": "Delete session",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "session id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/v2/sessions/{id}/calendar.ics": {
+            "get": {
+                "description": "iCalendar feed of the session: the session itself and every assigned slot",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "Schedule v2"
+                ],
+                "summary": "Session calendar",
+                "operationId": "Session calendar",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "session id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/v2/slots/{id}": {
+            "put": {
+                "description": "Put the doc into the slot, zero doc_id frees it. Supervisors, co-supervisors or reviewer sitting on another commission at that time are returned with 409.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule v2"
+                ],
+                "summary": "Assign slot",
+                "operationId": "Assign slot",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "slot id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "doc",
+                        "name": "slot",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.AssignSlotRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Slot"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/v2/workload/{person}": {
+            "get": {
+                "description": "Count the docs of the year the person supervises, co-supervises and reviews against the limits",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workload v2"
+                ],
+                "summary": "Get workload",
+                "operationId": "Get workload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "supervisor or reviewer name",
+                        "name": "person",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "year",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.GetWorkloadResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/v2/workload/{person}/{role}": {
+            "put": {
+                "description": "Set the personal limit of docs a year in the role, 0 means no limit. Secretaries and admins only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workload v2"
+                ],
+                "summary": "Set workload limit",
+                "operationId": "Set workload limit",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "supervisor or reviewer name",
+                        "name": "person",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "supervisor or reviewer",
+                        "name": "role",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.SetWorkloadLimitRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove the personal limit, so the configured one applies. Secretaries and admins only.",
+                "tags": [
+                    "Workload v2"
+                ],
+                "summary": "Reset workload limit",
+                "operationId": "Reset workload limit",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "supervisor or reviewer name",
+                        "name": "person",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "supervisor or reviewer",
+                        "name": "role",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "authv1.APIToken": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "integer"
+                },
+                "expires_at": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_used_at": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "authv1.ActivateAccountResponse": {
+            "type": "object",
+            "properties": {
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "authv1.ChangePasswordResponse": {
+            "type": "object",
+            "properties": {
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "authv1.CreateAPITokenResponse": {
+            "type": "object",
+            "properties": {
+                "secret": {
+                    "type": "string"
+                },
+                "token": {
+                    "$ref": "#/definitions/authv1.APIToken"
+                }
+            }
+        },
+        "authv1.ListAPITokensResponse": {
+            "type": "object",
+            "properties": {
+                "tokens": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/authv1.APIToken"
+                    }
+                }
+            }
+        },
+        "authv1.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "authv1.LogoutResponse": {
+            "type": "object",
+            "properties": {
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "authv1.RefreshResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "authv1.RegisterResponse": {
+            "type": "object",
+            "properties": {
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "authv1.RevokeAPITokenResponse": {
+            "type": "object",
+            "properties": {
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "authv1.SendPasswordLinkResponse": {
+            "type": "object",
+            "properties": {
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "authv1.SetRoleResponse": {
+            "type": "object",
+            "properties": {
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "common.Conflict": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "subject": {
+                    "type": "string"
+                }
+            }
+        },
+        "common.FieldViolation": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "rule": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.ActivateAccountRequest": {
+            "type": "object",
+            "required": [
+                "link"
+            ],
+            "properties": {
+                "link": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.Assessment": {
+            "type": "object",
+            "properties": {
+                "feedback": {
+                    "$ref": "#/definitions/entities.Feedback"
+                },
+                "protocol": {
+                    "$ref": "#/definitions/entities.Protocol"
+                },
+                "review": {
+                    "$ref": "#/definitions/entities.Review"
+                }
+            }
+        },
+        "entities.AssignSlotRequest": {
+            "type": "object",
+            "properties": {
+                "doc_id": {
+                    "description": "Zero frees the slot",
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "entities.Attachment": {
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "type": "integer"
+                },
+                "author_name": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "integer"
+                },
+                "doc_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parts": {
+                    "description": "indexed pages or paragraphs",
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "text_error": {
+                    "type": "string"
+                },
+                "text_status": {
+                    "description": "indexed, unsupported or failed",
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.ChangePasswordRequest": {
+            "type": "object",
+            "required": [
+                "link",
+                "password"
+            ],
+            "properties": {
+                "link": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.Commission": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.CommissionMember"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.CommissionMember": {
+            "type": "object",
+            "required": [
+                "name",
+                "role"
+            ],
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 250
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "chair",
+                        "secretary",
+                        "member"
+                    ]
+                },
+                "user_id": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "entities.ContentMatch": {
+            "type": "object",
+            "properties": {
+                "attachment_id": {
+                    "type": "integer"
+                },
+                "doc_id": {
+                    "type": "integer"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "snippet": {
+                    "description": "matched words are wrapped in \u003cb\u003e\u003c/b\u003e",
+                    "type": "string"
+                },
+                "unit": {
+                    "description": "page or paragraph",
+                    "type": "string"
+                }
+            }
+        },
+        "entities.CreateAPITokenRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "expires_at": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 250
+                },
+                "scopes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "entities.CreateCommissionRequest": {
+            "type": "object",
+            "required": [
+                "members",
+                "name",
+                "year"
+            ],
+            "properties": {
+                "members": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/entities.CommissionMember"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 250
+                },
+                "year": {
+                    "type": "integer",
+                    "maximum": 2100,
+                    "minimum": 2000
+                }
+            }
+        },
+        "entities.CreateRequest": {
+            "type": "object",
+            "properties": {
+                "director": {
+                    "type": "string"
+                },
+                "discipline": {
+                    "type": "string"
+                },
+                "fio": {
+                    "type": "string"
+                },
+                "force": {
+                    "description": "Create even if docs with similar themes exist",
+                    "type": "boolean"
+                },
+                "group": {
+                    "type": "string"
+                },
+                "order": {
+                    "type": "string"
+                },
+                "participants": {
+                    "description": "Overrides fio, director and reviewer when set, ordered within a role",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.Participant"
+                    }
+                },
+                "reviewer": {
+                    "type": "string"
+                },
+                "theme": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.CreateSessionRequest": {
             "type": "object",
+            "required": [
+                "commission_id",
+                "room",
+                "slot_minutes",
+                "slots_count",
+                "starts_at"
+            ],
             "properties": {
-                "access_token": {
+                "commission_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "room": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "slot_minutes": {
+                    "type": "integer",
+                    "maximum": 240,
+                    "minimum": 5
+                },
+                "slots_count": {
+                    "type": "integer",
+                    "maximum": 50,
+                    "minimum": 1
+                },
+                "starts_at": {
+                    "description": "Unix seconds",
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "entities.DeleteRequest": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.Doc": {
+            "type": "object",
+            "properties": {
+                "defense_date": {
+                    "type": "string"
+                },
+                "director": {
+                    "type": "string"
+                },
+                "discipline": {
+                    "type": "string"
+                },
+                "fio": {
+                    "type": "string"
+                },
+                "grade": {
+                    "type": "integer"
+                },
+                "group": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "order": {
+                    "type": "string"
+                },
+                "participants": {
+                    "description": "fio, director and reviewer hold the first student, supervisor and reviewer",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.Participant"
+                    }
+                },
+                "reviewer": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "theme": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "integer"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.Feedback": {
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "type": "integer"
+                },
+                "author_name": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "integer"
+                },
+                "doc_id": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.FindSimilarThemesRequest": {
+            "type": "object",
+            "required": [
+                "theme"
+            ],
+            "properties": {
+                "limit": {
+                    "type": "integer",
+                    "maximum": 50,
+                    "minimum": 1
+                },
+                "theme": {
+                    "type": "string",
+                    "maxLength": 250
+                },
+                "threshold": {
+                    "type": "number",
+                    "maximum": 1,
+                    "minimum": 0
+                }
+            }
+        },
+        "entities.FindSimilarThemesResponse": {
+            "type": "object",
+            "properties": {
+                "similar_themes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.SimilarTheme"
+                    }
+                }
+            }
+        },
+        "entities.GetAttachmentsResponse": {
+            "type": "object",
+            "properties": {
+                "attachments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.Attachment"
+                    }
+                }
+            }
+        },
+        "entities.GetFilteredRequest": {
+            "type": "object",
+            "properties": {
+                "director": {
+                    "type": "string"
+                },
+                "discipline": {
+                    "type": "string"
+                },
+                "fio": {
+                    "type": "string"
+                },
+                "group": {
+                    "type": "string"
+                },
+                "order": {
+                    "type": "string"
+                },
+                "participant": {
+                    "description": "Name of a participant in any role",
+                    "type": "string"
+                },
+                "reviewer": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "theme": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.GetResponse": {
+            "type": "object",
+            "properties": {
+                "docs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.Doc"
+                    }
+                },
+                "matches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.ContentMatch"
+                    }
+                }
+            }
+        },
+        "entities.GetWorkloadResponse": {
+            "type": "object",
+            "properties": {
+                "workload": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.Workload"
+                    }
+                }
+            }
+        },
+        "entities.ListCommissionsResponse": {
+            "type": "object",
+            "properties": {
+                "commissions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.Commission"
+                    }
+                }
+            }
+        },
+        "entities.ListDocsResponse": {
+            "type": "object",
+            "properties": {
+                "docs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.Doc"
+                    }
+                }
+            }
+        },
+        "entities.ListOrdersResponse": {
+            "type": "object",
+            "properties": {
+                "orders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.Order"
+                    }
+                }
+            }
+        },
+        "entities.ListSessionsResponse": {
+            "type": "object",
+            "properties": {
+                "sessions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.Session"
+                    }
+                }
+            }
+        },
+        "entities.ListTransitionsResponse": {
+            "type": "object",
+            "properties": {
+                "transitions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.Transition"
+                    }
+                }
+            }
+        },
+        "entities.LoginRequest": {
+            "type": "object",
+            "required": [
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.LogoutRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.Order": {
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "type": "integer"
+                },
+                "author_name": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "docs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.Doc"
+                    }
+                },
+                "docs_count": {
+                    "description": "Docs are listed only for a single order",
+                    "type": "integer"
+                },
+                "file_name": {
                     "type": "string"
                 },
-                "refresh_token": {
+                "file_size": {
+                    "type": "integer"
+                },
+                "file_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "number": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "theme_approval, reviewer_assignment or commission",
                     "type": "string"
+                },
+                "year": {
+                    "type": "integer"
                 }
             }
         },
-        "authv1.LogoutResponse": {
+        "entities.OrderDocsRequest": {
             "type": "object",
+            "required": [
+                "doc_ids"
+            ],
             "properties": {
-                "success": {
-                    "type": "boolean"
+                "doc_ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
                 }
             }
         },
-        "authv1.RefreshResponse": {
+        "entities.OrderDocsResponse": {
             "type": "object",
             "properties": {
-                "access_token": {
-                    "type": "string"
+                "changed": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.Participant": {
+            "type": "object",
+            "required": [
+                "name",
+                "role"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 250
                 },
-                "refresh_token": {
-                    "type": "string"
+                "position": {
+                    "type": "integer"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "student",
+                        "supervisor",
+                        "co_supervisor",
+                        "consultant",
+                        "reviewer"
+                    ]
+                },
+                "user_id": {
+                    "type": "integer",
+                    "minimum": 0
                 }
             }
         },
-        "authv1.RegisterResponse": {
+        "entities.PatchDocRequest": {
             "type": "object",
             "properties": {
-                "success": {
-                    "type": "boolean"
+                "director": {
+                    "type": "string"
+                },
+                "discipline": {
+                    "type": "string"
+                },
+                "fio": {
+                    "type": "string"
+                },
+                "group": {
+                    "type": "string"
+                },
+                "order": {
+                    "type": "string"
+                },
+                "participants": {
+                    "description": "Replaces all participants along with fio, director and reviewer",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.Participant"
+                    }
+                },
+                "reviewer": {
+                    "type": "string"
+                },
+                "theme": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer"
                 }
             }
         },
-        "authv1.SendPasswordLinkResponse": {
+        "entities.Protocol": {
             "type": "object",
             "properties": {
-                "success": {
-                    "type": "boolean"
+                "author_id": {
+                    "type": "integer"
+                },
+                "author_name": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "integer"
+                },
+                "doc_id": {
+                    "type": "integer"
+                },
+                "grade": {
+                    "type": "integer"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "string"
+                },
+                "questions": {
+                    "type": "string"
+                },
+                "session_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "integer"
                 }
             }
         },
-        "entities.ActivateAccountRequest": {
+        "entities.RefreshRequest": {
             "type": "object",
             "required": [
-                "link"
+                "refresh_token"
             ],
             "properties": {
-                "link": {
+                "refresh_token": {
                     "type": "string"
                 }
             }
         },
-        "entities.ChangePasswordRequest": {
+        "entities.RegisterRequest": {
             "type": "object",
             "required": [
-                "link",
-                "password"
+                "email",
+                "password",
+                "username"
             ],
             "properties": {
-                "link": {
+                "email": {
                     "type": "string"
                 },
                 "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "minLength": 3
+                }
+            }
+        },
+        "entities.Review": {
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "type": "integer"
+                },
+                "author_name": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "integer"
+                },
+                "doc_id": {
+                    "type": "integer"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "file_size": {
+                    "type": "integer"
+                },
+                "file_type": {
+                    "type": "string"
+                },
+                "grade": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.ReviewerAssignment": {
+            "type": "object",
+            "properties": {
+                "alternatives": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.ReviewerSuggestion"
+                    }
+                },
+                "doc_id": {
+                    "type": "integer"
+                },
+                "fio": {
+                    "type": "string"
+                },
+                "reviewer": {
+                    "type": "string"
+                },
+                "theme": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.ReviewerSuggestion": {
+            "type": "object",
+            "properties": {
+                "discipline_match": {
+                    "description": "Share of the docs the person reviewed or supervised in the doc's discipline",
+                    "type": "number"
+                },
+                "person": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "theme_similarity": {
+                    "description": "Best theme similarity among those docs, from 0 to 1",
+                    "type": "number"
+                },
+                "workload": {
+                    "description": "Reviews of the doc's year without the doc itself",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entities.Workload"
+                        }
+                    ]
+                }
+            }
+        },
+        "entities.SavedDocResponse": {
+            "type": "object",
+            "properties": {
+                "defense_date": {
+                    "type": "string"
+                },
+                "director": {
+                    "type": "string"
+                },
+                "discipline": {
+                    "type": "string"
+                },
+                "fio": {
+                    "type": "string"
+                },
+                "grade": {
+                    "type": "integer"
+                },
+                "group": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "order": {
+                    "type": "string"
+                },
+                "participants": {
+                    "description": "fio, director and reviewer hold the first student, supervisor and reviewer",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.Participant"
+                    }
+                },
+                "reviewer": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "theme": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "integer"
+                },
+                "workload_warnings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.Workload"
+                    }
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.SearchDocsResponse": {
+            "type": "object",
+            "properties": {
+                "docs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.Doc"
+                    }
+                },
+                "matches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.ContentMatch"
+                    }
+                }
+            }
+        },
+        "entities.SearchRequest": {
+            "type": "object",
+            "required": [
+                "search_line"
+            ],
+            "properties": {
+                "scope": {
+                    "description": "metadata (default), content or both",
                     "type": "string",
-                    "maxLength": 50,
-                    "minLength": 8
+                    "enum": [
+                        "metadata",
+                        "content",
+                        "both"
+                    ]
+                },
+                "search_line": {
+                    "type": "string"
                 }
             }
         },
-        "entities.CreateRequest": {
+        "entities.SendPasswordLinkRequest": {
             "type": "object",
             "required": [
-                "director",
-                "discipline",
-                "fio",
-                "group",
-                "order",
-                "reviewer",
-                "theme",
-                "type",
-                "year"
+                "email"
             ],
             "properties": {
-                "director": {
-                    "type": "string"
-                },
-                "discipline": {
+                "email": {
                     "type": "string"
+                }
+            }
+        },
+        "entities.Session": {
+            "type": "object",
+            "properties": {
+                "commission": {
+                    "$ref": "#/definitions/entities.Commission"
                 },
-                "fio": {
-                    "type": "string"
+                "commission_id": {
+                    "type": "integer"
                 },
-                "group": {
-                    "type": "string"
+                "ends_at": {
+                    "type": "integer"
                 },
-                "order": {
-                    "type": "string"
+                "id": {
+                    "type": "integer"
                 },
-                "reviewer": {
+                "room": {
                     "type": "string"
                 },
-                "theme": {
-                    "type": "string"
+                "slot_minutes": {
+                    "type": "integer"
                 },
-                "type": {
-                    "type": "string"
+                "slots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.Slot"
+                    }
                 },
-                "year": {
+                "starts_at": {
                     "type": "integer"
                 }
             }
         },
-        "entities.DeleteRequest": {
+        "entities.SetRoleRequest": {
             "type": "object",
             "required": [
-                "id"
+                "role"
             ],
             "properties": {
-                "id": {
-                    "type": "integer"
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "student",
+                        "supervisor",
+                        "secretary",
+                        "admin"
+                    ]
                 }
             }
         },
-        "entities.Doc": {
+        "entities.SetWorkloadLimitRequest": {
             "type": "object",
             "required": [
-                "director",
-                "discipline",
-                "fio",
-                "group",
-                "id",
-                "order",
-                "reviewer",
-                "theme",
-                "type",
-                "year"
+                "limit"
             ],
             "properties": {
-                "director": {
-                    "type": "string"
-                },
-                "discipline": {
-                    "type": "string"
-                },
-                "fio": {
-                    "type": "string"
-                },
-                "group": {
-                    "type": "string"
-                },
-                "id": {
+                "limit": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "entities.SimilarTheme": {
+            "type": "object",
+            "properties": {
+                "doc_id": {
                     "type": "integer"
                 },
-                "order": {
+                "fio": {
                     "type": "string"
                 },
-                "reviewer": {
-                    "type": "string"
+                "score": {
+                    "type": "number"
                 },
                 "theme": {
                     "type": "string"
@@ -772,167 +4284,283 @@ const docTemplate = `{
                 }
             }
         },
-        "entities.GetFilteredRequest": {
+        "entities.SimilarThemesResponse": {
             "type": "object",
-            "required": [
-                "director",
-                "discipline",
-                "fio",
-                "group",
-                "order",
-                "reviewer",
-                "theme",
-                "type",
-                "year"
-            ],
             "properties": {
-                "director": {
+                "code": {
                     "type": "string"
                 },
-                "discipline": {
-                    "type": "string"
+                "conflicts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/common.Conflict"
+                    }
                 },
-                "fio": {
+                "detail": {
                     "type": "string"
                 },
-                "group": {
+                "error": {
+                    "description": "Error repeats Detail for clients of the older {\"error\": ...} body.",
                     "type": "string"
                 },
-                "order": {
+                "fields": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "instance": {
                     "type": "string"
                 },
-                "reviewer": {
+                "request_id": {
                     "type": "string"
                 },
-                "theme": {
+                "similar_themes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.SimilarTheme"
+                    }
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "title": {
                     "type": "string"
                 },
                 "type": {
                     "type": "string"
                 },
-                "year": {
-                    "type": "integer"
+                "violations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/common.FieldViolation"
+                    }
                 }
             }
         },
-        "entities.GetResponse": {
+        "entities.Slot": {
             "type": "object",
             "properties": {
-                "docs": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/entities.Doc"
-                    }
+                "doc": {
+                    "$ref": "#/definitions/entities.Doc"
+                },
+                "doc_id": {
+                    "type": "integer"
+                },
+                "ends_at": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "session_id": {
+                    "type": "integer"
+                },
+                "starts_at": {
+                    "type": "integer"
                 }
             }
         },
-        "entities.LoginRequest": {
+        "entities.Stats": {
             "type": "object",
-            "required": [
-                "password"
-            ],
             "properties": {
-                "email": {
-                    "type": "string"
+                "buckets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.StatsBucket"
+                    }
                 },
-                "password": {
-                    "type": "string",
-                    "maxLength": 50,
-                    "minLength": 8
+                "group_by": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "username": {
-                    "type": "string"
+                "previous_total": {
+                    "type": "integer"
+                },
+                "total": {
+                    "description": "Totals are over all groups, not only the top ones",
+                    "type": "integer"
                 }
             }
         },
-        "entities.LogoutRequest": {
+        "entities.StatsBucket": {
             "type": "object",
-            "required": [
-                "refresh_token"
-            ],
             "properties": {
-                "refresh_token": {
-                    "type": "string"
+                "change": {
+                    "type": "integer"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "keys": {
+                    "description": "Group values by dimension, e.g. {\"director\": \"ivanov\", \"year\": \"2024\"}",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "previous_count": {
+                    "description": "Set when compared with the previous year",
+                    "type": "integer"
                 }
             }
         },
-        "entities.RefreshRequest": {
+        "entities.SubmitFeedbackRequest": {
             "type": "object",
             "required": [
-                "refresh_token"
+                "text"
             ],
             "properties": {
-                "refresh_token": {
-                    "type": "string"
+                "text": {
+                    "type": "string",
+                    "maxLength": 20000
                 }
             }
         },
-        "entities.RegisterRequest": {
+        "entities.SubmitProtocolRequest": {
             "type": "object",
             "required": [
-                "email",
-                "password",
-                "username"
+                "grade",
+                "number"
             ],
             "properties": {
-                "email": {
-                    "type": "string"
+                "grade": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 2
                 },
-                "password": {
+                "notes": {
                     "type": "string",
-                    "maxLength": 50,
-                    "minLength": 8
+                    "maxLength": 5000
                 },
-                "username": {
+                "number": {
                     "type": "string",
-                    "maxLength": 20,
-                    "minLength": 3
+                    "maxLength": 50
+                },
+                "questions": {
+                    "type": "string",
+                    "maxLength": 5000
                 }
             }
         },
-        "entities.SearchRequest": {
+        "entities.SuccessResponse": {
             "type": "object",
-            "required": [
-                "search_line"
-            ],
             "properties": {
-                "search_line": {
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "entities.SuggestReviewerAssignmentsResponse": {
+            "type": "object",
+            "properties": {
+                "assignments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.ReviewerAssignment"
+                    }
+                }
+            }
+        },
+        "entities.SuggestReviewersResponse": {
+            "type": "object",
+            "properties": {
+                "suggestions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.ReviewerSuggestion"
+                    }
+                }
+            }
+        },
+        "entities.Transition": {
+            "type": "object",
+            "properties": {
+                "actor_id": {
+                    "type": "integer"
+                },
+                "actor_name": {
+                    "type": "string"
+                },
+                "actor_role": {
+                    "type": "string"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "integer"
+                },
+                "doc_id": {
+                    "type": "integer"
+                },
+                "from_status": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "to_status": {
                     "type": "string"
                 }
             }
         },
-        "entities.SendPasswordLinkRequest": {
+        "entities.TransitionDocRequest": {
             "type": "object",
             "required": [
-                "email"
+                "to_status"
             ],
             "properties": {
-                "email": {
+                "comment": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "defense_date": {
                     "type": "string"
+                },
+                "grade": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 2
+                },
+                "order": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "to_status": {
+                    "type": "string",
+                    "enum": [
+                        "topic_proposed",
+                        "topic_approved",
+                        "in_progress",
+                        "submitted",
+                        "reviewed",
+                        "defended",
+                        "archived"
+                    ]
                 }
             }
         },
-        "entities.SuccessResponse": {
+        "entities.TransitionDocResponse": {
             "type": "object",
             "properties": {
-                "success": {
-                    "type": "boolean"
+                "doc": {
+                    "$ref": "#/definitions/entities.Doc"
+                },
+                "transition": {
+                    "$ref": "#/definitions/entities.Transition"
                 }
             }
         },
         "entities.UpdateRequest": {
             "type": "object",
             "required": [
-                "director",
-                "discipline",
-                "fio",
-                "group",
-                "id",
-                "order",
-                "reviewer",
-                "theme",
-                "type",
-                "year"
+                "version"
             ],
             "properties": {
                 "director": {
@@ -953,6 +4581,13 @@ const docTemplate = `{
                 "order": {
                     "type": "string"
                 },
+                "participants": {
+                    "description": "Replaces all participants along with fio, director and reviewer",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.Participant"
+                    }
+                },
                 "reviewer": {
                     "type": "string"
                 },
@@ -962,6 +4597,54 @@ const docTemplate = `{
                 "type": {
                     "type": "string"
                 },
+                "version": {
+                    "description": "Version the changes were made against, from the doc",
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.UpdateResponse": {
+            "type": "object",
+            "properties": {
+                "doc": {
+                    "$ref": "#/definitions/entities.Doc"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "workload_warnings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.Workload"
+                    }
+                }
+            }
+        },
+        "entities.Workload": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "limit": {
+                    "description": "0 means no limit",
+                    "type": "integer"
+                },
+                "person": {
+                    "type": "string"
+                },
+                "personal": {
+                    "description": "Set when the limit is the person's own, not the configured one",
+                    "type": "boolean"
+                },
+                "role": {
+                    "description": "supervisor or reviewer",
+                    "type": "string"
+                },
                 "year": {
                     "type": "integer"
                 }
@@ -980,9 +4663,9 @@ const docTemplate = `{
 // SwaggerInfo holds exported Swagger Info so clients can modify it
 var SwaggerInfo = &swag.Spec{
 	Version:          "1.0",
-	Host:             "158.160.159.90:5173",
-	BasePath:         "/api/v1",
-	Schemes:          []string{"https"},
+	Host:             "",
+	BasePath:         "/api",
+	Schemes:          []string{},
 	Title:            "API Gatewate",
 	Description:      "API Gatewate for service",
 	InfoInstanceName: "swagger",
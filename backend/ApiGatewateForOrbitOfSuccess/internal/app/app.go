@@ -1,49 +1,122 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
+	"os"
+	"time"
 
 	v1 "github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/controller/rest/v1"
 
 	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/config"
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/lib/breaker"
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/lib/httpserver"
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/lib/tracing"
 	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/services"
 
-	"github.com/evrone/go-clean-template/pkg/httpserver"
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
+// tracingShutdownTimeout bounds flushing the spans left on shutdown.
+const tracingShutdownTimeout = 5 * time.Second
+
 type HttpServer struct {
-	s     *httpserver.Server
-	authS *services.AuthService
-	docsS *services.DocsService
+	tracing *tracing.Provider
+	s       *httpserver.Server
+	authS   *services.AuthService
+	docsS   *services.DocsService
 }
 
 func Run(
 	log *slog.Logger,
 	cfg *config.Config,
 ) *HttpServer {
+	// Tracing
+	tp, err := tracing.New(context.Background(), &cfg.Tracing)
+	if err != nil {
+		slog.Error(fmt.Errorf("app - Run - tracing.New: %w", err).Error())
+		os.Exit(1)
+	}
+
 	// Services
 	authService := services.NewAuthService(log, cfg.AuthServiceCfg)
 	docsService := services.NewDocsService(log, cfg.DocsServiceCfg)
 
+	// Metrics are kept apart from the default registry, so building the
+	// router again, e.g. in tests, doesn't register them twice
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		breaker.NewCollector(authService.Breaker(), docsService.Breaker()),
+	)
+
 	// Clients
+	authClient, err := authService.Connect()
+	if err != nil {
+		slog.Error(fmt.Errorf("app - Run - authService.Connect: %w", err).Error())
+		os.Exit(1)
+	}
+
+	docsClient, err := docsService.Connect()
+	if err != nil {
+		slog.Error(fmt.Errorf("app - Run - docsService.Connect: %w", err).Error())
+		os.Exit(1)
+	}
+
 	clients := v1.Clients{
-		Auth: authService.Connect(),
-		Docs: docsService.Connect(),
+		Auth: authClient,
+		Docs: docsClient,
+		Health: map[string]healthpb.HealthClient{
+			"auth": authService.Health(),
+			"docs": docsService.Health(),
+		},
 	}
 
+	// Wait for the services, /readyz keeps failing while they are down
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.StartupTimeout)
+	if err := authService.WaitReady(ctx); err != nil {
+		log.Warn("starting without auth service", slog.String("err", err.Error()))
+	}
+	if err := docsService.WaitReady(ctx); err != nil {
+		log.Warn("starting without docs service", slog.String("err", err.Error()))
+	}
+	cancel()
+
 	// HTTP Server
 	handler := gin.New()
-	v1.NewRouter(handler, clients, log)
-	httpServer := httpserver.New(handler, httpserver.Port(cfg.HTTP.Port))
+	if err := handler.SetTrustedProxies(cfg.HTTP.TrustedProxies); err != nil {
+		slog.Error(fmt.Errorf("app - Run - handler.SetTrustedProxies: %w", err).Error())
+		os.Exit(1)
+	}
+	v1.NewRouter(handler, clients, log, cfg, reg)
 
-	log.Info("api gatewate server started", slog.String("addr", cfg.HTTP.Port))
+	opts := []httpserver.Option{
+		httpserver.Port(cfg.HTTP.Port),
+		httpserver.ReadTimeout(cfg.HTTP.ReadTimeout),
+		httpserver.WriteTimeout(cfg.HTTP.WriteTimeout),
+	}
+	if cfg.HTTP.TLS.Enabled() {
+		certs, err := httpserver.NewCertReloader(log, cfg.HTTP.TLS.CertFile, cfg.HTTP.TLS.KeyFile, cfg.HTTP.TLS.ReloadInterval)
+		if err != nil {
+			slog.Error(fmt.Errorf("app - Run - httpserver.NewCertReloader: %w", err).Error())
+			os.Exit(1)
+		}
+		opts = append(opts, httpserver.TLS(certs.GetCertificate))
+	}
+	httpServer := httpserver.New(handler, opts...)
+
+	log.Info("api gatewate server started", slog.String("addr", cfg.HTTP.Port), slog.Bool("tls", httpServer.TLSEnabled()))
 
 	return &HttpServer{
-		s:     httpServer,
-		authS: authService,
-		docsS: docsService,
+		tracing: tp,
+		s:       httpServer,
+		authS:   authService,
+		docsS:   docsService,
 	}
 }
 
@@ -62,4 +135,12 @@ func (s *HttpServer) Shutdown() {
 	if err != nil {
 		slog.Error(fmt.Errorf("app - Run - httpServer.Shutdown - s.docsS.CloseConn: %w", err).Error())
 	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	defer cancel()
+
+	err = s.tracing.Shutdown(ctx)
+	if err != nil {
+		slog.Error(fmt.Errorf("app - Run - httpServer.Shutdown - s.tracing.Shutdown: %w", err).Error())
+	}
 }
@@ -6,10 +6,13 @@ import (
 	"net/http"
 
 	"github.com/go-playground/validator/v10"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// GetErrMessages turns binding errors into an *APIError with the
+// VALIDATION_FAILED code.
 func GetErrMessages(errs error) error {
 	if errs == nil {
 		return nil
@@ -31,46 +34,174 @@ func GetErrMessages(errs error) error {
 			if v.Tag() == "max" {
 				newErrMes += fmt.Sprintf("Maximum lenght for field %s is %v;", v.Field(), v.Param())
 			}
+			if v.Tag() == "oneof" {
+				newErrMes += fmt.Sprintf("Field %s must be one of: %v;", v.Field(), v.Param())
+			}
+			if v.Tag() == "datetime" {
+				newErrMes += fmt.Sprintf("Field %s must be in %v format;", v.Field(), v.Param())
+			}
 		}
 	} else {
 		newErrMes = errs.Error()
 	}
 
-	return errors.New(newErrMes)
+	return &APIError{Code: CodeValidationFailed, Message: newErrMes}
 }
 
+// reasonStatuses overrides the status of the code for the service reasons
+// whose meaning the code doesn't carry over to HTTP.
+var reasonStatuses = map[string]int{
+	// Sent as FailedPrecondition, but nothing conflicts: the request lacks
+	// the version, as a v2 update without If-Match
+	"VERSION_REQUIRED": http.StatusPreconditionRequired,
+}
+
+// GetProtoErrWithStatusCode maps an error of a gRPC call to an HTTP status
+// and an *APIError. Its code is the ErrorInfo reason sent by the service, or
+// the generic code of the status. Errors that aren't gRPC statuses are 500.
 func GetProtoErrWithStatusCode(err error) (int, error) {
 	if err == nil {
 		return 0, nil
 	}
 
+	st, ok := status.FromError(err)
+	if !ok {
+		return http.StatusInternalServerError, &APIError{Code: CodeInternal, Message: "Internal server error"}
+	}
+
 	code := 0
+	switch st.Code() {
+	case codes.InvalidArgument:
+		code = http.StatusBadRequest
+		err = fmt.Errorf("Invalid argument error: %s", st.Message())
+	case codes.NotFound:
+		code = http.StatusNotFound
+		err = fmt.Errorf("Not found error: %s", st.Message())
+	case codes.AlreadyExists:
+		code = http.StatusConflict
+		err = fmt.Errorf("Already exists error: %s", st.Message())
+	case codes.Aborted, codes.FailedPrecondition:
+		code = http.StatusConflict
+		err = fmt.Errorf("Conflict: %s", st.Message())
+	case codes.Unavailable:
+		code = http.StatusServiceUnavailable
+		err = fmt.Errorf("Service unavailable")
+		// Transport errors have no details, those with them are made
+		// on purpose and say what happened
+		if len(st.Details()) > 0 {
+			err = fmt.Errorf("Service unavailable: %s", st.Message())
+		}
+	case codes.DeadlineExceeded:
+		code = http.StatusGatewayTimeout
+		err = fmt.Errorf("Service timed out")
+	case codes.Internal:
+		code = http.StatusInternalServerError
+		err = fmt.Errorf("Internal server error")
+	case codes.Unauthenticated:
+		code = http.StatusUnauthorized
+		err = fmt.Errorf("Unauthorized: %s", st.Message())
+	case codes.PermissionDenied:
+		code = http.StatusForbidden
+		err = fmt.Errorf("Forbidden: %s", st.Message())
+	case codes.ResourceExhausted:
+		code = http.StatusRequestEntityTooLarge
+		err = fmt.Errorf("Too large: %s", st.Message())
+	default:
+		code = http.StatusInternalServerError
+		err = fmt.Errorf("Unexpected error: %s", st.Message())
+	}
+
+	apiErr := &APIError{
+		Code:       codeOfStatus(code),
+		Message:    err.Error(),
+		Violations: GetFieldViolations(st.Err()),
+		Conflicts:  GetConflicts(st.Err()),
+	}
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.GetReason() != "" {
+			apiErr.Code = info.GetReason()
+		}
+		if retry, ok := detail.(*errdetails.RetryInfo); ok {
+			apiErr.RetryAfter = retry.GetRetryDelay().AsDuration()
+		}
+	}
+	if status, ok := reasonStatuses[apiErr.Code]; ok {
+		code = status
+	}
+	if code == http.StatusBadRequest && len(apiErr.Violations) > 0 {
+		apiErr.Fields = make(map[string]string, len(apiErr.Violations))
+		for _, v := range apiErr.Violations {
+			if msg, ok := apiErr.Fields[v.Field]; ok {
+				apiErr.Fields[v.Field] = msg + "; " + v.Message
+			} else {
+				apiErr.Fields[v.Field] = v.Message
+			}
+		}
+	}
+
+	return code, apiErr
+}
+
+type FieldViolation struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule,omitempty"`
+	Message string `json:"message"`
+}
+
+// GetFieldViolations returns field violations attached to a gRPC error
+// as errdetails.BadRequest, e.g. every broken password policy rule.
+func GetFieldViolations(err error) []FieldViolation {
 	st, ok := status.FromError(err)
-	if ok {
-		switch st.Code() {
-		case codes.InvalidArgument:
-			code = http.StatusBadRequest
-			err = fmt.Errorf("Invalid argument error: %s", st.Message())
-		case codes.NotFound:
-			code = http.StatusNotFound
-			err = fmt.Errorf("Not found error: %s", st.Message())
-		case codes.AlreadyExists:
-			code = http.StatusBadRequest
-			err = fmt.Errorf("Already exists error: %s", st.Message())
-		case codes.Unavailable:
-			code = http.StatusServiceUnavailable
-			err = fmt.Errorf("Service unavailable")
-		case codes.Internal:
-			code = http.StatusInternalServerError
-			err = fmt.Errorf("Internal server error")
-		case codes.Unauthenticated:
-			code = http.StatusUnauthorized
-			err = fmt.Errorf("Unauthorized: %s", st.Message())
-		default:
-			code = http.StatusInternalServerError
-			err = fmt.Errorf("Unexpected error: %s", st.Message())
+	if !ok {
+		return nil
+	}
+
+	var violations []FieldViolation
+	for _, detail := range st.Details() {
+		br, ok := detail.(*errdetails.BadRequest)
+		if !ok {
+			continue
+		}
+		for _, v := range br.GetFieldViolations() {
+			violations = append(violations, FieldViolation{
+				Field:   v.GetField(),
+				Rule:    v.GetReason(),
+				Message: v.GetDescription(),
+			})
+		}
+	}
+
+	return violations
+}
+
+type Conflict struct {
+	Kind        string `json:"kind"`
+	Subject     string `json:"subject"`
+	Description string `json:"description"`
+}
+
+// GetConflicts returns scheduling conflicts attached to a gRPC error
+// as errdetails.PreconditionFailure.
+func GetConflicts(err error) []Conflict {
+	st, ok := status.FromError(err)
+	if !ok {
+		return nil
+	}
+
+	var conflicts []Conflict
+	for _, detail := range st.Details() {
+		pf, ok := detail.(*errdetails.PreconditionFailure)
+		if !ok {
+			continue
+		}
+		for _, v := range pf.GetViolations() {
+			conflicts = append(conflicts, Conflict{
+				Kind:        v.GetType(),
+				Subject:     v.GetSubject(),
+				Description: v.GetDescription(),
+			})
 		}
 	}
 
-	return code, err
+	return conflicts
 }
@@ -0,0 +1,46 @@
+package common
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func statusWithReason(t *testing.T, code codes.Code, reason string) error {
+	st, err := status.New(code, "message").WithDetails(&errdetails.ErrorInfo{Reason: reason})
+	assert.NoError(t, err)
+	return st.Err()
+}
+
+func TestGetProtoErrWithStatusCode(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+	}{
+		{"version required", statusWithReason(t, codes.FailedPrecondition, "VERSION_REQUIRED"),
+			http.StatusPreconditionRequired, "VERSION_REQUIRED"},
+		{"version conflict", statusWithReason(t, codes.Aborted, "VERSION_CONFLICT"),
+			http.StatusConflict, "VERSION_CONFLICT"},
+		{"not found", statusWithReason(t, codes.NotFound, "DOC_NOT_FOUND"),
+			http.StatusNotFound, "DOC_NOT_FOUND"},
+		{"without reason", status.Error(codes.InvalidArgument, "message"),
+			http.StatusBadRequest, CodeBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := GetProtoErrWithStatusCode(tt.err)
+
+			assert.Equal(t, tt.status, code)
+			if apiErr, ok := err.(*APIError); assert.True(t, ok) {
+				assert.Equal(t, tt.code, apiErr.Code)
+			}
+		})
+	}
+}
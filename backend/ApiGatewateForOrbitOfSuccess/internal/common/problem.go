@@ -0,0 +1,135 @@
+package common
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/render"
+)
+
+// ProblemContentType is the media type of RFC 7807 error responses.
+const ProblemContentType = "application/problem+json"
+
+// RequestIDHeader carries the id of the request in both directions.
+const RequestIDHeader = "X-Request-ID"
+
+// Codes of the errors made by the gateway itself. Errors of the services
+// keep the codes they send, e.g. DOC_NOT_FOUND or THEME_TAKEN.
+const (
+	CodeBadRequest           = "BAD_REQUEST"
+	CodeValidationFailed     = "VALIDATION_FAILED"
+	CodeUnauthorized         = "UNAUTHORIZED"
+	CodeForbidden            = "FORBIDDEN"
+	CodeNotFound             = "NOT_FOUND"
+	CodeConflict             = "CONFLICT"
+	CodePreconditionRequired = "PRECONDITION_REQUIRED"
+	CodePayloadTooLarge      = "PAYLOAD_TOO_LARGE"
+	CodeTooManyRequests      = "TOO_MANY_REQUESTS"
+	CodeServiceUnavailable   = "SERVICE_UNAVAILABLE"
+	CodeTimeout              = "TIMEOUT"
+	CodeInternal             = "INTERNAL"
+)
+
+var statusCodes = map[int]string{
+	http.StatusBadRequest:            CodeBadRequest,
+	http.StatusUnauthorized:          CodeUnauthorized,
+	http.StatusForbidden:             CodeForbidden,
+	http.StatusNotFound:              CodeNotFound,
+	http.StatusConflict:              CodeConflict,
+	http.StatusPreconditionRequired:  CodePreconditionRequired,
+	http.StatusRequestEntityTooLarge: CodePayloadTooLarge,
+	http.StatusTooManyRequests:       CodeTooManyRequests,
+	http.StatusServiceUnavailable:    CodeServiceUnavailable,
+	http.StatusGatewayTimeout:        CodeTimeout,
+}
+
+func codeOfStatus(status int) string {
+	if code, ok := statusCodes[status]; ok {
+		return code
+	}
+	return CodeInternal
+}
+
+// APIError is an error with a stable code and the details to show the client.
+type APIError struct {
+	Code       string
+	Message    string
+	Fields     map[string]string
+	Violations []FieldViolation
+	Conflicts  []Conflict
+	// RetryAfter is sent as the Retry-After header when set.
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	return e.Message
+}
+
+// Problem is an RFC 7807 error response. Code is stable and meant for
+// programs, Detail for people.
+type Problem struct {
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Detail    string `json:"detail"`
+	Instance  string `json:"instance"`
+	Code      string `json:"code"`
+	RequestID string `json:"request_id,omitempty"`
+	// Error repeats Detail for clients of the older {"error": ...} body.
+	Error      string            `json:"error"`
+	Fields     map[string]string `json:"fields,omitempty"`
+	Violations []FieldViolation  `json:"violations,omitempty"`
+	Conflicts  []Conflict        `json:"conflicts,omitempty"`
+}
+
+// NewProblem describes the error of the request. An *APIError gives its
+// code and details, other errors get the generic code of the status.
+func NewProblem(c *gin.Context, status int, err error) *Problem {
+	p := &Problem{
+		Type:      "about:blank",
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    err.Error(),
+		Instance:  c.Request.URL.Path,
+		Code:      codeOfStatus(status),
+		RequestID: c.Writer.Header().Get(RequestIDHeader),
+		Error:     err.Error(),
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		p.Code = apiErr.Code
+		p.Fields = apiErr.Fields
+		p.Violations = apiErr.Violations
+		p.Conflicts = apiErr.Conflicts
+	}
+
+	return p
+}
+
+// WriteProblem aborts the request with the problem of the error.
+func WriteProblem(c *gin.Context, status int, err error) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(apiErr.RetryAfter.Seconds()))))
+	}
+
+	AbortWithProblem(c, status, NewProblem(c, status, err))
+}
+
+// WriteProblemMessage aborts the request with a problem of the message.
+func WriteProblemMessage(c *gin.Context, status int, msg string) {
+	WriteProblem(c, status, errors.New(msg))
+}
+
+// AbortWithProblem aborts the request with the body, a Problem or a struct
+// embedding one, as application/problem+json.
+func AbortWithProblem(c *gin.Context, status int, body any) {
+	c.Header("Content-Type", ProblemContentType)
+	c.Abort()
+	c.Render(status, render.JSON{Data: body})
+}
@@ -2,7 +2,9 @@ package config
 
 import (
 	"flag"
+	"fmt"
 	"os"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 )
@@ -12,19 +14,180 @@ type Config struct {
 	HTTP           HTTPConfig        `yaml:"http"`
 	AuthServiceCfg AuthServiceConfig `yaml:"auth_service"`
 	DocsServiceCfg DocsServiceConfig `yaml:"docs_service"`
+	Tracing        TracingConfig     `yaml:"tracing"`
+	RateLimit      RateLimitConfig   `yaml:"rate_limit"`
+	Swagger        SwaggerConfig     `yaml:"swagger"`
 	MigrationsPath string
 }
 
 type HTTPConfig struct {
 	Port string `yaml:"port" env-required:"true"`
+	// StartupTimeout bounds waiting for Auth and Docs on start. The gateway
+	// starts after it anyway and stays unready until they are up.
+	StartupTimeout time.Duration `yaml:"startup_timeout" env:"STARTUP_TIMEOUT" env-default:"30s"`
+	// ReadTimeout covers reading a whole request, uploads of up to 20MB
+	// included. WriteTimeout runs from the end of the request headers to
+	// the end of the response, so it covers reading the body and the gRPC
+	// calls made meanwhile. It must outlast the longest gRPC timeout.
+	ReadTimeout  time.Duration `yaml:"read_timeout" env:"HTTP_READ_TIMEOUT" env-default:"60s"`
+	WriteTimeout time.Duration `yaml:"write_timeout" env:"HTTP_WRITE_TIMEOUT" env-default:"90s"`
+	// TrustedProxies are the IPs or CIDRs whose X-Forwarded-For and
+	// X-Real-IP headers are believed, e.g. nginx. Empty trusts no one.
+	TrustedProxies []string   `yaml:"trusted_proxies" env:"HTTP_TRUSTED_PROXIES" env-default:"127.0.0.1,::1"`
+	CORS           CORSConfig `yaml:"cors"`
+	TLS            TLSConfig  `yaml:"tls"`
+}
+
+// CORSConfig lists who may call the API from a browser. The request id and
+// trace context headers are always allowed.
+type CORSConfig struct {
+	AllowOrigins     []string `yaml:"allow_origins" env:"HTTP_CORS_ALLOW_ORIGINS" env-default:"http://localhost:5173"`
+	AllowHeaders     []string `yaml:"allow_headers" env:"HTTP_CORS_ALLOW_HEADERS" env-default:"Content-Type,Content-Length,Accept-Encoding,X-CSRF-Token,Authorization,accept,origin,Cache-Control,X-Requested-With"`
+	AllowCredentials bool     `yaml:"allow_credentials" env:"HTTP_CORS_ALLOW_CREDENTIALS" env-default:"true"`
+}
+
+// TLSConfig turns HTTPS on when both files are set. The files are checked
+// for changes every ReloadInterval, so renewed certificates need no restart.
+type TLSConfig struct {
+	CertFile       string        `yaml:"cert_file" env:"HTTP_TLS_CERT_FILE"`
+	KeyFile        string        `yaml:"key_file" env:"HTTP_TLS_KEY_FILE"`
+	ReloadInterval time.Duration `yaml:"reload_interval" env:"HTTP_TLS_RELOAD_INTERVAL" env-default:"1m"`
+}
+
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != "" && c.KeyFile != ""
+}
+
+// SwaggerConfig sets where the Swagger UI sends the requests. The defaults
+// fit a local gateway, deployments set their public host.
+type SwaggerConfig struct {
+	Host    string   `yaml:"host" env:"SWAGGER_HOST" env-default:"localhost:8080"`
+	Schemes []string `yaml:"schemes" env:"SWAGGER_SCHEMES" env-default:"http"`
 }
 
 type AuthServiceConfig struct {
-	Addr string `yaml:"address" env:"AUTH_ADDRESS" env-required:"true"`
+	Addr             string              `yaml:"address" env:"AUTH_ADDRESS" env-required:"true"`
+	TLS              GRPCClientTLSConfig `yaml:"tls" env-prefix:"AUTH_TLS_"`
+	GRPCClientConfig `yaml:",inline" env-prefix:"AUTH_"`
 }
 
 type DocsServiceConfig struct {
-	Addr string `yaml:"address" env:"DOCS_ADDRESS" env-required:"true"`
+	Addr             string              `yaml:"address" env:"DOCS_ADDRESS" env-required:"true"`
+	TLS              GRPCClientTLSConfig `yaml:"tls" env-prefix:"DOCS_TLS_"`
+	GRPCClientConfig `yaml:",inline" env-prefix:"DOCS_"`
+}
+
+// GRPCClientConfig sets how the calls to a service are made. Every call gets
+// Timeout unless MethodTimeouts, by method name e.g. "UploadAttachment",
+// has its own. A shorter deadline of the request context still wins.
+type GRPCClientConfig struct {
+	Timeout        time.Duration            `yaml:"timeout" env:"TIMEOUT" env-default:"10s"`
+	MethodTimeouts map[string]time.Duration `yaml:"method_timeouts" env:"METHOD_TIMEOUTS"`
+	Retry          GRPCRetryConfig          `yaml:"retry" env-prefix:"RETRY_"`
+	Keepalive      GRPCKeepaliveConfig      `yaml:"keepalive" env-prefix:"KEEPALIVE_"`
+	Breaker        BreakerConfig            `yaml:"breaker" env-prefix:"BREAKER_"`
+}
+
+// LongestTimeout is the longest a call may take, retries included.
+func (c GRPCClientConfig) LongestTimeout() time.Duration {
+	longest := c.Timeout
+	for _, timeout := range c.MethodTimeouts {
+		longest = max(longest, timeout)
+	}
+
+	return longest
+}
+
+// GRPCRetryConfig retries the idempotent calls that failed with
+// UNAVAILABLE, backing off exponentially between the attempts. gRPC caps
+// the attempts at 5, fewer than 2 turn retries off.
+type GRPCRetryConfig struct {
+	MaxAttempts    int           `yaml:"max_attempts" env:"MAX_ATTEMPTS" env-default:"3"`
+	InitialBackoff time.Duration `yaml:"initial_backoff" env:"INITIAL_BACKOFF" env-default:"100ms"`
+	MaxBackoff     time.Duration `yaml:"max_backoff" env:"MAX_BACKOFF" env-default:"1s"`
+}
+
+// GRPCKeepaliveConfig pings the service after Time without activity and
+// drops the connection when no answer comes in Timeout. The services let
+// pings through every 10s at most, so Time must not be shorter.
+type GRPCKeepaliveConfig struct {
+	Time    time.Duration `yaml:"time" env:"TIME" env-default:"30s"`
+	Timeout time.Duration `yaml:"timeout" env:"TIMEOUT" env-default:"10s"`
+}
+
+// BreakerConfig opens the circuit after FailureThreshold calls in a row
+// failed with UNAVAILABLE or DEADLINE_EXCEEDED. Calls then fail at once for
+// OpenTimeout, after which one call is let through to probe the service.
+// 0 failures turn the breaker off.
+type BreakerConfig struct {
+	FailureThreshold int           `yaml:"failure_threshold" env:"FAILURE_THRESHOLD" env-default:"5"`
+	OpenTimeout      time.Duration `yaml:"open_timeout" env:"OPEN_TIMEOUT" env-default:"30s"`
+}
+
+// GRPCClientTLSConfig turns TLS on for the connection to a service. The CA
+// verifies the server, the system roots do without it. The certificate is
+// presented to the servers that require mTLS, its name must be allowed there.
+type GRPCClientTLSConfig struct {
+	Enabled  bool   `yaml:"enabled" env:"ENABLED" env-default:"false"`
+	CAFile   string `yaml:"ca_file" env:"CA_FILE"`
+	CertFile string `yaml:"cert_file" env:"CERT_FILE"`
+	KeyFile  string `yaml:"key_file" env:"KEY_FILE"`
+	// ServerName overrides the name checked in the server certificate,
+	// e.g. when the address is an IP.
+	ServerName string `yaml:"server_name" env:"SERVER_NAME"`
+}
+
+// RateLimitConfig sets the token buckets of the route groups. A group lets
+// Requests through per Period on average and up to Burst at once, 0
+// requests turn its limit off. Register and login are limited per client
+// IP and stricter, as they send mail and hash passwords.
+type RateLimitConfig struct {
+	Period       time.Duration `yaml:"period" env:"RATE_LIMIT_PERIOD" env-default:"1m"`
+	AuthRequests int           `yaml:"auth_requests" env:"RATE_LIMIT_AUTH_REQUESTS" env-default:"10"`
+	AuthBurst    int           `yaml:"auth_burst" env:"RATE_LIMIT_AUTH_BURST" env-default:"5"`
+	// The routes that need a token, limited per client IP before the token is
+	// checked and per user after it. Everyone behind one NAT shares the IP
+	// limit, so it only stops token guessing and must stay well above the
+	// user limit.
+	IPRequests   int `yaml:"ip_requests" env:"RATE_LIMIT_IP_REQUESTS" env-default:"3000"`
+	IPBurst      int `yaml:"ip_burst" env:"RATE_LIMIT_IP_BURST" env-default:"500"`
+	UserRequests int `yaml:"user_requests" env:"RATE_LIMIT_USER_REQUESTS" env-default:"300"`
+	UserBurst    int `yaml:"user_burst" env:"RATE_LIMIT_USER_BURST" env-default:"60"`
+	// IdleTTL drops the buckets of clients gone quiet. Keep it above Period.
+	IdleTTL time.Duration `yaml:"idle_ttl" env:"RATE_LIMIT_IDLE_TTL" env-default:"10m"`
+}
+
+// TracingConfig sets up OpenTelemetry tracing. Exporter is "otlp" to send
+// spans to the collector at Endpoint over gRPC, "stdout" to print them for
+// local debugging or "none" to only pass the trace context on.
+type TracingConfig struct {
+	Exporter    string  `yaml:"exporter" env:"TRACING_EXPORTER" env-default:"none"`
+	Endpoint    string  `yaml:"endpoint" env:"TRACING_ENDPOINT" env-default:"localhost:4317"`
+	Insecure    bool    `yaml:"insecure" env:"TRACING_INSECURE" env-default:"true"`
+	ServiceName string  `yaml:"service_name" env:"TRACING_SERVICE_NAME" env-default:"api-gateway"`
+	SampleRatio float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO" env-default:"1"`
+}
+
+// natUsers is how many users at their full limit the IP limit must let
+// through from one address.
+const natUsers = 5
+
+// validate checks the settings that only make sense together.
+func (c *Config) validate() error {
+	longest := max(c.AuthServiceCfg.LongestTimeout(), c.DocsServiceCfg.LongestTimeout())
+	if c.HTTP.WriteTimeout > 0 && c.HTTP.WriteTimeout <= longest {
+		return fmt.Errorf("http.write_timeout %s must be longer than the longest gRPC timeout %s, "+
+			"or the response is cut before the call ends", c.HTTP.WriteTimeout, longest)
+	}
+
+	rl := c.RateLimit
+	if rl.IPRequests > 0 && rl.UserRequests > 0 &&
+		(rl.IPRequests < natUsers*rl.UserRequests || rl.IPBurst < natUsers*rl.UserBurst) {
+		return fmt.Errorf("rate_limit.ip_requests and ip_burst must be at least %d times the user ones, "+
+			"or users behind one NAT throttle each other", natUsers)
+	}
+
+	return nil
 }
 
 func MustLoad() *Config {
@@ -46,6 +209,9 @@ func MustLoadPath(configPath string) *Config {
 	if err := cleanenv.ReadConfig(configPath, &cfg); err != nil {
 		panic("cannot read config: " + err.Error())
 	}
+	if err := cfg.validate(); err != nil {
+		panic("invalid config: " + err.Error())
+	}
 
 	return &cfg
 }
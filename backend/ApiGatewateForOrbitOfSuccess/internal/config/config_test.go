@@ -0,0 +1,101 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ilyakaznacheev/cleanenv"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLongestTimeout(t *testing.T) {
+	cfg := GRPCClientConfig{
+		Timeout: 10 * time.Second,
+		MethodTimeouts: map[string]time.Duration{
+			"UploadAttachment": 30 * time.Second,
+			"GetDoc":           2 * time.Second,
+		},
+	}
+
+	assert.Equal(t, 30*time.Second, cfg.LongestTimeout())
+	assert.Equal(t, 10*time.Second, GRPCClientConfig{Timeout: 10 * time.Second}.LongestTimeout())
+}
+
+func TestValidateWriteTimeout(t *testing.T) {
+	tests := []struct {
+		name         string
+		writeTimeout time.Duration
+		valid        bool
+	}{
+		{"longer than the calls", 31 * time.Second, true},
+		{"no timeout", 0, true},
+		{"as long as the longest call", 30 * time.Second, false},
+		{"the old default", 5 * time.Second, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				HTTP:           HTTPConfig{WriteTimeout: tt.writeTimeout},
+				AuthServiceCfg: AuthServiceConfig{GRPCClientConfig: GRPCClientConfig{Timeout: 10 * time.Second}},
+				DocsServiceCfg: DocsServiceConfig{GRPCClientConfig: GRPCClientConfig{
+					Timeout:        10 * time.Second,
+					MethodTimeouts: map[string]time.Duration{"UploadAttachment": 30 * time.Second},
+				}},
+			}
+
+			err := cfg.validate()
+
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateRateLimit(t *testing.T) {
+	tests := []struct {
+		name  string
+		rl    RateLimitConfig
+		valid bool
+	}{
+		{"defaults", RateLimitConfig{IPRequests: 3000, IPBurst: 500, UserRequests: 300, UserBurst: 60}, true},
+		{"no ip limit", RateLimitConfig{UserRequests: 300, UserBurst: 60}, true},
+		{"no user limit", RateLimitConfig{IPRequests: 100, IPBurst: 10}, true},
+		{"the old default", RateLimitConfig{IPRequests: 600, IPBurst: 100, UserRequests: 300, UserBurst: 60}, false},
+		{"low burst", RateLimitConfig{IPRequests: 3000, IPBurst: 100, UserRequests: 300, UserBurst: 60}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{RateLimit: tt.rl}
+
+			err := cfg.validate()
+
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestLocalConfig(t *testing.T) {
+	cfg := MustLoadPath("../../config/local.yaml")
+
+	assert.Greater(t, cfg.HTTP.WriteTimeout, cfg.DocsServiceCfg.LongestTimeout())
+}
+
+func TestLocalDefaults(t *testing.T) {
+	var cors CORSConfig
+	assert.NoError(t, cleanenv.ReadEnv(&cors))
+	assert.Equal(t, []string{"http://localhost:5173"}, cors.AllowOrigins)
+
+	var swagger SwaggerConfig
+	assert.NoError(t, cleanenv.ReadEnv(&swagger))
+	assert.Equal(t, "localhost:8080", swagger.Host)
+	assert.Equal(t, []string{"http"}, swagger.Schemes)
+}
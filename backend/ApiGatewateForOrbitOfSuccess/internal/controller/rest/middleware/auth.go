@@ -0,0 +1,89 @@
+// Package middleware contains gin middlewares shared by all API versions.
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/common"
+	authv1 "github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/proto/gen/auth"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	CtxAccessToken = "access_token"
+	CtxUserID      = "uid"
+	CtxUsername    = "username"
+	CtxScopes      = "scopes"
+	CtxRole        = "role"
+)
+
+// Metadata keys used to pass the verified user to downstream services.
+// The token lets them verify the user themselves.
+const (
+	mdUserID        = "x-user-id"
+	mdUsername      = "x-username"
+	mdUserRole      = "x-user-role"
+	mdAuthorization = "authorization"
+)
+
+// Auth accepts both JWT access tokens and personal API tokens
+// passed as "Authorization: Bearer <token>".
+func Auth(log *slog.Logger, s authv1.AuthClient) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authH := c.GetHeader("Authorization")
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 2*time.Second)
+		defer cancel()
+
+		if !strings.Contains(authH, "Bearer ") {
+			common.WriteProblemMessage(c, http.StatusUnauthorized, "bad token")
+			return
+		}
+
+		token := strings.Split(authH, "Bearer ")[1]
+
+		resp, err := s.Verify(ctx, &authv1.VerifyRequest{AccessToken: token})
+		if err != nil {
+			status, err := common.GetProtoErrWithStatusCode(err)
+			log.ErrorContext(ctx, err.Error())
+			common.WriteProblem(c, status, err)
+			return
+		}
+
+		c.Set(CtxAccessToken, token)
+		c.Set(CtxUserID, resp.UserId)
+		c.Set(CtxUsername, resp.Username)
+		c.Set(CtxScopes, resp.Scopes)
+		c.Set(CtxRole, resp.Role)
+
+		// Every gRPC call made with the request context carries the user
+		c.Request = c.Request.WithContext(metadata.AppendToOutgoingContext(c.Request.Context(),
+			mdUserID, strconv.FormatInt(resp.UserId, 10),
+			mdUsername, resp.Username,
+			mdUserRole, resp.Role,
+			mdAuthorization, "Bearer "+token,
+		))
+
+		c.Next()
+	}
+}
+
+// RequireScope rejects requests whose token wasn't granted the scope.
+// It must run after Auth.
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scopes := c.GetStringSlice(CtxScopes)
+		if !slices.Contains(scopes, scope) {
+			common.WriteProblemMessage(c, http.StatusForbidden, "token has no scope "+scope)
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Headers of the conditional requests.
+const (
+	HeaderETag        = "ETag"
+	HeaderIfNoneMatch = "If-None-Match"
+)
+
+// etagWriter holds the response back until its ETag is known.
+type etagWriter struct {
+	gin.ResponseWriter
+	body   bytes.Buffer
+	status int
+}
+
+func (w *etagWriter) WriteHeader(code int) {
+	w.status = code
+}
+
+func (w *etagWriter) WriteHeaderNow() {}
+
+func (w *etagWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *etagWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+func (w *etagWriter) Status() int {
+	return w.status
+}
+
+func (w *etagWriter) Size() int {
+	return w.body.Len()
+}
+
+func (w *etagWriter) Written() bool {
+	return w.status != 0 || w.body.Len() > 0
+}
+
+// ETag tags the successful responses with the hash of their body, unless
+// the handler tagged them, and answers 304 Not Modified when the client
+// already has it. The routes are
+// read-only, so it honours If-None-Match on the POST queries of v1 too.
+func ETag() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		w := &etagWriter{ResponseWriter: c.Writer, status: http.StatusOK}
+		c.Writer = w
+
+		c.Next()
+
+		c.Writer = w.ResponseWriter
+		if w.status != http.StatusOK {
+			c.Writer.WriteHeader(w.status)
+			_, _ = c.Writer.Write(w.body.Bytes())
+			return
+		}
+
+		// A tag set by the handler, e.g. the doc version, is kept
+		etag := c.Writer.Header().Get(HeaderETag)
+		if etag == "" {
+			sum := sha256.Sum256(w.body.Bytes())
+			etag = `"` + hex.EncodeToString(sum[:16]) + `"`
+			c.Header(HeaderETag, etag)
+		}
+		// The responses depend on the user, so only the client keeps them
+		// and checks they are still fresh every time
+		c.Header("Cache-Control", "private, no-cache")
+
+		if etagMatches(c.GetHeader(HeaderIfNoneMatch), etag) {
+			c.Writer.WriteHeader(http.StatusNotModified)
+			c.Writer.WriteHeaderNow()
+			return
+		}
+
+		c.Writer.WriteHeader(http.StatusOK)
+		_, _ = c.Writer.Write(w.body.Bytes())
+	}
+}
+
+// etagMatches compares the tags weakly, as If-None-Match wants.
+func etagMatches(header, etag string) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
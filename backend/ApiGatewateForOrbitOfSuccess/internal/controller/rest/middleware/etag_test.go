@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// serveETag runs the request through ETag and the handler.
+func serveETag(h gin.HandlerFunc, ifNoneMatch string) *httptest.ResponseRecorder {
+	r := gin.New()
+	r.GET("/", ETag(), h)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if ifNoneMatch != "" {
+		req.Header.Set(HeaderIfNoneMatch, ifNoneMatch)
+	}
+	r.ServeHTTP(w, req)
+
+	return w
+}
+
+func docHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"id": 7})
+}
+
+func TestETag(t *testing.T) {
+	w := serveETag(docHandler, "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id": 7}`, w.Body.String())
+	assert.Equal(t, "private, no-cache", w.Header().Get("Cache-Control"))
+	etag := w.Header().Get(HeaderETag)
+	assert.Regexp(t, `^"[0-9a-f]{32}"$`, etag)
+
+	// The same body gets the same tag
+	assert.Equal(t, etag, serveETag(docHandler, "").Header().Get(HeaderETag))
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		status      int
+	}{
+		{"current", etag, http.StatusNotModified},
+		{"weak", "W/" + etag, http.StatusNotModified},
+		{"one of many", `"old", ` + etag, http.StatusNotModified},
+		{"any", "*", http.StatusNotModified},
+		{"stale", `"old"`, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serveETag(docHandler, tt.ifNoneMatch)
+
+			assert.Equal(t, tt.status, w.Code)
+			assert.Equal(t, etag, w.Header().Get(HeaderETag))
+			if tt.status == http.StatusNotModified {
+				assert.Empty(t, w.Body.String())
+			} else {
+				assert.JSONEq(t, `{"id": 7}`, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestETagKeepsHandlerTag(t *testing.T) {
+	h := func(c *gin.Context) {
+		c.Header(HeaderETag, `"3"`)
+		docHandler(c)
+	}
+
+	w := serveETag(h, "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"3"`, w.Header().Get(HeaderETag))
+
+	w = serveETag(h, `"3"`)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+}
+
+func TestETagSkipsErrors(t *testing.T) {
+	h := func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+	}
+
+	w := serveETag(h, "*")
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.JSONEq(t, `{"error": "not found"}`, w.Body.String())
+	assert.Empty(t, w.Header().Get(HeaderETag))
+}
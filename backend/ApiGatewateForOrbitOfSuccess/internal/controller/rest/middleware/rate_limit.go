@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/common"
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/lib/ratelimit"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Headers telling the client about its limit.
+const (
+	HeaderRateLimitLimit     = "X-RateLimit-Limit"
+	HeaderRateLimitRemaining = "X-RateLimit-Remaining"
+	HeaderRateLimitReset     = "X-RateLimit-Reset"
+	HeaderRetryAfter         = "Retry-After"
+)
+
+// RateLimiter throttles the requests with a token bucket per client IP or
+// per user. Buckets are kept per route group, so each group has its limit.
+type RateLimiter struct {
+	store     ratelimit.Store
+	throttled *prometheus.CounterVec
+}
+
+func NewRateLimiter(store ratelimit.Store, reg prometheus.Registerer) *RateLimiter {
+	l := &RateLimiter{
+		store: store,
+		throttled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_throttled_total",
+			Help: "Number of HTTP requests rejected by the rate limit, by route group.",
+		}, []string{"group"}),
+	}
+	reg.MustRegister(l.throttled)
+
+	return l
+}
+
+// ByIP limits the requests of every client IP in the group.
+func (l *RateLimiter) ByIP(group string, limit ratelimit.Limit) gin.HandlerFunc {
+	return l.limit(group, limit, func(c *gin.Context) string {
+		return "ip:" + c.ClientIP()
+	})
+}
+
+// ByUser limits the requests of every user in the group. It must run after
+// Auth, requests without a user are limited by IP.
+func (l *RateLimiter) ByUser(group string, limit ratelimit.Limit) gin.HandlerFunc {
+	return l.limit(group, limit, func(c *gin.Context) string {
+		uid, ok := c.Get(CtxUserID)
+		if !ok {
+			return "ip:" + c.ClientIP()
+		}
+		return fmt.Sprintf("uid:%v", uid)
+	})
+}
+
+func (l *RateLimiter) limit(group string, limit ratelimit.Limit, key func(c *gin.Context) string) gin.HandlerFunc {
+	if limit.Disabled() {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	return func(c *gin.Context) {
+		res := l.store.Allow(group+":"+key(c), limit)
+
+		c.Header(HeaderRateLimitLimit, strconv.Itoa(res.Limit))
+		c.Header(HeaderRateLimitRemaining, strconv.Itoa(res.Remaining))
+		c.Header(HeaderRateLimitReset, ceilSeconds(res.Reset))
+
+		if !res.Allowed {
+			l.throttled.WithLabelValues(group).Inc()
+			retryAfter := ceilSeconds(res.RetryAfter)
+			c.Header(HeaderRetryAfter, retryAfter)
+			common.WriteProblemMessage(c, http.StatusTooManyRequests,
+				"too many requests, retry in "+retryAfter+"s")
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/lib/ratelimit"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// serve runs the request with the user set by an earlier middleware,
+// 0 for none.
+func serve(h gin.HandlerFunc, uid int64, ip string) *httptest.ResponseRecorder {
+	r := gin.New()
+	r.GET("/", func(c *gin.Context) {
+		if uid != 0 {
+			c.Set(CtxUserID, uid)
+		}
+	}, h, func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = ip + ":1234"
+	r.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestRateLimiterByIP(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	l := NewRateLimiter(ratelimit.NewMemoryStore(0), reg)
+	h := l.ByIP("auth", ratelimit.Limit{Requests: 2, Period: time.Minute})
+
+	w := serve(h, 0, "10.0.0.1")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "2", w.Header().Get(HeaderRateLimitLimit))
+	assert.Equal(t, "1", w.Header().Get(HeaderRateLimitRemaining))
+	assert.Equal(t, "30", w.Header().Get(HeaderRateLimitReset))
+
+	assert.Equal(t, http.StatusOK, serve(h, 0, "10.0.0.1").Code)
+
+	w = serve(h, 0, "10.0.0.1")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "30", w.Header().Get(HeaderRetryAfter))
+	assert.Equal(t, 1.0, testutil.ToFloat64(l.throttled.WithLabelValues("auth")))
+
+	assert.Equal(t, http.StatusOK, serve(h, 0, "10.0.0.2").Code)
+}
+
+func TestRateLimiterByUser(t *testing.T) {
+	l := NewRateLimiter(ratelimit.NewMemoryStore(0), prometheus.NewRegistry())
+	h := l.ByUser("user", ratelimit.Limit{Requests: 1, Period: time.Minute})
+
+	assert.Equal(t, http.StatusOK, serve(h, 7, "10.0.0.1").Code)
+	// The same user from another address shares the bucket
+	assert.Equal(t, http.StatusTooManyRequests, serve(h, 7, "10.0.0.2").Code)
+	// Another user behind the same address doesn't
+	assert.Equal(t, http.StatusOK, serve(h, 8, "10.0.0.1").Code)
+	// Without a user the address is counted
+	assert.Equal(t, http.StatusOK, serve(h, 0, "10.0.0.1").Code)
+	assert.Equal(t, http.StatusTooManyRequests, serve(h, 0, "10.0.0.1").Code)
+}
+
+func TestRateLimiterDisabled(t *testing.T) {
+	l := NewRateLimiter(ratelimit.NewMemoryStore(0), prometheus.NewRegistry())
+	h := l.ByIP("api", ratelimit.Limit{})
+
+	for range 5 {
+		w := serve(h, 0, "10.0.0.1")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get(HeaderRateLimitLimit))
+	}
+}
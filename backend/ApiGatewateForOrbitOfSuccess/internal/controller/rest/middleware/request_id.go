@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/common"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/metadata"
+)
+
+// CtxRequestID holds the id of the request.
+const CtxRequestID = "request_id"
+
+// mdRequestID passes the request id to downstream services.
+const mdRequestID = "x-request-id"
+
+// requestIDPattern limits ids taken from clients to something safe to log.
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,128}$`)
+
+// RequestID keeps the X-Request-ID of the client or uses the trace id, so
+// the id can be looked up in the tracing backend. The id is sent back in
+// the same header along with the traceparent of the request, shown in error
+// responses and passed to the gRPC calls made with the request context.
+// It must run after the tracing middleware.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		sc := trace.SpanContextFromContext(ctx)
+
+		id := c.GetHeader(common.RequestIDHeader)
+		if !requestIDPattern.MatchString(id) {
+			if sc.IsValid() {
+				id = sc.TraceID().String()
+			} else {
+				id = newRequestID()
+			}
+		}
+
+		c.Set(CtxRequestID, id)
+		c.Header(common.RequestIDHeader, id)
+		if sc.IsValid() {
+			propagation.TraceContext{}.Inject(ctx, propagation.HeaderCarrier(c.Writer.Header()))
+		}
+		c.Request = c.Request.WithContext(metadata.AppendToOutgoingContext(c.Request.Context(), mdRequestID, id))
+
+		c.Next()
+	}
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,79 @@
+package v1
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/common"
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/controller/rest/middleware"
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/entities"
+	authv1 "github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/proto/gen/auth"
+	"github.com/gin-gonic/gin"
+)
+
+type accountsRoutes struct {
+	s   authv1.AuthClient
+	log *slog.Logger
+}
+
+func NewAccountsRoutes(log *slog.Logger, handler *gin.RouterGroup, s authv1.AuthClient) {
+	r := &accountsRoutes{
+		log: log,
+		s:   s,
+	}
+
+	g := handler.Group("/auth/accounts")
+	{
+		g.PUT("/:id/role", r.setRole)
+	}
+}
+
+// @Summary     Set account role
+// @Description Change the role of the account. Only admins may do it, not on their own account. The role comes into effect with the next access token of the account.
+// @ID          Set account role
+// @Tags  	    Auth
+// @Accept      json
+// @Param       id path int true "account id"
+// @Param 		role body entities.SetRoleRequest true "role"
+// @Produce     json
+// @Success     200 {object} authv1.SetRoleResponse
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     404
+// @Failure     409
+// @Failure     500
+// @Failure     503
+// @Router      /v1/auth/accounts/{id}/role [put]
+func (r *accountsRoutes) setRole(c *gin.Context) {
+	const op = "accountsRoutes.setRole"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error(err.Error())
+		common.WriteProblemMessage(c, http.StatusBadRequest, "id must be a number")
+		return
+	}
+
+	var req *entities.SetRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error(err.Error())
+		common.WriteProblem(c, http.StatusBadRequest, common.GetErrMessages(err))
+		return
+	}
+
+	resp, err := r.s.SetRole(c.Request.Context(), req.ToGRPC(c.GetString(middleware.CtxAccessToken), id))
+	if err != nil {
+		code, err := common.GetProtoErrWithStatusCode(err)
+		log.Error(err.Error())
+		common.WriteProblem(c, code, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
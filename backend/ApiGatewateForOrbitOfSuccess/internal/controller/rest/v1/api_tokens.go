@@ -0,0 +1,141 @@
+package v1
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/common"
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/controller/rest/middleware"
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/entities"
+	authv1 "github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/proto/gen/auth"
+	"github.com/gin-gonic/gin"
+)
+
+type apiTokensRoutes struct {
+	s   authv1.AuthClient
+	log *slog.Logger
+}
+
+func NewAPITokensRoutes(log *slog.Logger, handler *gin.RouterGroup, s authv1.AuthClient) {
+	r := &apiTokensRoutes{
+		log: log,
+		s:   s,
+	}
+
+	g := handler.Group("/auth/api_tokens")
+	{
+		g.POST("", r.create)
+		g.GET("", r.list)
+		g.DELETE("/:id", r.revoke)
+	}
+}
+
+// @Summary     Create API token
+// @Description Create personal API token. The secret is returned only once.
+// @ID          Create API token
+// @Tags  	    Auth
+// @Accept      json
+// @Param 		token body entities.CreateAPITokenRequest false "token"
+// @Produce     json
+// @Success     200 {object} authv1.CreateAPITokenResponse
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     500
+// @Failure     503
+// @Router      /v1/auth/api_tokens [post]
+func (r *apiTokensRoutes) create(c *gin.Context) {
+	const op = "apiTokensRoutes.create"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	var req *entities.CreateAPITokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error(err.Error())
+		common.WriteProblem(c, http.StatusBadRequest, common.GetErrMessages(err))
+		return
+	}
+
+	resp, err := r.s.CreateAPIToken(c.Request.Context(), req.ToGRPC(c.GetString(middleware.CtxAccessToken)))
+	if err != nil {
+		code, err := common.GetProtoErrWithStatusCode(err)
+		log.Error(err.Error())
+		common.WriteProblem(c, code, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// @Summary     List API tokens
+// @Description List personal API tokens without their secrets
+// @ID          List API tokens
+// @Tags  	    Auth
+// @Produce     json
+// @Success     200 {object} authv1.ListAPITokensResponse
+// @Failure     401
+// @Failure     403
+// @Failure     500
+// @Failure     503
+// @Router      /v1/auth/api_tokens [get]
+func (r *apiTokensRoutes) list(c *gin.Context) {
+	const op = "apiTokensRoutes.list"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	req := &authv1.ListAPITokensRequest{AccessToken: c.GetString(middleware.CtxAccessToken)}
+	resp, err := r.s.ListAPITokens(c.Request.Context(), req)
+	if err != nil {
+		code, err := common.GetProtoErrWithStatusCode(err)
+		log.Error(err.Error())
+		common.WriteProblem(c, code, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// @Summary     Revoke API token
+// @Description Revoke personal API token
+// @ID          Revoke API token
+// @Tags  	    Auth
+// @Param       id path int true "token id"
+// @Produce     json
+// @Success     200 {object} authv1.RevokeAPITokenResponse
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     404
+// @Failure     500
+// @Failure     503
+// @Router      /v1/auth/api_tokens/{id} [delete]
+func (r *apiTokensRoutes) revoke(c *gin.Context) {
+	const op = "apiTokensRoutes.revoke"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error(err.Error())
+		common.WriteProblemMessage(c, http.StatusBadRequest, "id must be a number")
+		return
+	}
+
+	req := &authv1.RevokeAPITokenRequest{AccessToken: c.GetString(middleware.CtxAccessToken), Id: id}
+	resp, err := r.s.RevokeAPIToken(c.Request.Context(), req)
+	if err != nil {
+		code, err := common.GetProtoErrWithStatusCode(err)
+		log.Error(err.Error())
+		common.WriteProblem(c, code, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
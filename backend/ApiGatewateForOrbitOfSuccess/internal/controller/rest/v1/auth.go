@@ -15,7 +15,9 @@ type authRoutes struct {
 	log *slog.Logger
 }
 
-func NewAuthRoutes(log *slog.Logger, handler *gin.RouterGroup, s authv1.AuthClient) {
+// NewAuthRoutes adds the routes of the account life cycle. credentialsLimit
+// runs before register and login only, which take a password.
+func NewAuthRoutes(log *slog.Logger, handler *gin.RouterGroup, s authv1.AuthClient, credentialsLimit gin.HandlerFunc) {
 	r := &authRoutes{
 		log: log,
 		s:   s,
@@ -23,8 +25,8 @@ func NewAuthRoutes(log *slog.Logger, handler *gin.RouterGroup, s authv1.AuthClie
 
 	g := handler.Group("/auth")
 	{
-		g.POST("/register", r.register)
-		g.POST("/login", r.login)
+		g.POST("/register", credentialsLimit, r.register)
+		g.POST("/login", credentialsLimit, r.login)
 		g.POST("/logout", r.logout)
 		g.POST("/activate_account", r.activateAccount)
 		g.POST("/refresh", r.refresh)
@@ -45,7 +47,7 @@ func NewAuthRoutes(log *slog.Logger, handler *gin.RouterGroup, s authv1.AuthClie
 // @Failure     404
 // @Failure     500
 // @Failure     503
-// @Router      /auth/register [post]
+// @Router      /v1/auth/register [post]
 func (r *authRoutes) register(c *gin.Context) {
 	const op = "authRoutes.register"
 
@@ -56,7 +58,7 @@ func (r *authRoutes) register(c *gin.Context) {
 	var req *entities.RegisterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		log.Error(err.Error())
-		c.JSON(http.StatusBadRequest, gin.H{"error": common.GetErrMessages(err).Error()})
+		common.WriteProblem(c, http.StatusBadRequest, common.GetErrMessages(err))
 		return
 	}
 
@@ -64,7 +66,7 @@ func (r *authRoutes) register(c *gin.Context) {
 	if err != nil {
 		code, err := common.GetProtoErrWithStatusCode(err)
 		log.Error(err.Error())
-		c.JSON(code, gin.H{"error": err.Error()})
+		common.WriteProblem(c, code, err)
 		return
 	}
 
@@ -84,7 +86,7 @@ func (r *authRoutes) register(c *gin.Context) {
 // @Failure     404
 // @Failure     500
 // @Failure     503
-// @Router      /auth/login [post]
+// @Router      /v1/auth/login [post]
 func (r *authRoutes) login(c *gin.Context) {
 	const op = "authRoutes.login"
 
@@ -95,7 +97,7 @@ func (r *authRoutes) login(c *gin.Context) {
 	var req *entities.LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		log.Error(err.Error())
-		c.JSON(http.StatusBadRequest, gin.H{"error": common.GetErrMessages(err).Error()})
+		common.WriteProblem(c, http.StatusBadRequest, common.GetErrMessages(err))
 		return
 	}
 
@@ -103,7 +105,7 @@ func (r *authRoutes) login(c *gin.Context) {
 	if err != nil {
 		code, err := common.GetProtoErrWithStatusCode(err)
 		log.Error(err.Error())
-		c.JSON(code, gin.H{"error": err.Error()})
+		common.WriteProblem(c, code, err)
 		return
 	}
 
@@ -122,7 +124,7 @@ func (r *authRoutes) login(c *gin.Context) {
 // @Failure     404
 // @Failure     500
 // @Failure     503
-// @Router      /auth/logout [post]
+// @Router      /v1/auth/logout [post]
 func (r *authRoutes) logout(c *gin.Context) {
 	const op = "authRoutes.logout"
 
@@ -133,7 +135,7 @@ func (r *authRoutes) logout(c *gin.Context) {
 	var req *entities.LogoutRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		log.Error(err.Error())
-		c.JSON(http.StatusBadRequest, gin.H{"error": common.GetErrMessages(err).Error()})
+		common.WriteProblem(c, http.StatusBadRequest, common.GetErrMessages(err))
 		return
 	}
 
@@ -141,7 +143,7 @@ func (r *authRoutes) logout(c *gin.Context) {
 	if err != nil {
 		code, err := common.GetProtoErrWithStatusCode(err)
 		log.Error(err.Error())
-		c.JSON(code, gin.H{"error": err.Error()})
+		common.WriteProblem(c, code, err)
 		return
 	}
 
@@ -160,7 +162,7 @@ func (r *authRoutes) logout(c *gin.Context) {
 // @Failure     404
 // @Failure     500
 // @Failure     503
-// @Router      /auth/activate_account [post]
+// @Router      /v1/auth/activate_account [post]
 func (r *authRoutes) activateAccount(c *gin.Context) {
 	const op = "authRoutes.activateAccount"
 
@@ -171,7 +173,7 @@ func (r *authRoutes) activateAccount(c *gin.Context) {
 	var req *entities.ActivateAccountRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		log.Error(err.Error())
-		c.JSON(http.StatusBadRequest, gin.H{"error": common.GetErrMessages(err).Error()})
+		common.WriteProblem(c, http.StatusBadRequest, common.GetErrMessages(err))
 		return
 	}
 
@@ -179,7 +181,7 @@ func (r *authRoutes) activateAccount(c *gin.Context) {
 	if err != nil {
 		code, err := common.GetProtoErrWithStatusCode(err)
 		log.Error(err.Error())
-		c.JSON(code, gin.H{"error": err.Error()})
+		common.WriteProblem(c, code, err)
 		return
 	}
 
@@ -199,7 +201,7 @@ func (r *authRoutes) activateAccount(c *gin.Context) {
 // @Failure     404
 // @Failure     500
 // @Failure     503
-// @Router      /auth/refresh [post]
+// @Router      /v1/auth/refresh [post]
 func (r *authRoutes) refresh(c *gin.Context) {
 	const op = "authRoutes.activateArefreshccount"
 
@@ -210,7 +212,7 @@ func (r *authRoutes) refresh(c *gin.Context) {
 	var req *entities.RefreshRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		log.Error(err.Error())
-		c.JSON(http.StatusBadRequest, gin.H{"error": common.GetErrMessages(err).Error()})
+		common.WriteProblem(c, http.StatusBadRequest, common.GetErrMessages(err))
 		return
 	}
 
@@ -218,7 +220,7 @@ func (r *authRoutes) refresh(c *gin.Context) {
 	if err != nil {
 		code, err := common.GetProtoErrWithStatusCode(err)
 		log.Error(err.Error())
-		c.JSON(code, gin.H{"error": err.Error()})
+		common.WriteProblem(c, code, err)
 		return
 	}
 
@@ -238,7 +240,7 @@ func (r *authRoutes) refresh(c *gin.Context) {
 // @Failure     404
 // @Failure     500
 // @Failure     503
-// @Router      /auth/send_password_link [post]
+// @Router      /v1/auth/send_password_link [post]
 func (r *authRoutes) sndPwdLink(c *gin.Context) {
 	const op = "authRoutes.sndPwdLink"
 
@@ -249,7 +251,7 @@ func (r *authRoutes) sndPwdLink(c *gin.Context) {
 	var req *entities.SendPasswordLinkRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		log.Error(err.Error())
-		c.JSON(http.StatusBadRequest, gin.H{"error": common.GetErrMessages(err).Error()})
+		common.WriteProblem(c, http.StatusBadRequest, common.GetErrMessages(err))
 		return
 	}
 
@@ -257,7 +259,7 @@ func (r *authRoutes) sndPwdLink(c *gin.Context) {
 	if err != nil {
 		code, err := common.GetProtoErrWithStatusCode(err)
 		log.Error(err.Error())
-		c.JSON(code, gin.H{"error": err.Error()})
+		common.WriteProblem(c, code, err)
 		return
 	}
 
@@ -277,7 +279,7 @@ func (r *authRoutes) sndPwdLink(c *gin.Context) {
 // @Failure     404
 // @Failure     500
 // @Failure     503
-// @Router      /auth/change_password [post]
+// @Router      /v1/auth/change_password [post]
 func (r *authRoutes) changePwd(c *gin.Context) {
 	const op = "authRoutes.changePwd"
 
@@ -288,7 +290,7 @@ func (r *authRoutes) changePwd(c *gin.Context) {
 	var req *entities.ChangePasswordRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		log.Error(err.Error())
-		c.JSON(http.StatusBadRequest, gin.H{"error": common.GetErrMessages(err).Error()})
+		common.WriteProblem(c, http.StatusBadRequest, common.GetErrMessages(err))
 		return
 	}
 
@@ -296,7 +298,7 @@ func (r *authRoutes) changePwd(c *gin.Context) {
 	if err != nil {
 		code, err := common.GetProtoErrWithStatusCode(err)
 		log.Error(err.Error())
-		c.JSON(code, gin.H{"error": err.Error()})
+		common.WriteProblem(c, code, err)
 		return
 	}
 
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/common"
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/controller/rest/middleware"
 	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/entities"
 	docsv1 "github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/proto/gen/docs"
 	"github.com/gin-gonic/gin"
@@ -23,16 +24,19 @@ func NewDocsRoutes(log *slog.Logger, handler *gin.RouterGroup, s docsv1.DocsClie
 
 	g := handler.Group("/docs")
 	{
-		g.POST("/create", r.create)
-		g.POST("/delete", r.delete)
-		g.POST("/filtered", r.getFilterd)
-		g.POST("/search", r.search)
-		g.POST("/update", r.update)
+		g.POST("/create", middleware.RequireScope(entities.ScopeDocsWrite), r.create)
+		g.POST("/delete", middleware.RequireScope(entities.ScopeDocsWrite), r.delete)
+		g.POST("/filtered", middleware.RequireScope(entities.ScopeDocsRead), middleware.ETag(), r.getFilterd)
+		g.POST("/search", middleware.RequireScope(entities.ScopeDocsRead), middleware.ETag(), r.search)
+		g.POST("/update", middleware.RequireScope(entities.ScopeDocsWrite), r.update)
+		g.POST("/similar", middleware.RequireScope(entities.ScopeDocsRead), r.similar)
+		g.GET("/stats", middleware.RequireScope(entities.ScopeDocsRead), r.stats)
 	}
 }
 
 // @Summary     Create
-// @Description Create
+// @Description Create. Refused with 409 and the list of similar themes unless force is set.
+// @Description Enforced workload limits refuse it with 409 and conflicts, otherwise they come as workload_warnings.
 // @ID          Create
 // @Tags  	    Docs
 // @Accept      json
@@ -41,9 +45,10 @@ func NewDocsRoutes(log *slog.Logger, handler *gin.RouterGroup, s docsv1.DocsClie
 // @Success     200 {object} entities.SuccessResponse
 // @Failure     400
 // @Failure     404
+// @Failure     409 {object} entities.SimilarThemesResponse
 // @Failure     500
 // @Failure     503
-// @Router      /docs/create [post]
+// @Router      /v1/docs/create [post]
 func (r *docsRoutes) create(c *gin.Context) {
 	const op = "docsRoutes.create"
 
@@ -54,7 +59,7 @@ func (r *docsRoutes) create(c *gin.Context) {
 	var req *entities.CreateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		log.Error(err.Error())
-		c.JSON(http.StatusBadRequest, gin.H{"error": common.GetErrMessages(err).Error()})
+		common.WriteProblem(c, http.StatusBadRequest, common.GetErrMessages(err))
 		return
 	}
 
@@ -62,13 +67,62 @@ func (r *docsRoutes) create(c *gin.Context) {
 	if err != nil {
 		code, err := common.GetProtoErrWithStatusCode(err)
 		log.Error(err.Error())
-		c.JSON(code, gin.H{"error": err.Error()})
+		common.WriteProblem(c, code, err)
+		return
+	}
+	if !resp.Success {
+		common.AbortWithProblem(c, http.StatusConflict, entities.SimilarThemesResponse{
+			Problem: *common.NewProblem(c, http.StatusConflict, &common.APIError{
+				Code:    entities.CodeSimilarThemes,
+				Message: entities.SimilarThemesMessage,
+			}),
+			SimilarThemes: entities.SimilarThemesFromGRPC(resp.SimilarThemes),
+		})
 		return
 	}
 
 	c.JSON(http.StatusOK, resp)
 }
 
+// @Summary     Similar themes
+// @Description Find docs whose themes share most words with the given one
+// @ID          Similar themes
+// @Tags  	    Docs
+// @Accept      json
+// @Param 		similar body entities.FindSimilarThemesRequest true "theme"
+// @Produce     json
+// @Success     200 {object} entities.FindSimilarThemesResponse
+// @Failure     400
+// @Failure     500
+// @Failure     503
+// @Router      /v1/docs/similar [post]
+func (r *docsRoutes) similar(c *gin.Context) {
+	const op = "docsRoutes.similar"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	var req *entities.FindSimilarThemesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error(err.Error())
+		common.WriteProblem(c, http.StatusBadRequest, common.GetErrMessages(err))
+		return
+	}
+
+	resp, err := r.s.FindSimilarThemes(c.Request.Context(), req.ToGRPC())
+	if err != nil {
+		code, err := common.GetProtoErrWithStatusCode(err)
+		log.Error(err.Error())
+		common.WriteProblem(c, code, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entities.FindSimilarThemesResponse{
+		SimilarThemes: entities.SimilarThemesFromGRPC(resp.SimilarThemes),
+	})
+}
+
 // @Summary     Delete
 // @Description Delete
 // @ID          Delte
@@ -81,7 +135,7 @@ func (r *docsRoutes) create(c *gin.Context) {
 // @Failure     404
 // @Failure     500
 // @Failure     503
-// @Router      /docs/delete [post]
+// @Router      /v1/docs/delete [post]
 func (r *docsRoutes) delete(c *gin.Context) {
 	const op = "docsRoutes.delete"
 
@@ -92,7 +146,7 @@ func (r *docsRoutes) delete(c *gin.Context) {
 	var req *entities.DeleteRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		log.Error(err.Error())
-		c.JSON(http.StatusBadRequest, gin.H{"error": common.GetErrMessages(err).Error()})
+		common.WriteProblem(c, http.StatusBadRequest, common.GetErrMessages(err))
 		return
 	}
 
@@ -100,7 +154,7 @@ func (r *docsRoutes) delete(c *gin.Context) {
 	if err != nil {
 		code, err := common.GetProtoErrWithStatusCode(err)
 		log.Error(err.Error())
-		c.JSON(code, gin.H{"error": err.Error()})
+		common.WriteProblem(c, code, err)
 		return
 	}
 
@@ -113,13 +167,15 @@ func (r *docsRoutes) delete(c *gin.Context) {
 // @Tags  	    Docs
 // @Accept      json
 // @Param 		delete body entities.GetFilteredRequest false "get filtered"
+// @Param       If-None-Match header string false "ETag of the response the client has"
 // @Produce     json
 // @Success     200 {object} entities.GetResponse
+// @Success     304 "not modified"
 // @Failure     400
 // @Failure     404
 // @Failure     500
 // @Failure     503
-// @Router      /docs/filtered [post]
+// @Router      /v1/docs/filtered [post]
 func (r *docsRoutes) getFilterd(c *gin.Context) {
 	const op = "docsRoutes.getFilterd"
 
@@ -130,7 +186,7 @@ func (r *docsRoutes) getFilterd(c *gin.Context) {
 	var req *entities.GetFilteredRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		log.Error(err.Error())
-		c.JSON(http.StatusBadRequest, gin.H{"error": common.GetErrMessages(err).Error()})
+		common.WriteProblem(c, http.StatusBadRequest, common.GetErrMessages(err))
 		return
 	}
 
@@ -138,26 +194,67 @@ func (r *docsRoutes) getFilterd(c *gin.Context) {
 	if err != nil {
 		code, err := common.GetProtoErrWithStatusCode(err)
 		log.Error(err.Error())
-		c.JSON(code, gin.H{"error": err.Error()})
+		common.WriteProblem(c, code, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, resp)
 }
 
+// @Summary     Stats
+// @Description Count docs grouped by year, type, group, director, reviewer or discipline.
+// @Description With top only the largest groups are returned, with compare the year before is counted too.
+// @ID          Stats
+// @Tags  	    Docs
+// @Param       query query entities.StatsQuery true "grouping and filters"
+// @Produce     json
+// @Success     200 {object} entities.Stats
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     500
+// @Failure     503
+// @Router      /v1/docs/stats [get]
+func (r *docsRoutes) stats(c *gin.Context) {
+	const op = "docsRoutes.stats"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	var query entities.StatsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		log.Error(err.Error())
+		common.WriteProblem(c, http.StatusBadRequest, common.GetErrMessages(err))
+		return
+	}
+
+	resp, err := r.s.GetStats(c.Request.Context(), query.ToGRPC())
+	if err != nil {
+		code, err := common.GetProtoErrWithStatusCode(err)
+		log.Error(err.Error())
+		common.WriteProblem(c, code, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entities.StatsFromGRPC(resp, query.Compare))
+}
+
 // @Summary     Search
 // @Description Search
 // @ID          Search
 // @Tags  	    Docs
 // @Accept      json
 // @Param 		delete body entities.SearchRequest false "search"
+// @Param       If-None-Match header string false "ETag of the response the client has"
 // @Produce     json
 // @Success     200 {object} entities.GetResponse
+// @Success     304 "not modified"
 // @Failure     400
 // @Failure     404
 // @Failure     500
 // @Failure     503
-// @Router      /docs/search [post]
+// @Router      /v1/docs/search [post]
 func (r *docsRoutes) search(c *gin.Context) {
 	const op = "docsRoutes.search"
 
@@ -168,7 +265,7 @@ func (r *docsRoutes) search(c *gin.Context) {
 	var req *entities.SearchRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		log.Error(err.Error())
-		c.JSON(http.StatusBadRequest, gin.H{"error": common.GetErrMessages(err).Error()})
+		common.WriteProblem(c, http.StatusBadRequest, common.GetErrMessages(err))
 		return
 	}
 
@@ -176,7 +273,7 @@ func (r *docsRoutes) search(c *gin.Context) {
 	if err != nil {
 		code, err := common.GetProtoErrWithStatusCode(err)
 		log.Error(err.Error())
-		c.JSON(code, gin.H{"error": err.Error()})
+		common.WriteProblem(c, code, err)
 		return
 	}
 
@@ -184,18 +281,19 @@ func (r *docsRoutes) search(c *gin.Context) {
 }
 
 // @Summary     Update
-// @Description Update
+// @Description Update the fields present in the body. The version of the doc is required, a stale one is refused with 409.
 // @ID          Update
 // @Tags  	    Docs
 // @Accept      json
 // @Param 		update body entities.UpdateRequest false "update"
 // @Produce     json
-// @Success     200 {object} entities.SuccessResponse
+// @Success     200 {object} entities.UpdateResponse
 // @Failure     400
 // @Failure     404
+// @Failure     409
 // @Failure     500
 // @Failure     503
-// @Router      /docs/update [post]
+// @Router      /v1/docs/update [post]
 func (r *docsRoutes) update(c *gin.Context) {
 	const op = "docsRoutes.update"
 
@@ -206,7 +304,11 @@ func (r *docsRoutes) update(c *gin.Context) {
 	var req *entities.UpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		log.Error(err.Error())
-		c.JSON(http.StatusBadRequest, gin.H{"error": common.GetErrMessages(err).Error()})
+		common.WriteProblem(c, http.StatusBadRequest, common.GetErrMessages(err))
+		return
+	}
+	if req.IsEmpty() {
+		common.WriteProblemMessage(c, http.StatusBadRequest, "nothing to update")
 		return
 	}
 
@@ -214,7 +316,7 @@ func (r *docsRoutes) update(c *gin.Context) {
 	if err != nil {
 		code, err := common.GetProtoErrWithStatusCode(err)
 		log.Error(err.Error())
-		c.JSON(code, gin.H{"error": err.Error()})
+		common.WriteProblem(c, code, err)
 		return
 	}
 
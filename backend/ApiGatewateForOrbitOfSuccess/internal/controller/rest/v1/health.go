@@ -0,0 +1,77 @@
+package v1
+
+import (
+	"log/slog"
+	"net/http"
+	"sync"
+
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/entities"
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/services"
+	"github.com/gin-gonic/gin"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type healthRoutes struct {
+	log    *slog.Logger
+	checks map[string]healthpb.HealthClient
+}
+
+// NewHealthRoutes adds the probes. Liveness only shows the process runs,
+// readiness checks the services through the gRPC health protocol.
+func NewHealthRoutes(log *slog.Logger, handler *gin.Engine, checks map[string]healthpb.HealthClient) {
+	r := &healthRoutes{
+		log:    log,
+		checks: checks,
+	}
+
+	handler.GET("/livez", r.live)
+	// Kept for the probes set up before /livez
+	handler.GET("/healthz", r.live)
+	handler.GET("/readyz", r.ready)
+}
+
+func (r *healthRoutes) live(c *gin.Context) {
+	c.Status(http.StatusOK)
+}
+
+func (r *healthRoutes) ready(c *gin.Context) {
+	const op = "healthRoutes.ready"
+
+	resp := &entities.ReadinessResponse{
+		Status: entities.StatusReady,
+		Checks: make(map[string]string, len(r.checks)),
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for name, client := range r.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			res := healthpb.HealthCheckResponse_SERVING.String()
+			err := services.CheckHealth(c.Request.Context(), client)
+			if err != nil {
+				res = err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			resp.Checks[name] = res
+			if err != nil {
+				resp.Status = entities.StatusNotReady
+			}
+		}()
+	}
+	wg.Wait()
+
+	if resp.Status != entities.StatusReady {
+		r.log.With(slog.String("op", op)).Warn("not ready", slog.Any("checks", resp.Checks))
+		c.JSON(http.StatusServiceUnavailable, resp)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
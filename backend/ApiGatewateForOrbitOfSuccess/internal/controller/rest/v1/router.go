@@ -4,64 +4,122 @@ package v1
 import (
 	"log/slog"
 	"net/http"
+	"slices"
+	"strings"
 
-	_ "github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/docs"
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/docs"
 
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/common"
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/config"
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/controller/rest/middleware"
+	v2 "github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/controller/rest/v2"
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/lib/ratelimit"
 	authv1 "github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/proto/gen/auth"
 	docsv1 "github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/proto/gen/docs"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 type Clients struct {
 	Auth authv1.AuthClient
 	Docs docsv1.DocsClient
+	// Health checks the services by name for readiness
+	Health map[string]healthpb.HealthClient
 }
 
+// NewRouter sets up the routes. The metrics are registered in reg and
+// served from it on /metrics.
+//
 // Swagger spec:
 // @title       API Gatewate
 // @description API Gatewate for service
 // @version     1.0
-// @schemes 	https
-// @host        77.51.223.54:5173
-// @BasePath    /api/v1
+// @BasePath    /api
 // @securityDefinitions.apikey ApiKeyAuth
 // @in header
 // @name Authorization
-func NewRouter(handler *gin.Engine, c Clients, log *slog.Logger) {
+func NewRouter(handler *gin.Engine, c Clients, log *slog.Logger, cfg *config.Config, reg *prometheus.Registry) {
 	// Options
-	handler.Use(gin.Logger())
-	handler.Use(gin.Recovery())
+	handler.Use(otelgin.Middleware(cfg.Tracing.ServiceName, otelgin.WithFilter(traced)))
+	handler.Use(middleware.RequestID())
+	handler.Use(middleware.Logger(log))
+	handler.Use(middleware.Metrics(reg))
+	handler.Use(gin.CustomRecovery(func(c *gin.Context, _ any) {
+		common.WriteProblemMessage(c, http.StatusInternalServerError, "Internal server error")
+	}))
+	handler.NoRoute(func(c *gin.Context) {
+		common.WriteProblemMessage(c, http.StatusNotFound, "route not found")
+	})
 
 	// Set cors
 	corsConf := cors.DefaultConfig()
-	corsConf.AllowOrigins = []string{"http://localhost:5173", "http://77.51.223.54:5173"}
-	corsConf.AllowHeaders = []string{"Content-Type", "Content-Length", "Accept-Encoding", "X-CSRF-Token", "Authorization", "accept", "origin", "Cache-Control", "X-Requested-With"}
-	corsConf.AllowCredentials = true
+	corsConf.AllowOrigins = cfg.HTTP.CORS.AllowOrigins
+	corsConf.AllowHeaders = append(slices.Clone(cfg.HTTP.CORS.AllowHeaders), common.RequestIDHeader, "traceparent", "tracestate", middleware.HeaderIfNoneMatch)
+	corsConf.ExposeHeaders = []string{common.RequestIDHeader, "traceparent", middleware.HeaderETag,
+		middleware.HeaderRateLimitLimit, middleware.HeaderRateLimitRemaining, middleware.HeaderRateLimitReset, middleware.HeaderRetryAfter}
+	corsConf.AllowCredentials = cfg.HTTP.CORS.AllowCredentials
 	handler.Use(cors.New(corsConf))
 
 	// Swagger
+	docs.SwaggerInfo.Host = cfg.Swagger.Host
+	docs.SwaggerInfo.Schemes = cfg.Swagger.Schemes
 	swaggerHandler := ginSwagger.DisablingWrapHandler(swaggerFiles.Handler, "DISABLE_SWAGGER_HTTP_HANDLER")
 	handler.GET("/swagger/*any", swaggerHandler)
 
-	// K8s probe
-	handler.GET("/healthz", func(c *gin.Context) { c.Status(http.StatusOK) })
+	// K8s probes
+	NewHealthRoutes(log, handler, c.Health)
 
 	// Prometheus metrics
-	handler.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	handler.GET("/metrics", gin.WrapH(promhttp.HandlerFor(reg, promhttp.HandlerOpts{Registry: reg})))
+
+	// Rate limits
+	rl := cfg.RateLimit
+	limiter := middleware.NewRateLimiter(ratelimit.NewMemoryStore(rl.IdleTTL), reg)
+	authLimit := limiter.ByIP("auth", ratelimit.Limit{Requests: rl.AuthRequests, Period: rl.Period, Burst: rl.AuthBurst})
+	ipLimit := limiter.ByIP("api", ratelimit.Limit{Requests: rl.IPRequests, Period: rl.Period, Burst: rl.IPBurst})
+	userLimit := limiter.ByUser("user", ratelimit.Limit{Requests: rl.UserRequests, Period: rl.Period, Burst: rl.UserBurst})
 
 	// Routers
+	// The auth routes come without an access token, so the user limit
+	// counts them by IP. Password guessing gets the stricter auth limit.
 	g := handler.Group("/api/v1")
 	{
-		NewAuthRoutes(log, g, c.Auth)
+		g.Use(ipLimit, userLimit)
+		NewAuthRoutes(log, g, c.Auth, authLimit)
 	}
 
+	// The IP limit runs before Auth, so bad tokens are throttled before they
+	// are verified. It is far above the user limit, as a NAT full of users
+	// shares it.
 	ga := handler.Group("/api/v1")
 	{
-		ga.Use(authMiddleware(log, c.Auth))
+		ga.Use(ipLimit, middleware.Auth(log, c.Auth), userLimit)
+		NewAPITokensRoutes(log, ga, c.Auth)
+		NewAccountsRoutes(log, ga, c.Auth)
 		NewDocsRoutes(log, ga, c.Docs)
 	}
+
+	gv2 := handler.Group("/api/v2")
+	{
+		gv2.Use(ipLimit, middleware.Auth(log, c.Auth), userLimit)
+		v2.NewDocsRoutes(log, gv2, c.Docs)
+		v2.NewScheduleRoutes(log, gv2, c.Docs)
+		v2.NewWorkloadRoutes(log, gv2, c.Docs)
+		v2.NewOrderRoutes(log, gv2, c.Docs)
+	}
+}
+
+// traced leaves probes, metrics and swagger out of tracing.
+func traced(r *http.Request) bool {
+	switch r.URL.Path {
+	case "/livez", "/readyz", "/healthz", "/metrics":
+		return false
+	}
+	return !strings.HasPrefix(r.URL.Path, "/swagger/")
 }
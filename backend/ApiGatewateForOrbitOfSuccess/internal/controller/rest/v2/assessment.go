@@ -0,0 +1,313 @@
+package v2
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/common"
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/entities"
+	docsv1 "github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/proto/gen/docs"
+	"github.com/gin-gonic/gin"
+)
+
+// sendFile replies with the file as an attachment.
+func sendFile(c *gin.Context, f *docsv1.File) {
+	contentType := f.Type
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", f.Name))
+	c.Data(http.StatusOK, contentType, f.Content)
+}
+
+// @Summary     Get doc assessment
+// @Description Review, supervisor feedback and defense protocol submitted so far. Missing parts are null.
+// @ID          Get doc assessment
+// @Tags  	    Docs v2
+// @Param       id path int true "doc id"
+// @Produce     json
+// @Success     200 {object} entities.Assessment
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     404
+// @Failure     500
+// @Failure     503
+// @Router      /v2/docs/{id}/assessment [get]
+func (r *docsRoutes) getAssessment(c *gin.Context) {
+	const op = "v2.docsRoutes.getAssessment"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := r.s.GetAssessment(c.Request.Context(), &docsv1.GetAssessmentRequest{DocId: id})
+	if err != nil {
+		code, err := common.GetProtoErrWithStatusCode(err)
+		log.Error(err.Error())
+		common.WriteProblem(c, code, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entities.AssessmentFromGRPC(resp))
+}
+
+// @Summary     Submit review
+// @Description Submit the reviewer's grade with a text, a file or both. Only the account linked to the doc's reviewer may do it, as well as secretaries and admins.
+// @ID          Submit review
+// @Tags  	    Docs v2
+// @Accept      multipart/form-data
+// @Param       id path int true "doc id"
+// @Param       grade formData int true "grade 2..5"
+// @Param       text formData string false "review text"
+// @Param       file formData file false "review file, up to 3 MiB"
+// @Produce     json
+// @Success     200 {object} entities.Review
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     404
+// @Failure     413
+// @Failure     500
+// @Failure     503
+// @Router      /v2/docs/{id}/review [put]
+func (r *docsRoutes) submitReview(c *gin.Context) {
+	const op = "v2.docsRoutes.submitReview"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	var form entities.SubmitReviewForm
+	if err := c.ShouldBind(&form); err != nil {
+		log.Error(err.Error())
+		common.WriteProblem(c, http.StatusBadRequest, common.GetErrMessages(err))
+		return
+	}
+
+	req := &docsv1.SubmitReviewRequest{
+		DocId: id,
+		Grade: int32(form.Grade),
+		Text:  form.Text,
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil && err != http.ErrMissingFile {
+		log.Error(err.Error())
+		common.WriteProblemMessage(c, http.StatusBadRequest, "failed to read file")
+		return
+	}
+	if header != nil {
+		if header.Size > entities.MaxReviewFileSize {
+			common.WriteProblemMessage(c, http.StatusRequestEntityTooLarge, "review file must not exceed 3 MiB")
+			return
+		}
+
+		f, err := header.Open()
+		if err != nil {
+			log.Error(err.Error())
+			common.WriteProblemMessage(c, http.StatusBadRequest, "failed to read file")
+			return
+		}
+		defer f.Close()
+
+		req.File, err = io.ReadAll(f)
+		if err != nil {
+			log.Error(err.Error())
+			common.WriteProblemMessage(c, http.StatusBadRequest, "failed to read file")
+			return
+		}
+		req.FileName = header.Filename
+		req.FileType = header.Header.Get("Content-Type")
+	}
+
+	resp, err := r.s.SubmitReview(c.Request.Context(), req)
+	if err != nil {
+		code, err := common.GetProtoErrWithStatusCode(err)
+		log.Error(err.Error())
+		common.WriteProblem(c, code, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entities.ReviewFromGRPC(resp))
+}
+
+// @Summary     Get review file
+// @Description Download the file attached to the review
+// @ID          Get review file
+// @Tags  	    Docs v2
+// @Param       id path int true "doc id"
+// @Produce     octet-stream
+// @Success     200 {file} file
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     404
+// @Failure     500
+// @Failure     503
+// @Router      /v2/docs/{id}/review/file [get]
+func (r *docsRoutes) getReviewFile(c *gin.Context) {
+	const op = "v2.docsRoutes.getReviewFile"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := r.s.GetReviewFile(c.Request.Context(), &docsv1.GetReviewFileRequest{DocId: id})
+	if err != nil {
+		code, err := common.GetProtoErrWithStatusCode(err)
+		log.Error(err.Error())
+		common.WriteProblem(c, code, err)
+		return
+	}
+
+	sendFile(c, resp)
+}
+
+// @Summary     Submit supervisor feedback
+// @Description Submit the supervisor's feedback. Only the accounts linked to the doc's supervisors may do it, as well as secretaries and admins.
+// @ID          Submit supervisor feedback
+// @Tags  	    Docs v2
+// @Accept      json
+// @Param       id path int true "doc id"
+// @Param       feedback body entities.SubmitFeedbackRequest true "feedback"
+// @Produce     json
+// @Success     200 {object} entities.Feedback
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     404
+// @Failure     500
+// @Failure     503
+// @Router      /v2/docs/{id}/feedback [put]
+func (r *docsRoutes) submitFeedback(c *gin.Context) {
+	const op = "v2.docsRoutes.submitFeedback"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	var req *entities.SubmitFeedbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error(err.Error())
+		common.WriteProblem(c, http.StatusBadRequest, common.GetErrMessages(err))
+		return
+	}
+
+	resp, err := r.s.SubmitFeedback(c.Request.Context(), req.ToGRPC(id))
+	if err != nil {
+		code, err := common.GetProtoErrWithStatusCode(err)
+		log.Error(err.Error())
+		common.WriteProblem(c, code, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entities.FeedbackFromGRPC(resp))
+}
+
+// @Summary     Submit defense protocol
+// @Description Submit the commission's final grade. Only the accounts linked to the members of the commission the doc is defended before may do it, as well as secretaries and admins.
+// @ID          Submit defense protocol
+// @Tags  	    Docs v2
+// @Accept      json
+// @Param       id path int true "doc id"
+// @Param       protocol body entities.SubmitProtocolRequest true "protocol"
+// @Produce     json
+// @Success     200 {object} entities.Protocol
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     404
+// @Failure     409
+// @Failure     500
+// @Failure     503
+// @Router      /v2/docs/{id}/protocol [put]
+func (r *docsRoutes) submitProtocol(c *gin.Context) {
+	const op = "v2.docsRoutes.submitProtocol"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	var req *entities.SubmitProtocolRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error(err.Error())
+		common.WriteProblem(c, http.StatusBadRequest, common.GetErrMessages(err))
+		return
+	}
+
+	resp, err := r.s.SubmitProtocol(c.Request.Context(), req.ToGRPC(id))
+	if err != nil {
+		code, err := common.GetProtoErrWithStatusCode(err)
+		log.Error(err.Error())
+		common.WriteProblem(c, code, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entities.ProtocolFromGRPC(resp))
+}
+
+// @Summary     Get defense protocol PDF
+// @Description Protocol of defense filled from the doc, its review, feedback and the commission
+// @ID          Get defense protocol PDF
+// @Tags  	    Docs v2
+// @Param       id path int true "doc id"
+// @Produce     application/pdf
+// @Success     200 {file} file
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     404
+// @Failure     500
+// @Failure     503
+// @Router      /v2/docs/{id}/protocol.pdf [get]
+func (r *docsRoutes) getProtocolPDF(c *gin.Context) {
+	const op = "v2.docsRoutes.getProtocolPDF"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := r.s.GetProtocolPDF(c.Request.Context(), &docsv1.GetProtocolPDFRequest{DocId: id})
+	if err != nil {
+		code, err := common.GetProtoErrWithStatusCode(err)
+		log.Error(err.Error())
+		common.WriteProblem(c, code, err)
+		return
+	}
+
+	sendFile(c, resp)
+}
@@ -0,0 +1,219 @@
+package v2
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/common"
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/entities"
+	docsv1 "github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/proto/gen/docs"
+	"github.com/gin-gonic/gin"
+)
+
+func attachmentID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("attachment_id"), 10, 64)
+	if err != nil || id <= 0 {
+		common.WriteProblemMessage(c, http.StatusBadRequest, "attachment id must be a positive number")
+		return 0, false
+	}
+
+	return id, true
+}
+
+// @Summary     List attachments
+// @Description Files attached to the doc with the state of their text indexing
+// @ID          List attachments
+// @Tags  	    Docs v2
+// @Param       id path int true "doc id"
+// @Produce     json
+// @Success     200 {object} entities.GetAttachmentsResponse
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     404
+// @Failure     500
+// @Failure     503
+// @Router      /v2/docs/{id}/attachments [get]
+func (r *docsRoutes) listAttachments(c *gin.Context) {
+	const op = "v2.docsRoutes.listAttachments"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := r.s.GetAttachments(c.Request.Context(), &docsv1.GetAttachmentsRequest{DocId: id})
+	if err != nil {
+		code, err := common.GetProtoErrWithStatusCode(err)
+		log.Error(err.Error())
+		common.WriteProblem(c, code, err)
+		return
+	}
+
+	attachments := make([]*entities.Attachment, 0, len(resp.Attachments))
+	for _, a := range resp.Attachments {
+		attachments = append(attachments, entities.AttachmentFromGRPC(a))
+	}
+
+	c.JSON(http.StatusOK, entities.GetAttachmentsResponse{Attachments: attachments})
+}
+
+// @Summary     Upload attachment
+// @Description Attach a file to the doc. Text of PDF, DOCX and ODT files is indexed for content search.
+// @ID          Upload attachment
+// @Tags  	    Docs v2
+// @Accept      multipart/form-data
+// @Param       id path int true "doc id"
+// @Param       file formData file true "file, up to 20 MiB"
+// @Produce     json
+// @Success     201 {object} entities.Attachment
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     404
+// @Failure     413
+// @Failure     500
+// @Failure     503
+// @Router      /v2/docs/{id}/attachments [post]
+func (r *docsRoutes) uploadAttachment(c *gin.Context) {
+	const op = "v2.docsRoutes.uploadAttachment"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		log.Error(err.Error())
+		common.WriteProblemMessage(c, http.StatusBadRequest, "file must be provided")
+		return
+	}
+	if header.Size > entities.MaxAttachmentSize {
+		common.WriteProblemMessage(c, http.StatusRequestEntityTooLarge, "attachment file must not exceed 20 MiB")
+		return
+	}
+
+	f, err := header.Open()
+	if err != nil {
+		log.Error(err.Error())
+		common.WriteProblemMessage(c, http.StatusBadRequest, "failed to read file")
+		return
+	}
+	defer f.Close()
+
+	content, err := io.ReadAll(f)
+	if err != nil {
+		log.Error(err.Error())
+		common.WriteProblemMessage(c, http.StatusBadRequest, "failed to read file")
+		return
+	}
+
+	resp, err := r.s.UploadAttachment(c.Request.Context(), &docsv1.UploadAttachmentRequest{
+		DocId:   id,
+		Name:    header.Filename,
+		Type:    header.Header.Get("Content-Type"),
+		Content: content,
+	})
+	if err != nil {
+		code, err := common.GetProtoErrWithStatusCode(err)
+		log.Error(err.Error())
+		common.WriteProblem(c, code, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, entities.AttachmentFromGRPC(resp))
+}
+
+// @Summary     Get attachment file
+// @Description Download the attached file
+// @ID          Get attachment file
+// @Tags  	    Docs v2
+// @Param       id path int true "doc id"
+// @Param       attachment_id path int true "attachment id"
+// @Produce     octet-stream
+// @Success     200 {file} file
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     404
+// @Failure     500
+// @Failure     503
+// @Router      /v2/docs/{id}/attachments/{attachment_id} [get]
+func (r *docsRoutes) getAttachmentFile(c *gin.Context) {
+	const op = "v2.docsRoutes.getAttachmentFile"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	docID, ok := pathID(c)
+	if !ok {
+		return
+	}
+	id, ok := attachmentID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := r.s.GetAttachmentFile(c.Request.Context(), &docsv1.AttachmentRequest{DocId: docID, Id: id})
+	if err != nil {
+		code, err := common.GetProtoErrWithStatusCode(err)
+		log.Error(err.Error())
+		common.WriteProblem(c, code, err)
+		return
+	}
+
+	sendFile(c, resp)
+}
+
+// @Summary     Delete attachment
+// @Description Delete the attached file with its indexed text. Allowed to the uploader, secretaries and admins.
+// @ID          Delete attachment
+// @Tags  	    Docs v2
+// @Param       id path int true "doc id"
+// @Param       attachment_id path int true "attachment id"
+// @Success     204
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     404
+// @Failure     500
+// @Failure     503
+// @Router      /v2/docs/{id}/attachments/{attachment_id} [delete]
+func (r *docsRoutes) deleteAttachment(c *gin.Context) {
+	const op = "v2.docsRoutes.deleteAttachment"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	docID, ok := pathID(c)
+	if !ok {
+		return
+	}
+	id, ok := attachmentID(c)
+	if !ok {
+		return
+	}
+
+	_, err := r.s.DeleteAttachment(c.Request.Context(), &docsv1.AttachmentRequest{DocId: docID, Id: id})
+	if err != nil {
+		code, err := common.GetProtoErrWithStatusCode(err)
+		log.Error(err.Error())
+		common.WriteProblem(c, code, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,422 @@
+// Package v2 implements resource-oriented routing paths. Each services in own file.
+package v2
+
+import (
+	"log/slog"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/common"
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/controller/rest/middleware"
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/entities"
+	docsv1 "github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/proto/gen/docs"
+	"github.com/gin-gonic/gin"
+)
+
+type docsRoutes struct {
+	s   docsv1.DocsClient
+	log *slog.Logger
+}
+
+func NewDocsRoutes(log *slog.Logger, handler *gin.RouterGroup, s docsv1.DocsClient) {
+	r := &docsRoutes{
+		log: log,
+		s:   s,
+	}
+
+	g := handler.Group("/docs")
+	{
+		g.GET("", middleware.RequireScope(entities.ScopeDocsRead), middleware.ETag(), r.list)
+		g.GET("/similar", middleware.RequireScope(entities.ScopeDocsRead), r.similar)
+		g.GET("/search", middleware.RequireScope(entities.ScopeDocsRead), middleware.ETag(), r.search)
+		g.GET("/reviewer-assignments", middleware.RequireScope(entities.ScopeDocsRead), r.suggestReviewerAssignments)
+		g.GET("/:id", middleware.RequireScope(entities.ScopeDocsRead), middleware.ETag(), r.get)
+		g.POST("", middleware.RequireScope(entities.ScopeDocsWrite), r.create)
+		g.PATCH("/:id", middleware.RequireScope(entities.ScopeDocsWrite), r.patch)
+		g.DELETE("/:id", middleware.RequireScope(entities.ScopeDocsWrite), r.delete)
+		g.GET("/:id/transitions", middleware.RequireScope(entities.ScopeDocsRead), r.listTransitions)
+		g.POST("/:id/transitions", middleware.RequireScope(entities.ScopeDocsWrite), r.transition)
+		g.GET("/:id/assessment", middleware.RequireScope(entities.ScopeDocsRead), r.getAssessment)
+		g.PUT("/:id/review", middleware.RequireScope(entities.ScopeDocsWrite), r.submitReview)
+		g.GET("/:id/review/file", middleware.RequireScope(entities.ScopeDocsRead), r.getReviewFile)
+		g.PUT("/:id/feedback", middleware.RequireScope(entities.ScopeDocsWrite), r.submitFeedback)
+		g.PUT("/:id/protocol", middleware.RequireScope(entities.ScopeDocsWrite), r.submitProtocol)
+		g.GET("/:id/protocol.pdf", middleware.RequireScope(entities.ScopeDocsRead), r.getProtocolPDF)
+		g.GET("/:id/attachments", middleware.RequireScope(entities.ScopeDocsRead), r.listAttachments)
+		g.POST("/:id/attachments", middleware.RequireScope(entities.ScopeDocsWrite), r.uploadAttachment)
+		g.GET("/:id/attachments/:attachment_id", middleware.RequireScope(entities.ScopeDocsRead), r.getAttachmentFile)
+		g.DELETE("/:id/attachments/:attachment_id", middleware.RequireScope(entities.ScopeDocsWrite), r.deleteAttachment)
+		g.GET("/:id/reviewer-suggestions", middleware.RequireScope(entities.ScopeDocsRead), r.suggestReviewers)
+	}
+}
+
+func pathID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		common.WriteProblemMessage(c, http.StatusBadRequest, "id must be a positive number")
+		return 0, false
+	}
+
+	return id, true
+}
+
+// protoErr replies with the gRPC error as a problem with the details attached
+// to it, e.g. conflicts of a schedule or of workload limits.
+func protoErr(c *gin.Context, log *slog.Logger, err error) {
+	code, err := common.GetProtoErrWithStatusCode(err)
+	log.Error(err.Error())
+	common.WriteProblem(c, code, err)
+}
+
+func etag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// ifMatchVersion reads the doc version from the If-Match header. Updates
+// must name the version they were made against, so "*" is refused too.
+func ifMatchVersion(c *gin.Context) (int, bool) {
+	h := strings.TrimSpace(c.GetHeader("If-Match"))
+	if h == "" || h == "*" {
+		common.WriteProblemMessage(c, http.StatusPreconditionRequired, "If-Match header with document version must be provided")
+		return 0, false
+	}
+
+	version, err := strconv.Atoi(strings.Trim(strings.TrimPrefix(h, "W/"), `"`))
+	if err != nil || version <= 0 {
+		common.WriteProblemMessage(c, http.StatusBadRequest, "If-Match header must contain document version")
+		return 0, false
+	}
+
+	return version, true
+}
+
+// @Summary     List docs
+// @Description List docs matching query-string filters
+// @ID          List docs
+// @Tags  	    Docs v2
+// @Param       query query entities.ListDocsQuery false "filters"
+// @Param       If-None-Match header string false "ETag of the response the client has"
+// @Produce     json
+// @Success     200 {object} entities.ListDocsResponse
+// @Success     304 "not modified"
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     500
+// @Failure     503
+// @Router      /v2/docs [get]
+func (r *docsRoutes) list(c *gin.Context) {
+	const op = "v2.docsRoutes.list"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	var query entities.ListDocsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		log.Error(err.Error())
+		common.WriteProblem(c, http.StatusBadRequest, common.GetErrMessages(err))
+		return
+	}
+
+	resp, err := r.s.GetFiltered(c.Request.Context(), query.ToGRPC())
+	if err != nil {
+		code, err := common.GetProtoErrWithStatusCode(err)
+		log.Error(err.Error())
+		common.WriteProblem(c, code, err)
+		return
+	}
+
+	docs := make([]*entities.Doc, 0, len(resp.Docs))
+	for _, doc := range resp.Docs {
+		docs = append(docs, entities.DocFromGRPC(doc))
+	}
+
+	c.JSON(http.StatusOK, entities.ListDocsResponse{Docs: docs})
+}
+
+// @Summary     Similar themes
+// @Description Find docs whose themes share most words with the given one
+// @ID          Similar themes v2
+// @Tags  	    Docs v2
+// @Param       query query entities.FindSimilarThemesRequest true "theme"
+// @Produce     json
+// @Success     200 {object} entities.FindSimilarThemesResponse
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     500
+// @Failure     503
+// @Router      /v2/docs/similar [get]
+func (r *docsRoutes) similar(c *gin.Context) {
+	const op = "v2.docsRoutes.similar"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	var query entities.FindSimilarThemesRequest
+	if err := c.ShouldBindQuery(&query); err != nil {
+		log.Error(err.Error())
+		common.WriteProblem(c, http.StatusBadRequest, common.GetErrMessages(err))
+		return
+	}
+
+	resp, err := r.s.FindSimilarThemes(c.Request.Context(), query.ToGRPC())
+	if err != nil {
+		code, err := common.GetProtoErrWithStatusCode(err)
+		log.Error(err.Error())
+		common.WriteProblem(c, code, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entities.FindSimilarThemesResponse{
+		SimilarThemes: entities.SimilarThemesFromGRPC(resp.SimilarThemes),
+	})
+}
+
+// @Summary     Search docs
+// @Description Search the doc fields (metadata), the text of attached PDF, DOCX and ODT files (content) or both.
+// @Description Content matches tell the page or paragraph of the file they were found in.
+// @ID          Search docs
+// @Tags  	    Docs v2
+// @Param       query query entities.SearchDocsQuery true "search"
+// @Param       If-None-Match header string false "ETag of the response the client has"
+// @Produce     json
+// @Success     200 {object} entities.SearchDocsResponse
+// @Success     304 "not modified"
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     500
+// @Failure     503
+// @Router      /v2/docs/search [get]
+func (r *docsRoutes) search(c *gin.Context) {
+	const op = "v2.docsRoutes.search"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	var query entities.SearchDocsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		log.Error(err.Error())
+		common.WriteProblem(c, http.StatusBadRequest, common.GetErrMessages(err))
+		return
+	}
+
+	resp, err := r.s.Search(c.Request.Context(), query.ToGRPC())
+	if err != nil {
+		code, err := common.GetProtoErrWithStatusCode(err)
+		log.Error(err.Error())
+		common.WriteProblem(c, code, err)
+		return
+	}
+
+	docs := make([]*entities.Doc, 0, len(resp.Docs))
+	for _, doc := range resp.Docs {
+		docs = append(docs, entities.DocFromGRPC(doc))
+	}
+
+	c.JSON(http.StatusOK, entities.SearchDocsResponse{
+		Docs:    docs,
+		Matches: entities.ContentMatchesFromGRPC(resp.Matches),
+	})
+}
+
+// @Summary     Get doc
+// @Description Get doc by id
+// @ID          Get doc
+// @Tags  	    Docs v2
+// @Param       id path int true "doc id"
+// @Param       If-None-Match header string false "ETag of the response the client has"
+// @Produce     json
+// @Success     200 {object} entities.Doc
+// @Success     304 "not modified"
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     404
+// @Failure     500
+// @Failure     503
+// @Router      /v2/docs/{id} [get]
+func (r *docsRoutes) get(c *gin.Context) {
+	const op = "v2.docsRoutes.get"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := r.s.GetByID(c.Request.Context(), &docsv1.GetByIDRequest{Id: id})
+	if err != nil {
+		code, err := common.GetProtoErrWithStatusCode(err)
+		log.Error(err.Error())
+		common.WriteProblem(c, code, err)
+		return
+	}
+
+	c.Header("ETag", etag(resp.Version))
+	c.JSON(http.StatusOK, entities.DocFromGRPC(resp))
+}
+
+// @Summary     Create doc
+// @Description Create doc. Location header points to the new doc.
+// @Description Refused with 409 and the list of similar themes unless force is set.
+// @Description Exceeded workload limits of the supervisors, co-supervisors and reviewers refuse it with 409 and conflicts
+// @Description when enforced, otherwise they are returned in workload_warnings.
+// @ID          Create doc
+// @Tags  	    Docs v2
+// @Accept      json
+// @Param       doc body entities.CreateRequest true "doc"
+// @Produce     json
+// @Success     201 {object} entities.SavedDocResponse
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     409 {object} entities.SimilarThemesResponse
+// @Failure     500
+// @Failure     503
+// @Router      /v2/docs [post]
+func (r *docsRoutes) create(c *gin.Context) {
+	const op = "v2.docsRoutes.create"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	var req *entities.CreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error(err.Error())
+		common.WriteProblem(c, http.StatusBadRequest, common.GetErrMessages(err))
+		return
+	}
+
+	created, err := r.s.Create(c.Request.Context(), req.ToGRPC())
+	if err != nil {
+		protoErr(c, log, err)
+		return
+	}
+	if !created.Success {
+		common.AbortWithProblem(c, http.StatusConflict, entities.SimilarThemesResponse{
+			Problem: *common.NewProblem(c, http.StatusConflict, &common.APIError{
+				Code:    entities.CodeSimilarThemes,
+				Message: entities.SimilarThemesMessage,
+			}),
+			SimilarThemes: entities.SimilarThemesFromGRPC(created.SimilarThemes),
+		})
+		return
+	}
+
+	resp, err := r.s.GetByID(c.Request.Context(), &docsv1.GetByIDRequest{Id: created.Id})
+	if err != nil {
+		code, err := common.GetProtoErrWithStatusCode(err)
+		log.Error(err.Error())
+		common.WriteProblem(c, code, err)
+		return
+	}
+
+	c.Header("Location", path.Join(c.FullPath(), strconv.FormatInt(created.Id, 10)))
+	c.Header("ETag", etag(resp.Version))
+	c.JSON(http.StatusCreated, entities.SavedDocFromGRPC(resp, created.WorkloadWarnings))
+}
+
+// @Summary     Patch doc
+// @Description Change only the fields present in the body. If-Match must hold the version from ETag.
+// @Description A new supervisor, co-supervisor, reviewer or year is checked against the workload limits like on create.
+// @ID          Patch doc
+// @Tags  	    Docs v2
+// @Accept      json
+// @Param       id path int true "doc id"
+// @Param       If-Match header string true "doc version"
+// @Param       doc body entities.PatchDocRequest true "changed fields"
+// @Produce     json
+// @Success     200 {object} entities.SavedDocResponse
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     404
+// @Failure     409
+// @Failure     428
+// @Failure     500
+// @Failure     503
+// @Router      /v2/docs/{id} [patch]
+func (r *docsRoutes) patch(c *gin.Context) {
+	const op = "v2.docsRoutes.patch"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	var req *entities.PatchDocRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error(err.Error())
+		common.WriteProblem(c, http.StatusBadRequest, common.GetErrMessages(err))
+		return
+	}
+	if req.IsEmpty() {
+		common.WriteProblemMessage(c, http.StatusBadRequest, "nothing to update")
+		return
+	}
+
+	resp, err := r.s.Update(c.Request.Context(), req.ToGRPC(int(id), version))
+	if err != nil {
+		protoErr(c, log, err)
+		return
+	}
+
+	c.Header("ETag", etag(resp.Doc.Version))
+	c.JSON(http.StatusOK, entities.SavedDocFromGRPC(resp.Doc, resp.WorkloadWarnings))
+}
+
+// @Summary     Delete doc
+// @Description Delete doc by id
+// @ID          Delete doc
+// @Tags  	    Docs v2
+// @Param       id path int true "doc id"
+// @Success     204
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     404
+// @Failure     500
+// @Failure     503
+// @Router      /v2/docs/{id} [delete]
+func (r *docsRoutes) delete(c *gin.Context) {
+	const op = "v2.docsRoutes.delete"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	_, err := r.s.Delete(c.Request.Context(), &docsv1.DeleteRequest{Id: id})
+	if err != nil {
+		code, err := common.GetProtoErrWithStatusCode(err)
+		log.Error(err.Error())
+		common.WriteProblem(c, code, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
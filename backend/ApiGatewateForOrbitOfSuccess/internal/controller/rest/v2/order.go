@@ -0,0 +1,296 @@
+package v2
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"path"
+	"strconv"
+
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/common"
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/controller/rest/middleware"
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/entities"
+	docsv1 "github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/proto/gen/docs"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+)
+
+type orderRoutes struct {
+	s   docsv1.DocsClient
+	log *slog.Logger
+}
+
+func NewOrderRoutes(log *slog.Logger, handler *gin.RouterGroup, s docsv1.DocsClient) {
+	r := &orderRoutes{
+		log: log,
+		s:   s,
+	}
+
+	g := handler.Group("/orders")
+	{
+		g.GET("", middleware.RequireScope(entities.ScopeDocsRead), r.list)
+		g.POST("", middleware.RequireScope(entities.ScopeDocsWrite), r.create)
+		g.GET("/:id", middleware.RequireScope(entities.ScopeDocsRead), r.get)
+		g.GET("/:id/file", middleware.RequireScope(entities.ScopeDocsRead), r.getFile)
+		g.POST("/:id/docs", middleware.RequireScope(entities.ScopeDocsWrite), r.attachDocs)
+		g.DELETE("/:id/docs", middleware.RequireScope(entities.ScopeDocsWrite), r.detachDocs)
+	}
+}
+
+// @Summary     List orders
+// @Description Orders of the academic year, of one type or covering one doc, by date
+// @ID          List orders
+// @Tags  	    Orders v2
+// @Param       query query entities.ListOrdersQuery false "filters"
+// @Produce     json
+// @Success     200 {object} entities.ListOrdersResponse
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     500
+// @Failure     503
+// @Router      /v2/orders [get]
+func (r *orderRoutes) list(c *gin.Context) {
+	const op = "v2.orderRoutes.list"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	var query entities.ListOrdersQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		log.Error(err.Error())
+		common.WriteProblem(c, http.StatusBadRequest, common.GetErrMessages(err))
+		return
+	}
+
+	resp, err := r.s.GetOrders(c.Request.Context(), query.ToGRPC())
+	if err != nil {
+		code, err := common.GetProtoErrWithStatusCode(err)
+		log.Error(err.Error())
+		common.WriteProblem(c, code, err)
+		return
+	}
+
+	orders := make([]*entities.Order, 0, len(resp.Orders))
+	for _, o := range resp.Orders {
+		orders = append(orders, entities.OrderFromGRPC(o))
+	}
+
+	c.JSON(http.StatusOK, entities.ListOrdersResponse{Orders: orders})
+}
+
+// @Summary     Create order
+// @Description Record a university order. The signed file is optional. Secretaries and admins only.
+// @ID          Create order
+// @Tags  	    Orders v2
+// @Accept      multipart/form-data
+// @Param       order formData entities.CreateOrderForm true "order"
+// @Param       file formData file false "signed order, up to 20 MiB"
+// @Produce     json
+// @Success     201 {object} entities.Order
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     409
+// @Failure     413
+// @Failure     500
+// @Failure     503
+// @Router      /v2/orders [post]
+func (r *orderRoutes) create(c *gin.Context) {
+	const op = "v2.orderRoutes.create"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	var form entities.CreateOrderForm
+	if err := c.ShouldBind(&form); err != nil {
+		log.Error(err.Error())
+		common.WriteProblem(c, http.StatusBadRequest, common.GetErrMessages(err))
+		return
+	}
+	req := form.ToGRPC()
+
+	if header, err := c.FormFile("file"); err == nil {
+		if header.Size > entities.MaxAttachmentSize {
+			common.WriteProblemMessage(c, http.StatusRequestEntityTooLarge, "order file must not exceed 20 MiB")
+			return
+		}
+
+		f, err := header.Open()
+		if err != nil {
+			log.Error(err.Error())
+			common.WriteProblemMessage(c, http.StatusBadRequest, "failed to read file")
+			return
+		}
+		defer f.Close()
+
+		req.Content, err = io.ReadAll(f)
+		if err != nil {
+			log.Error(err.Error())
+			common.WriteProblemMessage(c, http.StatusBadRequest, "failed to read file")
+			return
+		}
+		req.FileName = header.Filename
+		req.FileType = header.Header.Get("Content-Type")
+	}
+
+	resp, err := r.s.CreateOrder(c.Request.Context(), req)
+	if err != nil {
+		code, err := common.GetProtoErrWithStatusCode(err)
+		log.Error(err.Error())
+		common.WriteProblem(c, code, err)
+		return
+	}
+
+	c.Header("Location", path.Join(c.FullPath(), strconv.FormatInt(resp.Id, 10)))
+	c.JSON(http.StatusCreated, entities.OrderFromGRPC(resp))
+}
+
+// @Summary     Get order
+// @Description Get the order with the docs it covers
+// @ID          Get order
+// @Tags  	    Orders v2
+// @Param       id path int true "order id"
+// @Produce     json
+// @Success     200 {object} entities.Order
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     404
+// @Failure     500
+// @Failure     503
+// @Router      /v2/orders/{id} [get]
+func (r *orderRoutes) get(c *gin.Context) {
+	const op = "v2.orderRoutes.get"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := r.s.GetOrder(c.Request.Context(), &docsv1.GetOrderRequest{Id: id})
+	if err != nil {
+		code, err := common.GetProtoErrWithStatusCode(err)
+		log.Error(err.Error())
+		common.WriteProblem(c, code, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entities.OrderFromGRPC(resp))
+}
+
+// @Summary     Get order file
+// @Description Download the signed order
+// @ID          Get order file
+// @Tags  	    Orders v2
+// @Param       id path int true "order id"
+// @Produce     octet-stream
+// @Success     200 {file} file
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     404
+// @Failure     500
+// @Failure     503
+// @Router      /v2/orders/{id}/file [get]
+func (r *orderRoutes) getFile(c *gin.Context) {
+	const op = "v2.orderRoutes.getFile"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := r.s.GetOrderFile(c.Request.Context(), &docsv1.GetOrderRequest{Id: id})
+	if err != nil {
+		code, err := common.GetProtoErrWithStatusCode(err)
+		log.Error(err.Error())
+		common.WriteProblem(c, code, err)
+		return
+	}
+
+	sendFile(c, resp)
+}
+
+// @Summary     Attach docs to order
+// @Description Link docs of the order's year to it in one go. Nothing is linked if any doc is missing or of another year.
+// @ID          Attach docs to order
+// @Tags  	    Orders v2
+// @Accept      json
+// @Param       id path int true "order id"
+// @Param       docs body entities.OrderDocsRequest true "doc ids"
+// @Produce     json
+// @Success     200 {object} entities.OrderDocsResponse
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     404
+// @Failure     500
+// @Failure     503
+// @Router      /v2/orders/{id}/docs [post]
+func (r *orderRoutes) attachDocs(c *gin.Context) {
+	r.changeDocs(c, "v2.orderRoutes.attachDocs", r.s.AttachDocsToOrder)
+}
+
+// @Summary     Detach docs from order
+// @Description Unlink the docs from the order
+// @ID          Detach docs from order
+// @Tags  	    Orders v2
+// @Accept      json
+// @Param       id path int true "order id"
+// @Param       docs body entities.OrderDocsRequest true "doc ids"
+// @Produce     json
+// @Success     200 {object} entities.OrderDocsResponse
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     404
+// @Failure     500
+// @Failure     503
+// @Router      /v2/orders/{id}/docs [delete]
+func (r *orderRoutes) detachDocs(c *gin.Context) {
+	r.changeDocs(c, "v2.orderRoutes.detachDocs", r.s.DetachDocsFromOrder)
+}
+
+func (r *orderRoutes) changeDocs(
+	c *gin.Context,
+	op string,
+	call func(ctx context.Context, in *docsv1.OrderDocsRequest, opts ...grpc.CallOption) (*docsv1.OrderDocsResponse, error),
+) {
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	var req *entities.OrderDocsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error(err.Error())
+		common.WriteProblem(c, http.StatusBadRequest, common.GetErrMessages(err))
+		return
+	}
+
+	resp, err := call(c.Request.Context(), &docsv1.OrderDocsRequest{OrderId: id, DocIds: req.DocIDs})
+	if err != nil {
+		code, err := common.GetProtoErrWithStatusCode(err)
+		log.Error(err.Error())
+		common.WriteProblem(c, code, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entities.OrderDocsResponse{Changed: int(resp.Changed)})
+}
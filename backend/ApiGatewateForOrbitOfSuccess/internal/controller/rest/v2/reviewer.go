@@ -0,0 +1,106 @@
+package v2
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/common"
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/entities"
+	docsv1 "github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/proto/gen/docs"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary     Suggest reviewers
+// @Description Rank candidate reviewers of the doc among people who reviewed docs before.
+// @Description Discipline and theme matches raise the score, the workload of the doc's year lowers it.
+// @Description The supervisor and former supervisors of the student are left out.
+// @ID          Suggest reviewers
+// @Tags  	    Docs v2
+// @Param       id path int true "doc id"
+// @Param       query query entities.SuggestReviewersQuery false "limit"
+// @Produce     json
+// @Success     200 {object} entities.SuggestReviewersResponse
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     404
+// @Failure     500
+// @Failure     503
+// @Router      /v2/docs/{id}/reviewer-suggestions [get]
+func (r *docsRoutes) suggestReviewers(c *gin.Context) {
+	const op = "v2.docsRoutes.suggestReviewers"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	var query entities.SuggestReviewersQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		log.Error(err.Error())
+		common.WriteProblem(c, http.StatusBadRequest, common.GetErrMessages(err))
+		return
+	}
+
+	resp, err := r.s.SuggestReviewers(c.Request.Context(), &docsv1.SuggestReviewersRequest{
+		DocId: id,
+		Limit: int32(query.Limit),
+	})
+	if err != nil {
+		code, err := common.GetProtoErrWithStatusCode(err)
+		log.Error(err.Error())
+		common.WriteProblem(c, code, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entities.SuggestReviewersResponse{
+		Suggestions: entities.ReviewerSuggestionsFromGRPC(resp.Suggestions),
+	})
+}
+
+// @Summary     Suggest reviewer assignments
+// @Description Propose a reviewer for every doc of the year without one, spreading the workload.
+// @Description Nothing is saved, apply the proposals by patching the docs.
+// @ID          Suggest reviewer assignments
+// @Tags  	    Docs v2
+// @Param       query query entities.SuggestReviewerAssignmentsQuery false "year"
+// @Produce     json
+// @Success     200 {object} entities.SuggestReviewerAssignmentsResponse
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     500
+// @Failure     503
+// @Router      /v2/docs/reviewer-assignments [get]
+func (r *docsRoutes) suggestReviewerAssignments(c *gin.Context) {
+	const op = "v2.docsRoutes.suggestReviewerAssignments"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	var query entities.SuggestReviewerAssignmentsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		log.Error(err.Error())
+		common.WriteProblem(c, http.StatusBadRequest, common.GetErrMessages(err))
+		return
+	}
+
+	resp, err := r.s.SuggestReviewerAssignments(c.Request.Context(), &docsv1.SuggestReviewerAssignmentsRequest{
+		Year: int32(query.Year),
+	})
+	if err != nil {
+		code, err := common.GetProtoErrWithStatusCode(err)
+		log.Error(err.Error())
+		common.WriteProblem(c, code, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entities.SuggestReviewerAssignmentsResponse{
+		Assignments: entities.ReviewerAssignmentsFromGRPC(resp.Assignments),
+	})
+}
@@ -0,0 +1,402 @@
+package v2
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/common"
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/controller/rest/middleware"
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/entities"
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/lib/ical"
+	docsv1 "github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/proto/gen/docs"
+	"github.com/gin-gonic/gin"
+)
+
+type scheduleRoutes struct {
+	s   docsv1.DocsClient
+	log *slog.Logger
+}
+
+func NewScheduleRoutes(log *slog.Logger, handler *gin.RouterGroup, s docsv1.DocsClient) {
+	r := &scheduleRoutes{
+		log: log,
+		s:   s,
+	}
+
+	g := handler.Group("/commissions")
+	{
+		g.GET("", middleware.RequireScope(entities.ScopeDocsRead), r.listCommissions)
+		g.POST("", middleware.RequireScope(entities.ScopeDocsWrite), r.createCommission)
+	}
+
+	g = handler.Group("/sessions")
+	{
+		g.GET("", middleware.RequireScope(entities.ScopeDocsRead), r.listSessions)
+		g.GET("/:id", middleware.RequireScope(entities.ScopeDocsRead), r.getSession)
+		g.GET("/:id/calendar.ics", middleware.RequireScope(entities.ScopeDocsRead), r.sessionCalendar)
+		g.POST("", middleware.RequireScope(entities.ScopeDocsWrite), r.createSession)
+		g.DELETE("/:id", middleware.RequireScope(entities.ScopeDocsWrite), r.deleteSession)
+	}
+
+	g = handler.Group("/slots")
+	{
+		g.PUT("/:id", middleware.RequireScope(entities.ScopeDocsWrite), r.assignSlot)
+	}
+}
+
+// @Summary     List commissions
+// @Description List defense commissions with their members
+// @ID          List commissions
+// @Tags  	    Schedule v2
+// @Param       query query entities.ListCommissionsQuery false "filters"
+// @Produce     json
+// @Success     200 {object} entities.ListCommissionsResponse
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     500
+// @Failure     503
+// @Router      /v2/commissions [get]
+func (r *scheduleRoutes) listCommissions(c *gin.Context) {
+	const op = "v2.scheduleRoutes.listCommissions"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	var query entities.ListCommissionsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		log.Error(err.Error())
+		common.WriteProblem(c, http.StatusBadRequest, common.GetErrMessages(err))
+		return
+	}
+
+	resp, err := r.s.GetCommissions(c.Request.Context(), &docsv1.GetCommissionsRequest{Year: int32(query.Year)})
+	if err != nil {
+		protoErr(c, log, err)
+		return
+	}
+
+	commissions := make([]*entities.Commission, 0, len(resp.Commissions))
+	for _, cm := range resp.Commissions {
+		commissions = append(commissions, entities.CommissionFromGRPC(cm))
+	}
+
+	c.JSON(http.StatusOK, entities.ListCommissionsResponse{Commissions: commissions})
+}
+
+// @Summary     Create commission
+// @Description Create defense commission. It must have one chair and at most one secretary.
+// @ID          Create commission
+// @Tags  	    Schedule v2
+// @Accept      json
+// @Param       commission body entities.CreateCommissionRequest true "commission"
+// @Produce     json
+// @Success     201 {object} entities.Commission
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     500
+// @Failure     503
+// @Router      /v2/commissions [post]
+func (r *scheduleRoutes) createCommission(c *gin.Context) {
+	const op = "v2.scheduleRoutes.createCommission"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	var req *entities.CreateCommissionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error(err.Error())
+		common.WriteProblem(c, http.StatusBadRequest, common.GetErrMessages(err))
+		return
+	}
+
+	resp, err := r.s.CreateCommission(c.Request.Context(), req.ToGRPC())
+	if err != nil {
+		protoErr(c, log, err)
+		return
+	}
+
+	c.Header("Location", path.Join(c.FullPath(), strconv.FormatInt(resp.Id, 10)))
+	c.JSON(http.StatusCreated, entities.CommissionFromGRPC(resp))
+}
+
+// @Summary     List sessions
+// @Description List defense sessions overlapping the period
+// @ID          List sessions
+// @Tags  	    Schedule v2
+// @Param       query query entities.ListSessionsQuery false "period"
+// @Produce     json
+// @Success     200 {object} entities.ListSessionsResponse
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     500
+// @Failure     503
+// @Router      /v2/sessions [get]
+func (r *scheduleRoutes) listSessions(c *gin.Context) {
+	const op = "v2.scheduleRoutes.listSessions"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	var query entities.ListSessionsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		log.Error(err.Error())
+		common.WriteProblem(c, http.StatusBadRequest, common.GetErrMessages(err))
+		return
+	}
+
+	resp, err := r.s.GetSessions(c.Request.Context(), query.ToGRPC())
+	if err != nil {
+		protoErr(c, log, err)
+		return
+	}
+
+	sessions := make([]*entities.Session, 0, len(resp.Sessions))
+	for _, s := range resp.Sessions {
+		sessions = append(sessions, entities.SessionFromGRPC(s))
+	}
+
+	c.JSON(http.StatusOK, entities.ListSessionsResponse{Sessions: sessions})
+}
+
+// @Summary     Get session
+// @Description Get defense session with its commission and slots
+// @ID          Get session
+// @Tags  	    Schedule v2
+// @Param       id path int true "session id"
+// @Produce     json
+// @Success     200 {object} entities.Session
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     404
+// @Failure     500
+// @Failure     503
+// @Router      /v2/sessions/{id} [get]
+func (r *scheduleRoutes) getSession(c *gin.Context) {
+	const op = "v2.scheduleRoutes.getSession"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := r.s.GetSession(c.Request.Context(), &docsv1.GetSessionRequest{Id: id})
+	if err != nil {
+		protoErr(c, log, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entities.SessionFromGRPC(resp))
+}
+
+// @Summary     Session calendar
+// @Description iCalendar feed of the session: the session itself and every assigned slot
+// @ID          Session calendar
+// @Tags  	    Schedule v2
+// @Param       id path int true "session id"
+// @Produce     text/calendar
+// @Success     200 {string} string
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     404
+// @Failure     500
+// @Failure     503
+// @Router      /v2/sessions/{id}/calendar.ics [get]
+func (r *scheduleRoutes) sessionCalendar(c *gin.Context) {
+	const op = "v2.scheduleRoutes.sessionCalendar"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := r.s.GetSession(c.Request.Context(), &docsv1.GetSessionRequest{Id: id})
+	if err != nil {
+		protoErr(c, log, err)
+		return
+	}
+
+	cal := calendarFromSession(resp)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="session-%d.ics"`, resp.Id))
+	c.Data(http.StatusOK, ical.ContentType, []byte(cal.String()))
+}
+
+func calendarFromSession(s *docsv1.Session) *ical.Calendar {
+	name := fmt.Sprintf("Defense session %d", s.Id)
+	var members []string
+	if s.Commission != nil {
+		name = s.Commission.Name
+		for _, m := range s.Commission.Members {
+			members = append(members, fmt.Sprintf("%s (%s)", m.Name, m.Role))
+		}
+	}
+
+	cal := &ical.Calendar{
+		ProdID: "-//BD-Doc//Defense schedule//EN",
+		Name:   name,
+		Stamp:  time.Now(),
+	}
+	cal.Events = append(cal.Events, ical.Event{
+		UID:         fmt.Sprintf("session-%d@bd-doc", s.Id),
+		Start:       time.Unix(s.StartsAt, 0),
+		End:         time.Unix(s.EndsAt, 0),
+		Summary:     name,
+		Description: "Commission: " + strings.Join(members, ", "),
+		Location:    s.Room,
+	})
+
+	for _, slot := range s.Slots {
+		if slot.Doc == nil {
+			continue
+		}
+		cal.Events = append(cal.Events, ical.Event{
+			UID:         fmt.Sprintf("slot-%d@bd-doc", slot.Id),
+			Start:       time.Unix(slot.StartsAt, 0),
+			End:         time.Unix(slot.EndsAt, 0),
+			Summary:     fmt.Sprintf("%d. %s", slot.Position, slot.Doc.Fio),
+			Description: fmt.Sprintf("%s\nSupervisor: %s\nReviewer: %s", slot.Doc.Theme, slot.Doc.Director, slot.Doc.Reviewer),
+			Location:    s.Room,
+		})
+	}
+
+	return cal
+}
+
+// @Summary     Create session
+// @Description Create defense session split into ordered slots. Conflicting room or commission bookings are returned with 409.
+// @ID          Create session
+// @Tags  	    Schedule v2
+// @Accept      json
+// @Param       session body entities.CreateSessionRequest true "session"
+// @Produce     json
+// @Success     201 {object} entities.Session
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     404
+// @Failure     409
+// @Failure     500
+// @Failure     503
+// @Router      /v2/sessions [post]
+func (r *scheduleRoutes) createSession(c *gin.Context) {
+	const op = "v2.scheduleRoutes.createSession"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	var req *entities.CreateSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error(err.Error())
+		common.WriteProblem(c, http.StatusBadRequest, common.GetErrMessages(err))
+		return
+	}
+
+	resp, err := r.s.CreateSession(c.Request.Context(), req.ToGRPC())
+	if err != nil {
+		protoErr(c, log, err)
+		return
+	}
+
+	c.Header("Location", path.Join(c.FullPath(), strconv.FormatInt(resp.Id, 10)))
+	c.JSON(http.StatusCreated, entities.SessionFromGRPC(resp))
+}
+
+// @Summary     Delete session
+// @Description Delete defense session with its slots
+// @ID          Delete session
+// @Tags  	    Schedule v2
+// @Param       id path int true "session id"
+// @Success     204
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     404
+// @Failure     500
+// @Failure     503
+// @Router      /v2/sessions/{id} [delete]
+func (r *scheduleRoutes) deleteSession(c *gin.Context) {
+	const op = "v2.scheduleRoutes.deleteSession"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	_, err := r.s.DeleteSession(c.Request.Context(), &docsv1.DeleteSessionRequest{Id: id})
+	if err != nil {
+		protoErr(c, log, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary     Assign slot
+// @Description Put the doc into the slot, zero doc_id frees it. Supervisors, co-supervisors or reviewer sitting on another commission at that time are returned with 409.
+// @ID          Assign slot
+// @Tags  	    Schedule v2
+// @Accept      json
+// @Param       id path int true "slot id"
+// @Param       slot body entities.AssignSlotRequest true "doc"
+// @Produce     json
+// @Success     200 {object} entities.Slot
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     404
+// @Failure     409
+// @Failure     500
+// @Failure     503
+// @Router      /v2/slots/{id} [put]
+func (r *scheduleRoutes) assignSlot(c *gin.Context) {
+	const op = "v2.scheduleRoutes.assignSlot"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	var req *entities.AssignSlotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error(err.Error())
+		common.WriteProblem(c, http.StatusBadRequest, common.GetErrMessages(err))
+		return
+	}
+
+	resp, err := r.s.AssignSlot(c.Request.Context(), &docsv1.AssignSlotRequest{SlotId: id, DocId: int64(req.DocID)})
+	if err != nil {
+		protoErr(c, log, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entities.SlotFromGRPC(resp))
+}
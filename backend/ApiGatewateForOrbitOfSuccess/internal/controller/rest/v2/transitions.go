@@ -0,0 +1,104 @@
+package v2
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/common"
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/entities"
+	docsv1 "github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/proto/gen/docs"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary     Transition doc
+// @Description Move doc to another status. Allowed moves depend on the current status. Students and supervisors may only move docs whose participants are linked to their account.
+// @ID          Transition doc
+// @Tags  	    Docs v2
+// @Accept      json
+// @Param       id path int true "doc id"
+// @Param       transition body entities.TransitionDocRequest true "transition"
+// @Produce     json
+// @Success     200 {object} entities.TransitionDocResponse
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     404
+// @Failure     409
+// @Failure     500
+// @Failure     503
+// @Router      /v2/docs/{id}/transitions [post]
+func (r *docsRoutes) transition(c *gin.Context) {
+	const op = "v2.docsRoutes.transition"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	var req *entities.TransitionDocRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error(err.Error())
+		common.WriteProblem(c, http.StatusBadRequest, common.GetErrMessages(err))
+		return
+	}
+
+	resp, err := r.s.TransitionDoc(c.Request.Context(), req.ToGRPC(id))
+	if err != nil {
+		code, err := common.GetProtoErrWithStatusCode(err)
+		log.Error(err.Error())
+		common.WriteProblem(c, code, err)
+		return
+	}
+
+	c.Header("ETag", etag(resp.Doc.Version))
+	c.JSON(http.StatusOK, entities.TransitionDocResponse{
+		Doc:        entities.DocFromGRPC(resp.Doc),
+		Transition: entities.TransitionFromGRPC(resp.Transition),
+	})
+}
+
+// @Summary     List doc transitions
+// @Description Status history of the doc, oldest first
+// @ID          List doc transitions
+// @Tags  	    Docs v2
+// @Param       id path int true "doc id"
+// @Produce     json
+// @Success     200 {object} entities.ListTransitionsResponse
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     404
+// @Failure     500
+// @Failure     503
+// @Router      /v2/docs/{id}/transitions [get]
+func (r *docsRoutes) listTransitions(c *gin.Context) {
+	const op = "v2.docsRoutes.listTransitions"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := r.s.GetTransitions(c.Request.Context(), &docsv1.GetTransitionsRequest{DocId: id})
+	if err != nil {
+		code, err := common.GetProtoErrWithStatusCode(err)
+		log.Error(err.Error())
+		common.WriteProblem(c, code, err)
+		return
+	}
+
+	transitions := make([]*entities.Transition, 0, len(resp.Transitions))
+	for _, tr := range resp.Transitions {
+		transitions = append(transitions, entities.TransitionFromGRPC(tr))
+	}
+
+	c.JSON(http.StatusOK, entities.ListTransitionsResponse{Transitions: transitions})
+}
@@ -0,0 +1,154 @@
+package v2
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/common"
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/controller/rest/middleware"
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/entities"
+	docsv1 "github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/proto/gen/docs"
+	"github.com/gin-gonic/gin"
+)
+
+type workloadRoutes struct {
+	s   docsv1.DocsClient
+	log *slog.Logger
+}
+
+func NewWorkloadRoutes(log *slog.Logger, handler *gin.RouterGroup, s docsv1.DocsClient) {
+	r := &workloadRoutes{
+		log: log,
+		s:   s,
+	}
+
+	g := handler.Group("/workload")
+	{
+		g.GET("/:person", middleware.RequireScope(entities.ScopeDocsRead), r.get)
+		g.PUT("/:person/:role", middleware.RequireScope(entities.ScopeDocsWrite), r.setLimit)
+		g.DELETE("/:person/:role", middleware.RequireScope(entities.ScopeDocsWrite), r.resetLimit)
+	}
+}
+
+// @Summary     Get workload
+// @Description Count the docs of the year the person supervises, co-supervises and reviews against the limits
+// @ID          Get workload
+// @Tags  	    Workload v2
+// @Param       person path string true "supervisor or reviewer name"
+// @Param       query query entities.WorkloadQuery false "year, the current one by default"
+// @Produce     json
+// @Success     200 {object} entities.GetWorkloadResponse
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     500
+// @Failure     503
+// @Router      /v2/workload/{person} [get]
+func (r *workloadRoutes) get(c *gin.Context) {
+	const op = "v2.workloadRoutes.get"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	var query entities.WorkloadQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		log.Error(err.Error())
+		common.WriteProblem(c, http.StatusBadRequest, common.GetErrMessages(err))
+		return
+	}
+
+	resp, err := r.s.GetWorkload(c.Request.Context(), &docsv1.GetWorkloadRequest{
+		Person: c.Param("person"),
+		Year:   int32(query.Year),
+	})
+	if err != nil {
+		code, err := common.GetProtoErrWithStatusCode(err)
+		log.Error(err.Error())
+		common.WriteProblem(c, code, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entities.GetWorkloadResponse{
+		Workload: entities.WorkloadFromGRPC(resp.Workload),
+	})
+}
+
+// @Summary     Set workload limit
+// @Description Set the personal limit of docs a year in the role, 0 means no limit. Secretaries and admins only.
+// @ID          Set workload limit
+// @Tags  	    Workload v2
+// @Accept      json
+// @Param       person path string true "supervisor or reviewer name"
+// @Param       role path string true "supervisor or reviewer"
+// @Param       limit body entities.SetWorkloadLimitRequest true "limit"
+// @Success     204
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     500
+// @Failure     503
+// @Router      /v2/workload/{person}/{role} [put]
+func (r *workloadRoutes) setLimit(c *gin.Context) {
+	const op = "v2.workloadRoutes.setLimit"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	var req *entities.SetWorkloadLimitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error(err.Error())
+		common.WriteProblem(c, http.StatusBadRequest, common.GetErrMessages(err))
+		return
+	}
+
+	_, err := r.s.SetWorkloadLimit(c.Request.Context(), &docsv1.SetWorkloadLimitRequest{
+		Person: c.Param("person"),
+		Role:   c.Param("role"),
+		Limit:  int32(*req.Limit),
+	})
+	if err != nil {
+		code, err := common.GetProtoErrWithStatusCode(err)
+		log.Error(err.Error())
+		common.WriteProblem(c, code, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary     Reset workload limit
+// @Description Remove the personal limit, so the configured one applies. Secretaries and admins only.
+// @ID          Reset workload limit
+// @Tags  	    Workload v2
+// @Param       person path string true "supervisor or reviewer name"
+// @Param       role path string true "supervisor or reviewer"
+// @Success     204
+// @Failure     400
+// @Failure     401
+// @Failure     403
+// @Failure     500
+// @Failure     503
+// @Router      /v2/workload/{person}/{role} [delete]
+func (r *workloadRoutes) resetLimit(c *gin.Context) {
+	const op = "v2.workloadRoutes.resetLimit"
+
+	log := r.log.With(
+		slog.String("op", op),
+	)
+
+	_, err := r.s.SetWorkloadLimit(c.Request.Context(), &docsv1.SetWorkloadLimitRequest{
+		Person: c.Param("person"),
+		Role:   c.Param("role"),
+		Reset_: true,
+	})
+	if err != nil {
+		code, err := common.GetProtoErrWithStatusCode(err)
+		log.Error(err.Error())
+		common.WriteProblem(c, code, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
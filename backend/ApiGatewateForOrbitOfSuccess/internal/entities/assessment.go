@@ -0,0 +1,140 @@
+package entities
+
+import (
+	docv1 "github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/proto/gen/docs"
+)
+
+// MaxReviewFileSize matches the limit of the docs service.
+const MaxReviewFileSize = 3 << 20
+
+// SubmitReviewForm is sent as multipart/form-data with an optional "file" part.
+type SubmitReviewForm struct {
+	Grade int    `form:"grade" binding:"required,min=2,max=5"`
+	Text  string `form:"text" binding:"max=20000"`
+}
+
+type SubmitFeedbackRequest struct {
+	Text string `json:"text" binding:"required,max=20000"`
+}
+
+func (r *SubmitFeedbackRequest) ToGRPC(docID int64) *docv1.SubmitFeedbackRequest {
+	return &docv1.SubmitFeedbackRequest{
+		DocId: docID,
+		Text:  r.Text,
+	}
+}
+
+type SubmitProtocolRequest struct {
+	Number    string `json:"number" binding:"required,max=50"`
+	Grade     int    `json:"grade" binding:"required,min=2,max=5"`
+	Questions string `json:"questions" binding:"max=5000"`
+	Notes     string `json:"notes" binding:"max=5000"`
+}
+
+func (r *SubmitProtocolRequest) ToGRPC(docID int64) *docv1.SubmitProtocolRequest {
+	return &docv1.SubmitProtocolRequest{
+		DocId:     docID,
+		Number:    r.Number,
+		Grade:     int32(r.Grade),
+		Questions: r.Questions,
+		Notes:     r.Notes,
+	}
+}
+
+type Review struct {
+	DocID      int    `json:"doc_id"`
+	AuthorID   int    `json:"author_id"`
+	AuthorName string `json:"author_name"`
+	Grade      int    `json:"grade"`
+	Text       string `json:"text"`
+	FileName   string `json:"file_name,omitempty"`
+	FileType   string `json:"file_type,omitempty"`
+	FileSize   int    `json:"file_size,omitempty"`
+	CreatedAt  int64  `json:"created_at"`
+	UpdatedAt  int64  `json:"updated_at"`
+}
+
+func ReviewFromGRPC(r *docv1.Review) *Review {
+	return &Review{
+		DocID:      int(r.DocId),
+		AuthorID:   int(r.AuthorId),
+		AuthorName: r.AuthorName,
+		Grade:      int(r.Grade),
+		Text:       r.Text,
+		FileName:   r.FileName,
+		FileType:   r.FileType,
+		FileSize:   int(r.FileSize),
+		CreatedAt:  r.CreatedAt,
+		UpdatedAt:  r.UpdatedAt,
+	}
+}
+
+type Feedback struct {
+	DocID      int    `json:"doc_id"`
+	AuthorID   int    `json:"author_id"`
+	AuthorName string `json:"author_name"`
+	Text       string `json:"text"`
+	CreatedAt  int64  `json:"created_at"`
+	UpdatedAt  int64  `json:"updated_at"`
+}
+
+func FeedbackFromGRPC(f *docv1.Feedback) *Feedback {
+	return &Feedback{
+		DocID:      int(f.DocId),
+		AuthorID:   int(f.AuthorId),
+		AuthorName: f.AuthorName,
+		Text:       f.Text,
+		CreatedAt:  f.CreatedAt,
+		UpdatedAt:  f.UpdatedAt,
+	}
+}
+
+type Protocol struct {
+	DocID      int    `json:"doc_id"`
+	Number     string `json:"number"`
+	Grade      int    `json:"grade"`
+	SessionID  int    `json:"session_id,omitempty"`
+	Questions  string `json:"questions"`
+	Notes      string `json:"notes"`
+	AuthorID   int    `json:"author_id"`
+	AuthorName string `json:"author_name"`
+	CreatedAt  int64  `json:"created_at"`
+	UpdatedAt  int64  `json:"updated_at"`
+}
+
+func ProtocolFromGRPC(p *docv1.Protocol) *Protocol {
+	return &Protocol{
+		DocID:      int(p.DocId),
+		Number:     p.Number,
+		Grade:      int(p.Grade),
+		SessionID:  int(p.SessionId),
+		Questions:  p.Questions,
+		Notes:      p.Notes,
+		AuthorID:   int(p.AuthorId),
+		AuthorName: p.AuthorName,
+		CreatedAt:  p.CreatedAt,
+		UpdatedAt:  p.UpdatedAt,
+	}
+}
+
+// Assessment parts not submitted yet are null.
+type Assessment struct {
+	Review   *Review   `json:"review"`
+	Feedback *Feedback `json:"feedback"`
+	Protocol *Protocol `json:"protocol"`
+}
+
+func AssessmentFromGRPC(a *docv1.Assessment) *Assessment {
+	resp := &Assessment{}
+	if a.Review != nil {
+		resp.Review = ReviewFromGRPC(a.Review)
+	}
+	if a.Feedback != nil {
+		resp.Feedback = FeedbackFromGRPC(a.Feedback)
+	}
+	if a.Protocol != nil {
+		resp.Protocol = ProtocolFromGRPC(a.Protocol)
+	}
+
+	return resp
+}
@@ -0,0 +1,44 @@
+package entities
+
+import (
+	docv1 "github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/proto/gen/docs"
+)
+
+// MaxAttachmentSize matches the limit of the docs service.
+const MaxAttachmentSize = 20 << 20
+
+type Attachment struct {
+	ID    int    `json:"id"`
+	DocID int    `json:"doc_id"`
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Size  int    `json:"size"`
+	// indexed, unsupported or failed
+	TextStatus string `json:"text_status"`
+	TextError  string `json:"text_error,omitempty"`
+	// indexed pages or paragraphs
+	Parts      int    `json:"parts"`
+	AuthorID   int    `json:"author_id"`
+	AuthorName string `json:"author_name"`
+	CreatedAt  int64  `json:"created_at"`
+}
+
+func AttachmentFromGRPC(a *docv1.Attachment) *Attachment {
+	return &Attachment{
+		ID:         int(a.Id),
+		DocID:      int(a.DocId),
+		Name:       a.Name,
+		Type:       a.Type,
+		Size:       int(a.Size),
+		TextStatus: a.TextStatus,
+		TextError:  a.TextError,
+		Parts:      int(a.Parts),
+		AuthorID:   int(a.AuthorId),
+		AuthorName: a.AuthorName,
+		CreatedAt:  a.CreatedAt,
+	}
+}
+
+type GetAttachmentsResponse struct {
+	Attachments []*Attachment `json:"attachments"`
+}
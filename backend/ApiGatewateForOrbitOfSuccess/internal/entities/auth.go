@@ -5,7 +5,7 @@ import authv1 "github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/proto/gen/au
 type RegisterRequest struct {
 	Username string `json:"username" binding:"required,min=3,max=20"`
 	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required,min=8,max=50"`
+	Password string `json:"password" binding:"required"`
 }
 
 func (r *RegisterRequest) ToGRPC() *authv1.RegisterRequest {
@@ -19,7 +19,7 @@ func (r *RegisterRequest) ToGRPC() *authv1.RegisterRequest {
 type LoginRequest struct {
 	Username string `json:"username,omitempty"`
 	Email    string `json:"email,omitempty"`
-	Password string `json:"password" binding:"required,min=8,max=50"`
+	Password string `json:"password" binding:"required"`
 }
 
 func (r *LoginRequest) ToGRPC() *authv1.LoginRequest {
@@ -72,7 +72,7 @@ func (r *SendPasswordLinkRequest) ToGRPC() *authv1.SendPasswordLinkRequest {
 
 type ChangePasswordRequest struct {
 	Link     string `json:"link" binding:"required"`
-	Password string `json:"password" binding:"required,min=8,max=50"`
+	Password string `json:"password" binding:"required"`
 }
 
 func (r *ChangePasswordRequest) ToGRPC() *authv1.ChangePasswordRequest {
@@ -81,3 +81,35 @@ func (r *ChangePasswordRequest) ToGRPC() *authv1.ChangePasswordRequest {
 		Link:     r.Link,
 	}
 }
+
+const (
+	ScopeDocsRead  = "docs:read"
+	ScopeDocsWrite = "docs:write"
+)
+
+type CreateAPITokenRequest struct {
+	Name      string   `json:"name" binding:"required,max=250"`
+	Scopes    []string `json:"scopes" binding:"required,min=1,dive,oneof=docs:read docs:write"`
+	ExpiresAt int64    `json:"expires_at,omitempty"`
+}
+
+func (r *CreateAPITokenRequest) ToGRPC(accessToken string) *authv1.CreateAPITokenRequest {
+	return &authv1.CreateAPITokenRequest{
+		AccessToken: accessToken,
+		Name:        r.Name,
+		Scopes:      r.Scopes,
+		ExpiresAt:   r.ExpiresAt,
+	}
+}
+
+type SetRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=student supervisor secretary admin"`
+}
+
+func (r *SetRoleRequest) ToGRPC(accessToken string, userID int64) *authv1.SetRoleRequest {
+	return &authv1.SetRoleRequest{
+		AccessToken: accessToken,
+		UserId:      userID,
+		Role:        r.Role,
+	}
+}
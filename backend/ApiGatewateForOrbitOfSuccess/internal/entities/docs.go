@@ -1,9 +1,44 @@
 package entities
 
 import (
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/common"
 	docv1 "github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/proto/gen/docs"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
+// Participant is a person taking part in a doc. Position orders the
+// participants of one role and is set by the server. UserID links the
+// person's account, which is what the participant is authorized by.
+type Participant struct {
+	Role     string `json:"role" binding:"required,oneof=student supervisor co_supervisor consultant reviewer"`
+	Name     string `json:"name" binding:"required,max=250"`
+	Position int    `json:"position,omitempty"`
+	UserID   int    `json:"user_id,omitempty" binding:"min=0"`
+}
+
+func ParticipantsToGRPC(participants []*Participant) []*docv1.Participant {
+	resp := make([]*docv1.Participant, 0, len(participants))
+	for _, p := range participants {
+		resp = append(resp, &docv1.Participant{Role: p.Role, Name: p.Name, UserId: int64(p.UserID)})
+	}
+
+	return resp
+}
+
+func ParticipantsFromGRPC(participants []*docv1.Participant) []*Participant {
+	resp := make([]*Participant, 0, len(participants))
+	for _, p := range participants {
+		resp = append(resp, &Participant{
+			Role:     p.Role,
+			Name:     p.Name,
+			Position: int(p.Position),
+			UserID:   int(p.UserId),
+		})
+	}
+
+	return resp
+}
+
 type CreateRequest struct {
 	Type       string `json:"type"`
 	Group      string `json:"group"`
@@ -14,19 +49,25 @@ type CreateRequest struct {
 	Order      string `json:"order"`
 	Reviewer   string `json:"reviewer"`
 	Discipline string `json:"discipline"`
+	// Create even if docs with similar themes exist
+	Force bool `json:"force"`
+	// Overrides fio, director and reviewer when set, ordered within a role
+	Participants []*Participant `json:"participants" binding:"omitempty,dive"`
 }
 
 func (r *CreateRequest) ToGRPC() *docv1.CreateRequest {
 	return &docv1.CreateRequest{
-		Type:       r.Type,
-		Group:      r.Group,
-		Fio:        r.FIO,
-		Theme:      r.Theme,
-		Director:   r.Director,
-		Year:       int32(r.Year),
-		Order:      r.Order,
-		Reviewer:   r.Reviewer,
-		Discipline: r.Discipline,
+		Type:         r.Type,
+		Group:        r.Group,
+		Fio:          r.FIO,
+		Theme:        r.Theme,
+		Director:     r.Director,
+		Year:         int32(r.Year),
+		Order:        r.Order,
+		Reviewer:     r.Reviewer,
+		Discipline:   r.Discipline,
+		Force:        r.Force,
+		Participants: ParticipantsToGRPC(r.Participants),
 	}
 }
 
@@ -50,77 +91,355 @@ type GetFilteredRequest struct {
 	Order      string `json:"order"`
 	Reviewer   string `json:"reviewer"`
 	Discipline string `json:"discipline"`
+	Status     string `json:"status"`
+	// Name of a participant in any role
+	Participant string `json:"participant"`
 }
 
 func (r *GetFilteredRequest) ToGRPC() *docv1.GetFilteredRequest {
 	return &docv1.GetFilteredRequest{
-		Type:       r.Type,
-		Group:      r.Group,
-		Fio:        r.FIO,
-		Theme:      r.Theme,
-		Director:   r.Director,
-		Year:       int32(r.Year),
-		Order:      r.Order,
-		Reviewer:   r.Reviewer,
-		Discipline: r.Discipline,
+		Type:        r.Type,
+		Group:       r.Group,
+		Fio:         r.FIO,
+		Theme:       r.Theme,
+		Director:    r.Director,
+		Year:        int32(r.Year),
+		Order:       r.Order,
+		Reviewer:    r.Reviewer,
+		Discipline:  r.Discipline,
+		Status:      r.Status,
+		Participant: r.Participant,
 	}
 }
 
 type SearchRequest struct {
 	SearchLine string `json:"search_line" binding:"required"`
+	// metadata (default), content or both
+	Scope string `json:"scope" binding:"omitempty,oneof=metadata content both"`
 }
 
 func (r *SearchRequest) ToGRPC() *docv1.SearchRequest {
 	return &docv1.SearchRequest{
 		SearchLine: r.SearchLine,
+		Scope:      r.Scope,
+	}
+}
+
+// SearchDocsQuery searches the doc fields, the text of attachments or both.
+// Content search takes quoted phrases, "or" and "-" before excluded words.
+type SearchDocsQuery struct {
+	Q     string `form:"q" binding:"required"`
+	Scope string `form:"scope" binding:"omitempty,oneof=metadata content both"`
+}
+
+func (r *SearchDocsQuery) ToGRPC() *docv1.SearchRequest {
+	return &docv1.SearchRequest{
+		SearchLine: r.Q,
+		Scope:      r.Scope,
 	}
 }
 
+// ContentMatch is the page of a PDF or the paragraph of a DOCX or ODT
+// attachment the search matched.
+type ContentMatch struct {
+	DocID        int    `json:"doc_id"`
+	AttachmentID int    `json:"attachment_id"`
+	FileName     string `json:"file_name"`
+	// page or paragraph
+	Unit   string `json:"unit"`
+	Number int    `json:"number"`
+	// matched words are wrapped in <b></b>
+	Snippet string `json:"snippet"`
+}
+
+func ContentMatchesFromGRPC(matches []*docv1.ContentMatch) []*ContentMatch {
+	resp := make([]*ContentMatch, 0, len(matches))
+	for _, m := range matches {
+		resp = append(resp, &ContentMatch{
+			DocID:        int(m.DocId),
+			AttachmentID: int(m.AttachmentId),
+			FileName:     m.FileName,
+			Unit:         m.Unit,
+			Number:       int(m.Number),
+			Snippet:      m.Snippet,
+		})
+	}
+
+	return resp
+}
+
+type SearchDocsResponse struct {
+	Docs    []*Doc          `json:"docs"`
+	Matches []*ContentMatch `json:"matches,omitempty"`
+}
+
+// UpdateRequest changes only the fields present in the body.
+// Version is optional here, v1 clients update unconditionally.
 type UpdateRequest struct {
-	ID         int    `json:"id"`
-	Type       string `json:"type"`
-	Group      string `json:"group"`
-	FIO        string `json:"fio"`
-	Theme      string `json:"theme"`
-	Director   string `json:"director"`
-	Year       int    `json:"year"`
-	Order      string `json:"order"`
-	Reviewer   string `json:"reviewer"`
-	Discipline string `json:"discipline"`
+	ID int `json:"id"`
+	// Version the changes were made against, from the doc
+	Version int `json:"version" binding:"required,min=1"`
+	PatchDocRequest
 }
 
 func (r *UpdateRequest) ToGRPC() *docv1.UpdateRequest {
-	return &docv1.UpdateRequest{
-		Id:         int64(r.ID),
-		Type:       r.Type,
-		Group:      r.Group,
-		Fio:        r.FIO,
-		Theme:      r.Theme,
-		Director:   r.Director,
-		Year:       int32(r.Year),
-		Order:      r.Order,
-		Reviewer:   r.Reviewer,
-		Discipline: r.Discipline,
-	}
+	return r.PatchDocRequest.ToGRPC(r.ID, r.Version)
 }
 
 type SuccessResponse struct {
 	Success bool `json:"success,omitempty"`
 }
 
+type UpdateResponse struct {
+	Success          bool        `json:"success,omitempty"`
+	Doc              *Doc        `json:"doc,omitempty"`
+	WorkloadWarnings []*Workload `json:"workload_warnings,omitempty"`
+}
+
 type Doc struct {
-	ID         int    `json:"id"`
-	Type       string `json:"type"`
-	Group      string `json:"group"`
-	FIO        string `json:"fio"`
-	Theme      string `json:"theme"`
-	Director   string `json:"director"`
-	Year       int    `json:"year"`
-	Order      string `json:"order"`
-	Reviewer   string `json:"reviewer"`
-	Discipline string `json:"discipline"`
+	ID          int    `json:"id"`
+	Type        string `json:"type"`
+	Group       string `json:"group"`
+	FIO         string `json:"fio"`
+	Theme       string `json:"theme"`
+	Director    string `json:"director"`
+	Year        int    `json:"year"`
+	Order       string `json:"order"`
+	Reviewer    string `json:"reviewer"`
+	Discipline  string `json:"discipline"`
+	Version     int    `json:"version"`
+	UpdatedAt   int64  `json:"updated_at"`
+	Status      string `json:"status"`
+	Grade       int    `json:"grade,omitempty"`
+	DefenseDate string `json:"defense_date,omitempty"`
+	// fio, director and reviewer hold the first student, supervisor and reviewer
+	Participants []*Participant `json:"participants"`
 }
 
 type GetResponse struct {
-	Docs []*Doc `json:"docs,omitempty"`
+	Docs    []*Doc          `json:"docs,omitempty"`
+	Matches []*ContentMatch `json:"matches,omitempty"`
+}
+
+func DocFromGRPC(d *docv1.Doc) *Doc {
+	return &Doc{
+		ID:           int(d.Id),
+		Type:         d.Type,
+		Group:        d.Group,
+		FIO:          d.Fio,
+		Theme:        d.Theme,
+		Director:     d.Director,
+		Year:         int(d.Year),
+		Order:        d.Order,
+		Reviewer:     d.Reviewer,
+		Discipline:   d.Discipline,
+		Version:      int(d.Version),
+		UpdatedAt:    d.UpdatedAt,
+		Status:       d.Status,
+		Grade:        int(d.Grade),
+		DefenseDate:  d.DefenseDate,
+		Participants: ParticipantsFromGRPC(d.Participants),
+	}
+}
+
+type ListDocsQuery struct {
+	Type       string `form:"type"`
+	Group      string `form:"group"`
+	FIO        string `form:"fio"`
+	Theme      string `form:"theme"`
+	Director   string `form:"director"`
+	Year       int    `form:"year"`
+	Order      string `form:"order"`
+	Reviewer   string `form:"reviewer"`
+	Discipline string `form:"discipline"`
+	Status     string `form:"status"`
+	// Name of a participant in any role
+	Participant string `form:"participant"`
+}
+
+func (r *ListDocsQuery) ToGRPC() *docv1.GetFilteredRequest {
+	return &docv1.GetFilteredRequest{
+		Type:        r.Type,
+		Group:       r.Group,
+		Fio:         r.FIO,
+		Theme:       r.Theme,
+		Director:    r.Director,
+		Year:        int32(r.Year),
+		Order:       r.Order,
+		Reviewer:    r.Reviewer,
+		Discipline:  r.Discipline,
+		Status:      r.Status,
+		Participant: r.Participant,
+	}
+}
+
+type ListDocsResponse struct {
+	Docs []*Doc `json:"docs"`
+}
+
+// PatchDocRequest holds only the fields a client wants to change.
+type PatchDocRequest struct {
+	Type       *string `json:"type"`
+	Group      *string `json:"group"`
+	FIO        *string `json:"fio"`
+	Theme      *string `json:"theme"`
+	Director   *string `json:"director"`
+	Year       *int    `json:"year"`
+	Order      *string `json:"order"`
+	Reviewer   *string `json:"reviewer"`
+	Discipline *string `json:"discipline"`
+	// Replaces all participants along with fio, director and reviewer
+	Participants []*Participant `json:"participants" binding:"omitempty,dive"`
+}
+
+// ToGRPC puts every present field into the update mask.
+func (r *PatchDocRequest) ToGRPC(id, version int) *docv1.UpdateRequest {
+	req := &docv1.UpdateRequest{
+		Id:         int64(id),
+		Version:    int64(version),
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{}},
+	}
+	set := func(field string, value *string, dst *string) {
+		if value != nil {
+			*dst = *value
+			req.UpdateMask.Paths = append(req.UpdateMask.Paths, field)
+		}
+	}
+
+	set("type", r.Type, &req.Type)
+	set("group", r.Group, &req.Group)
+	set("fio", r.FIO, &req.Fio)
+	set("theme", r.Theme, &req.Theme)
+	set("director", r.Director, &req.Director)
+	if r.Year != nil {
+		req.Year = int32(*r.Year)
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "year")
+	}
+	set("order", r.Order, &req.Order)
+	set("reviewer", r.Reviewer, &req.Reviewer)
+	set("discipline", r.Discipline, &req.Discipline)
+	if r.Participants != nil {
+		req.Participants = ParticipantsToGRPC(r.Participants)
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "participants")
+	}
+
+	return req
 }
+
+// IsEmpty reports whether the request changes nothing.
+func (r *PatchDocRequest) IsEmpty() bool {
+	return r.Type == nil && r.Group == nil && r.FIO == nil && r.Theme == nil && r.Director == nil &&
+		r.Year == nil && r.Order == nil && r.Reviewer == nil && r.Discipline == nil && r.Participants == nil
+}
+
+type TransitionDocRequest struct {
+	ToStatus    string `json:"to_status" binding:"required,oneof=topic_proposed topic_approved in_progress submitted reviewed defended archived"`
+	Comment     string `json:"comment" binding:"max=1000"`
+	Order       string `json:"order" binding:"max=100"`
+	Grade       int    `json:"grade" binding:"omitempty,min=2,max=5"`
+	DefenseDate string `json:"defense_date" binding:"omitempty,datetime=2006-01-02"`
+}
+
+func (r *TransitionDocRequest) ToGRPC(id int64) *docv1.TransitionDocRequest {
+	return &docv1.TransitionDocRequest{
+		Id:          id,
+		ToStatus:    r.ToStatus,
+		Comment:     r.Comment,
+		Order:       r.Order,
+		Grade:       int32(r.Grade),
+		DefenseDate: r.DefenseDate,
+	}
+}
+
+type Transition struct {
+	ID         int    `json:"id"`
+	DocID      int    `json:"doc_id"`
+	FromStatus string `json:"from_status"`
+	ToStatus   string `json:"to_status"`
+	ActorID    int    `json:"actor_id"`
+	ActorName  string `json:"actor_name"`
+	ActorRole  string `json:"actor_role"`
+	Comment    string `json:"comment"`
+	CreatedAt  int64  `json:"created_at"`
+}
+
+func TransitionFromGRPC(t *docv1.Transition) *Transition {
+	return &Transition{
+		ID:         int(t.Id),
+		DocID:      int(t.DocId),
+		FromStatus: t.FromStatus,
+		ToStatus:   t.ToStatus,
+		ActorID:    int(t.ActorId),
+		ActorName:  t.ActorName,
+		ActorRole:  t.ActorRole,
+		Comment:    t.Comment,
+		CreatedAt:  t.CreatedAt,
+	}
+}
+
+type TransitionDocResponse struct {
+	Doc        *Doc        `json:"doc"`
+	Transition *Transition `json:"transition"`
+}
+
+type ListTransitionsResponse struct {
+	Transitions []*Transition `json:"transitions"`
+}
+
+type SimilarTheme struct {
+	DocID int     `json:"doc_id"`
+	Type  string  `json:"type"`
+	Year  int     `json:"year"`
+	FIO   string  `json:"fio"`
+	Theme string  `json:"theme"`
+	Score float64 `json:"score"`
+}
+
+func SimilarThemesFromGRPC(similar []*docv1.SimilarTheme) []*SimilarTheme {
+	resp := make([]*SimilarTheme, 0, len(similar))
+	for _, st := range similar {
+		resp = append(resp, &SimilarTheme{
+			DocID: int(st.DocId),
+			Type:  st.Type,
+			Year:  int(st.Year),
+			FIO:   st.Fio,
+			Theme: st.Theme,
+			Score: st.Score,
+		})
+	}
+
+	return resp
+}
+
+// SimilarThemesResponse is the problem returned with 409 when a doc isn't
+// created because of similar themes. Repeat the request with force to create
+// it anyway.
+type SimilarThemesResponse struct {
+	common.Problem
+	SimilarThemes []*SimilarTheme `json:"similar_themes"`
+}
+
+type FindSimilarThemesRequest struct {
+	Theme     string  `json:"theme" form:"theme" binding:"required,max=250"`
+	Threshold float64 `json:"threshold" form:"threshold" binding:"omitempty,min=0,max=1"`
+	Limit     int     `json:"limit" form:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+func (r *FindSimilarThemesRequest) ToGRPC() *docv1.FindSimilarThemesRequest {
+	return &docv1.FindSimilarThemesRequest{
+		Theme:     r.Theme,
+		Threshold: r.Threshold,
+		Limit:     int32(r.Limit),
+	}
+}
+
+type FindSimilarThemesResponse struct {
+	SimilarThemes []*SimilarTheme `json:"similar_themes"`
+}
+
+// SimilarThemesMessage and CodeSimilarThemes are the detail and the code
+// of SimilarThemesResponse.
+const (
+	SimilarThemesMessage = "documents with similar themes exist, set force to create anyway"
+	CodeSimilarThemes    = "SIMILAR_THEMES"
+)
@@ -0,0 +1,14 @@
+package entities
+
+// Readiness statuses.
+const (
+	StatusReady    = "ready"
+	StatusNotReady = "not_ready"
+)
+
+// ReadinessResponse gives the status of every service the gateway
+// depends on, "SERVING" or the reason it isn't.
+type ReadinessResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
@@ -0,0 +1,95 @@
+package entities
+
+import (
+	docv1 "github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/proto/gen/docs"
+)
+
+// CreateOrderForm comes as multipart/form-data with an optional "file" part
+// holding the signed order.
+type CreateOrderForm struct {
+	Number string `form:"number" binding:"required,max=100"`
+	Date   string `form:"date" binding:"required,datetime=2006-01-02"`
+	Type   string `form:"type" binding:"required,oneof=theme_approval reviewer_assignment commission"`
+	// Academic year of the docs the order covers
+	Year  int    `form:"year" binding:"required,min=1"`
+	Title string `form:"title" binding:"max=500"`
+}
+
+func (r *CreateOrderForm) ToGRPC() *docv1.CreateOrderRequest {
+	return &docv1.CreateOrderRequest{
+		Number: r.Number,
+		Date:   r.Date,
+		Type:   r.Type,
+		Year:   int32(r.Year),
+		Title:  r.Title,
+	}
+}
+
+type ListOrdersQuery struct {
+	Year  int    `form:"year" binding:"min=0"`
+	Type  string `form:"type" binding:"omitempty,oneof=theme_approval reviewer_assignment commission"`
+	DocID int64  `form:"doc_id" binding:"min=0"`
+}
+
+func (r *ListOrdersQuery) ToGRPC() *docv1.GetOrdersRequest {
+	return &docv1.GetOrdersRequest{
+		Year:  int32(r.Year),
+		Type:  r.Type,
+		DocId: r.DocID,
+	}
+}
+
+type Order struct {
+	ID     int    `json:"id"`
+	Number string `json:"number"`
+	Date   string `json:"date"`
+	// theme_approval, reviewer_assignment or commission
+	Type     string `json:"type"`
+	Year     int    `json:"year"`
+	Title    string `json:"title"`
+	FileName string `json:"file_name,omitempty"`
+	FileType string `json:"file_type,omitempty"`
+	FileSize int    `json:"file_size"`
+	// Docs are listed only for a single order
+	DocsCount  int    `json:"docs_count"`
+	Docs       []*Doc `json:"docs,omitempty"`
+	AuthorID   int    `json:"author_id"`
+	AuthorName string `json:"author_name"`
+	CreatedAt  int64  `json:"created_at"`
+}
+
+func OrderFromGRPC(o *docv1.Order) *Order {
+	resp := &Order{
+		ID:         int(o.Id),
+		Number:     o.Number,
+		Date:       o.Date,
+		Type:       o.Type,
+		Year:       int(o.Year),
+		Title:      o.Title,
+		FileName:   o.FileName,
+		FileType:   o.FileType,
+		FileSize:   int(o.FileSize),
+		DocsCount:  int(o.DocsCount),
+		AuthorID:   int(o.AuthorId),
+		AuthorName: o.AuthorName,
+		CreatedAt:  o.CreatedAt,
+	}
+	for _, doc := range o.Docs {
+		resp.Docs = append(resp.Docs, DocFromGRPC(doc))
+	}
+
+	return resp
+}
+
+type ListOrdersResponse struct {
+	Orders []*Order `json:"orders"`
+}
+
+type OrderDocsRequest struct {
+	DocIDs []int64 `json:"doc_ids" binding:"required,min=1,dive,min=1"`
+}
+
+// OrderDocsResponse counts the docs newly attached or actually detached.
+type OrderDocsResponse struct {
+	Changed int `json:"changed"`
+}
@@ -0,0 +1,79 @@
+package entities
+
+import (
+	docv1 "github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/proto/gen/docs"
+)
+
+type SuggestReviewersQuery struct {
+	// 0 means the default of 5
+	Limit int `form:"limit" binding:"min=0,max=50"`
+}
+
+// ReviewerSuggestion is a candidate reviewer. Score grows with the discipline
+// and theme matches and falls with the workload.
+type ReviewerSuggestion struct {
+	Person string  `json:"person"`
+	Score  float64 `json:"score"`
+	// Share of the docs the person reviewed or supervised in the doc's discipline
+	DisciplineMatch float64 `json:"discipline_match"`
+	// Best theme similarity among those docs, from 0 to 1
+	ThemeSimilarity float64 `json:"theme_similarity"`
+	// Reviews of the doc's year without the doc itself
+	Workload *Workload `json:"workload"`
+}
+
+func ReviewerSuggestionsFromGRPC(suggestions []*docv1.ReviewerSuggestion) []*ReviewerSuggestion {
+	resp := make([]*ReviewerSuggestion, 0, len(suggestions))
+	for _, s := range suggestions {
+		r := &ReviewerSuggestion{
+			Person:          s.Person,
+			Score:           s.Score,
+			DisciplineMatch: s.DisciplineMatch,
+			ThemeSimilarity: s.ThemeSimilarity,
+		}
+		if s.Workload != nil {
+			r.Workload = WorkloadFromGRPC([]*docv1.Workload{s.Workload})[0]
+		}
+		resp = append(resp, r)
+	}
+
+	return resp
+}
+
+type SuggestReviewersResponse struct {
+	Suggestions []*ReviewerSuggestion `json:"suggestions"`
+}
+
+type SuggestReviewerAssignmentsQuery struct {
+	// The current year by default
+	Year int `form:"year" binding:"min=0"`
+}
+
+// ReviewerAssignment proposes a reviewer for a doc without one.
+// Reviewer is empty when no candidate is left.
+type ReviewerAssignment struct {
+	DocID        int                   `json:"doc_id"`
+	FIO          string                `json:"fio"`
+	Theme        string                `json:"theme"`
+	Reviewer     string                `json:"reviewer"`
+	Alternatives []*ReviewerSuggestion `json:"alternatives"`
+}
+
+type SuggestReviewerAssignmentsResponse struct {
+	Assignments []*ReviewerAssignment `json:"assignments"`
+}
+
+func ReviewerAssignmentsFromGRPC(assignments []*docv1.ReviewerAssignment) []*ReviewerAssignment {
+	resp := make([]*ReviewerAssignment, 0, len(assignments))
+	for _, a := range assignments {
+		resp = append(resp, &ReviewerAssignment{
+			DocID:        int(a.DocId),
+			FIO:          a.Fio,
+			Theme:        a.Theme,
+			Reviewer:     a.Reviewer,
+			Alternatives: ReviewerSuggestionsFromGRPC(a.Alternatives),
+		})
+	}
+
+	return resp
+}
@@ -0,0 +1,170 @@
+package entities
+
+import (
+	docv1 "github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/proto/gen/docs"
+)
+
+// CommissionMember is a person of the commission. UserID links the person's
+// account, which is what the member is authorized by.
+type CommissionMember struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name" binding:"required,max=250"`
+	Role   string `json:"role" binding:"required,oneof=chair secretary member"`
+	UserID int    `json:"user_id,omitempty" binding:"min=0"`
+}
+
+type Commission struct {
+	ID        int                 `json:"id"`
+	Name      string              `json:"name"`
+	Year      int                 `json:"year"`
+	Members   []*CommissionMember `json:"members"`
+	CreatedAt int64               `json:"created_at"`
+}
+
+func CommissionFromGRPC(c *docv1.Commission) *Commission {
+	members := make([]*CommissionMember, 0, len(c.Members))
+	for _, m := range c.Members {
+		members = append(members, &CommissionMember{
+			ID:     int(m.Id),
+			Name:   m.Name,
+			Role:   m.Role,
+			UserID: int(m.UserId),
+		})
+	}
+
+	return &Commission{
+		ID:        int(c.Id),
+		Name:      c.Name,
+		Year:      int(c.Year),
+		Members:   members,
+		CreatedAt: c.CreatedAt,
+	}
+}
+
+type CreateCommissionRequest struct {
+	Name    string              `json:"name" binding:"required,max=250"`
+	Year    int                 `json:"year" binding:"required,min=2000,max=2100"`
+	Members []*CommissionMember `json:"members" binding:"required,min=1,dive"`
+}
+
+func (r *CreateCommissionRequest) ToGRPC() *docv1.CreateCommissionRequest {
+	members := make([]*docv1.CommissionMember, 0, len(r.Members))
+	for _, m := range r.Members {
+		members = append(members, &docv1.CommissionMember{
+			Name:   m.Name,
+			Role:   m.Role,
+			UserId: int64(m.UserID),
+		})
+	}
+
+	return &docv1.CreateCommissionRequest{
+		Name:    r.Name,
+		Year:    int32(r.Year),
+		Members: members,
+	}
+}
+
+type ListCommissionsQuery struct {
+	Year int `form:"year" binding:"omitempty,min=2000,max=2100"`
+}
+
+type ListCommissionsResponse struct {
+	Commissions []*Commission `json:"commissions"`
+}
+
+type Slot struct {
+	ID        int   `json:"id"`
+	SessionID int   `json:"session_id"`
+	Position  int   `json:"position"`
+	StartsAt  int64 `json:"starts_at"`
+	EndsAt    int64 `json:"ends_at"`
+	DocID     int   `json:"doc_id,omitempty"`
+	Doc       *Doc  `json:"doc,omitempty"`
+}
+
+func SlotFromGRPC(s *docv1.Slot) *Slot {
+	slot := &Slot{
+		ID:        int(s.Id),
+		SessionID: int(s.SessionId),
+		Position:  int(s.Position),
+		StartsAt:  s.StartsAt,
+		EndsAt:    s.EndsAt,
+		DocID:     int(s.DocId),
+	}
+	if s.Doc != nil {
+		slot.Doc = DocFromGRPC(s.Doc)
+	}
+
+	return slot
+}
+
+type Session struct {
+	ID           int         `json:"id"`
+	CommissionID int         `json:"commission_id"`
+	Room         string      `json:"room"`
+	StartsAt     int64       `json:"starts_at"`
+	EndsAt       int64       `json:"ends_at"`
+	SlotMinutes  int         `json:"slot_minutes"`
+	Commission   *Commission `json:"commission,omitempty"`
+	Slots        []*Slot     `json:"slots,omitempty"`
+}
+
+func SessionFromGRPC(s *docv1.Session) *Session {
+	session := &Session{
+		ID:           int(s.Id),
+		CommissionID: int(s.CommissionId),
+		Room:         s.Room,
+		StartsAt:     s.StartsAt,
+		EndsAt:       s.EndsAt,
+		SlotMinutes:  int(s.SlotMinutes),
+	}
+	if s.Commission != nil {
+		session.Commission = CommissionFromGRPC(s.Commission)
+	}
+	for _, slot := range s.Slots {
+		session.Slots = append(session.Slots, SlotFromGRPC(slot))
+	}
+
+	return session
+}
+
+type CreateSessionRequest struct {
+	CommissionID int    `json:"commission_id" binding:"required,min=1"`
+	Room         string `json:"room" binding:"required,max=100"`
+	// Unix seconds
+	StartsAt    int64 `json:"starts_at" binding:"required,min=1"`
+	SlotMinutes int   `json:"slot_minutes" binding:"required,min=5,max=240"`
+	SlotsCount  int   `json:"slots_count" binding:"required,min=1,max=50"`
+}
+
+func (r *CreateSessionRequest) ToGRPC() *docv1.CreateSessionRequest {
+	return &docv1.CreateSessionRequest{
+		CommissionId: int64(r.CommissionID),
+		Room:         r.Room,
+		StartsAt:     r.StartsAt,
+		SlotMinutes:  int32(r.SlotMinutes),
+		SlotsCount:   int32(r.SlotsCount),
+	}
+}
+
+// ListSessionsQuery bounds are unix seconds. Zero means unbounded.
+type ListSessionsQuery struct {
+	From int64 `form:"from" binding:"omitempty,min=0"`
+	To   int64 `form:"to" binding:"omitempty,min=0"`
+}
+
+func (q *ListSessionsQuery) ToGRPC() *docv1.GetSessionsRequest {
+	return &docv1.GetSessionsRequest{
+		From: q.From,
+		To:   q.To,
+	}
+}
+
+type ListSessionsResponse struct {
+	Sessions []*Session `json:"sessions"`
+}
+
+type AssignSlotRequest struct {
+	// Zero frees the slot
+	DocID int `json:"doc_id" binding:"min=0"`
+}
@@ -0,0 +1,98 @@
+package entities
+
+import (
+	"strings"
+
+	docv1 "github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/proto/gen/docs"
+)
+
+// StatsQuery groups the docs matching the filters. group_by takes
+// year, type, group, director, reviewer and discipline, repeated or comma separated.
+type StatsQuery struct {
+	GroupBy []string `form:"group_by" binding:"required"`
+	// Only the largest groups, 0 means all
+	Top int `form:"top" binding:"min=0,max=100"`
+	// Also count the year before the given one
+	Compare    bool   `form:"compare"`
+	Year       int    `form:"year"`
+	Type       string `form:"type"`
+	Group      string `form:"group"`
+	Director   string `form:"director"`
+	Reviewer   string `form:"reviewer"`
+	Discipline string `form:"discipline"`
+	Status     string `form:"status"`
+}
+
+func (r *StatsQuery) ToGRPC() *docv1.GetStatsRequest {
+	groupBy := make([]string, 0, len(r.GroupBy))
+	for _, v := range r.GroupBy {
+		for _, dim := range strings.Split(v, ",") {
+			if dim = strings.TrimSpace(dim); dim != "" {
+				groupBy = append(groupBy, dim)
+			}
+		}
+	}
+
+	return &docv1.GetStatsRequest{
+		GroupBy: groupBy,
+		Filter: &docv1.GetFilteredRequest{
+			Year:       int32(r.Year),
+			Type:       r.Type,
+			Group:      r.Group,
+			Director:   r.Director,
+			Reviewer:   r.Reviewer,
+			Discipline: r.Discipline,
+			Status:     r.Status,
+		},
+		Top:                 int32(r.Top),
+		ComparePreviousYear: r.Compare,
+	}
+}
+
+type StatsBucket struct {
+	// Group values by dimension, e.g. {"director": "ivanov", "year": "2024"}
+	Keys  map[string]string `json:"keys"`
+	Count int               `json:"count"`
+	// Set when compared with the previous year
+	PreviousCount *int `json:"previous_count,omitempty"`
+	Change        *int `json:"change,omitempty"`
+}
+
+type Stats struct {
+	GroupBy []string `json:"group_by"`
+	// Totals are over all groups, not only the top ones
+	Total         int            `json:"total"`
+	PreviousTotal *int           `json:"previous_total,omitempty"`
+	Buckets       []*StatsBucket `json:"buckets"`
+}
+
+func StatsFromGRPC(s *docv1.GetStatsResponse, compare bool) *Stats {
+	stats := &Stats{
+		GroupBy: s.GroupBy,
+		Total:   int(s.Total),
+		Buckets: make([]*StatsBucket, 0, len(s.Buckets)),
+	}
+	if compare {
+		previous := int(s.PreviousTotal)
+		stats.PreviousTotal = &previous
+	}
+
+	for _, b := range s.Buckets {
+		bucket := &StatsBucket{
+			Keys:  make(map[string]string, len(s.GroupBy)),
+			Count: int(b.Count),
+		}
+		for i, dim := range s.GroupBy {
+			if i < len(b.Keys) {
+				bucket.Keys[dim] = b.Keys[i]
+			}
+		}
+		if compare {
+			previous, change := int(b.PreviousCount), int(b.Count-b.PreviousCount)
+			bucket.PreviousCount, bucket.Change = &previous, &change
+		}
+		stats.Buckets = append(stats.Buckets, bucket)
+	}
+
+	return stats
+}
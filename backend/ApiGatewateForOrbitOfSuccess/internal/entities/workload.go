@@ -0,0 +1,63 @@
+package entities
+
+import (
+	docv1 "github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/proto/gen/docs"
+)
+
+// Workload counts the docs of a year a person supervises or reviews.
+type Workload struct {
+	Person string `json:"person"`
+	// supervisor or reviewer
+	Role  string `json:"role"`
+	Year  int    `json:"year"`
+	Count int    `json:"count"`
+	// 0 means no limit
+	Limit int `json:"limit"`
+	// Set when the limit is the person's own, not the configured one
+	Personal bool `json:"personal"`
+}
+
+func WorkloadFromGRPC(workload []*docv1.Workload) []*Workload {
+	resp := make([]*Workload, 0, len(workload))
+	for _, w := range workload {
+		resp = append(resp, &Workload{
+			Person:   w.Person,
+			Role:     w.Role,
+			Year:     int(w.Year),
+			Count:    int(w.Count),
+			Limit:    int(w.Limit),
+			Personal: w.Personal,
+		})
+	}
+
+	return resp
+}
+
+// WorkloadQuery takes the current year when year is omitted.
+type WorkloadQuery struct {
+	Year int `form:"year" binding:"min=0"`
+}
+
+type GetWorkloadResponse struct {
+	Workload []*Workload `json:"workload"`
+}
+
+type SetWorkloadLimitRequest struct {
+	Limit *int `json:"limit" binding:"required,min=0"`
+}
+
+// SavedDocResponse is the saved doc with the workload limits it exceeds
+// when they are not enforced.
+type SavedDocResponse struct {
+	Doc
+	WorkloadWarnings []*Workload `json:"workload_warnings,omitempty"`
+}
+
+func SavedDocFromGRPC(doc *docv1.Doc, warnings []*docv1.Workload) *SavedDocResponse {
+	resp := &SavedDocResponse{Doc: *DocFromGRPC(doc)}
+	if len(warnings) > 0 {
+		resp.WorkloadWarnings = WorkloadFromGRPC(warnings)
+	}
+
+	return resp
+}
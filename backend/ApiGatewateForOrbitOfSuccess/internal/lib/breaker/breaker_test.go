@@ -0,0 +1,149 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type transition struct{ from, to State }
+
+// clock is a fake time moved on by the tests.
+type clock struct{ t time.Time }
+
+func (c *clock) now() time.Time { return c.t }
+
+func (c *clock) add(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestBreaker(threshold int) (*Breaker, *clock, *[]transition) {
+	var transitions []transition
+	c := &clock{t: time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)}
+
+	b := New("docs", Settings{
+		FailureThreshold: threshold,
+		OpenTimeout:      30 * time.Second,
+		OnStateChange: func(from, to State) {
+			transitions = append(transitions, transition{from, to})
+		},
+	})
+	b.now = c.now
+
+	return b, c, &transitions
+}
+
+func call(b *Breaker, success bool) error {
+	if err := b.Allow(); err != nil {
+		return err
+	}
+	b.Done(success)
+
+	return nil
+}
+
+func TestBreakerOpensAfterFailuresInARow(t *testing.T) {
+	b, _, transitions := newTestBreaker(3)
+
+	assert.NoError(t, call(b, false))
+	assert.NoError(t, call(b, false))
+	// A success starts the count again
+	assert.NoError(t, call(b, true))
+	assert.NoError(t, call(b, false))
+	assert.NoError(t, call(b, false))
+	assert.Equal(t, StateClosed, b.State())
+
+	assert.NoError(t, call(b, false))
+
+	assert.Equal(t, StateOpen, b.State())
+	assert.ErrorIs(t, b.Allow(), ErrOpen)
+	assert.Equal(t, []transition{{StateClosed, StateOpen}}, *transitions)
+}
+
+func TestBreakerRetryAfter(t *testing.T) {
+	b, c, _ := newTestBreaker(1)
+	assert.Zero(t, b.RetryAfter())
+
+	assert.NoError(t, call(b, false))
+	assert.Equal(t, 30*time.Second, b.RetryAfter())
+
+	c.add(20 * time.Second)
+	assert.Equal(t, 10*time.Second, b.RetryAfter())
+
+	c.add(time.Minute)
+	assert.Zero(t, b.RetryAfter())
+}
+
+func TestBreakerProbeCloses(t *testing.T) {
+	b, c, transitions := newTestBreaker(1)
+	assert.NoError(t, call(b, false))
+
+	c.add(29 * time.Second)
+	assert.ErrorIs(t, b.Allow(), ErrOpen)
+
+	c.add(time.Second)
+	assert.NoError(t, b.Allow())
+	assert.Equal(t, StateHalfOpen, b.State())
+	// Only one probe at a time
+	assert.ErrorIs(t, b.Allow(), ErrOpen)
+
+	b.Done(true)
+
+	assert.Equal(t, StateClosed, b.State())
+	assert.NoError(t, call(b, true))
+	assert.Equal(t, []transition{
+		{StateClosed, StateOpen},
+		{StateOpen, StateHalfOpen},
+		{StateHalfOpen, StateClosed},
+	}, *transitions)
+}
+
+func TestBreakerProbeFailureReopens(t *testing.T) {
+	b, c, transitions := newTestBreaker(2)
+	assert.NoError(t, call(b, false))
+	assert.NoError(t, call(b, false))
+
+	c.add(30 * time.Second)
+	assert.NoError(t, call(b, false))
+
+	assert.Equal(t, StateOpen, b.State())
+	// The open timeout starts again from the failed probe
+	assert.Equal(t, 30*time.Second, b.RetryAfter())
+	c.add(29 * time.Second)
+	assert.ErrorIs(t, b.Allow(), ErrOpen)
+	assert.Equal(t, []transition{
+		{StateClosed, StateOpen},
+		{StateOpen, StateHalfOpen},
+		{StateHalfOpen, StateOpen},
+	}, *transitions)
+}
+
+func TestBreakerLateCallsDontChangeOpen(t *testing.T) {
+	b, _, transitions := newTestBreaker(1)
+
+	// Both calls were let through before the breaker opened
+	assert.NoError(t, b.Allow())
+	assert.NoError(t, b.Allow())
+	b.Done(false)
+	b.Done(true)
+
+	assert.Equal(t, StateOpen, b.State())
+	assert.Equal(t, []transition{{StateClosed, StateOpen}}, *transitions)
+}
+
+func TestBreakerOff(t *testing.T) {
+	b, _, transitions := newTestBreaker(0)
+
+	for range 10 {
+		assert.NoError(t, call(b, false))
+	}
+
+	assert.Equal(t, StateClosed, b.State())
+	assert.Empty(t, *transitions)
+}
+
+func TestStateString(t *testing.T) {
+	assert.Equal(t, "closed", StateClosed.String())
+	assert.Equal(t, "half-open", StateHalfOpen.String())
+	assert.Equal(t, "open", StateOpen.String())
+	assert.Equal(t, "unknown", State(7).String())
+}
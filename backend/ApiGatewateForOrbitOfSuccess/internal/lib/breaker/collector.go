@@ -0,0 +1,31 @@
+package breaker
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Collector exports the state of the breakers, 0 closed, 1 half-open and
+// 2 open, read on every scrape.
+type Collector struct {
+	breakers []*Breaker
+	state    *prometheus.Desc
+}
+
+func NewCollector(breakers ...*Breaker) *Collector {
+	return &Collector{
+		breakers: breakers,
+		state: prometheus.NewDesc(
+			"grpc_client_circuit_breaker_state",
+			"State of the circuit breaker of the service: 0 closed, 1 half-open, 2 open.",
+			[]string{"service"}, nil,
+		),
+	}
+}
+
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.state
+}
+
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	for _, b := range c.breakers {
+		ch <- prometheus.MustNewConstMetric(c.state, prometheus.GaugeValue, float64(b.State()), b.Name())
+	}
+}
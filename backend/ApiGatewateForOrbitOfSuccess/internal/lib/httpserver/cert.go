@@ -0,0 +1,101 @@
+package httpserver
+
+import (
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// CertReloader serves the certificate of the files and loads it again
+// when they change, so renewed certificates are picked up without a
+// restart. The files are checked at most once per interval.
+type CertReloader struct {
+	log      *slog.Logger
+	certFile string
+	keyFile  string
+	interval time.Duration
+
+	mu        sync.Mutex
+	cert      *tls.Certificate
+	modTime   time.Time
+	lastCheck time.Time
+}
+
+// NewCertReloader loads the certificate, failing if it can't.
+func NewCertReloader(log *slog.Logger, certFile, keyFile string, interval time.Duration) (*CertReloader, error) {
+	const op = "httpserver.NewCertReloader"
+
+	r := &CertReloader{
+		log:      log,
+		certFile: certFile,
+		keyFile:  keyFile,
+		interval: interval,
+	}
+
+	modTime, err := r.filesModTime()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := r.load(modTime); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return r, nil
+}
+
+// GetCertificate is meant for tls.Config. A certificate that fails to
+// load is logged and the previous one is kept.
+func (r *CertReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	const op = "CertReloader.GetCertificate"
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(r.lastCheck) < r.interval {
+		return r.cert, nil
+	}
+	r.lastCheck = now
+
+	modTime, err := r.filesModTime()
+	if err == nil && modTime.After(r.modTime) {
+		err = r.load(modTime)
+	}
+	if err != nil {
+		r.log.With(slog.String("op", op)).Error("failed to reload certificate", slog.String("err", err.Error()))
+	}
+
+	return r.cert, nil
+}
+
+// filesModTime is the latest change of the two files.
+func (r *CertReloader) filesModTime() (time.Time, error) {
+	var latest time.Time
+	for _, file := range []string{r.certFile, r.keyFile} {
+		info, err := os.Stat(file)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+
+	return latest, nil
+}
+
+func (r *CertReloader) load(modTime time.Time) error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return err
+	}
+
+	r.cert = &cert
+	r.modTime = modTime
+	r.log.Info("certificate loaded", slog.String("cert", r.certFile))
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package httpserver
+
+import (
+	"crypto/tls"
+	"net"
+	"time"
+)
+
+// Option -.
+type Option func(*Server)
+
+// Port -.
+func Port(port string) Option {
+	return func(s *Server) {
+		s.server.Addr = net.JoinHostPort("", port)
+	}
+}
+
+// ReadTimeout -.
+func ReadTimeout(timeout time.Duration) Option {
+	return func(s *Server) {
+		s.server.ReadTimeout = timeout
+	}
+}
+
+// WriteTimeout -.
+func WriteTimeout(timeout time.Duration) Option {
+	return func(s *Server) {
+		s.server.WriteTimeout = timeout
+	}
+}
+
+// ShutdownTimeout -.
+func ShutdownTimeout(timeout time.Duration) Option {
+	return func(s *Server) {
+		s.shutdownTimeout = timeout
+	}
+}
+
+// TLS serves HTTPS with the certificates returned by getCertificate,
+// e.g. CertReloader.GetCertificate.
+func TLS(getCertificate func(*tls.ClientHelloInfo) (*tls.Certificate, error)) Option {
+	return func(s *Server) {
+		s.server.TLSConfig = &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: getCertificate,
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// Package ical renders iCalendar (RFC 5545) feeds.
+package ical
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	ContentType = "text/calendar; charset=utf-8"
+
+	timeFormat = "20060102T150405Z"
+	// Lines longer than this many octets must be folded.
+	maxLineLen = 75
+)
+
+type Event struct {
+	// UID must be globally unique, e.g. "slot-12@docs".
+	UID         string
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Description string
+	Location    string
+}
+
+type Calendar struct {
+	ProdID string
+	Name   string
+	// Stamp is written as DTSTAMP of every event.
+	Stamp  time.Time
+	Events []Event
+}
+
+// Encode writes the calendar to w.
+func (c *Calendar) Encode(w io.Writer) error {
+	b := &bytes.Buffer{}
+
+	writeLine(b, "BEGIN:VCALENDAR")
+	writeLine(b, "VERSION:2.0")
+	writeLine(b, "PRODID:"+escape(c.ProdID))
+	writeLine(b, "CALSCALE:GREGORIAN")
+	if c.Name != "" {
+		writeLine(b, "X-WR-CALNAME:"+escape(c.Name))
+	}
+
+	for _, e := range c.Events {
+		writeLine(b, "BEGIN:VEVENT")
+		writeLine(b, "UID:"+escape(e.UID))
+		writeLine(b, "DTSTAMP:"+c.Stamp.UTC().Format(timeFormat))
+		writeLine(b, "DTSTART:"+e.Start.UTC().Format(timeFormat))
+		writeLine(b, "DTEND:"+e.End.UTC().Format(timeFormat))
+		writeLine(b, "SUMMARY:"+escape(e.Summary))
+		if e.Description != "" {
+			writeLine(b, "DESCRIPTION:"+escape(e.Description))
+		}
+		if e.Location != "" {
+			writeLine(b, "LOCATION:"+escape(e.Location))
+		}
+		writeLine(b, "END:VEVENT")
+	}
+
+	writeLine(b, "END:VCALENDAR")
+
+	if _, err := w.Write(b.Bytes()); err != nil {
+		return fmt.Errorf("ical.Encode: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Calendar) String() string {
+	b := &strings.Builder{}
+	_ = c.Encode(b)
+
+	return b.String()
+}
+
+var escaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+)
+
+func escape(s string) string {
+	return escaper.Replace(s)
+}
+
+// writeLine folds the content line so no physical line is longer than
+// maxLineLen octets. Runes are never split.
+func writeLine(b *bytes.Buffer, line string) {
+	limit := maxLineLen
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// The leading space of a continuation line counts too.
+		limit = maxLineLen - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
@@ -0,0 +1,116 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestStore returns a store on a fake clock and the function moving it.
+func newTestStore(idleTTL time.Duration) (*MemoryStore, func(time.Duration)) {
+	now := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	s := NewMemoryStore(idleTTL)
+	s.now = func() time.Time { return now }
+	s.lastSweep = now
+
+	return s, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestLimitDisabled(t *testing.T) {
+	assert.True(t, Limit{}.Disabled())
+	assert.True(t, Limit{Requests: 10}.Disabled())
+	assert.True(t, Limit{Period: time.Minute}.Disabled())
+	assert.False(t, Limit{Requests: 10, Period: time.Minute}.Disabled())
+}
+
+func TestAllowBurst(t *testing.T) {
+	s, _ := newTestStore(0)
+	limit := Limit{Requests: 60, Period: time.Minute, Burst: 3}
+
+	for i := 2; i >= 0; i-- {
+		res := s.Allow("ip:1", limit)
+		assert.True(t, res.Allowed)
+		assert.Equal(t, 3, res.Limit)
+		assert.Equal(t, i, res.Remaining)
+		assert.Zero(t, res.RetryAfter)
+	}
+
+	res := s.Allow("ip:1", limit)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, 0, res.Remaining)
+	assert.Equal(t, time.Second, res.RetryAfter)
+	assert.Equal(t, 3*time.Second, res.Reset)
+}
+
+func TestAllowBurstDefaultsToRequests(t *testing.T) {
+	s, _ := newTestStore(0)
+
+	res := s.Allow("ip:1", Limit{Requests: 10, Period: time.Minute})
+
+	assert.Equal(t, 10, res.Limit)
+	assert.Equal(t, 9, res.Remaining)
+}
+
+func TestAllowRefills(t *testing.T) {
+	s, advance := newTestStore(0)
+	limit := Limit{Requests: 10, Period: 10 * time.Second, Burst: 2}
+
+	assert.True(t, s.Allow("ip:1", limit).Allowed)
+	assert.True(t, s.Allow("ip:1", limit).Allowed)
+	assert.False(t, s.Allow("ip:1", limit).Allowed)
+
+	advance(500 * time.Millisecond)
+	res := s.Allow("ip:1", limit)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, 500*time.Millisecond, res.RetryAfter)
+
+	advance(500 * time.Millisecond)
+	assert.True(t, s.Allow("ip:1", limit).Allowed)
+
+	// The bucket never holds more than the burst
+	advance(time.Hour)
+	res = s.Allow("ip:1", limit)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 1, res.Remaining)
+}
+
+func TestAllowKeysAreSeparate(t *testing.T) {
+	s, _ := newTestStore(0)
+	limit := Limit{Requests: 1, Period: time.Minute}
+
+	assert.True(t, s.Allow("auth:ip:1", limit).Allowed)
+	assert.False(t, s.Allow("auth:ip:1", limit).Allowed)
+	assert.True(t, s.Allow("auth:ip:2", limit).Allowed)
+	assert.True(t, s.Allow("api:ip:1", limit).Allowed)
+}
+
+func TestSweepDropsIdleBuckets(t *testing.T) {
+	s, advance := newTestStore(10 * time.Minute)
+	limit := Limit{Requests: 1, Period: time.Minute}
+
+	s.Allow("ip:idle", limit)
+	advance(9 * time.Minute)
+	s.Allow("ip:busy", limit)
+	assert.Len(t, s.buckets, 2)
+
+	advance(time.Minute)
+	s.Allow("ip:busy", limit)
+
+	assert.Len(t, s.buckets, 1)
+	assert.Contains(t, s.buckets, "ip:busy")
+	// A dropped bucket starts full again
+	assert.True(t, s.Allow("ip:idle", limit).Allowed)
+}
+
+func TestSweepOff(t *testing.T) {
+	s, advance := newTestStore(0)
+	limit := Limit{Requests: 1, Period: time.Minute}
+
+	s.Allow("ip:1", limit)
+	advance(24 * time.Hour)
+	s.Allow("ip:2", limit)
+
+	assert.Len(t, s.buckets, 2)
+}
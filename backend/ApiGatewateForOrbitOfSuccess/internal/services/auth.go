@@ -1,51 +1,93 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 
 	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/config"
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/lib/breaker"
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/lib/grpctls"
 	authv1 "github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/proto/gen/auth"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
+// authIdempotent are the calls that are safe to retry.
+var authIdempotent = []string{"Verify"}
+
 type AuthService struct {
-	log  *slog.Logger
-	cfg  config.AuthServiceConfig
-	conn *grpc.ClientConn
+	log     *slog.Logger
+	cfg     config.AuthServiceConfig
+	conn    *grpc.ClientConn
+	breaker *breaker.Breaker
 }
 
 func NewAuthService(log *slog.Logger, cfg config.AuthServiceConfig) *AuthService {
 	return &AuthService{
-		log: log,
-		cfg: cfg,
+		log:     log,
+		cfg:     cfg,
+		breaker: newBreaker(log, "auth", cfg.Breaker),
 	}
 }
 
-func (s *AuthService) Connect() authv1.AuthClient {
+// Connect creates the client of the service. The connection is made
+// lazily, use WaitReady to wait for the service.
+func (s *AuthService) Connect() (authv1.AuthClient, error) {
 	const op = "AuthService.Connect"
 
 	log := s.log.With(
 		slog.String("op", op),
 	)
 
-	log.Info("trying to connect to auth service")
+	log.Info("creating auth service client")
+	creds, err := grpctls.ClientCredentials(&s.cfg.TLS)
+	if err != nil {
+		log.Error("failed to load TLS credentials")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	opts := []grpc.DialOption{
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(creds),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
 	}
+	opts = append(opts, clientOptions(authv1.Auth_ServiceDesc.ServiceName, authIdempotent, s.cfg.GRPCClientConfig, s.breaker)...)
 
 	conn, err := grpc.NewClient(s.cfg.Addr, opts...)
 	if err != nil {
-		log.Error("failed to connect to auth service")
-		panic(fmt.Errorf("%s: %w", op, err))
+		log.Error("failed to create auth service client")
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 	s.conn = conn
 
 	client := authv1.NewAuthClient(conn)
-	log.Info("successfully connected to the auth service")
+	log.Info("auth service client created")
+
+	return client, nil
+}
+
+// Breaker returns the circuit breaker of the calls to the service.
+func (s *AuthService) Breaker() *breaker.Breaker {
+	return s.breaker
+}
 
-	return client
+// Health returns the health protocol client of the service.
+// It must be called after Connect.
+func (s *AuthService) Health() healthpb.HealthClient {
+	return healthpb.NewHealthClient(s.conn)
+}
+
+// WaitReady blocks until the service reports SERVING or ctx is done.
+func (s *AuthService) WaitReady(ctx context.Context) error {
+	const op = "AuthService.WaitReady"
+
+	err := waitReady(ctx, s.log.With(slog.String("op", op)), s.Health())
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
 }
 
 func (s *AuthService) CloseConn() error {
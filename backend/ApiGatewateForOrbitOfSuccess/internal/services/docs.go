@@ -1,51 +1,100 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 
 	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/config"
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/lib/breaker"
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/lib/grpctls"
 	docsv1 "github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/proto/gen/docs"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
+// maxMessageSize lets attachments of the largest allowed size through.
+const maxMessageSize = 21 << 20
+
+// docsIdempotent are the calls that are safe to retry.
+var docsIdempotent = []string{"GetFiltered", "Search"}
+
 type DocsService struct {
-	log  *slog.Logger
-	cfg  config.DocsServiceConfig
-	conn *grpc.ClientConn
+	log     *slog.Logger
+	cfg     config.DocsServiceConfig
+	conn    *grpc.ClientConn
+	breaker *breaker.Breaker
 }
 
 func NewDocsService(log *slog.Logger, cfg config.DocsServiceConfig) *DocsService {
 	return &DocsService{
-		log: log,
-		cfg: cfg,
+		log:     log,
+		cfg:     cfg,
+		breaker: newBreaker(log, "docs", cfg.Breaker),
 	}
 }
 
-func (s *DocsService) Connect() docsv1.DocsClient {
+// Connect creates the client of the service. The connection is made
+// lazily, use WaitReady to wait for the service.
+func (s *DocsService) Connect() (docsv1.DocsClient, error) {
 	const op = "DocsService.Connect"
 
 	log := s.log.With(
 		slog.String("op", op),
 	)
 
-	log.Info("trying to connect to auth service")
+	log.Info("creating docs service client")
+	creds, err := grpctls.ClientCredentials(&s.cfg.TLS)
+	if err != nil {
+		log.Error("failed to load TLS credentials")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	opts := []grpc.DialOption{
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(creds),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(maxMessageSize),
+			grpc.MaxCallSendMsgSize(maxMessageSize),
+		),
 	}
+	opts = append(opts, clientOptions(docsv1.Docs_ServiceDesc.ServiceName, docsIdempotent, s.cfg.GRPCClientConfig, s.breaker)...)
 
 	conn, err := grpc.NewClient(s.cfg.Addr, opts...)
 	if err != nil {
-		log.Error("failed to connect to auth service")
-		panic(fmt.Errorf("%s: %w", op, err))
+		log.Error("failed to create docs service client")
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 	s.conn = conn
 
 	client := docsv1.NewDocsClient(conn)
-	log.Info("successfully connected to the auth service")
+	log.Info("docs service client created")
+
+	return client, nil
+}
+
+// Breaker returns the circuit breaker of the calls to the service.
+func (s *DocsService) Breaker() *breaker.Breaker {
+	return s.breaker
+}
+
+// Health returns the health protocol client of the service.
+// It must be called after Connect.
+func (s *DocsService) Health() healthpb.HealthClient {
+	return healthpb.NewHealthClient(s.conn)
+}
+
+// WaitReady blocks until the service reports SERVING or ctx is done.
+func (s *DocsService) WaitReady(ctx context.Context) error {
+	const op = "DocsService.WaitReady"
 
-	return client
+	err := waitReady(ctx, s.log.With(slog.String("op", op)), s.Health())
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
 }
 
 func (s *DocsService) CloseConn() error {
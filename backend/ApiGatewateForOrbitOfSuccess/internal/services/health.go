@@ -0,0 +1,59 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	healthCheckTimeout = 2 * time.Second
+	readyPollInterval  = 2 * time.Second
+)
+
+var ErrNotServing = errors.New("service is not serving")
+
+// CheckHealth asks the service for its status through the gRPC health
+// protocol. It fails unless the service is SERVING.
+func CheckHealth(ctx context.Context, client healthpb.HealthClient) error {
+	const op = "services.CheckHealth"
+
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("%s: %w: %s", op, ErrNotServing, resp.GetStatus())
+	}
+
+	return nil
+}
+
+// waitReady checks the health of the service until it is SERVING or the
+// context is done.
+func waitReady(ctx context.Context, log *slog.Logger, client healthpb.HealthClient) error {
+	ticker := time.NewTicker(readyPollInterval)
+	defer ticker.Stop()
+
+	for {
+		err := CheckHealth(ctx, client)
+		if err == nil {
+			log.Info("service is ready")
+			return nil
+		}
+		log.Info("waiting for the service to get ready", slog.String("err", err.Error()))
+
+		select {
+		case <-ctx.Done():
+			return errors.Join(ctx.Err(), err)
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/config"
+	"github.com/Homyakadze14/ApiGatewateForOrbitOfSuccess/internal/lib/breaker"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// ReasonCircuitOpen is the ErrorInfo reason of the calls failed by the
+// breaker without reaching the service.
+const ReasonCircuitOpen = "CIRCUIT_OPEN"
+
+// methodConfig is an entry of the gRPC service config,
+// see https://github.com/grpc/grpc/blob/master/doc/service_config.md.
+type methodConfig struct {
+	Name        []methodName `json:"name"`
+	Timeout     string       `json:"timeout,omitempty"`
+	RetryPolicy *retryPolicy `json:"retryPolicy,omitempty"`
+}
+
+type methodName struct {
+	Service string `json:"service"`
+	Method  string `json:"method,omitempty"`
+}
+
+type retryPolicy struct {
+	MaxAttempts          int      `json:"maxAttempts"`
+	InitialBackoff       string   `json:"initialBackoff"`
+	MaxBackoff           string   `json:"maxBackoff"`
+	BackoffMultiplier    float64  `json:"backoffMultiplier"`
+	RetryableStatusCodes []string `json:"retryableStatusCodes"`
+}
+
+// serviceConfig sets the deadlines of the calls to the service and retries
+// the idempotent ones. The health service is left out, its checks have
+// their own timeout.
+func serviceConfig(service string, idempotent []string, cfg config.GRPCClientConfig) string {
+	methods := []methodConfig{{
+		Name:    []methodName{{Service: service}},
+		Timeout: protoDuration(cfg.Timeout),
+	}}
+
+	// A method may be listed once, so its timeout and retries go together
+	names := make([]string, 0, len(cfg.MethodTimeouts)+len(idempotent))
+	for name := range cfg.MethodTimeouts {
+		names = append(names, name)
+	}
+	names = append(names, idempotent...)
+	slices.Sort(names)
+
+	for _, name := range slices.Compact(names) {
+		mc := methodConfig{
+			Name:    []methodName{{Service: service, Method: name}},
+			Timeout: protoDuration(cfg.Timeout),
+		}
+		if timeout, ok := cfg.MethodTimeouts[name]; ok {
+			mc.Timeout = protoDuration(timeout)
+		}
+		if slices.Contains(idempotent, name) && cfg.Retry.MaxAttempts >= 2 {
+			mc.RetryPolicy = &retryPolicy{
+				MaxAttempts:          cfg.Retry.MaxAttempts,
+				InitialBackoff:       protoDuration(cfg.Retry.InitialBackoff),
+				MaxBackoff:           protoDuration(cfg.Retry.MaxBackoff),
+				BackoffMultiplier:    2,
+				RetryableStatusCodes: []string{"UNAVAILABLE"},
+			}
+		}
+		methods = append(methods, mc)
+	}
+
+	// Can't fail, the config has only strings and numbers
+	js, _ := json.Marshal(map[string]any{"methodConfig": methods})
+	return string(js)
+}
+
+// protoDuration formats the duration as the service config wants it, e.g. "1.5s".
+func protoDuration(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "s"
+}
+
+// clientOptions are the dial options every service client shares.
+func clientOptions(service string, idempotent []string, cfg config.GRPCClientConfig, b *breaker.Breaker) []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithDefaultServiceConfig(serviceConfig(service, idempotent, cfg)),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                cfg.Keepalive.Time,
+			Timeout:             cfg.Keepalive.Timeout,
+			PermitWithoutStream: true,
+		}),
+		grpc.WithChainUnaryInterceptor(breakerInterceptor(b)),
+	}
+}
+
+// newBreaker returns the breaker of the service that logs its changes.
+func newBreaker(log *slog.Logger, name string, cfg config.BreakerConfig) *breaker.Breaker {
+	return breaker.New(name, breaker.Settings{
+		FailureThreshold: cfg.FailureThreshold,
+		OpenTimeout:      cfg.OpenTimeout,
+		OnStateChange: func(from, to breaker.State) {
+			log.Warn("circuit breaker state changed",
+				slog.String("service", name),
+				slog.String("from", from.String()),
+				slog.String("to", to.String()),
+			)
+		},
+	})
+}
+
+// breakerInterceptor fails the calls at once while the service is failing.
+// The retries are made below it, so it sees a call that failed after all of
+// them as one failure.
+func breakerInterceptor(b *breaker.Breaker) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		// Readiness must see the service itself
+		if isHealthMethod(method) {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		if err := b.Allow(); err != nil {
+			return circuitOpenError(b)
+		}
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		switch status.Code(err) {
+		case codes.Unavailable, codes.DeadlineExceeded:
+			b.Done(false)
+		default:
+			b.Done(true)
+		}
+
+		return err
+	}
+}
+
+func isHealthMethod(method string) bool {
+	return strings.HasPrefix(method, "/"+healthpb.Health_ServiceDesc.ServiceName+"/")
+}
+
+// circuitOpenError tells the client when to retry.
+func circuitOpenError(b *breaker.Breaker) error {
+	st := status.New(codes.Unavailable, b.Name()+" service is failing, calls to it are paused")
+	withDetails, err := st.WithDetails(
+		&errdetails.ErrorInfo{Reason: ReasonCircuitOpen, Domain: "gateway"},
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(b.RetryAfter())},
+	)
+	if err != nil {
+		return st.Err()
+	}
+
+	return withDetails.Err()
+}
@@ -1,28 +1,50 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"os"
+	"time"
 
 	grpcapp "github.com/Homyakadze14/AuthMicroservice/internal/app/grpc"
+	metricsapp "github.com/Homyakadze14/AuthMicroservice/internal/app/metrics"
 	"github.com/Homyakadze14/AuthMicroservice/internal/config"
+	"github.com/Homyakadze14/AuthMicroservice/internal/lib/grpctls"
 	actLinkMailer "github.com/Homyakadze14/AuthMicroservice/internal/lib/mailer"
+	"github.com/Homyakadze14/AuthMicroservice/internal/lib/metrics"
+	"github.com/Homyakadze14/AuthMicroservice/internal/lib/pwdhash"
+	"github.com/Homyakadze14/AuthMicroservice/internal/lib/pwdpolicy"
+	"github.com/Homyakadze14/AuthMicroservice/internal/lib/tracing"
 	"github.com/Homyakadze14/AuthMicroservice/internal/repositories"
 	"github.com/Homyakadze14/AuthMicroservice/internal/services"
 	"github.com/Homyakadze14/AuthMicroservice/pkg/mailer"
 	"github.com/Homyakadze14/AuthMicroservice/pkg/postgres"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 )
 
+// tracingShutdownTimeout bounds flushing the spans left on shutdown.
+const tracingShutdownTimeout = 5 * time.Second
+
 type App struct {
-	db         *postgres.Postgres
-	GRPCServer *grpcapp.App
+	tracing       *tracing.Provider
+	db            *postgres.Postgres
+	GRPCServer    *grpcapp.App
+	MetricsServer *metricsapp.App
 }
 
 func Run(
 	log *slog.Logger,
 	cfg *config.Config,
 ) *App {
+	// Tracing
+	tp, err := tracing.New(context.Background(), &cfg.Tracing)
+	if err != nil {
+		slog.Error(fmt.Errorf("app - Run - tracing.New: %w", err).Error())
+		os.Exit(1)
+	}
+
 	// Database
 	pg, err := postgres.New(cfg.Database.URL, postgres.MaxPoolSize(cfg.Database.PoolMax))
 	if err != nil {
@@ -35,23 +57,69 @@ func Run(
 	tokenRepo := repositories.NewTokenRepository(pg)
 	linkRepo := repositories.NewLinkRepository(pg)
 	pwdLinkRepo := repositories.NewPasswordLinkRepository(pg)
+	apiTokRepo := repositories.NewAPITokenRepository(pg)
+
+	// Metrics
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		metrics.NewPasswordHashCollector(log, accRepo),
+		metrics.NewPoolCollector(pg.Pool),
+	)
+	authMetrics := metrics.NewAuthMetrics(registry)
+	grpcMetrics := metrics.NewGRPCMetrics(registry)
 
 	// Mailer
-	mailer := actLinkMailer.New(cfg.BaseLinks, mailer.New(&cfg.Mailer))
+	mailer := metrics.InstrumentMailer(actLinkMailer.New(cfg.BaseLinks, mailer.New(&cfg.Mailer)), authMetrics)
+
+	// Password policy
+	pwdPolicy := pwdpolicy.New(cfg.PasswordPolicy)
+
+	// Password hasher
+	pwdHasher := pwdhash.New(cfg.PasswordHash)
 
 	// Services
-	auth := services.NewAuthService(log, accRepo, tokenRepo, linkRepo, &cfg.JWTAccess, &cfg.JWTRefresh, mailer, pwdLinkRepo)
+	auth := services.NewAuthService(log, accRepo, tokenRepo, linkRepo, &cfg.JWTAccess, &cfg.JWTRefresh, mailer, pwdLinkRepo, apiTokRepo, pwdPolicy, pwdHasher)
+
+	// Admins
+	err = auth.SeedAdmins(context.Background(), cfg.Roles.AdminEmails)
+	if err != nil {
+		slog.Error(fmt.Errorf("app - Run - auth.SeedAdmins: %w", err).Error())
+		os.Exit(1)
+	}
 
 	// GRPC
-	gRPCServer := grpcapp.New(log, auth, cfg.GRPC.Port)
+	creds, err := grpctls.ServerCredentials(&cfg.GRPC.TLS)
+	if err != nil {
+		slog.Error(fmt.Errorf("app - Run - grpctls.ServerCredentials: %w", err).Error())
+		os.Exit(1)
+	}
+	gRPCServer := grpcapp.New(log, metrics.InstrumentAuth(auth, authMetrics), pg.Pool, grpcMetrics, creds, cfg.GRPC.Port)
+
+	// Metrics server
+	metricsServer := metricsapp.New(log, registry, cfg.Metrics.Port)
 
 	return &App{
-		db:         pg,
-		GRPCServer: gRPCServer,
+		tracing:       tp,
+		db:            pg,
+		GRPCServer:    gRPCServer,
+		MetricsServer: metricsServer,
 	}
 }
 
 func (s *App) Shutdown() {
+	defer s.shutdownTracing()
 	defer s.db.Close()
 	defer s.GRPCServer.Stop()
+	defer s.MetricsServer.Stop()
+}
+
+func (s *App) shutdownTracing() {
+	ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	defer cancel()
+
+	if err := s.tracing.Shutdown(ctx); err != nil {
+		slog.Error(fmt.Errorf("app - Shutdown - tracing.Shutdown: %w", err).Error())
+	}
 }
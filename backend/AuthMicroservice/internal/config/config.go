@@ -15,13 +15,27 @@ type Config struct {
 	JWTAccess      JWTAccessConfig  `yaml:"jwt_access"`
 	JWTRefresh     JWTRefreshConfig `yaml:"jwt_refresh"`
 	MigrationsPath string
-	Mailer         MailerConfig    `yaml:"mailer"`
-	BaseLinks      BaseLinksConfig `yaml:"base_links"`
+	Mailer         MailerConfig         `yaml:"mailer"`
+	BaseLinks      BaseLinksConfig      `yaml:"base_links"`
+	PasswordPolicy PasswordPolicyConfig `yaml:"password_policy"`
+	PasswordHash   PasswordHashConfig   `yaml:"password_hash"`
+	Metrics        MetricsConfig        `yaml:"metrics"`
+	Tracing        TracingConfig        `yaml:"tracing"`
+	Roles          RolesConfig          `yaml:"roles"`
 }
 
 type GRPCConfig struct {
 	Port    int           `yaml:"port" env-required:"true"`
 	Timeout time.Duration `yaml:"timeout" env-required:"true"`
+	TLS     GRPCTLSConfig `yaml:"tls"`
+}
+
+// GRPCTLSConfig turns TLS on when the certificate is set. With the client
+// CA set too, callers must present a certificate signed by it (mTLS).
+type GRPCTLSConfig struct {
+	CertFile     string `yaml:"cert_file" env:"GRPC_TLS_CERT_FILE"`
+	KeyFile      string `yaml:"key_file" env:"GRPC_TLS_KEY_FILE"`
+	ClientCAFile string `yaml:"client_ca_file" env:"GRPC_TLS_CLIENT_CA_FILE"`
 }
 
 type JWTAccessConfig struct {
@@ -51,6 +65,44 @@ type BaseLinksConfig struct {
 	ChangePasswordUrl string `yaml:"change_password_url" env-required:"true"`
 }
 
+type PasswordPolicyConfig struct {
+	MinLength      int  `yaml:"min_length" env-default:"8"`
+	MaxLength      int  `yaml:"max_length" env-default:"72"`
+	RequireUpper   bool `yaml:"require_upper" env-default:"true"`
+	RequireLower   bool `yaml:"require_lower" env-default:"true"`
+	RequireDigit   bool `yaml:"require_digit" env-default:"true"`
+	RequireSpecial bool `yaml:"require_special" env-default:"false"`
+}
+
+type PasswordHashConfig struct {
+	Memory      uint32 `yaml:"memory" env-default:"65536"`
+	Iterations  uint32 `yaml:"iterations" env-default:"3"`
+	Parallelism uint8  `yaml:"parallelism" env-default:"2"`
+	SaltLength  uint32 `yaml:"salt_length" env-default:"16"`
+	KeyLength   uint32 `yaml:"key_length" env-default:"32"`
+}
+
+type MetricsConfig struct {
+	Port int `yaml:"port" env-default:"9101"`
+}
+
+// TracingConfig sets up OpenTelemetry tracing. Exporter is "otlp" to send
+// spans to the collector at Endpoint over gRPC, "stdout" to print them for
+// local debugging or "none" to only pass the trace context on.
+type TracingConfig struct {
+	Exporter    string  `yaml:"exporter" env:"TRACING_EXPORTER" env-default:"none"`
+	Endpoint    string  `yaml:"endpoint" env:"TRACING_ENDPOINT" env-default:"localhost:4317"`
+	Insecure    bool    `yaml:"insecure" env:"TRACING_INSECURE" env-default:"true"`
+	ServiceName string  `yaml:"service_name" env:"TRACING_SERVICE_NAME" env-default:"auth"`
+	SampleRatio float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO" env-default:"1"`
+}
+
+// RolesConfig lists the accounts made admins on every start, so that a fresh
+// deployment has someone to assign the other roles with SetRole.
+type RolesConfig struct {
+	AdminEmails []string `yaml:"admin_emails" env:"ROLES_ADMIN_EMAILS" env-separator:","`
+}
+
 func MustLoad() *Config {
 	configPath := fetchConfigPath()
 	if configPath == "" {
@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Homyakadze14/AuthMicroservice/internal/entities"
+	"github.com/Homyakadze14/AuthMicroservice/internal/lib/jwt"
+	"github.com/Homyakadze14/AuthMicroservice/internal/services"
+	authv1 "github.com/Homyakadze14/AuthMicroservice/proto/gen/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func sessionErr(err error) error {
+	if errors.Is(err, services.ErrAPITokenNotAllowed) {
+		return status.Error(codes.PermissionDenied, "api tokens can't manage api tokens")
+	}
+	if errors.Is(err, jwt.ErrTokenExpired) {
+		return status.Error(codes.Unauthenticated, "token expired")
+	}
+	if errors.Is(err, jwt.ErrBadToken) {
+		return status.Error(codes.Unauthenticated, "bad token")
+	}
+	return nil
+}
+
+func unixOrZero(t *time.Time) int64 {
+	if t == nil {
+		return 0
+	}
+	return t.Unix()
+}
+
+func apiTokenToProto(token *entities.APIToken) *authv1.APIToken {
+	return &authv1.APIToken{
+		Id:         int64(token.ID),
+		Name:       token.Name,
+		Prefix:     token.Prefix,
+		Scopes:     token.Scopes,
+		CreatedAt:  token.CreatedAt.Unix(),
+		LastUsedAt: unixOrZero(token.LastUsedAt),
+		ExpiresAt:  unixOrZero(token.ExpiresAt),
+	}
+}
+
+func (s *serverAPI) CreateAPIToken(
+	ctx context.Context,
+	in *authv1.CreateAPITokenRequest,
+) (*authv1.CreateAPITokenResponse, error) {
+	if in.AccessToken == "" {
+		return nil, status.Error(codes.InvalidArgument, "access token is required")
+	}
+
+	if in.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+
+	if len(in.Scopes) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one scope is required")
+	}
+
+	token := &entities.APIToken{
+		Name:   in.Name,
+		Scopes: in.Scopes,
+	}
+	if in.ExpiresAt != 0 {
+		expiresAt := time.Unix(in.ExpiresAt, 0)
+		if expiresAt.Before(time.Now()) {
+			return nil, status.Error(codes.InvalidArgument, "expiration time must be in the future")
+		}
+		token.ExpiresAt = &expiresAt
+	}
+
+	secret, err := s.auth.CreateAPIToken(ctx, in.AccessToken, token)
+	if err != nil {
+		if sErr := sessionErr(err); sErr != nil {
+			return nil, sErr
+		}
+		if errors.Is(err, services.ErrBadScope) {
+			return nil, status.Error(codes.InvalidArgument, "unknown scope")
+		}
+
+		return nil, status.Error(codes.Internal, "failed to create api token")
+	}
+
+	return &authv1.CreateAPITokenResponse{
+		Token:  apiTokenToProto(token),
+		Secret: secret,
+	}, nil
+}
+
+func (s *serverAPI) ListAPITokens(
+	ctx context.Context,
+	in *authv1.ListAPITokensRequest,
+) (*authv1.ListAPITokensResponse, error) {
+	if in.AccessToken == "" {
+		return nil, status.Error(codes.InvalidArgument, "access token is required")
+	}
+
+	tokens, err := s.auth.ListAPITokens(ctx, in.AccessToken)
+	if err != nil {
+		if sErr := sessionErr(err); sErr != nil {
+			return nil, sErr
+		}
+
+		return nil, status.Error(codes.Internal, "failed to list api tokens")
+	}
+
+	resp := make([]*authv1.APIToken, 0, len(tokens))
+	for _, token := range tokens {
+		resp = append(resp, apiTokenToProto(token))
+	}
+
+	return &authv1.ListAPITokensResponse{Tokens: resp}, nil
+}
+
+func (s *serverAPI) RevokeAPIToken(
+	ctx context.Context,
+	in *authv1.RevokeAPITokenRequest,
+) (*authv1.RevokeAPITokenResponse, error) {
+	if in.AccessToken == "" {
+		return nil, status.Error(codes.InvalidArgument, "access token is required")
+	}
+
+	if in.Id == 0 {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	err := s.auth.RevokeAPIToken(ctx, in.AccessToken, int(in.Id))
+	if err != nil {
+		if sErr := sessionErr(err); sErr != nil {
+			return nil, sErr
+		}
+		if errors.Is(err, services.ErrAPITokenNotFound) {
+			return nil, status.Error(codes.NotFound, "api token not found")
+		}
+
+		return nil, status.Error(codes.Internal, "failed to revoke api token")
+	}
+
+	return &authv1.RevokeAPITokenResponse{Success: true}, nil
+}
@@ -6,8 +6,10 @@ import (
 
 	"github.com/Homyakadze14/AuthMicroservice/internal/entities"
 	"github.com/Homyakadze14/AuthMicroservice/internal/lib/jwt"
+	"github.com/Homyakadze14/AuthMicroservice/internal/lib/pwdpolicy"
 	"github.com/Homyakadze14/AuthMicroservice/internal/services"
 	authv1 "github.com/Homyakadze14/AuthMicroservice/proto/gen/auth"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -24,15 +26,46 @@ type Auth interface {
 	Logout(ctx context.Context, tok *entities.LogoutRequest) error
 	ActivateAccount(ctx context.Context, link string) error
 	Refresh(ctx context.Context, refreshToken string) (*entities.TokenPair, error)
-	Verify(ctx context.Context, accToken string) (bool, error)
+	Verify(ctx context.Context, token string) (*entities.Identity, error)
 	SendPwdLink(ctx context.Context, email string) (bool, error)
 	ChangePwd(ctx context.Context, link *entities.ChPwdLink) (bool, error)
+	CreateAPIToken(ctx context.Context, accessToken string, token *entities.APIToken) (string, error)
+	ListAPITokens(ctx context.Context, accessToken string) ([]*entities.APIToken, error)
+	RevokeAPIToken(ctx context.Context, accessToken string, id int) error
+	SetRole(ctx context.Context, accessToken string, uid int, role string) error
 }
 
 func Register(gRPCServer *grpc.Server, auth Auth) {
 	authv1.RegisterAuthServer(gRPCServer, &serverAPI{auth: auth})
 }
 
+// weakPasswordErr returns InvalidArgument with a field violation
+// for every broken password policy rule.
+func weakPasswordErr(err error) error {
+	st := status.New(codes.InvalidArgument, "password does not satisfy the password policy")
+
+	var vErr *pwdpolicy.ViolationError
+	if !errors.As(err, &vErr) {
+		return st.Err()
+	}
+
+	br := &errdetails.BadRequest{}
+	for _, v := range vErr.Violations {
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       "password",
+			Description: v.Message,
+			Reason:      v.Rule,
+		})
+	}
+
+	stWithDetails, dErr := st.WithDetails(br)
+	if dErr != nil {
+		return st.Err()
+	}
+
+	return stWithDetails.Err()
+}
+
 func (s *serverAPI) Login(
 	ctx context.Context,
 	in *authv1.LoginRequest,
@@ -95,6 +128,9 @@ func (s *serverAPI) Register(
 		if errors.Is(err, services.ErrAccountAlreadyExists) {
 			return nil, status.Error(codes.AlreadyExists, "account already exists")
 		}
+		if errors.Is(err, pwdpolicy.ErrWeakPassword) {
+			return nil, weakPasswordErr(err)
+		}
 
 		return nil, status.Error(codes.Internal, "failed to register")
 	}
@@ -182,7 +218,7 @@ func (s *serverAPI) Verify(
 		return nil, status.Error(codes.InvalidArgument, "access token is required")
 	}
 
-	verified, err := s.auth.Verify(ctx, in.AccessToken)
+	identity, err := s.auth.Verify(ctx, in.AccessToken)
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
 			return nil, status.Error(codes.Unauthenticated, "token expired")
@@ -190,10 +226,19 @@ func (s *serverAPI) Verify(
 		if errors.Is(err, jwt.ErrBadToken) {
 			return nil, status.Error(codes.InvalidArgument, "bad token")
 		}
+		if errors.Is(err, services.ErrAPITokenNotFound) || errors.Is(err, services.ErrAccountNotFound) {
+			return nil, status.Error(codes.Unauthenticated, "bad token")
+		}
 		return nil, status.Error(codes.Internal, "failed to verify")
 	}
 
-	return &authv1.VerifyResponse{Verified: verified}, nil
+	return &authv1.VerifyResponse{
+		Verified: true,
+		UserId:   int64(identity.UserID),
+		Username: identity.Username,
+		Scopes:   identity.Scopes,
+		Role:     identity.Role,
+	}, nil
 }
 
 func (s *serverAPI) SendPasswordLink(
@@ -233,6 +278,9 @@ func (s *serverAPI) ChangePassword(
 		if errors.Is(err, services.ErrLinkNotFound) {
 			return nil, status.Error(codes.NotFound, "link not found")
 		}
+		if errors.Is(err, pwdpolicy.ErrWeakPassword) {
+			return nil, weakPasswordErr(err)
+		}
 		return nil, status.Error(codes.Internal, "failed to change password")
 	}
 
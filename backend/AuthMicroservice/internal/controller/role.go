@@ -0,0 +1,50 @@
+package controller
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Homyakadze14/AuthMicroservice/internal/services"
+	authv1 "github.com/Homyakadze14/AuthMicroservice/proto/gen/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *serverAPI) SetRole(
+	ctx context.Context,
+	in *authv1.SetRoleRequest,
+) (*authv1.SetRoleResponse, error) {
+	if in.AccessToken == "" {
+		return nil, status.Error(codes.InvalidArgument, "access token is required")
+	}
+
+	if in.UserId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "user id is required")
+	}
+
+	err := s.auth.SetRole(ctx, in.AccessToken, int(in.UserId), in.Role)
+	if err != nil {
+		if errors.Is(err, services.ErrAPITokenNotAllowed) {
+			return nil, status.Error(codes.PermissionDenied, "api tokens can't assign roles")
+		}
+		if sErr := sessionErr(err); sErr != nil {
+			return nil, sErr
+		}
+		if errors.Is(err, services.ErrUnknownRole) {
+			return nil, status.Error(codes.InvalidArgument, services.ErrUnknownRole.Error())
+		}
+		if errors.Is(err, services.ErrRoleForbidden) {
+			return nil, status.Error(codes.PermissionDenied, services.ErrRoleForbidden.Error())
+		}
+		if errors.Is(err, services.ErrOwnRole) {
+			return nil, status.Error(codes.FailedPrecondition, services.ErrOwnRole.Error())
+		}
+		if errors.Is(err, services.ErrAccountNotFound) {
+			return nil, status.Error(codes.NotFound, "account not found")
+		}
+
+		return nil, status.Error(codes.Internal, "failed to set role")
+	}
+
+	return &authv1.SetRoleResponse{Success: true}, nil
+}
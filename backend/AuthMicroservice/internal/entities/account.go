@@ -5,15 +5,26 @@ import (
 	"time"
 )
 
+// Account roles. New accounts are students, other roles are granted by an admin.
+const (
+	RoleStudent    = "student"
+	RoleSupervisor = "supervisor"
+	RoleSecretary  = "secretary"
+	RoleAdmin      = "admin"
+)
+
+var Roles = []string{RoleStudent, RoleSupervisor, RoleSecretary, RoleAdmin}
+
 type Account struct {
 	ID        int
 	Username  string
 	Email     string
 	Password  string
+	Role      string
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
 
 func (a Account) String() string {
-	return fmt.Sprintf("ID: %v; Username: %v; Email: %v", a.ID, a.Username, a.Email)
+	return fmt.Sprintf("ID: %v; Username: %v; Email: %v; Role: %v", a.ID, a.Username, a.Email, a.Role)
 }
@@ -0,0 +1,41 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	APITokenPrefix = "bdd_"
+
+	ScopeDocsRead  = "docs:read"
+	ScopeDocsWrite = "docs:write"
+)
+
+// Scopes lists every scope that can be granted to a personal API token.
+// Sessions opened with a password get all of them.
+var Scopes = []string{ScopeDocsRead, ScopeDocsWrite}
+
+type APIToken struct {
+	ID         int
+	UserID     int
+	Name       string
+	Prefix     string
+	TokenHash  string
+	Scopes     []string
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+	ExpiresAt  *time.Time
+}
+
+func (t APIToken) String() string {
+	return fmt.Sprintf("ID: %v; UserID: %v; Name: %v; Prefix: %v; Scopes: %v", t.ID, t.UserID, t.Name, t.Prefix, t.Scopes)
+}
+
+// Identity describes who is behind a verified access or API token.
+type Identity struct {
+	UserID   int
+	Username string
+	Role     string
+	Scopes   []string
+}
@@ -21,6 +21,7 @@ func NewToken(acc *entities.Account, secret string, duration time.Duration) (str
 	claims["jti"] = uuid.NewString()
 	claims["uid"] = acc.ID
 	claims["username"] = acc.Username
+	claims["role"] = acc.Role
 	claims["exp"] = time.Now().Add(duration).Unix()
 
 	tokenString, err := token.SignedString([]byte(secret))
@@ -45,3 +46,34 @@ func ParseToken(token, secret string) (*jwt.Token, error) {
 
 	return jwtToken, nil
 }
+
+// ParseAccount validates the token and returns the account data stored in its claims.
+func ParseAccount(token, secret string) (*entities.Account, error) {
+	jwtToken, err := ParseToken(token, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := jwtToken.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, ErrBadToken
+	}
+
+	uid, ok := claims["uid"].(float64)
+	if !ok {
+		return nil, ErrBadToken
+	}
+	username, _ := claims["username"].(string)
+
+	// Tokens issued before roles were introduced have no role claim
+	role, _ := claims["role"].(string)
+	if role == "" {
+		role = entities.RoleStudent
+	}
+
+	return &entities.Account{
+		ID:       int(uid),
+		Username: username,
+		Role:     role,
+	}, nil
+}
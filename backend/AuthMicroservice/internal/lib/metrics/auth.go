@@ -0,0 +1,164 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+
+	authgrpc "github.com/Homyakadze14/AuthMicroservice/internal/controller"
+	"github.com/Homyakadze14/AuthMicroservice/internal/entities"
+	"github.com/Homyakadze14/AuthMicroservice/internal/lib/jwt"
+	"github.com/Homyakadze14/AuthMicroservice/internal/lib/pwdpolicy"
+	"github.com/Homyakadze14/AuthMicroservice/internal/services"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Results of the counted operations. Failures are counted by reason.
+const (
+	ResultSuccess        = "success"
+	ResultError          = "error"
+	ResultAlreadyExists  = "already_exists"
+	ResultWeakPassword   = "weak_password"
+	ResultBadCredentials = "bad_credentials"
+	ResultNotFound       = "account_not_found"
+	ResultNotActivated   = "not_activated"
+	ResultTokenNotFound  = "token_not_found"
+	ResultTokenExpired   = "token_expired"
+	ResultBadToken       = "bad_token"
+)
+
+// Kinds of the mail sent.
+const (
+	MailActivation = "activation"
+	MailPassword   = "password"
+)
+
+// AuthMetrics count registrations, logins, refreshes and failed mails.
+type AuthMetrics struct {
+	registrations *prometheus.CounterVec
+	logins        *prometheus.CounterVec
+	refreshes     *prometheus.CounterVec
+	mailFailures  *prometheus.CounterVec
+}
+
+func NewAuthMetrics(reg prometheus.Registerer) *AuthMetrics {
+	m := &AuthMetrics{
+		registrations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "auth_registrations_total",
+			Help: "Number of registration attempts, by result.",
+		}, []string{"result"}),
+		logins: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "auth_logins_total",
+			Help: "Number of login attempts, by result.",
+		}, []string{"result"}),
+		refreshes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "auth_refreshes_total",
+			Help: "Number of token refresh attempts, by result.",
+		}, []string{"result"}),
+		mailFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "auth_mail_send_failures_total",
+			Help: "Number of mails that couldn't be sent, by kind.",
+		}, []string{"kind"}),
+	}
+	reg.MustRegister(m.registrations, m.logins, m.refreshes, m.mailFailures)
+
+	// Show the results with zero before they first happen
+	for _, r := range []string{ResultSuccess, ResultAlreadyExists, ResultWeakPassword, ResultError} {
+		m.registrations.WithLabelValues(r)
+	}
+	for _, r := range []string{ResultSuccess, ResultBadCredentials, ResultNotFound, ResultNotActivated, ResultError} {
+		m.logins.WithLabelValues(r)
+	}
+	for _, r := range []string{ResultSuccess, ResultTokenNotFound, ResultTokenExpired, ResultBadToken, ResultError} {
+		m.refreshes.WithLabelValues(r)
+	}
+	for _, k := range []string{MailActivation, MailPassword} {
+		m.mailFailures.WithLabelValues(k)
+	}
+
+	return m
+}
+
+// result gives the reason of the first matching error.
+func result(err error, reasons map[error]string) string {
+	if err == nil {
+		return ResultSuccess
+	}
+	for target, reason := range reasons {
+		if errors.Is(err, target) {
+			return reason
+		}
+	}
+	return ResultError
+}
+
+var (
+	registerReasons = map[error]string{
+		services.ErrAccountAlreadyExists: ResultAlreadyExists,
+		pwdpolicy.ErrWeakPassword:        ResultWeakPassword,
+	}
+	loginReasons = map[error]string{
+		services.ErrBadCredentials:  ResultBadCredentials,
+		services.ErrAccountNotFound: ResultNotFound,
+		services.ErrNotActivated:    ResultNotActivated,
+	}
+	refreshReasons = map[error]string{
+		services.ErrTokenNotFound: ResultTokenNotFound,
+		jwt.ErrTokenExpired:       ResultTokenExpired,
+		jwt.ErrBadToken:           ResultBadToken,
+	}
+)
+
+type instrumentedAuth struct {
+	authgrpc.Auth
+	m *AuthMetrics
+}
+
+// InstrumentAuth counts the registrations, logins and refreshes made
+// through the service.
+func InstrumentAuth(auth authgrpc.Auth, m *AuthMetrics) authgrpc.Auth {
+	return &instrumentedAuth{Auth: auth, m: m}
+}
+
+func (a *instrumentedAuth) Register(ctx context.Context, acc *entities.Account) error {
+	err := a.Auth.Register(ctx, acc)
+	a.m.registrations.WithLabelValues(result(err, registerReasons)).Inc()
+	return err
+}
+
+func (a *instrumentedAuth) Login(ctx context.Context, acc *entities.Account) (*entities.TokenPair, error) {
+	pair, err := a.Auth.Login(ctx, acc)
+	a.m.logins.WithLabelValues(result(err, loginReasons)).Inc()
+	return pair, err
+}
+
+func (a *instrumentedAuth) Refresh(ctx context.Context, refreshToken string) (*entities.TokenPair, error) {
+	pair, err := a.Auth.Refresh(ctx, refreshToken)
+	a.m.refreshes.WithLabelValues(result(err, refreshReasons)).Inc()
+	return pair, err
+}
+
+type instrumentedMailer struct {
+	services.Mailer
+	m *AuthMetrics
+}
+
+// InstrumentMailer counts the mails that couldn't be sent.
+func InstrumentMailer(mailer services.Mailer, m *AuthMetrics) services.Mailer {
+	return &instrumentedMailer{Mailer: mailer, m: m}
+}
+
+func (ml *instrumentedMailer) SendActivationMail(email, link string) error {
+	err := ml.Mailer.SendActivationMail(email, link)
+	if err != nil {
+		ml.m.mailFailures.WithLabelValues(MailActivation).Inc()
+	}
+	return err
+}
+
+func (ml *instrumentedMailer) SendPwdMail(email, link string) error {
+	err := ml.Mailer.SendPwdMail(email, link)
+	if err != nil {
+		ml.m.mailFailures.WithLabelValues(MailPassword).Inc()
+	}
+	return err
+}
@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	authgrpc "github.com/Homyakadze14/AuthMicroservice/internal/controller"
+	"github.com/Homyakadze14/AuthMicroservice/internal/entities"
+	"github.com/Homyakadze14/AuthMicroservice/internal/services"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeAuth struct {
+	authgrpc.Auth
+	loginErr error
+}
+
+func (f *fakeAuth) Login(context.Context, *entities.Account) (*entities.TokenPair, error) {
+	if f.loginErr != nil {
+		return nil, f.loginErr
+	}
+	return &entities.TokenPair{}, nil
+}
+
+func TestInstrumentAuthCountsLoginsByResult(t *testing.T) {
+	m := NewAuthMetrics(prometheus.NewRegistry())
+	fake := &fakeAuth{}
+	auth := InstrumentAuth(fake, m)
+
+	_, err := auth.Login(context.Background(), &entities.Account{})
+	assert.NoError(t, err)
+
+	fake.loginErr = fmt.Errorf("Auth.Login: %w", services.ErrBadCredentials)
+	_, err = auth.Login(context.Background(), &entities.Account{})
+	assert.ErrorIs(t, err, services.ErrBadCredentials)
+
+	fake.loginErr = errors.New("connection refused")
+	_, _ = auth.Login(context.Background(), &entities.Account{})
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.logins.WithLabelValues(ResultSuccess)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.logins.WithLabelValues(ResultBadCredentials)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.logins.WithLabelValues(ResultError)))
+	assert.Equal(t, 0.0, testutil.ToFloat64(m.logins.WithLabelValues(ResultNotActivated)))
+}
@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const collectTimeout = 5 * time.Second
+
+type PasswordHashCounter interface {
+	CountPasswordHashes(ctx context.Context) (total int, migrated int, err error)
+}
+
+// PasswordHashCollector reports how many accounts have been moved
+// to argon2id. Values are read from the database on every scrape.
+type PasswordHashCollector struct {
+	log      *slog.Logger
+	counter  PasswordHashCounter
+	total    *prometheus.Desc
+	migrated *prometheus.Desc
+	ratio    *prometheus.Desc
+}
+
+func NewPasswordHashCollector(log *slog.Logger, counter PasswordHashCounter) *PasswordHashCollector {
+	return &PasswordHashCollector{
+		log:     log,
+		counter: counter,
+		total: prometheus.NewDesc(
+			"auth_accounts_total",
+			"Number of registered accounts.",
+			nil, nil,
+		),
+		migrated: prometheus.NewDesc(
+			"auth_accounts_password_migrated",
+			"Number of accounts whose password is hashed with argon2id.",
+			nil, nil,
+		),
+		ratio: prometheus.NewDesc(
+			"auth_accounts_password_migrated_ratio",
+			"Share of accounts whose password is hashed with argon2id.",
+			nil, nil,
+		),
+	}
+}
+
+func (c *PasswordHashCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.total
+	ch <- c.migrated
+	ch <- c.ratio
+}
+
+func (c *PasswordHashCollector) Collect(ch chan<- prometheus.Metric) {
+	const op = "metrics.PasswordHashCollector.Collect"
+
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	total, migrated, err := c.counter.CountPasswordHashes(ctx)
+	if err != nil {
+		c.log.With(slog.String("op", op)).Error(err.Error())
+		return
+	}
+
+	ratio := 1.0
+	if total > 0 {
+		ratio = float64(migrated) / float64(total)
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.total, prometheus.GaugeValue, float64(total))
+	ch <- prometheus.MustNewConstMetric(c.migrated, prometheus.GaugeValue, float64(migrated))
+	ch <- prometheus.MustNewConstMetric(c.ratio, prometheus.GaugeValue, ratio)
+}
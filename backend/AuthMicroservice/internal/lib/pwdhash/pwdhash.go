@@ -0,0 +1,147 @@
+package pwdhash
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Homyakadze14/AuthMicroservice/internal/config"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	AlgArgon2id = "argon2id"
+	AlgBcrypt   = "bcrypt"
+)
+
+var (
+	ErrMismatch      = errors.New("password does not match the hash")
+	ErrUnknownFormat = errors.New("unknown password hash format")
+)
+
+var b64 = base64.RawStdEncoding
+
+// Hasher stores passwords as argon2id in PHC string format:
+//
+//	$argon2id$v=19$m=65536,t=3,p=2$<salt>$<key>
+//
+// Legacy bcrypt hashes are still accepted by Compare.
+type Hasher struct {
+	cfg config.PasswordHashConfig
+}
+
+func New(cfg config.PasswordHashConfig) *Hasher {
+	return &Hasher{cfg: cfg}
+}
+
+func (h *Hasher) Hash(password string) (string, error) {
+	const op = "pwdhash.Hash"
+
+	salt := make([]byte, h.cfg.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.cfg.Iterations, h.cfg.Memory, h.cfg.Parallelism, h.cfg.KeyLength)
+
+	return fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		AlgArgon2id, argon2.Version, h.cfg.Memory, h.cfg.Iterations, h.cfg.Parallelism,
+		b64.EncodeToString(salt), b64.EncodeToString(key)), nil
+}
+
+func (h *Hasher) Compare(hash, password string) error {
+	const op = "pwdhash.Compare"
+
+	switch Algorithm(hash) {
+	case AlgBcrypt:
+		if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
+			return fmt.Errorf("%s: %w", op, ErrMismatch)
+		}
+		return nil
+	case AlgArgon2id:
+		p, err := parseArgon2id(hash)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		key := argon2.IDKey([]byte(password), p.salt, p.iterations, p.memory, p.parallelism, uint32(len(p.key)))
+		if subtle.ConstantTimeCompare(key, p.key) != 1 {
+			return fmt.Errorf("%s: %w", op, ErrMismatch)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%s: %w", op, ErrUnknownFormat)
+	}
+}
+
+// NeedsRehash reports whether the hash was made by another algorithm
+// or with parameters that differ from the current config.
+func (h *Hasher) NeedsRehash(hash string) bool {
+	if Algorithm(hash) != AlgArgon2id {
+		return true
+	}
+
+	p, err := parseArgon2id(hash)
+	if err != nil {
+		return true
+	}
+
+	return p.memory != h.cfg.Memory ||
+		p.iterations != h.cfg.Iterations ||
+		p.parallelism != h.cfg.Parallelism ||
+		uint32(len(p.salt)) != h.cfg.SaltLength ||
+		uint32(len(p.key)) != h.cfg.KeyLength
+}
+
+// Algorithm returns the algorithm the hash was made with
+// or an empty string if the format is unknown.
+func Algorithm(hash string) string {
+	switch {
+	case strings.HasPrefix(hash, "$"+AlgArgon2id+"$"):
+		return AlgArgon2id
+	case strings.HasPrefix(hash, "$2a$"), strings.HasPrefix(hash, "$2b$"), strings.HasPrefix(hash, "$2y$"):
+		return AlgBcrypt
+	default:
+		return ""
+	}
+}
+
+type argon2Params struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+	salt        []byte
+	key         []byte
+}
+
+func parseArgon2id(hash string) (*argon2Params, error) {
+	// "", "argon2id", "v=19", "m=...,t=...,p=...", salt, key
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return nil, ErrUnknownFormat
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return nil, ErrUnknownFormat
+	}
+
+	p := &argon2Params{}
+	_, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.memory, &p.iterations, &p.parallelism)
+	if err != nil {
+		return nil, ErrUnknownFormat
+	}
+
+	if p.salt, err = b64.DecodeString(parts[4]); err != nil {
+		return nil, ErrUnknownFormat
+	}
+	if p.key, err = b64.DecodeString(parts[5]); err != nil || len(p.key) == 0 {
+		return nil, ErrUnknownFormat
+	}
+
+	return p, nil
+}
@@ -0,0 +1,67 @@
+package pwdhash
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Homyakadze14/AuthMicroservice/internal/config"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func newHasher() *Hasher {
+	return New(config.PasswordHashConfig{
+		Memory:      1024,
+		Iterations:  1,
+		Parallelism: 1,
+		SaltLength:  16,
+		KeyLength:   32,
+	})
+}
+
+func TestHashAndCompare(t *testing.T) {
+	h := newHasher()
+
+	hash, err := h.Hash("Tr0ub4dor-Horse")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$"))
+	assert.Equal(t, AlgArgon2id, Algorithm(hash))
+
+	assert.NoError(t, h.Compare(hash, "Tr0ub4dor-Horse"))
+	assert.ErrorIs(t, h.Compare(hash, "wrong"), ErrMismatch)
+	assert.False(t, h.NeedsRehash(hash))
+}
+
+func TestCompareBcrypt(t *testing.T) {
+	h := newHasher()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	assert.NoError(t, err)
+
+	assert.Equal(t, AlgBcrypt, Algorithm(string(hash)))
+	assert.NoError(t, h.Compare(string(hash), "password"))
+	assert.ErrorIs(t, h.Compare(string(hash), "wrong"), ErrMismatch)
+	assert.True(t, h.NeedsRehash(string(hash)))
+}
+
+func TestNeedsRehashOnParamsChange(t *testing.T) {
+	hash, err := newHasher().Hash("password")
+	assert.NoError(t, err)
+
+	stronger := New(config.PasswordHashConfig{
+		Memory:      2048,
+		Iterations:  1,
+		Parallelism: 1,
+		SaltLength:  16,
+		KeyLength:   32,
+	})
+	assert.True(t, stronger.NeedsRehash(hash))
+	assert.NoError(t, stronger.Compare(hash, "password"))
+}
+
+func TestCompareUnknownFormat(t *testing.T) {
+	h := newHasher()
+
+	assert.ErrorIs(t, h.Compare("plain", "plain"), ErrUnknownFormat)
+	assert.ErrorIs(t, h.Compare("$argon2id$v=19$m=x$salt$key", "plain"), ErrUnknownFormat)
+}
@@ -0,0 +1,163 @@
+package pwdpolicy
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Homyakadze14/AuthMicroservice/internal/config"
+	"github.com/Homyakadze14/AuthMicroservice/internal/entities"
+)
+
+const (
+	RuleMinLength        = "min_length"
+	RuleMaxLength        = "max_length"
+	RuleUpper            = "uppercase"
+	RuleLower            = "lowercase"
+	RuleDigit            = "digit"
+	RuleSpecial          = "special"
+	RuleContainsUsername = "contains_username"
+	RuleContainsEmail    = "contains_email"
+	RuleCommon           = "common_password"
+
+	// Parts of the username or email shorter than this aren't checked,
+	// otherwise one-letter logins would reject almost every password.
+	minIdentityPartLen = 3
+	minCommonBaseLen   = 4
+)
+
+var ErrWeakPassword = errors.New("password does not satisfy the password policy")
+
+//go:embed common_passwords.txt
+var commonPasswords string
+
+type Violation struct {
+	Rule    string
+	Message string
+}
+
+// ViolationError lists every rule a password breaks.
+type ViolationError struct {
+	Violations []Violation
+}
+
+func (e *ViolationError) Error() string {
+	msgs := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		msgs = append(msgs, v.Message)
+	}
+	return fmt.Sprintf("%s: %s", ErrWeakPassword.Error(), strings.Join(msgs, "; "))
+}
+
+func (e *ViolationError) Is(target error) bool {
+	return target == ErrWeakPassword
+}
+
+type Policy struct {
+	cfg    config.PasswordPolicyConfig
+	common map[string]struct{}
+}
+
+func New(cfg config.PasswordPolicyConfig) *Policy {
+	common := make(map[string]struct{})
+	for _, line := range strings.Split(commonPasswords, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		common[strings.ToLower(line)] = struct{}{}
+	}
+
+	return &Policy{
+		cfg:    cfg,
+		common: common,
+	}
+}
+
+// Check validates the password of the account against the policy.
+// It returns *ViolationError when at least one rule is broken.
+func (p *Policy) Check(password string, acc *entities.Account) error {
+	var violations []Violation
+	add := func(rule, format string, args ...any) {
+		violations = append(violations, Violation{Rule: rule, Message: fmt.Sprintf(format, args...)})
+	}
+
+	length := utf8.RuneCountInString(password)
+	if length < p.cfg.MinLength {
+		add(RuleMinLength, "password must be at least %d characters long", p.cfg.MinLength)
+	}
+	if p.cfg.MaxLength > 0 && length > p.cfg.MaxLength {
+		add(RuleMaxLength, "password must be at most %d characters long", p.cfg.MaxLength)
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSpecial bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSpecial = true
+		}
+	}
+	if p.cfg.RequireUpper && !hasUpper {
+		add(RuleUpper, "password must contain an uppercase letter")
+	}
+	if p.cfg.RequireLower && !hasLower {
+		add(RuleLower, "password must contain a lowercase letter")
+	}
+	if p.cfg.RequireDigit && !hasDigit {
+		add(RuleDigit, "password must contain a digit")
+	}
+	if p.cfg.RequireSpecial && !hasSpecial {
+		add(RuleSpecial, "password must contain a special character")
+	}
+
+	lower := strings.ToLower(password)
+	if acc != nil {
+		username := strings.ToLower(acc.Username)
+		if utf8.RuneCountInString(username) >= minIdentityPartLen && strings.Contains(lower, username) {
+			add(RuleContainsUsername, "password must not contain the username")
+		}
+
+		email := strings.ToLower(acc.Email)
+		local, _, _ := strings.Cut(email, "@")
+		if utf8.RuneCountInString(local) >= minIdentityPartLen && strings.Contains(lower, local) {
+			add(RuleContainsEmail, "password must not contain the email")
+		}
+	}
+
+	if p.isCommon(lower) {
+		add(RuleCommon, "password is too common or was found in a data breach")
+	}
+
+	if len(violations) > 0 {
+		return &ViolationError{Violations: violations}
+	}
+
+	return nil
+}
+
+// isCommon checks the password and its base without trailing digits
+// and symbols, so "Qwerty2024!" is caught as well as "qwerty".
+func (p *Policy) isCommon(password string) bool {
+	if _, ok := p.common[password]; ok {
+		return true
+	}
+
+	base := strings.TrimRightFunc(password, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	if utf8.RuneCountInString(base) < minCommonBaseLen {
+		return false
+	}
+
+	_, ok := p.common[base]
+	return ok
+}
@@ -0,0 +1,61 @@
+package pwdpolicy
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Homyakadze14/AuthMicroservice/internal/config"
+	"github.com/Homyakadze14/AuthMicroservice/internal/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func newPolicy() *Policy {
+	return New(config.PasswordPolicyConfig{
+		MinLength:    8,
+		MaxLength:    72,
+		RequireUpper: true,
+		RequireLower: true,
+		RequireDigit: true,
+	})
+}
+
+func rules(t *testing.T, err error) []string {
+	var vErr *ViolationError
+	if !errors.As(err, &vErr) {
+		t.Fatalf("expected *ViolationError, got %v", err)
+	}
+
+	res := make([]string, 0, len(vErr.Violations))
+	for _, v := range vErr.Violations {
+		res = append(res, v.Rule)
+	}
+	return res
+}
+
+func TestCheckStrongPassword(t *testing.T) {
+	acc := &entities.Account{Username: "ivanov", Email: "ivanov@mail.ru"}
+
+	assert.NoError(t, newPolicy().Check("Tr0ub4dor-Horse", acc))
+}
+
+func TestCheckCharacterClasses(t *testing.T) {
+	err := newPolicy().Check("short", nil)
+
+	assert.ErrorIs(t, err, ErrWeakPassword)
+	assert.ElementsMatch(t, []string{RuleMinLength, RuleUpper, RuleDigit}, rules(t, err))
+}
+
+func TestCheckContainsIdentity(t *testing.T) {
+	acc := &entities.Account{Username: "Petrov", Email: "p.sidorov@mail.ru"}
+
+	err := newPolicy().Check("xPETROV-p.sidorov1", acc)
+
+	assert.ElementsMatch(t, []string{RuleContainsUsername, RuleContainsEmail}, rules(t, err))
+}
+
+func TestCheckCommonPassword(t *testing.T) {
+	policy := newPolicy()
+
+	assert.Equal(t, []string{RuleCommon}, rules(t, policy.Check("Password123", nil)))
+	assert.Equal(t, []string{RuleCommon}, rules(t, policy.Check("Qwerty2024!", nil)))
+}
@@ -0,0 +1,47 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/Homyakadze14/AuthMicroservice/internal/config"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+)
+
+func TestNewUnknownExporter(t *testing.T) {
+	_, err := New(context.Background(), &config.TracingConfig{Exporter: "jaeger"})
+	assert.ErrorIs(t, err, ErrUnknownExporter)
+}
+
+func TestLogHandlerAddsTraceIDs(t *testing.T) {
+	p, err := New(context.Background(), &config.TracingConfig{
+		Exporter:    ExporterStdout,
+		ServiceName: "auth",
+		SampleRatio: 1,
+	})
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = p.Shutdown(context.Background()) })
+
+	var buf bytes.Buffer
+	log := slog.New(NewLogHandler(slog.NewJSONHandler(&buf, nil))).With(slog.String("op", "test"))
+
+	ctx, span := otel.Tracer("test").Start(context.Background(), "test")
+	log.InfoContext(ctx, "with span")
+	span.End()
+
+	var rec map[string]any
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &rec))
+	assert.Equal(t, span.SpanContext().TraceID().String(), rec["trace_id"])
+	assert.Equal(t, span.SpanContext().SpanID().String(), rec["span_id"])
+	assert.Equal(t, "test", rec["op"])
+
+	buf.Reset()
+	log.Info("without span")
+	rec = nil
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &rec))
+	assert.NotContains(t, rec, "trace_id")
+}
@@ -42,7 +42,7 @@ func (r *AccountRepository) Create(ctx context.Context, acc *entities.Account) (
 
 func getUser(op string, row pgx.Row) (*entities.Account, error) {
 	acc := &entities.Account{}
-	err := row.Scan(&acc.ID, &acc.Username, &acc.Email, &acc.Password, &acc.CreatedAt, &acc.UpdatedAt)
+	err := row.Scan(&acc.ID, &acc.Username, &acc.Email, &acc.Password, &acc.Role, &acc.CreatedAt, &acc.UpdatedAt)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, services.ErrAccountNotFound
@@ -58,7 +58,7 @@ func (r *AccountRepository) GetByUserID(ctx context.Context, uid string) (*entit
 
 	row := r.Pool.QueryRow(
 		ctx,
-		"SELECT id, username, email, password, created_at, updated_at FROM account WHERE id=$1",
+		"SELECT id, username, email, password, role, created_at, updated_at FROM account WHERE id=$1",
 		uid)
 
 	return getUser(op, row)
@@ -69,7 +69,7 @@ func (r *AccountRepository) GetByUsername(ctx context.Context, username string)
 
 	row := r.Pool.QueryRow(
 		ctx,
-		"SELECT id, username, email, password, created_at, updated_at FROM account WHERE username=$1",
+		"SELECT id, username, email, password, role, created_at, updated_at FROM account WHERE username=$1",
 		username)
 
 	return getUser(op, row)
@@ -80,7 +80,7 @@ func (r *AccountRepository) GetByEmail(ctx context.Context, email string) (*enti
 
 	row := r.Pool.QueryRow(
 		ctx,
-		"SELECT id, username, email, password, created_at, updated_at FROM account WHERE email=$1",
+		"SELECT id, username, email, password, role, created_at, updated_at FROM account WHERE email=$1",
 		email)
 
 	return getUser(op, row)
@@ -101,6 +101,40 @@ func (r *AccountRepository) UpdatePwdByEmail(ctx context.Context, email string,
 	return nil
 }
 
+// CountPasswordHashes returns the number of accounts and how many of them
+// already store an argon2id password hash.
+func (r *AccountRepository) CountPasswordHashes(ctx context.Context) (total int, migrated int, err error) {
+	const op = "repositories.AccountRepository.CountPasswordHashes"
+
+	row := r.Pool.QueryRow(
+		ctx,
+		"SELECT count(*), count(*) FILTER (WHERE password LIKE '$argon2id$%') FROM account")
+
+	err = row.Scan(&total, &migrated)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return total, migrated, nil
+}
+
+func (r *AccountRepository) UpdateRole(ctx context.Context, uid int, role string) error {
+	const op = "repositories.AccountRepository.UpdateRole"
+
+	tag, err := r.Pool.Exec(
+		ctx,
+		"UPDATE account SET role=$1, updated_at=$2 WHERE id=$3",
+		role, time.Now(), uid)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return services.ErrAccountNotFound
+	}
+
+	return nil
+}
+
 func (r *AccountRepository) Delete(ctx context.Context, uid int) error {
 	const op = "repositories.AccountRepository.Delete"
 
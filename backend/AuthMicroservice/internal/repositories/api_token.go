@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Homyakadze14/AuthMicroservice/internal/entities"
+	"github.com/Homyakadze14/AuthMicroservice/internal/services"
+	"github.com/Homyakadze14/AuthMicroservice/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+type APITokenRepository struct {
+	*postgres.Postgres
+}
+
+func NewAPITokenRepository(pg *postgres.Postgres) *APITokenRepository {
+	return &APITokenRepository{pg}
+}
+
+func (r *APITokenRepository) Create(ctx context.Context, token *entities.APIToken) (id int, err error) {
+	const op = "repositories.APITokenRepository.Create"
+
+	row := r.Pool.QueryRow(
+		ctx,
+		`INSERT INTO api_token(user_id, name, prefix, token_hash, scopes, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`,
+		token.UserID, token.Name, token.Prefix, token.TokenHash, token.Scopes, token.CreatedAt, token.ExpiresAt)
+
+	err = row.Scan(&id)
+	if err != nil {
+		return -1, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func getAPIToken(op string, row pgx.Row) (*entities.APIToken, error) {
+	token := &entities.APIToken{}
+	err := row.Scan(&token.ID, &token.UserID, &token.Name, &token.Prefix, &token.TokenHash,
+		&token.Scopes, &token.CreatedAt, &token.LastUsedAt, &token.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, services.ErrAPITokenNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return token, nil
+}
+
+func (r *APITokenRepository) GetByHash(ctx context.Context, hash string) (*entities.APIToken, error) {
+	const op = "repositories.APITokenRepository.GetByHash"
+
+	row := r.Pool.QueryRow(
+		ctx,
+		`SELECT id, user_id, name, prefix, token_hash, scopes, created_at, last_used_at, expires_at
+		FROM api_token WHERE token_hash=$1`,
+		hash)
+
+	return getAPIToken(op, row)
+}
+
+func (r *APITokenRepository) GetAllByUserID(ctx context.Context, uid int) ([]*entities.APIToken, error) {
+	const op = "repositories.APITokenRepository.GetAllByUserID"
+
+	rows, err := r.Pool.Query(
+		ctx,
+		`SELECT id, user_id, name, prefix, token_hash, scopes, created_at, last_used_at, expires_at
+		FROM api_token WHERE user_id=$1 ORDER BY id`,
+		uid)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	tokens := make([]*entities.APIToken, 0)
+	for rows.Next() {
+		token, err := getAPIToken(op, rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		tokens = append(tokens, token)
+	}
+
+	return tokens, nil
+}
+
+func (r *APITokenRepository) UpdateLastUsed(ctx context.Context, id int, lastUsed time.Time) error {
+	const op = "repositories.APITokenRepository.UpdateLastUsed"
+
+	_, err := r.Pool.Exec(
+		ctx,
+		"UPDATE api_token SET last_used_at=$1 WHERE id=$2",
+		lastUsed, id)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *APITokenRepository) Delete(ctx context.Context, id int, uid int) error {
+	const op = "repositories.APITokenRepository.Delete"
+
+	tag, err := r.Pool.Exec(
+		ctx,
+		"DELETE FROM api_token WHERE id=$1 AND user_id=$2",
+		id, uid)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return services.ErrAPITokenNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Homyakadze14/AuthMicroservice/internal/entities"
+	"github.com/Homyakadze14/AuthMicroservice/internal/lib/jwt"
+)
+
+const (
+	apiTokenSecretSize = 32
+	apiTokenPrefixSize = 8
+)
+
+func hashAPIToken(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func newAPITokenSecret() (string, error) {
+	buf := make([]byte, apiTokenSecretSize)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return entities.APITokenPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// accountFromSession returns the account behind a password session.
+// API tokens are rejected so that a leaked token can't mint new ones.
+func (s *AuthService) accountFromSession(accessToken string) (*entities.Account, error) {
+	if strings.HasPrefix(accessToken, entities.APITokenPrefix) {
+		return nil, ErrAPITokenNotAllowed
+	}
+
+	return jwt.ParseAccount(accessToken, s.jwtAcc.Secret)
+}
+
+func (s *AuthService) CreateAPIToken(ctx context.Context, accessToken string, token *entities.APIToken) (string, error) {
+	const op = "Auth.CreateAPIToken"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("token", token.String()),
+	)
+
+	log.Info("trying to create api token")
+	acc, err := s.accountFromSession(accessToken)
+	if err != nil {
+		log.Error(err.Error())
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, scope := range token.Scopes {
+		if !slices.Contains(entities.Scopes, scope) {
+			log.Error(fmt.Sprintf("unknown scope %q", scope))
+			return "", fmt.Errorf("%s: %w", op, ErrBadScope)
+		}
+	}
+
+	secret, err := newAPITokenSecret()
+	if err != nil {
+		log.Error("failed to generate api token")
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	token.UserID = acc.ID
+	token.Prefix = secret[:len(entities.APITokenPrefix)+apiTokenPrefixSize]
+	token.TokenHash = hashAPIToken(secret)
+	token.CreatedAt = time.Now()
+
+	token.ID, err = s.apiTokRepo.Create(ctx, token)
+	if err != nil {
+		log.Error(err.Error())
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	log.Info("api token successfully created")
+
+	return secret, nil
+}
+
+func (s *AuthService) ListAPITokens(ctx context.Context, accessToken string) ([]*entities.APIToken, error) {
+	const op = "Auth.ListAPITokens"
+
+	log := s.log.With(
+		slog.String("op", op),
+	)
+
+	log.Info("trying to list api tokens")
+	acc, err := s.accountFromSession(accessToken)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tokens, err := s.apiTokRepo.GetAllByUserID(ctx, acc.ID)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tokens, nil
+}
+
+func (s *AuthService) RevokeAPIToken(ctx context.Context, accessToken string, id int) error {
+	const op = "Auth.RevokeAPIToken"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.Int("id", id),
+	)
+
+	log.Info("trying to revoke api token")
+	acc, err := s.accountFromSession(accessToken)
+	if err != nil {
+		log.Error(err.Error())
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.apiTokRepo.Delete(ctx, id, acc.ID)
+	if err != nil {
+		log.Error(err.Error())
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	log.Info("api token has been revoked")
+
+	return nil
+}
+
+func (s *AuthService) verifyAPIToken(ctx context.Context, secret string) (*entities.Identity, error) {
+	const op = "Auth.verifyAPIToken"
+
+	token, err := s.apiTokRepo.GetByHash(ctx, hashAPIToken(secret))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	now := time.Now()
+	if token.ExpiresAt != nil && now.After(*token.ExpiresAt) {
+		return nil, fmt.Errorf("%s: %w", op, jwt.ErrTokenExpired)
+	}
+
+	acc, err := s.accRepo.GetByUserID(ctx, strconv.Itoa(token.UserID))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.apiTokRepo.UpdateLastUsed(ctx, token.ID, now)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &entities.Identity{
+		UserID:   acc.ID,
+		Username: acc.Username,
+		Role:     acc.Role,
+		Scopes:   token.Scopes,
+	}, nil
+}
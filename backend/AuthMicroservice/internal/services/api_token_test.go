@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Homyakadze14/AuthMicroservice/internal/entities"
+	"github.com/Homyakadze14/AuthMicroservice/internal/lib/jwt"
+	"github.com/Homyakadze14/AuthMicroservice/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newAccessToken(t *testing.T, service *AuthService, acc *entities.Account) string {
+	token, err := jwt.NewToken(acc, service.jwtAcc.Secret, service.jwtAcc.Duration)
+	assert.NoError(t, err)
+	return token
+}
+
+func TestCreateAPIToken(t *testing.T) {
+	ctx := context.Background()
+
+	testAcc := &entities.Account{ID: 1, Username: "test"}
+
+	var stored *entities.APIToken
+	apiTokRepo := &mocks.APITokenRepo{}
+	apiTokRepo.On("Create", ctx, mock.AnythingOfType("*entities.APIToken")).
+		Run(func(args mock.Arguments) { stored = args.Get(1).(*entities.APIToken) }).
+		Return(7, nil).Once()
+
+	service := NewService(cfg{apiTokRepo: apiTokRepo})
+	token := &entities.APIToken{Name: "sync", Scopes: []string{entities.ScopeDocsRead}}
+	secret, err := service.CreateAPIToken(ctx, newAccessToken(t, service, testAcc), token)
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(secret, entities.APITokenPrefix))
+	assert.Equal(t, 7, token.ID)
+	assert.Equal(t, testAcc.ID, stored.UserID)
+	assert.Equal(t, hashAPIToken(secret), stored.TokenHash)
+	assert.NotContains(t, stored.TokenHash, secret)
+	assert.True(t, strings.HasPrefix(secret, stored.Prefix))
+}
+
+func TestCreateAPITokenBadScope(t *testing.T) {
+	ctx := context.Background()
+
+	service := NewService(cfg{})
+	token := &entities.APIToken{Name: "sync", Scopes: []string{"admin"}}
+	_, err := service.CreateAPIToken(ctx, newAccessToken(t, service, &entities.Account{ID: 1}), token)
+
+	assert.ErrorIs(t, err, ErrBadScope)
+}
+
+func TestCreateAPITokenWithAPIToken(t *testing.T) {
+	ctx := context.Background()
+
+	service := NewService(cfg{})
+	token := &entities.APIToken{Name: "sync", Scopes: []string{entities.ScopeDocsRead}}
+	_, err := service.CreateAPIToken(ctx, entities.APITokenPrefix+"secret", token)
+
+	assert.ErrorIs(t, err, ErrAPITokenNotAllowed)
+}
+
+func TestVerifyAPIToken(t *testing.T) {
+	ctx := context.Background()
+
+	secret := entities.APITokenPrefix + "secret"
+	dbToken := &entities.APIToken{ID: 3, UserID: 1, Scopes: []string{entities.ScopeDocsRead}}
+
+	apiTokRepo := &mocks.APITokenRepo{}
+	apiTokRepo.On("GetByHash", ctx, hashAPIToken(secret)).Return(dbToken, nil).Once()
+	apiTokRepo.On("UpdateLastUsed", ctx, dbToken.ID, mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+	accRepo := &mocks.AccountRepo{}
+	accRepo.On("GetByUserID", ctx, "1").Return(&entities.Account{ID: 1, Username: "test", Role: entities.RoleSupervisor}, nil).Once()
+
+	service := NewService(cfg{accRepo: accRepo, apiTokRepo: apiTokRepo})
+	identity, err := service.Verify(ctx, secret)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, identity.UserID)
+	assert.Equal(t, "test", identity.Username)
+	assert.Equal(t, entities.RoleSupervisor, identity.Role)
+	assert.Equal(t, dbToken.Scopes, identity.Scopes)
+	apiTokRepo.AssertExpectations(t)
+}
+
+func TestVerifyExpiredAPIToken(t *testing.T) {
+	ctx := context.Background()
+
+	secret := entities.APITokenPrefix + "secret"
+	expiresAt := time.Now().Add(-time.Hour)
+	dbToken := &entities.APIToken{ID: 3, UserID: 1, ExpiresAt: &expiresAt}
+
+	apiTokRepo := &mocks.APITokenRepo{}
+	apiTokRepo.On("GetByHash", ctx, hashAPIToken(secret)).Return(dbToken, nil).Once()
+
+	service := NewService(cfg{apiTokRepo: apiTokRepo})
+	identity, err := service.Verify(ctx, secret)
+
+	assert.ErrorIs(t, err, jwt.ErrTokenExpired)
+	assert.Nil(t, identity)
+}
+
+func TestRevokeAPITokenNotFound(t *testing.T) {
+	ctx := context.Background()
+
+	apiTokRepo := &mocks.APITokenRepo{}
+	apiTokRepo.On("Delete", ctx, 5, 1).Return(ErrAPITokenNotFound).Once()
+
+	service := NewService(cfg{apiTokRepo: apiTokRepo})
+	err := service.RevokeAPIToken(ctx, newAccessToken(t, service, &entities.Account{ID: 1}), 5)
+
+	assert.ErrorIs(t, err, ErrAPITokenNotFound)
+}
@@ -6,13 +6,13 @@ import (
 	"fmt"
 	"log/slog"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Homyakadze14/AuthMicroservice/internal/config"
 	"github.com/Homyakadze14/AuthMicroservice/internal/entities"
 	"github.com/Homyakadze14/AuthMicroservice/internal/lib/jwt"
 	"github.com/google/uuid"
-	"golang.org/x/crypto/bcrypt"
 )
 
 var (
@@ -22,6 +22,9 @@ var (
 	ErrTokenNotFound        = errors.New("token not found")
 	ErrLinkNotFound         = errors.New("link not found")
 	ErrNotActivated         = errors.New("not activated account")
+	ErrAPITokenNotFound     = errors.New("api token not found")
+	ErrBadScope             = errors.New("unknown scope")
+	ErrAPITokenNotAllowed   = errors.New("api tokens can't be used for this action")
 )
 
 type AccountRepo interface {
@@ -30,6 +33,7 @@ type AccountRepo interface {
 	GetByEmail(ctx context.Context, email string) (*entities.Account, error)
 	GetByUserID(ctx context.Context, uid string) (*entities.Account, error)
 	UpdatePwdByEmail(ctx context.Context, email string, password string) error
+	UpdateRole(ctx context.Context, uid int, role string) error
 	Delete(ctx context.Context, uid int) error
 }
 
@@ -54,6 +58,24 @@ type PwdLinkRepo interface {
 	Delete(ctx context.Context, link string) error
 }
 
+type APITokenRepo interface {
+	Create(ctx context.Context, token *entities.APIToken) (id int, err error)
+	GetByHash(ctx context.Context, hash string) (*entities.APIToken, error)
+	GetAllByUserID(ctx context.Context, uid int) ([]*entities.APIToken, error)
+	UpdateLastUsed(ctx context.Context, id int, lastUsed time.Time) error
+	Delete(ctx context.Context, id int, uid int) error
+}
+
+type PasswordPolicy interface {
+	Check(password string, acc *entities.Account) error
+}
+
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	Compare(hash, password string) error
+	NeedsRehash(hash string) bool
+}
+
 type Mailer interface {
 	SendActivationMail(email, link string) error
 	SendPwdMail(email, link string) error
@@ -68,6 +90,9 @@ type AuthService struct {
 	jwtRef      *config.JWTRefreshConfig
 	mailer      Mailer
 	pwdLinkRepo PwdLinkRepo
+	apiTokRepo  APITokenRepo
+	pwdPolicy   PasswordPolicy
+	pwdHasher   PasswordHasher
 }
 
 func NewAuthService(
@@ -79,6 +104,9 @@ func NewAuthService(
 	jwtRef *config.JWTRefreshConfig,
 	mailer Mailer,
 	pwdLinkRepo PwdLinkRepo,
+	apiTokRepo APITokenRepo,
+	pwdPolicy PasswordPolicy,
+	pwdHasher PasswordHasher,
 ) *AuthService {
 	return &AuthService{
 		log:         log,
@@ -89,6 +117,9 @@ func NewAuthService(
 		jwtRef:      jwtRef,
 		mailer:      mailer,
 		pwdLinkRepo: pwdLinkRepo,
+		apiTokRepo:  apiTokRepo,
+		pwdPolicy:   pwdPolicy,
+		pwdHasher:   pwdHasher,
 	}
 }
 
@@ -101,13 +132,20 @@ func (s *AuthService) Register(ctx context.Context, acc *entities.Account) error
 	)
 
 	log.Info("trying to register account")
+	// Check password
+	err := s.pwdPolicy.Check(acc.Password, acc)
+	if err != nil {
+		log.Error(err.Error())
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
 	// Hash password
-	passHash, err := bcrypt.GenerateFromPassword([]byte(acc.Password), bcrypt.DefaultCost)
+	passHash, err := s.pwdHasher.Hash(acc.Password)
 	if err != nil {
 		log.Error("failed to generate password hash")
 		return fmt.Errorf("%s: %w", op, err)
 	}
-	acc.Password = string(passHash)
+	acc.Password = passHash
 
 	// Create user
 	uid, err := s.accRepo.Create(ctx, acc)
@@ -178,7 +216,7 @@ func (s *AuthService) Login(ctx context.Context, acc *entities.Account) (*entiti
 	}
 
 	// Compare passwords
-	err = bcrypt.CompareHashAndPassword([]byte(dbAcc.Password), []byte(acc.Password))
+	err = s.pwdHasher.Compare(dbAcc.Password, acc.Password)
 	if err != nil {
 		log.Error("failed to compare passwords")
 		return nil, fmt.Errorf("%s: %w", op, ErrBadCredentials)
@@ -190,6 +228,11 @@ func (s *AuthService) Login(ctx context.Context, acc *entities.Account) (*entiti
 		return nil, fmt.Errorf("%s: %w", op, ErrNotActivated)
 	}
 
+	// Upgrade legacy or outdated password hash
+	if s.pwdHasher.NeedsRehash(dbAcc.Password) {
+		s.rehashPassword(ctx, log, dbAcc, acc.Password)
+	}
+
 	// Generate tokens
 	accTok, err := jwt.NewToken(dbAcc, s.jwtAcc.Secret, s.jwtAcc.Duration)
 	if err != nil {
@@ -223,6 +266,24 @@ func (s *AuthService) Login(ctx context.Context, acc *entities.Account) (*entiti
 	}, nil
 }
 
+// rehashPassword stores the password with the current hashing parameters.
+// Failures are only logged: the user has already proven the password.
+func (s *AuthService) rehashPassword(ctx context.Context, log *slog.Logger, acc *entities.Account, password string) {
+	passHash, err := s.pwdHasher.Hash(password)
+	if err != nil {
+		log.Error(err.Error())
+		return
+	}
+
+	err = s.accRepo.UpdatePwdByEmail(ctx, acc.Email, passHash)
+	if err != nil {
+		log.Error(err.Error())
+		return
+	}
+	acc.Password = passHash
+	log.Info("password hash has been upgraded")
+}
+
 func (s *AuthService) Logout(ctx context.Context, tok *entities.LogoutRequest) error {
 	const op = "Auth.Logout"
 
@@ -331,7 +392,7 @@ func (s *AuthService) checkToken(token, secret string) error {
 	return nil
 }
 
-func (s *AuthService) Verify(ctx context.Context, accToken string) (bool, error) {
+func (s *AuthService) Verify(ctx context.Context, token string) (*entities.Identity, error) {
 	const op = "Auth.Verify"
 
 	log := s.log.With(
@@ -339,14 +400,30 @@ func (s *AuthService) Verify(ctx context.Context, accToken string) (bool, error)
 	)
 
 	log.Info("trying to verify")
-	err := s.checkToken(accToken, s.jwtAcc.Secret)
+	if strings.HasPrefix(token, entities.APITokenPrefix) {
+		identity, err := s.verifyAPIToken(ctx, token)
+		if err != nil {
+			log.Error(err.Error())
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		log.Info("verification has been successfully completed")
+
+		return identity, nil
+	}
+
+	acc, err := jwt.ParseAccount(token, s.jwtAcc.Secret)
 	if err != nil {
 		log.Error(err.Error())
-		return false, fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 	log.Info("verification has been successfully completed")
 
-	return true, nil
+	return &entities.Identity{
+		UserID:   acc.ID,
+		Username: acc.Username,
+		Role:     acc.Role,
+		Scopes:   entities.Scopes,
+	}, nil
 }
 
 func (s *AuthService) createPwdLink(ctx context.Context, email string) (*entities.PwdLink, error) {
@@ -420,13 +497,26 @@ func (s *AuthService) ChangePwd(ctx context.Context, link *entities.ChPwdLink) (
 		return false, fmt.Errorf("%s: %w", op, err)
 	}
 
+	// Check password
+	acc, err := s.accRepo.GetByEmail(ctx, dbLink.Email)
+	if err != nil {
+		log.Error(err.Error())
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.pwdPolicy.Check(link.Password, acc)
+	if err != nil {
+		log.Error(err.Error())
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
 	// Hash password
-	passHash, err := bcrypt.GenerateFromPassword([]byte(link.Password), bcrypt.DefaultCost)
+	passHash, err := s.pwdHasher.Hash(link.Password)
 	if err != nil {
 		log.Error("failed to generate password hash")
 		return false, fmt.Errorf("%s: %w", op, err)
 	}
-	link.Password = string(passHash)
+	link.Password = passHash
 
 	err = s.accRepo.UpdatePwdByEmail(ctx, dbLink.Email, link.Password)
 	if err != nil {
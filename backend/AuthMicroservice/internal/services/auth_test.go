@@ -12,6 +12,8 @@ import (
 	"github.com/Homyakadze14/AuthMicroservice/internal/config"
 	"github.com/Homyakadze14/AuthMicroservice/internal/entities"
 	"github.com/Homyakadze14/AuthMicroservice/internal/lib/jwt"
+	"github.com/Homyakadze14/AuthMicroservice/internal/lib/pwdhash"
+	"github.com/Homyakadze14/AuthMicroservice/internal/lib/pwdpolicy"
 	"github.com/Homyakadze14/AuthMicroservice/internal/services/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -27,6 +29,22 @@ type cfg struct {
 	jwtRef      *config.JWTRefreshConfig
 	mailer      *mocks.Mailer
 	pwdLinkRepo *mocks.PwdLinkRepo
+	apiTokRepo  *mocks.APITokenRepo
+	pwdPolicy   *mocks.PasswordPolicy
+	pwdHasher   PasswordHasher
+}
+
+var testHasher = pwdhash.New(config.PasswordHashConfig{
+	Memory:      1024,
+	Iterations:  1,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+})
+
+func hashPassword(pwd string) string {
+	hash, _ := testHasher.Hash(pwd)
+	return hash
 }
 
 func NewService(cfg cfg) *AuthService {
@@ -73,7 +91,23 @@ func NewService(cfg cfg) *AuthService {
 		mailer = &mocks.Mailer{}
 	}
 
-	return NewAuthService(log, accRepo, tokenRepo, linkRepo, jwtAcc, jwtRef, mailer, pwdLinkRepo)
+	apiTokRepo := cfg.apiTokRepo
+	if apiTokRepo == nil {
+		apiTokRepo = &mocks.APITokenRepo{}
+	}
+
+	pwdPolicy := cfg.pwdPolicy
+	if pwdPolicy == nil {
+		pwdPolicy = &mocks.PasswordPolicy{}
+		pwdPolicy.On("Check", mock.Anything, mock.Anything).Return(nil)
+	}
+
+	pwdHasher := cfg.pwdHasher
+	if pwdHasher == nil {
+		pwdHasher = testHasher
+	}
+
+	return NewAuthService(log, accRepo, tokenRepo, linkRepo, jwtAcc, jwtRef, mailer, pwdLinkRepo, apiTokRepo, pwdPolicy, pwdHasher)
 }
 
 func TestRegister(t *testing.T) {
@@ -142,6 +176,29 @@ func TestRegisterLinkError(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestRegisterWeakPassword(t *testing.T) {
+	ctx := context.Background()
+
+	testAcc := &entities.Account{Username: "Test", Email: "test@mail.com", Password: "qwerty"}
+	policyErr := &pwdpolicy.ViolationError{Violations: []pwdpolicy.Violation{{Rule: pwdpolicy.RuleCommon}}}
+
+	accRepo := &mocks.AccountRepo{}
+
+	pwdPolicy := &mocks.PasswordPolicy{}
+	pwdPolicy.On("Check", testAcc.Password, testAcc).Return(policyErr).Once()
+
+	sCfg := cfg{
+		accRepo:   accRepo,
+		pwdPolicy: pwdPolicy,
+	}
+
+	service := NewService(sCfg)
+	err := service.Register(ctx, testAcc)
+
+	assert.ErrorIs(t, err, pwdpolicy.ErrWeakPassword)
+	accRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
 func TestLoginByUsername(t *testing.T) {
 	ctx := context.Background()
 
@@ -152,11 +209,11 @@ func TestLoginByUsername(t *testing.T) {
 		Password: pwd,
 	}
 
-	hashPwd, _ := bcrypt.GenerateFromPassword([]byte(pwd), bcrypt.DefaultCost)
+	hashPwd := hashPassword(pwd)
 	bdTestAccount := &entities.Account{
 		Username: "Test",
 		Email:    "Test",
-		Password: string(hashPwd),
+		Password: hashPwd,
 	}
 
 	accRepo := &mocks.AccountRepo{}
@@ -187,11 +244,11 @@ func TestLoginByEmail(t *testing.T) {
 		Password: pwd,
 	}
 
-	hashPwd, _ := bcrypt.GenerateFromPassword([]byte(pwd), bcrypt.DefaultCost)
+	hashPwd := hashPassword(pwd)
 	bdTestAccount := &entities.Account{
 		Username: "Test",
 		Email:    "test@mail.com",
-		Password: string(hashPwd),
+		Password: hashPwd,
 	}
 
 	accRepo := &mocks.AccountRepo{}
@@ -212,6 +269,83 @@ func TestLoginByEmail(t *testing.T) {
 	assert.NotEmpty(t, pair)
 }
 
+func TestLoginRehashesLegacyPassword(t *testing.T) {
+	ctx := context.Background()
+
+	pwd := "Test"
+	testAccount := &entities.Account{
+		Username: "Test",
+		Password: pwd,
+	}
+
+	hashPwd, _ := bcrypt.GenerateFromPassword([]byte(pwd), bcrypt.MinCost)
+	bdTestAccount := &entities.Account{
+		Username: "Test",
+		Email:    "test@mail.com",
+		Password: string(hashPwd),
+	}
+
+	var newHash string
+	accRepo := &mocks.AccountRepo{}
+	accRepo.On("GetByUsername", ctx, testAccount.Username).Return(bdTestAccount, nil).Once()
+	accRepo.On("UpdatePwdByEmail", ctx, bdTestAccount.Email, mock.AnythingOfType("string")).
+		Run(func(args mock.Arguments) { newHash = args.String(2) }).
+		Return(nil).Once()
+
+	linkRepo := &mocks.LinkRepo{}
+	linkRepo.On("IsActivated", ctx, testAccount.ID).Return(true, nil).Once()
+
+	sCfg := cfg{
+		accRepo:  accRepo,
+		linkRepo: linkRepo,
+	}
+
+	service := NewService(sCfg)
+	pair, err := service.Login(ctx, testAccount)
+
+	assert.Nil(t, err)
+	assert.NotEmpty(t, pair)
+	accRepo.AssertExpectations(t)
+	assert.Equal(t, pwdhash.AlgArgon2id, pwdhash.Algorithm(newHash))
+	assert.NoError(t, testHasher.Compare(newHash, pwd))
+}
+
+func TestLoginRehashErrorIgnored(t *testing.T) {
+	ctx := context.Background()
+
+	pwd := "Test"
+	testAccount := &entities.Account{
+		Username: "Test",
+		Password: pwd,
+	}
+
+	hashPwd, _ := bcrypt.GenerateFromPassword([]byte(pwd), bcrypt.MinCost)
+	bdTestAccount := &entities.Account{
+		Username: "Test",
+		Email:    "test@mail.com",
+		Password: string(hashPwd),
+	}
+
+	accRepo := &mocks.AccountRepo{}
+	accRepo.On("GetByUsername", ctx, testAccount.Username).Return(bdTestAccount, nil).Once()
+	accRepo.On("UpdatePwdByEmail", ctx, bdTestAccount.Email, mock.AnythingOfType("string")).
+		Return(errors.New("db is down")).Once()
+
+	linkRepo := &mocks.LinkRepo{}
+	linkRepo.On("IsActivated", ctx, testAccount.ID).Return(true, nil).Once()
+
+	sCfg := cfg{
+		accRepo:  accRepo,
+		linkRepo: linkRepo,
+	}
+
+	service := NewService(sCfg)
+	pair, err := service.Login(ctx, testAccount)
+
+	assert.Nil(t, err)
+	assert.NotEmpty(t, pair)
+}
+
 func TestTokenExpirationAndVerification(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
@@ -223,11 +357,11 @@ func TestTokenExpirationAndVerification(t *testing.T) {
 		Password: pwd,
 	}
 
-	hashPwd, _ := bcrypt.GenerateFromPassword([]byte(pwd), bcrypt.DefaultCost)
+	hashPwd := hashPassword(pwd)
 	bdTestAccount := &entities.Account{
 		Username: "Test",
 		Email:    "Test",
-		Password: string(hashPwd),
+		Password: hashPwd,
 	}
 
 	accRepo := &mocks.AccountRepo{}
@@ -251,16 +385,16 @@ func TestTokenExpirationAndVerification(t *testing.T) {
 	wg := &sync.WaitGroup{}
 	wg.Add(2)
 
-	verified, err := service.Verify(ctx, pair.AccessToken)
+	identity, err := service.Verify(ctx, pair.AccessToken)
 	assert.NoError(t, err, jwt.ErrTokenExpired)
-	assert.True(t, verified)
+	assert.NotNil(t, identity)
 
 	go func() {
 		defer wg.Done()
 		time.Sleep(service.jwtAcc.Duration)
-		verified, err := service.Verify(ctx, pair.AccessToken)
+		identity, err := service.Verify(ctx, pair.AccessToken)
 		assert.ErrorIs(t, err, jwt.ErrTokenExpired)
-		assert.False(t, verified)
+		assert.Nil(t, identity)
 	}()
 
 	go func() {
@@ -302,11 +436,11 @@ func TestLoginWrongPasswordError(t *testing.T) {
 		Password: pwd,
 	}
 
-	hashPwd, _ := bcrypt.GenerateFromPassword([]byte("Test1"), bcrypt.DefaultCost)
+	hashPwd := hashPassword("Test1")
 	bdTestAccount := &entities.Account{
 		Username: "Test",
 		Email:    "test@mail.com",
-		Password: string(hashPwd),
+		Password: hashPwd,
 	}
 
 	accRepo := &mocks.AccountRepo{}
@@ -333,11 +467,11 @@ func TestLoginActivationErr(t *testing.T) {
 		Password: pwd,
 	}
 
-	hashPwd, _ := bcrypt.GenerateFromPassword([]byte(pwd), bcrypt.DefaultCost)
+	hashPwd := hashPassword(pwd)
 	bdTestAccount := &entities.Account{
 		Username: "Test",
 		Email:    "test@mail.com",
-		Password: string(hashPwd),
+		Password: hashPwd,
 	}
 
 	accRepo := &mocks.AccountRepo{}
@@ -658,6 +792,7 @@ func TestChangePassword(t *testing.T) {
 	email := "test"
 
 	accRepo := &mocks.AccountRepo{}
+	accRepo.On("GetByEmail", ctx, email).Return(&entities.Account{Email: email}, nil).Once()
 	accRepo.On("UpdatePwdByEmail", ctx, email, mock.AnythingOfType("string")).Return(nil).Once()
 
 	pwdLinkRepo := &mocks.PwdLinkRepo{}
@@ -688,6 +823,7 @@ func TestChangePasswordGetByLinkErr(t *testing.T) {
 	tErr := errors.New("test")
 
 	accRepo := &mocks.AccountRepo{}
+	accRepo.On("GetByEmail", ctx, email).Return(&entities.Account{Email: email}, nil).Once()
 	accRepo.On("UpdatePwdByEmail", ctx, email, mock.AnythingOfType("string")).Return(nil).Once()
 
 	pwdLinkRepo := &mocks.PwdLinkRepo{}
@@ -718,6 +854,7 @@ func TestChangePasswordUpdatePwdByEmailErr(t *testing.T) {
 	tErr := errors.New("test")
 
 	accRepo := &mocks.AccountRepo{}
+	accRepo.On("GetByEmail", ctx, email).Return(&entities.Account{Email: email}, nil).Once()
 	accRepo.On("UpdatePwdByEmail", ctx, email, mock.AnythingOfType("string")).Return(tErr).Once()
 
 	pwdLinkRepo := &mocks.PwdLinkRepo{}
@@ -748,6 +885,7 @@ func TestChangePasswordDeleteErr(t *testing.T) {
 	tErr := errors.New("test")
 
 	accRepo := &mocks.AccountRepo{}
+	accRepo.On("GetByEmail", ctx, email).Return(&entities.Account{Email: email}, nil).Once()
 	accRepo.On("UpdatePwdByEmail", ctx, email, mock.AnythingOfType("string")).Return(nil).Once()
 
 	pwdLinkRepo := &mocks.PwdLinkRepo{}
@@ -778,6 +916,7 @@ func TestChangePasswordDeleteAllAccsByEmail(t *testing.T) {
 	tErr := errors.New("test")
 
 	accRepo := &mocks.AccountRepo{}
+	accRepo.On("GetByEmail", ctx, email).Return(&entities.Account{Email: email}, nil).Once()
 	accRepo.On("UpdatePwdByEmail", ctx, email, mock.AnythingOfType("string")).Return(nil).Once()
 
 	pwdLinkRepo := &mocks.PwdLinkRepo{}
@@ -799,3 +938,34 @@ func TestChangePasswordDeleteAllAccsByEmail(t *testing.T) {
 	assert.ErrorIs(t, err, tErr)
 	assert.Empty(t, success)
 }
+
+func TestChangePasswordWeakPassword(t *testing.T) {
+	ctx := context.Background()
+
+	link := "test"
+	email := "test@mail.com"
+	acc := &entities.Account{Username: "test", Email: email}
+	policyErr := &pwdpolicy.ViolationError{Violations: []pwdpolicy.Violation{{Rule: pwdpolicy.RuleContainsUsername}}}
+
+	accRepo := &mocks.AccountRepo{}
+	accRepo.On("GetByEmail", ctx, email).Return(acc, nil).Once()
+
+	pwdLinkRepo := &mocks.PwdLinkRepo{}
+	pwdLinkRepo.On("GetByLink", ctx, link).Return(&entities.PwdLink{Email: email}, nil).Once()
+
+	pwdPolicy := &mocks.PasswordPolicy{}
+	pwdPolicy.On("Check", "test12345", acc).Return(policyErr).Once()
+
+	sCfg := cfg{
+		accRepo:     accRepo,
+		pwdLinkRepo: pwdLinkRepo,
+		pwdPolicy:   pwdPolicy,
+	}
+
+	service := NewService(sCfg)
+	success, err := service.ChangePwd(ctx, &entities.ChPwdLink{Link: link, Password: "test12345"})
+
+	assert.ErrorIs(t, err, pwdpolicy.ErrWeakPassword)
+	assert.False(t, success)
+	accRepo.AssertNotCalled(t, "UpdatePwdByEmail", mock.Anything, mock.Anything, mock.Anything)
+}
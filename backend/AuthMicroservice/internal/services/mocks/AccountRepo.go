@@ -168,6 +168,24 @@ func (_m *AccountRepo) UpdatePwdByEmail(ctx context.Context, email string, passw
 	return r0
 }
 
+// UpdateRole provides a mock function with given fields: ctx, uid, role
+func (_m *AccountRepo) UpdateRole(ctx context.Context, uid int, role string) error {
+	ret := _m.Called(ctx, uid, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) error); ok {
+		r0 = rf(ctx, uid, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewAccountRepo creates a new instance of AccountRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewAccountRepo(t interface {
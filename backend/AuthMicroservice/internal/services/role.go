@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/Homyakadze14/AuthMicroservice/internal/entities"
+)
+
+var (
+	ErrUnknownRole   = errors.New("role must be student, supervisor, secretary or admin")
+	ErrRoleForbidden = errors.New("only admins may assign roles")
+	ErrOwnRole       = errors.New("admins can't change their own role")
+)
+
+// SetRole changes the role of the account. The admin's role is read from the
+// database, so a demoted admin can't keep assigning roles until the access
+// token expires.
+func (s *AuthService) SetRole(ctx context.Context, accessToken string, uid int, role string) error {
+	const op = "Auth.SetRole"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.Int("uid", uid),
+		slog.String("role", role),
+	)
+
+	log.Info("trying to set role")
+	if !slices.Contains(entities.Roles, role) {
+		return fmt.Errorf("%s: %w", op, ErrUnknownRole)
+	}
+
+	session, err := s.accountFromSession(accessToken)
+	if err != nil {
+		log.Error(err.Error())
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	admin, err := s.getAccount(ctx, &entities.Account{ID: session.ID})
+	if err != nil {
+		log.Error(err.Error())
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if admin.Role != entities.RoleAdmin {
+		log.Error("account is not an admin", slog.Int("admin", admin.ID))
+		return fmt.Errorf("%s: %w", op, ErrRoleForbidden)
+	}
+	if admin.ID == uid {
+		return fmt.Errorf("%s: %w", op, ErrOwnRole)
+	}
+
+	err = s.accRepo.UpdateRole(ctx, uid, role)
+	if err != nil {
+		log.Error(err.Error())
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	log.Info("role has been set", slog.Int("admin", admin.ID))
+
+	return nil
+}
+
+// SeedAdmins makes the accounts with the emails admins, so that a fresh
+// deployment has someone to assign the other roles. Emails without an
+// account yet are skipped and picked up on the next start.
+func (s *AuthService) SeedAdmins(ctx context.Context, emails []string) error {
+	const op = "Auth.SeedAdmins"
+
+	log := s.log.With(
+		slog.String("op", op),
+	)
+
+	for _, email := range emails {
+		acc, err := s.accRepo.GetByEmail(ctx, email)
+		if err != nil {
+			if errors.Is(err, ErrAccountNotFound) {
+				log.Warn("admin account is not registered yet", slog.String("email", email))
+				continue
+			}
+			log.Error(err.Error())
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if acc.Role == entities.RoleAdmin {
+			continue
+		}
+
+		err = s.accRepo.UpdateRole(ctx, acc.ID, entities.RoleAdmin)
+		if err != nil {
+			log.Error(err.Error())
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		log.Info("account has been made admin", slog.Int("uid", acc.ID))
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Homyakadze14/AuthMicroservice/internal/entities"
+	"github.com/Homyakadze14/AuthMicroservice/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSetRole(t *testing.T) {
+	ctx := context.Background()
+
+	admin := &entities.Account{ID: 1, Username: "admin", Role: entities.RoleAdmin}
+
+	accRepo := &mocks.AccountRepo{}
+	accRepo.On("GetByUserID", ctx, "1").Return(admin, nil).Once()
+	accRepo.On("UpdateRole", ctx, 2, entities.RoleSupervisor).Return(nil).Once()
+
+	service := NewService(cfg{accRepo: accRepo})
+	err := service.SetRole(ctx, newAccessToken(t, service, admin), 2, entities.RoleSupervisor)
+
+	assert.NoError(t, err)
+	accRepo.AssertExpectations(t)
+}
+
+func TestSetRoleNotAdmin(t *testing.T) {
+	ctx := context.Background()
+
+	// The token still says admin, the account doesn't anymore.
+	session := &entities.Account{ID: 1, Username: "admin", Role: entities.RoleAdmin}
+	demoted := &entities.Account{ID: 1, Username: "admin", Role: entities.RoleSecretary}
+
+	accRepo := &mocks.AccountRepo{}
+	accRepo.On("GetByUserID", ctx, "1").Return(demoted, nil).Once()
+
+	service := NewService(cfg{accRepo: accRepo})
+	err := service.SetRole(ctx, newAccessToken(t, service, session), 2, entities.RoleAdmin)
+
+	assert.ErrorIs(t, err, ErrRoleForbidden)
+	accRepo.AssertNotCalled(t, "UpdateRole", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSetRoleOwnAccount(t *testing.T) {
+	ctx := context.Background()
+
+	admin := &entities.Account{ID: 1, Username: "admin", Role: entities.RoleAdmin}
+
+	accRepo := &mocks.AccountRepo{}
+	accRepo.On("GetByUserID", ctx, "1").Return(admin, nil).Once()
+
+	service := NewService(cfg{accRepo: accRepo})
+	err := service.SetRole(ctx, newAccessToken(t, service, admin), 1, entities.RoleStudent)
+
+	assert.ErrorIs(t, err, ErrOwnRole)
+}
+
+func TestSetRoleUnknownRole(t *testing.T) {
+	ctx := context.Background()
+
+	service := NewService(cfg{})
+	err := service.SetRole(ctx, "token", 2, "dean")
+
+	assert.ErrorIs(t, err, ErrUnknownRole)
+}
+
+func TestSetRoleWithAPIToken(t *testing.T) {
+	ctx := context.Background()
+
+	service := NewService(cfg{})
+	err := service.SetRole(ctx, entities.APITokenPrefix+"secret", 2, entities.RoleAdmin)
+
+	assert.ErrorIs(t, err, ErrAPITokenNotAllowed)
+}
+
+func TestSeedAdmins(t *testing.T) {
+	ctx := context.Background()
+
+	accRepo := &mocks.AccountRepo{}
+	accRepo.On("GetByEmail", ctx, "new@example.com").
+		Return(&entities.Account{ID: 1, Role: entities.RoleStudent}, nil).Once()
+	accRepo.On("GetByEmail", ctx, "admin@example.com").
+		Return(&entities.Account{ID: 2, Role: entities.RoleAdmin}, nil).Once()
+	accRepo.On("GetByEmail", ctx, "later@example.com").Return(nil, ErrAccountNotFound).Once()
+	accRepo.On("UpdateRole", ctx, 1, entities.RoleAdmin).Return(nil).Once()
+
+	service := NewService(cfg{accRepo: accRepo})
+	err := service.SeedAdmins(ctx, []string{"new@example.com", "admin@example.com", "later@example.com"})
+
+	assert.NoError(t, err)
+	accRepo.AssertExpectations(t)
+	accRepo.AssertNotCalled(t, "UpdateRole", ctx, 2, entities.RoleAdmin)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/Homyakadze14/DocsMicroservice/internal/app"
 	"github.com/Homyakadze14/DocsMicroservice/internal/config"
+	"github.com/Homyakadze14/DocsMicroservice/internal/lib/tracing"
 )
 
 const (
@@ -27,6 +28,10 @@ func main() {
 		application.GRPCServer.Run()
 	}()
 
+	go func() {
+		application.MetricsServer.Run()
+	}()
+
 	// Graceful shutdown
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, syscall.SIGTERM, syscall.SIGINT)
@@ -55,5 +60,6 @@ func setupLogger(env string) *slog.Logger {
 		)
 	}
 
-	return log
+	// Records logged with a context get its trace and span ids
+	return slog.New(tracing.NewLogHandler(log.Handler()))
 }
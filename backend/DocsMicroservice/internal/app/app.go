@@ -1,26 +1,49 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"os"
+	"time"
 
 	grpcapp "github.com/Homyakadze14/DocsMicroservice/internal/app/grpc"
+	metricsapp "github.com/Homyakadze14/DocsMicroservice/internal/app/metrics"
 	"github.com/Homyakadze14/DocsMicroservice/internal/config"
+	"github.com/Homyakadze14/DocsMicroservice/internal/controller"
+	"github.com/Homyakadze14/DocsMicroservice/internal/lib/cache"
+	"github.com/Homyakadze14/DocsMicroservice/internal/lib/grpctls"
+	"github.com/Homyakadze14/DocsMicroservice/internal/lib/metrics"
+	"github.com/Homyakadze14/DocsMicroservice/internal/lib/pdf"
+	"github.com/Homyakadze14/DocsMicroservice/internal/lib/tracing"
 	"github.com/Homyakadze14/DocsMicroservice/internal/repositories"
 	"github.com/Homyakadze14/DocsMicroservice/internal/services"
 	"github.com/Homyakadze14/DocsMicroservice/pkg/postgres"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 )
 
+// tracingShutdownTimeout bounds flushing the spans left on shutdown.
+const tracingShutdownTimeout = 5 * time.Second
+
 type App struct {
-	db         *postgres.Postgres
-	GRPCServer *grpcapp.App
+	tracing       *tracing.Provider
+	db            *postgres.Postgres
+	GRPCServer    *grpcapp.App
+	MetricsServer *metricsapp.App
 }
 
 func Run(
 	log *slog.Logger,
 	cfg *config.Config,
 ) *App {
+	// Tracing
+	tp, err := tracing.New(context.Background(), &cfg.Tracing)
+	if err != nil {
+		slog.Error(fmt.Errorf("app - Run - tracing.New: %w", err).Error())
+		os.Exit(1)
+	}
+
 	// Database
 	pg, err := postgres.New(cfg.Database.URL, postgres.MaxPoolSize(cfg.Database.PoolMax))
 	if err != nil {
@@ -28,22 +51,93 @@ func Run(
 		os.Exit(1)
 	}
 
+	// Protocol font
+	font, err := pdf.LoadFont(cfg.Protocol.FontPath)
+	if err != nil {
+		slog.Error(fmt.Errorf("app - Run - pdf.LoadFont: %w", err).Error())
+		os.Exit(1)
+	}
+
 	// Repository
 	docRepo := repositories.NewDocRepository(pg)
+	scheduleRepo := repositories.NewScheduleRepository(pg)
+	assessmentRepo := repositories.NewAssessmentRepository(pg)
+	attachmentRepo := repositories.NewAttachmentRepository(pg)
+	workloadRepo := repositories.NewWorkloadRepository(pg)
+	orderRepo := repositories.NewOrderRepository(pg)
 
 	// Services
-	doc := services.NewDocService(log, docRepo)
+	doc := services.NewDocService(log, docRepo, workloadRepo, services.WorkloadLimits{
+		Supervisor: cfg.Workload.SupervisorLimit,
+		Reviewer:   cfg.Workload.ReviewerLimit,
+		Enforce:    cfg.Workload.Enforce,
+	}, services.DocRules{
+		Types:      cfg.Validation.Types,
+		MinYear:    cfg.Validation.MinYear,
+		YearsAhead: cfg.Validation.YearsAhead,
+	})
+	// Not fatal: docs without tokens are just missed by similarity search
+	_ = doc.ReindexThemes(context.Background())
+	schedule := services.NewScheduleService(log, scheduleRepo, docRepo)
+	assessment := services.NewAssessmentService(log, assessmentRepo, docRepo, scheduleRepo, font)
+	attachment := services.NewAttachmentService(log, attachmentRepo, docRepo)
+	order := services.NewOrderService(log, orderRepo, docRepo)
+
+	// Cache
+	docsCache, err := cache.New(&cfg.Cache)
+	if err != nil {
+		slog.Error(fmt.Errorf("app - Run - cache.New: %w", err).Error())
+		os.Exit(1)
+	}
+	cachedDoc := controller.CacheDocs(log, doc, docsCache, cfg.Cache.TTL)
+
+	// Metrics
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		metrics.NewPoolCollector(pg.Pool),
+	)
+	docsMetrics := metrics.NewDocsMetrics(registry)
+	grpcMetrics := metrics.NewGRPCMetrics(registry)
+	metricsServer := metricsapp.New(log, registry, cfg.Metrics.Port)
 
 	// GRPC
-	gRPCServer := grpcapp.New(log, doc, cfg.GRPC.Port)
+	creds, err := grpctls.ServerCredentials(&cfg.GRPC.TLS)
+	if err != nil {
+		slog.Error(fmt.Errorf("app - Run - grpctls.ServerCredentials: %w", err).Error())
+		os.Exit(1)
+	}
+	callers := controller.CallerPolicy{
+		AllowedCallers: cfg.CallerAuth.AllowedCallers,
+		JWTSecret:      cfg.CallerAuth.JWTSecret,
+		Required:       !cfg.CallerAuth.Disabled,
+	}
+	if !callers.Required {
+		log.Warn("caller auth is off, anyone who can reach the port may call the service")
+	}
+	gRPCServer := grpcapp.New(log, metrics.InstrumentDocs(cachedDoc, docsMetrics), schedule, assessment, controller.InvalidateOnAttachments(attachment, cachedDoc), controller.InvalidateOnOrders(order, cachedDoc), pg.Pool, grpcMetrics, creds, callers, cfg.GRPC.Port)
 
 	return &App{
-		db:         pg,
-		GRPCServer: gRPCServer,
+		tracing:       tp,
+		db:            pg,
+		GRPCServer:    gRPCServer,
+		MetricsServer: metricsServer,
 	}
 }
 
 func (s *App) Shutdown() {
+	defer s.shutdownTracing()
 	defer s.db.Close()
 	defer s.GRPCServer.Stop()
+	defer s.MetricsServer.Stop()
+}
+
+func (s *App) shutdownTracing() {
+	ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	defer cancel()
+
+	if err := s.tracing.Shutdown(ctx); err != nil {
+		slog.Error(fmt.Errorf("app - Shutdown - tracing.Shutdown: %w", err).Error())
+	}
 }
@@ -5,24 +5,53 @@ import (
 	"fmt"
 	"log/slog"
 	"net"
+	"time"
 
 	docsgrpc "github.com/Homyakadze14/DocsMicroservice/internal/controller"
+	"github.com/Homyakadze14/DocsMicroservice/internal/lib/metrics"
+	"github.com/Homyakadze14/DocsMicroservice/internal/services"
+	docsv1 "github.com/Homyakadze14/DocsMicroservice/proto/gen/docs"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/recovery"
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/selector"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/status"
 )
 
+// maxMessageSize lets attachments of the largest allowed size through.
+const maxMessageSize = services.MaxAttachmentSize + 1<<20
+
+// keepaliveMinTime is how often clients may ping, the gateway pings idle
+// connections to find the dead ones. Pinging more often gets them dropped.
+const keepaliveMinTime = 10 * time.Second
+
 type App struct {
 	log        *slog.Logger
 	gRPCServer *grpc.Server
+	health     *health.Server
+	db         Pinger
+	services   []string
+	stopHealth context.CancelFunc
 	port       int
 }
 
 func New(
 	log *slog.Logger,
 	docsService docsgrpc.Docs,
+	scheduleService docsgrpc.Schedule,
+	assessmentService docsgrpc.Assessment,
+	attachmentService docsgrpc.Attachment,
+	orderService docsgrpc.Order,
+	db Pinger,
+	grpcMetrics *metrics.GRPCMetrics,
+	creds credentials.TransportCredentials,
+	callers docsgrpc.CallerPolicy,
 	port int,
 ) *App {
 	loggingOpts := []logging.Option{
@@ -39,16 +68,43 @@ func New(
 		}),
 	}
 
-	gRPCServer := grpc.NewServer(grpc.ChainUnaryInterceptor(
-		recovery.UnaryServerInterceptor(recoveryOpts...),
-		logging.UnaryServerInterceptor(InterceptorLogger(log), loggingOpts...),
-	))
+	gRPCServer := grpc.NewServer(
+		grpc.Creds(creds),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             keepaliveMinTime,
+			PermitWithoutStream: true,
+		}),
+		// Continues the trace of the gateway, so the interceptors log its ids
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(
+			grpcMetrics.UnaryServerInterceptor(),
+			recovery.UnaryServerInterceptor(recoveryOpts...),
+			// Probes check the health often, so their calls aren't logged
+			selector.UnaryServerInterceptor(
+				logging.UnaryServerInterceptor(InterceptorLogger(log), loggingOpts...),
+				selector.MatchFunc(notHealthCheck),
+			),
+			docsgrpc.CallerInterceptor(callers),
+		),
+		grpc.MaxRecvMsgSize(maxMessageSize),
+	)
 
-	docsgrpc.Register(gRPCServer, docsService)
+	docsgrpc.Register(gRPCServer, docsService, scheduleService, assessmentService, attachmentService, orderService)
+
+	// The empty name stands for the whole server
+	services := []string{"", docsv1.Docs_ServiceDesc.ServiceName}
+	healthServer := health.NewServer()
+	for _, service := range services {
+		healthServer.SetServingStatus(service, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+	healthpb.RegisterHealthServer(gRPCServer, healthServer)
 
 	return &App{
 		log:        log,
 		gRPCServer: gRPCServer,
+		health:     healthServer,
+		db:         db,
+		services:   services,
 		port:       port,
 	}
 }
@@ -73,6 +129,10 @@ func (a *App) Run() error {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	ctx, cancel := context.WithCancel(context.Background())
+	a.stopHealth = cancel
+	go a.watchHealth(ctx)
+
 	a.log.Info("grpc server started", slog.String("addr", l.Addr().String()))
 
 	if err := a.gRPCServer.Serve(l); err != nil {
@@ -88,5 +148,10 @@ func (a *App) Stop() {
 	a.log.With(slog.String("op", op)).
 		Info("stopping gRPC server", slog.Int("port", a.port))
 
+	if a.stopHealth != nil {
+		a.stopHealth()
+	}
+	a.health.Shutdown()
+
 	a.gRPCServer.GracefulStop()
 }
@@ -0,0 +1,63 @@
+package grpcapp
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	healthInterval = 5 * time.Second
+	healthTimeout  = 2 * time.Second
+)
+
+// Pinger checks the connection to a dependency, e.g. the pgx pool.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// watchHealth reports the services as NOT_SERVING while the database
+// can't be pinged. It stops when the context is canceled.
+func (a *App) watchHealth(ctx context.Context) {
+	const op = "grpcapp.watchHealth"
+
+	log := a.log.With(slog.String("op", op))
+
+	ticker := time.NewTicker(healthInterval)
+	defer ticker.Stop()
+
+	serving := true
+	for {
+		pingCtx, cancel := context.WithTimeout(ctx, healthTimeout)
+		err := a.db.Ping(pingCtx)
+		cancel()
+
+		status := healthpb.HealthCheckResponse_SERVING
+		if err != nil {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		for _, service := range a.services {
+			a.health.SetServingStatus(service, status)
+		}
+
+		if err != nil && serving {
+			log.Error("database is unavailable", slog.String("err", err.Error()))
+		} else if err == nil && !serving {
+			log.Info("database is available again")
+		}
+		serving = err == nil
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func notHealthCheck(_ context.Context, c interceptors.CallMeta) bool {
+	return c.Service != healthpb.Health_ServiceDesc.ServiceName
+}
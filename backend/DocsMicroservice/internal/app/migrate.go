@@ -25,7 +25,10 @@ func init() {
 		log.Fatalf("migrate: environment variable not declared: PG_URL")
 	}
 
-	databaseURL += "?x-migrations-table=auth_migrations&sslmode=disable"
+	// Auth keeps its versions in auth_migrations on the same database. The
+	// first Docs migration only creates what's missing, so deployments that
+	// ran it from there pass it again
+	databaseURL += "?x-migrations-table=docs_migrations&sslmode=disable"
 
 	var (
 		attempts = _defaultAttempts
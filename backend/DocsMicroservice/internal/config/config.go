@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"flag"
 	"os"
 	"time"
@@ -9,15 +10,92 @@ import (
 )
 
 type Config struct {
-	Env            string         `yaml:"env" env-default:"local"`
-	Database       DatabaseConfig `yaml:"database"`
-	GRPC           GRPCConfig     `yaml:"GRPC"`
+	Env            string           `yaml:"env" env-default:"local"`
+	Database       DatabaseConfig   `yaml:"database"`
+	GRPC           GRPCConfig       `yaml:"GRPC"`
+	Protocol       ProtocolConfig   `yaml:"protocol"`
+	Workload       WorkloadConfig   `yaml:"workload"`
+	Validation     ValidationConfig `yaml:"validation"`
+	Tracing        TracingConfig    `yaml:"tracing"`
+	Metrics        MetricsConfig    `yaml:"metrics"`
+	CallerAuth     CallerAuthConfig `yaml:"caller_auth"`
+	Cache          CacheConfig      `yaml:"cache"`
 	MigrationsPath string
 }
 
 type GRPCConfig struct {
 	Port    int           `yaml:"port" env-required:"true"`
 	Timeout time.Duration `yaml:"timeout" env-required:"true"`
+	TLS     GRPCTLSConfig `yaml:"tls"`
+}
+
+// GRPCTLSConfig turns TLS on when the certificate is set. With the client
+// CA set too, callers must present a certificate signed by it (mTLS).
+type GRPCTLSConfig struct {
+	CertFile     string `yaml:"cert_file" env:"GRPC_TLS_CERT_FILE"`
+	KeyFile      string `yaml:"key_file" env:"GRPC_TLS_KEY_FILE"`
+	ClientCAFile string `yaml:"client_ca_file" env:"GRPC_TLS_CLIENT_CA_FILE"`
+}
+
+// CallerAuthConfig sets who may call the service: a client with an allowed
+// certificate name, which needs mTLS, or a user with an access token signed
+// by JWTSecret, the access secret of the auth service.
+type CallerAuthConfig struct {
+	AllowedCallers []string `yaml:"allowed_callers" env:"CALLER_AUTH_ALLOWED_CALLERS" env-default:"api-gateway"`
+	JWTSecret      string   `yaml:"jwt_secret" env:"CALLER_AUTH_JWT_SECRET"`
+	// Disabled lets unknown callers in, which is only meant for local setups.
+	// It is a negative so that leaving it out of the file keeps calls checked.
+	Disabled bool `yaml:"disabled" env:"CALLER_AUTH_DISABLED"`
+}
+
+// validate makes sure a caller can prove who it is: with a client
+// certificate, which needs the server certificate and the client CA, or
+// with a user token, which needs JWTSecret. Otherwise every call would be
+// rejected.
+func (c CallerAuthConfig) validate(tls GRPCTLSConfig) error {
+	if c.Disabled {
+		return nil
+	}
+
+	mtls := tls.CertFile != "" && tls.ClientCAFile != "" && len(c.AllowedCallers) > 0
+	if !mtls && c.JWTSecret == "" {
+		return errors.New("caller_auth needs GRPC.tls.client_ca_file with allowed_callers or jwt_secret, or must be disabled")
+	}
+
+	return nil
+}
+
+// CacheConfig sets up caching of the doc reads. Backend is "memory" for an
+// in-process LRU of Size entries or "none". Entries live for TTL at most,
+// changes made through the instance drop them at once. Other instances see
+// them after TTL, so keep it short when there are several.
+type CacheConfig struct {
+	Backend string        `yaml:"backend" env:"CACHE_BACKEND" env-default:"memory"`
+	Size    int           `yaml:"size" env:"CACHE_SIZE" env-default:"1000"`
+	TTL     time.Duration `yaml:"ttl" env:"CACHE_TTL" env-default:"30s"`
+}
+
+// ProtocolConfig sets up defense protocol PDFs.
+// The font must be TrueType and cover Cyrillic.
+type ProtocolConfig struct {
+	FontPath string `yaml:"font_path" env:"PROTOCOL_FONT_PATH" env-default:"/fonts/DejaVuSans.ttf"`
+}
+
+// WorkloadConfig caps the docs of a year one person may supervise or review.
+// 0 means no limit. Personal limits set through the API take precedence.
+type WorkloadConfig struct {
+	SupervisorLimit int `yaml:"supervisor_limit" env:"WORKLOAD_SUPERVISOR_LIMIT" env-default:"0"`
+	ReviewerLimit   int `yaml:"reviewer_limit" env:"WORKLOAD_REVIEWER_LIMIT" env-default:"0"`
+	// Enforce rejects assignments above the limit, otherwise they pass with a warning.
+	Enforce bool `yaml:"enforce" env:"WORKLOAD_ENFORCE" env-default:"true"`
+}
+
+// ValidationConfig sets the allowed doc types and years. Years run from
+// MinYear to YearsAhead years after the current one.
+type ValidationConfig struct {
+	Types      []string `yaml:"types" env:"DOC_TYPES" env-default:"diploma,coursework"`
+	MinYear    int      `yaml:"min_year" env:"DOC_MIN_YEAR" env-default:"2000"`
+	YearsAhead int      `yaml:"years_ahead" env:"DOC_YEARS_AHEAD" env-default:"1"`
 }
 
 type DatabaseConfig struct {
@@ -25,6 +103,21 @@ type DatabaseConfig struct {
 	PoolMax int    `yaml:"pool_max" env-required:"true"`
 }
 
+type MetricsConfig struct {
+	Port int `yaml:"port" env-default:"9102"`
+}
+
+// TracingConfig sets up OpenTelemetry tracing. Exporter is "otlp" to send
+// spans to the collector at Endpoint over gRPC, "stdout" to print them for
+// local debugging or "none" to only pass the trace context on.
+type TracingConfig struct {
+	Exporter    string  `yaml:"exporter" env:"TRACING_EXPORTER" env-default:"none"`
+	Endpoint    string  `yaml:"endpoint" env:"TRACING_ENDPOINT" env-default:"localhost:4317"`
+	Insecure    bool    `yaml:"insecure" env:"TRACING_INSECURE" env-default:"true"`
+	ServiceName string  `yaml:"service_name" env:"TRACING_SERVICE_NAME" env-default:"docs"`
+	SampleRatio float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO" env-default:"1"`
+}
+
 func MustLoad() *Config {
 	configPath := fetchConfigPath()
 	if configPath == "" {
@@ -44,6 +137,9 @@ func MustLoadPath(configPath string) *Config {
 	if err := cleanenv.ReadConfig(configPath, &cfg); err != nil {
 		panic("cannot read config: " + err.Error())
 	}
+	if err := cfg.CallerAuth.validate(cfg.GRPC.TLS); err != nil {
+		panic("invalid config: " + err.Error())
+	}
 
 	return &cfg
 }
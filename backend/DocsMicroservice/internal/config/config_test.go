@@ -0,0 +1,72 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/ilyakaznacheev/cleanenv"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidationDefaults(t *testing.T) {
+	var cfg ValidationConfig
+	err := cleanenv.ReadEnv(&cfg)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"diploma", "coursework"}, cfg.Types)
+	assert.Equal(t, 2000, cfg.MinYear)
+	assert.Equal(t, 1, cfg.YearsAhead)
+}
+
+func TestValidationTypesFromEnv(t *testing.T) {
+	t.Setenv("DOC_TYPES", "diploma,coursework,practice")
+
+	var cfg ValidationConfig
+	err := cleanenv.ReadEnv(&cfg)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"diploma", "coursework", "practice"}, cfg.Types)
+}
+
+func TestCallerAuthDefaults(t *testing.T) {
+	var cfg CallerAuthConfig
+	err := cleanenv.ReadEnv(&cfg)
+
+	assert.NoError(t, err)
+	assert.False(t, cfg.Disabled)
+	assert.Equal(t, []string{"api-gateway"}, cfg.AllowedCallers)
+}
+
+func TestCallerAuthValidate(t *testing.T) {
+	mtls := GRPCTLSConfig{CertFile: "server.crt", KeyFile: "server.key", ClientCAFile: "ca.crt"}
+
+	tests := []struct {
+		name  string
+		auth  CallerAuthConfig
+		tls   GRPCTLSConfig
+		valid bool
+	}{
+		{"disabled", CallerAuthConfig{Disabled: true}, GRPCTLSConfig{}, true},
+		{"client certificates", CallerAuthConfig{AllowedCallers: []string{"api-gateway"}}, mtls, true},
+		{"user tokens", CallerAuthConfig{JWTSecret: "secret"}, GRPCTLSConfig{}, true},
+		{"nothing to check", CallerAuthConfig{AllowedCallers: []string{"api-gateway"}}, GRPCTLSConfig{}, false},
+		{"tls without client ca", CallerAuthConfig{AllowedCallers: []string{"api-gateway"}},
+			GRPCTLSConfig{CertFile: "server.crt", KeyFile: "server.key"}, false},
+		{"no allowed callers", CallerAuthConfig{}, mtls, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.auth.validate(tt.tls)
+
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestExampleConfig(t *testing.T) {
+	assert.NotPanics(t, func() { MustLoadPath("../../config/example.yaml") })
+}
@@ -0,0 +1,48 @@
+package controller
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+	"google.golang.org/grpc/metadata"
+)
+
+// Metadata keys the gateway uses to pass the verified user.
+const (
+	mdUserID   = "x-user-id"
+	mdUsername = "x-username"
+	mdUserRole = "x-user-role"
+)
+
+// actorFromContext returns the user the request is made for
+// or nil if the metadata is missing.
+func actorFromContext(ctx context.Context) *entities.Actor {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	get := func(key string) string {
+		if v := md.Get(key); len(v) > 0 {
+			return v[0]
+		}
+		return ""
+	}
+
+	uid, err := strconv.Atoi(get(mdUserID))
+	if err != nil || uid <= 0 {
+		return nil
+	}
+
+	role := get(mdUserRole)
+	if role == "" {
+		return nil
+	}
+
+	return &entities.Actor{
+		UserID:   uid,
+		Username: get(mdUsername),
+		Role:     role,
+	}
+}
@@ -0,0 +1,189 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+	docv1 "github.com/Homyakadze14/DocsMicroservice/proto/gen/docs"
+)
+
+type Assessment interface {
+	SubmitReview(ctx context.Context, actor *entities.Actor, review *entities.Review, file []byte) (*entities.Review, error)
+	SubmitFeedback(ctx context.Context, actor *entities.Actor, feedback *entities.Feedback) (*entities.Feedback, error)
+	SubmitProtocol(ctx context.Context, actor *entities.Actor, protocol *entities.Protocol) (*entities.Protocol, error)
+	GetAssessment(ctx context.Context, docID int) (*entities.Assessment, error)
+	GetReviewFile(ctx context.Context, docID int) (*entities.File, error)
+	GetProtocolPDF(ctx context.Context, docID int) (*entities.File, error)
+}
+
+func reviewToProto(r *entities.Review) *docv1.Review {
+	return &docv1.Review{
+		DocId:      int64(r.DocID),
+		AuthorId:   int64(r.AuthorID),
+		AuthorName: r.AuthorName,
+		Grade:      int32(r.Grade),
+		Text:       r.Text,
+		FileName:   r.FileName,
+		FileType:   r.FileType,
+		FileSize:   int64(r.FileSize),
+		CreatedAt:  r.CreatedAt.Unix(),
+		UpdatedAt:  r.UpdatedAt.Unix(),
+	}
+}
+
+func feedbackToProto(f *entities.Feedback) *docv1.Feedback {
+	return &docv1.Feedback{
+		DocId:      int64(f.DocID),
+		AuthorId:   int64(f.AuthorID),
+		AuthorName: f.AuthorName,
+		Text:       f.Text,
+		CreatedAt:  f.CreatedAt.Unix(),
+		UpdatedAt:  f.UpdatedAt.Unix(),
+	}
+}
+
+func protocolToProto(p *entities.Protocol) *docv1.Protocol {
+	return &docv1.Protocol{
+		DocId:      int64(p.DocID),
+		Number:     p.Number,
+		Grade:      int32(p.Grade),
+		SessionId:  int64(p.SessionID),
+		Questions:  p.Questions,
+		Notes:      p.Notes,
+		AuthorId:   int64(p.AuthorID),
+		AuthorName: p.AuthorName,
+		CreatedAt:  p.CreatedAt.Unix(),
+		UpdatedAt:  p.UpdatedAt.Unix(),
+	}
+}
+
+func fileToProto(f *entities.File) *docv1.File {
+	return &docv1.File{
+		Name:    f.Name,
+		Type:    f.Type,
+		Content: f.Content,
+	}
+}
+
+func (s *serverAPI) SubmitReview(
+	ctx context.Context,
+	in *docv1.SubmitReviewRequest,
+) (*docv1.Review, error) {
+	actor := actorFromContext(ctx)
+	if actor == nil {
+		return nil, errNoActor
+	}
+
+	if len(in.File) > 0 && in.FileName == "" {
+		return nil, invalidArgument("file name is required")
+	}
+
+	data := &entities.Review{
+		DocID:    int(in.DocId),
+		Grade:    int(in.Grade),
+		Text:     in.Text,
+		FileName: in.FileName,
+		FileType: in.FileType,
+	}
+
+	review, err := s.assessment.SubmitReview(ctx, actor, data, in.File)
+	if err != nil {
+		return nil, statusErr(err, "failed to submit review")
+	}
+
+	return reviewToProto(review), nil
+}
+
+func (s *serverAPI) SubmitFeedback(
+	ctx context.Context,
+	in *docv1.SubmitFeedbackRequest,
+) (*docv1.Feedback, error) {
+	actor := actorFromContext(ctx)
+	if actor == nil {
+		return nil, errNoActor
+	}
+
+	data := &entities.Feedback{
+		DocID: int(in.DocId),
+		Text:  in.Text,
+	}
+
+	feedback, err := s.assessment.SubmitFeedback(ctx, actor, data)
+	if err != nil {
+		return nil, statusErr(err, "failed to submit feedback")
+	}
+
+	return feedbackToProto(feedback), nil
+}
+
+func (s *serverAPI) SubmitProtocol(
+	ctx context.Context,
+	in *docv1.SubmitProtocolRequest,
+) (*docv1.Protocol, error) {
+	actor := actorFromContext(ctx)
+	if actor == nil {
+		return nil, errNoActor
+	}
+
+	data := &entities.Protocol{
+		DocID:     int(in.DocId),
+		Number:    in.Number,
+		Grade:     int(in.Grade),
+		Questions: in.Questions,
+		Notes:     in.Notes,
+	}
+
+	protocol, err := s.assessment.SubmitProtocol(ctx, actor, data)
+	if err != nil {
+		return nil, statusErr(err, "failed to submit protocol")
+	}
+
+	return protocolToProto(protocol), nil
+}
+
+func (s *serverAPI) GetAssessment(
+	ctx context.Context,
+	in *docv1.GetAssessmentRequest,
+) (*docv1.Assessment, error) {
+	a, err := s.assessment.GetAssessment(ctx, int(in.DocId))
+	if err != nil {
+		return nil, statusErr(err, "failed to get assessment")
+	}
+
+	resp := &docv1.Assessment{}
+	if a.Review != nil {
+		resp.Review = reviewToProto(a.Review)
+	}
+	if a.Feedback != nil {
+		resp.Feedback = feedbackToProto(a.Feedback)
+	}
+	if a.Protocol != nil {
+		resp.Protocol = protocolToProto(a.Protocol)
+	}
+
+	return resp, nil
+}
+
+func (s *serverAPI) GetReviewFile(
+	ctx context.Context,
+	in *docv1.GetReviewFileRequest,
+) (*docv1.File, error) {
+	file, err := s.assessment.GetReviewFile(ctx, int(in.DocId))
+	if err != nil {
+		return nil, statusErr(err, "failed to get review file")
+	}
+
+	return fileToProto(file), nil
+}
+
+func (s *serverAPI) GetProtocolPDF(
+	ctx context.Context,
+	in *docv1.GetProtocolPDFRequest,
+) (*docv1.File, error) {
+	file, err := s.assessment.GetProtocolPDF(ctx, int(in.DocId))
+	if err != nil {
+		return nil, statusErr(err, "failed to generate protocol")
+	}
+
+	return fileToProto(file), nil
+}
@@ -0,0 +1,116 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+	docv1 "github.com/Homyakadze14/DocsMicroservice/proto/gen/docs"
+)
+
+type Attachment interface {
+	Upload(ctx context.Context, actor *entities.Actor, a *entities.Attachment, content []byte) (*entities.Attachment, error)
+	GetAttachments(ctx context.Context, docID int) ([]*entities.Attachment, error)
+	GetAttachmentFile(ctx context.Context, docID, id int) (*entities.File, error)
+	Delete(ctx context.Context, actor *entities.Actor, docID, id int) error
+}
+
+func attachmentToProto(a *entities.Attachment) *docv1.Attachment {
+	return &docv1.Attachment{
+		Id:         int64(a.ID),
+		DocId:      int64(a.DocID),
+		Name:       a.Name,
+		Type:       a.Type,
+		Size:       int64(a.Size),
+		TextStatus: a.TextStatus,
+		TextError:  a.TextError,
+		Parts:      int32(a.Parts),
+		AuthorId:   int64(a.AuthorID),
+		AuthorName: a.AuthorName,
+		CreatedAt:  a.CreatedAt.Unix(),
+	}
+}
+
+func contentMatchesToProto(matches []*entities.ContentMatch) []*docv1.ContentMatch {
+	resp := make([]*docv1.ContentMatch, 0, len(matches))
+	for _, m := range matches {
+		resp = append(resp, &docv1.ContentMatch{
+			DocId:        int64(m.DocID),
+			AttachmentId: int64(m.AttachmentID),
+			FileName:     m.FileName,
+			Unit:         m.Unit,
+			Number:       int32(m.Number),
+			Snippet:      m.Snippet,
+		})
+	}
+
+	return resp
+}
+
+func (s *serverAPI) UploadAttachment(
+	ctx context.Context,
+	in *docv1.UploadAttachmentRequest,
+) (*docv1.Attachment, error) {
+	actor := actorFromContext(ctx)
+	if actor == nil {
+		return nil, errNoActor
+	}
+
+	data := &entities.Attachment{
+		DocID: int(in.DocId),
+		Name:  in.Name,
+		Type:  in.Type,
+	}
+
+	a, err := s.attachment.Upload(ctx, actor, data, in.Content)
+	if err != nil {
+		return nil, statusErr(err, "failed to upload attachment")
+	}
+
+	return attachmentToProto(a), nil
+}
+
+func (s *serverAPI) GetAttachments(
+	ctx context.Context,
+	in *docv1.GetAttachmentsRequest,
+) (*docv1.GetAttachmentsResponse, error) {
+	attachments, err := s.attachment.GetAttachments(ctx, int(in.DocId))
+	if err != nil {
+		return nil, statusErr(err, "failed to get attachments")
+	}
+
+	resp := make([]*docv1.Attachment, 0, len(attachments))
+	for _, a := range attachments {
+		resp = append(resp, attachmentToProto(a))
+	}
+
+	return &docv1.GetAttachmentsResponse{Attachments: resp}, nil
+}
+
+func (s *serverAPI) GetAttachmentFile(
+	ctx context.Context,
+	in *docv1.AttachmentRequest,
+) (*docv1.File, error) {
+	file, err := s.attachment.GetAttachmentFile(ctx, int(in.DocId), int(in.Id))
+	if err != nil {
+		return nil, statusErr(err, "failed to get attachment file")
+	}
+
+	return fileToProto(file), nil
+}
+
+func (s *serverAPI) DeleteAttachment(
+	ctx context.Context,
+	in *docv1.AttachmentRequest,
+) (*docv1.SuccessResponse, error) {
+	actor := actorFromContext(ctx)
+	if actor == nil {
+		return nil, errNoActor
+	}
+
+	err := s.attachment.Delete(ctx, actor, int(in.DocId), int(in.Id))
+	if err != nil {
+		return nil, statusErr(err, "failed to delete attachment")
+	}
+
+	return &docv1.SuccessResponse{Success: true}, nil
+}
@@ -0,0 +1,248 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+	"github.com/Homyakadze14/DocsMicroservice/internal/lib/cache"
+)
+
+// CachedDocs caches the doc reads: by id, filtered and searched. Any change
+// of a doc drops every entry, as it may move the doc in or out of a list.
+type CachedDocs struct {
+	Docs
+	log   *slog.Logger
+	cache cache.Cache
+	ttl   time.Duration
+	// generation changes on every invalidation, so a read that started
+	// before it doesn't store what it loaded.
+	generation atomic.Uint64
+}
+
+// CacheDocs caches the reads of the docs for ttl.
+func CacheDocs(log *slog.Logger, docs Docs, c cache.Cache, ttl time.Duration) *CachedDocs {
+	return &CachedDocs{Docs: docs, log: log, cache: c, ttl: ttl}
+}
+
+// searchResult is the cached result of Search.
+type searchResult struct {
+	Docs    []*entities.Doc
+	Matches []*entities.ContentMatch
+}
+
+// filterKey is the filter as GetFiltered sees it: the fields matched with
+// LIKE are compared lower-cased, the rest as they are.
+type filterKey struct {
+	Type         string   `json:"t,omitempty"`
+	Group        string   `json:"g,omitempty"`
+	FIO          string   `json:"f,omitempty"`
+	Theme        string   `json:"th,omitempty"`
+	Director     string   `json:"d,omitempty"`
+	Year         int      `json:"y,omitempty"`
+	Order        string   `json:"o,omitempty"`
+	Reviewer     string   `json:"r,omitempty"`
+	Discipline   string   `json:"di,omitempty"`
+	Status       string   `json:"s,omitempty"`
+	Participants []string `json:"p,omitempty"`
+}
+
+func filteredKey(doc *entities.Doc) string {
+	k := filterKey{
+		Type:       strings.ToLower(doc.Type),
+		Group:      strings.ToLower(doc.Group),
+		FIO:        strings.ToLower(doc.FIO),
+		Theme:      strings.ToLower(doc.Theme),
+		Director:   strings.ToLower(doc.Director),
+		Year:       doc.Year,
+		Order:      strings.ToLower(doc.Order),
+		Reviewer:   strings.ToLower(doc.Reviewer),
+		Discipline: strings.ToLower(doc.Discipline),
+		Status:     doc.Status,
+	}
+	for _, p := range doc.Participants {
+		k.Participants = append(k.Participants, p.Role+":"+strings.ToLower(p.Name))
+	}
+
+	// Can't fail, the key has only strings and numbers
+	js, _ := json.Marshal(k)
+	return "docs:filtered:" + string(js)
+}
+
+func searchKey(line, scope string) string {
+	if scope == "" {
+		scope = entities.SearchMetadata
+	}
+	return "docs:search:" + scope + ":" + strings.ToLower(line)
+}
+
+func docKey(id int) string {
+	return "docs:id:" + strconv.Itoa(id)
+}
+
+func (d *CachedDocs) GetByID(ctx context.Context, id int) (*entities.Doc, error) {
+	return loadCached(ctx, d, docKey(id), func() (*entities.Doc, error) {
+		return d.Docs.GetByID(ctx, id)
+	})
+}
+
+func (d *CachedDocs) GetFiltered(ctx context.Context, doc *entities.Doc) ([]*entities.Doc, error) {
+	return loadCached(ctx, d, filteredKey(doc), func() ([]*entities.Doc, error) {
+		return d.Docs.GetFiltered(ctx, doc)
+	})
+}
+
+func (d *CachedDocs) Search(ctx context.Context, line string, scope string) ([]*entities.Doc, []*entities.ContentMatch, error) {
+	res, err := loadCached(ctx, d, searchKey(line, scope), func() (*searchResult, error) {
+		docs, matches, err := d.Docs.Search(ctx, line, scope)
+		if err != nil {
+			return nil, err
+		}
+		return &searchResult{Docs: docs, Matches: matches}, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res.Docs, res.Matches, nil
+}
+
+func (d *CachedDocs) Create(ctx context.Context, doc *entities.Doc, force bool) (int, []*entities.Workload, error) {
+	id, workload, err := d.Docs.Create(ctx, doc, force)
+	if err == nil {
+		d.Invalidate(ctx)
+	}
+	return id, workload, err
+}
+
+func (d *CachedDocs) Update(ctx context.Context, doc *entities.Doc, fields []string) (*entities.Doc, []*entities.Workload, error) {
+	updated, workload, err := d.Docs.Update(ctx, doc, fields)
+	if err == nil {
+		d.Invalidate(ctx)
+	}
+	return updated, workload, err
+}
+
+func (d *CachedDocs) Delete(ctx context.Context, id int) error {
+	err := d.Docs.Delete(ctx, id)
+	if err == nil {
+		d.Invalidate(ctx)
+	}
+	return err
+}
+
+func (d *CachedDocs) Transition(ctx context.Context, actor *entities.Actor, id int, to string, params *entities.TransitionParams) (*entities.Doc, *entities.Transition, error) {
+	doc, tr, err := d.Docs.Transition(ctx, actor, id, to, params)
+	if err == nil {
+		d.Invalidate(ctx)
+	}
+	return doc, tr, err
+}
+
+// Invalidate drops the cached reads.
+func (d *CachedDocs) Invalidate(ctx context.Context) {
+	const op = "controller.CachedDocs.Invalidate"
+
+	d.generation.Add(1)
+	if err := d.cache.Clear(ctx); err != nil {
+		d.log.With(slog.String("op", op)).Error("failed to clear the cache", slog.String("err", err.Error()))
+	}
+}
+
+// loadCached returns the cached value of the key or loads and caches it. The
+// cache failing is logged and the value loaded, errors are never cached.
+func loadCached[T any](ctx context.Context, d *CachedDocs, key string, fetch func() (T, error)) (T, error) {
+	const op = "controller.CachedDocs.load"
+
+	log := d.log.With(slog.String("op", op), slog.String("key", key))
+
+	var value T
+	raw, err := d.cache.Get(ctx, key)
+	if err == nil {
+		if err = json.Unmarshal(raw, &value); err == nil {
+			return value, nil
+		}
+	}
+	if !errors.Is(err, cache.ErrMiss) {
+		log.Error("failed to read the cache", slog.String("err", err.Error()))
+	}
+
+	generation := d.generation.Load()
+	value, err = fetch()
+	if err != nil {
+		return value, err
+	}
+	if d.generation.Load() != generation {
+		return value, nil
+	}
+
+	raw, err = json.Marshal(value)
+	if err == nil {
+		err = d.cache.Set(ctx, key, raw, d.ttl)
+	}
+	if err != nil {
+		log.Error("failed to write the cache", slog.String("err", err.Error()))
+	}
+
+	return value, nil
+}
+
+type invalidatingAttachments struct {
+	Attachment
+	docs *CachedDocs
+}
+
+// InvalidateOnAttachments drops the cached reads when attachments change,
+// as the content search looks into them.
+func InvalidateOnAttachments(attachment Attachment, docs *CachedDocs) Attachment {
+	return &invalidatingAttachments{Attachment: attachment, docs: docs}
+}
+
+func (a *invalidatingAttachments) Upload(ctx context.Context, actor *entities.Actor, at *entities.Attachment, content []byte) (*entities.Attachment, error) {
+	res, err := a.Attachment.Upload(ctx, actor, at, content)
+	if err == nil {
+		a.docs.Invalidate(ctx)
+	}
+	return res, err
+}
+
+func (a *invalidatingAttachments) Delete(ctx context.Context, actor *entities.Actor, docID, id int) error {
+	err := a.Attachment.Delete(ctx, actor, docID, id)
+	if err == nil {
+		a.docs.Invalidate(ctx)
+	}
+	return err
+}
+
+type invalidatingOrders struct {
+	Order
+	docs *CachedDocs
+}
+
+// InvalidateOnOrders drops the cached reads when docs are attached to or
+// detached from an order, as the docs show their theme approval order.
+func InvalidateOnOrders(order Order, docs *CachedDocs) Order {
+	return &invalidatingOrders{Order: order, docs: docs}
+}
+
+func (o *invalidatingOrders) AttachDocs(ctx context.Context, actor *entities.Actor, orderID int, docIDs []int) (int, error) {
+	n, err := o.Order.AttachDocs(ctx, actor, orderID, docIDs)
+	if err == nil {
+		o.docs.Invalidate(ctx)
+	}
+	return n, err
+}
+
+func (o *invalidatingOrders) DetachDocs(ctx context.Context, actor *entities.Actor, orderID int, docIDs []int) (int, error) {
+	n, err := o.Order.DetachDocs(ctx, actor, orderID, docIDs)
+	if err == nil {
+		o.docs.Invalidate(ctx)
+	}
+	return n, err
+}
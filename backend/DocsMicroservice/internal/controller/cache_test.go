@@ -0,0 +1,259 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+	"github.com/Homyakadze14/DocsMicroservice/internal/lib/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+var testLog = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+var errFake = errors.New("fake error")
+
+// fakeDocs counts the reads reaching the service. Every call fails with err
+// when it's set.
+type fakeDocs struct {
+	Docs
+	reads int
+	err   error
+	// onRead runs in the middle of a read
+	onRead func()
+}
+
+func (f *fakeDocs) read() error {
+	f.reads++
+	if f.onRead != nil {
+		f.onRead()
+	}
+	return f.err
+}
+
+func (f *fakeDocs) GetByID(_ context.Context, id int) (*entities.Doc, error) {
+	if err := f.read(); err != nil {
+		return nil, err
+	}
+	return &entities.Doc{ID: id, Theme: "theme", Version: f.reads}, nil
+}
+
+func (f *fakeDocs) GetFiltered(_ context.Context, doc *entities.Doc) ([]*entities.Doc, error) {
+	if err := f.read(); err != nil {
+		return nil, err
+	}
+	return []*entities.Doc{{ID: 1, FIO: doc.FIO}}, nil
+}
+
+func (f *fakeDocs) Search(_ context.Context, line string, _ string) ([]*entities.Doc, []*entities.ContentMatch, error) {
+	if err := f.read(); err != nil {
+		return nil, nil, err
+	}
+	return []*entities.Doc{{ID: 1}}, []*entities.ContentMatch{{DocID: 1, Snippet: line}}, nil
+}
+
+func (f *fakeDocs) Create(context.Context, *entities.Doc, bool) (int, []*entities.Workload, error) {
+	return 1, nil, f.err
+}
+
+func (f *fakeDocs) Update(_ context.Context, doc *entities.Doc, _ []string) (*entities.Doc, []*entities.Workload, error) {
+	return doc, nil, f.err
+}
+
+func (f *fakeDocs) Delete(context.Context, int) error {
+	return f.err
+}
+
+func (f *fakeDocs) Transition(context.Context, *entities.Actor, int, string, *entities.TransitionParams) (*entities.Doc, *entities.Transition, error) {
+	return &entities.Doc{}, &entities.Transition{}, f.err
+}
+
+type fakeAttachments struct {
+	Attachment
+	err error
+}
+
+func (f *fakeAttachments) Upload(_ context.Context, _ *entities.Actor, a *entities.Attachment, _ []byte) (*entities.Attachment, error) {
+	return a, f.err
+}
+
+func (f *fakeAttachments) Delete(context.Context, *entities.Actor, int, int) error {
+	return f.err
+}
+
+type fakeOrders struct {
+	Order
+	err error
+}
+
+func (f *fakeOrders) AttachDocs(_ context.Context, _ *entities.Actor, _ int, docIDs []int) (int, error) {
+	return len(docIDs), f.err
+}
+
+func (f *fakeOrders) DetachDocs(_ context.Context, _ *entities.Actor, _ int, docIDs []int) (int, error) {
+	return len(docIDs), f.err
+}
+
+func newTestDocs() (*CachedDocs, *fakeDocs) {
+	docs := &fakeDocs{}
+	return CacheDocs(testLog, docs, cache.NewLRU(100), time.Minute), docs
+}
+
+func TestCachedDocsReads(t *testing.T) {
+	ctx := context.Background()
+	cached, docs := newTestDocs()
+
+	for range 2 {
+		doc, err := cached.GetByID(ctx, 7)
+		assert.NoError(t, err)
+		assert.Equal(t, &entities.Doc{ID: 7, Theme: "theme", Version: 1}, doc)
+	}
+	assert.Equal(t, 1, docs.reads)
+
+	for range 2 {
+		filtered, err := cached.GetFiltered(ctx, &entities.Doc{FIO: "Иванов"})
+		assert.NoError(t, err)
+		assert.Equal(t, []*entities.Doc{{ID: 1, FIO: "Иванов"}}, filtered)
+	}
+	// The filter is matched case-insensitively, so is its key
+	_, err := cached.GetFiltered(ctx, &entities.Doc{FIO: "иванов"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, docs.reads)
+
+	for range 2 {
+		found, matches, err := cached.Search(ctx, "диплом", entities.SearchMetadata)
+		assert.NoError(t, err)
+		assert.Len(t, found, 1)
+		assert.Equal(t, "диплом", matches[0].Snippet)
+	}
+	// No scope is the metadata scope
+	_, _, err = cached.Search(ctx, "диплом", "")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, docs.reads)
+
+	// Other keys miss
+	_, err = cached.GetByID(ctx, 8)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, docs.reads)
+}
+
+func TestCachedDocsDoesNotCacheErrors(t *testing.T) {
+	ctx := context.Background()
+	cached, docs := newTestDocs()
+
+	docs.err = errFake
+	_, err := cached.GetByID(ctx, 7)
+	assert.ErrorIs(t, err, errFake)
+
+	docs.err = nil
+	_, err = cached.GetByID(ctx, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, docs.reads)
+}
+
+// wrapped are the services the cache is wired into.
+type wrapped struct {
+	docs        *CachedDocs
+	attachments Attachment
+	orders      Order
+}
+
+func TestCachedDocsInvalidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(ctx context.Context, w wrapped) error
+	}{
+		{"create", func(ctx context.Context, w wrapped) error {
+			_, _, err := w.docs.Create(ctx, &entities.Doc{}, false)
+			return err
+		}},
+		{"update", func(ctx context.Context, w wrapped) error {
+			_, _, err := w.docs.Update(ctx, &entities.Doc{ID: 7}, []string{entities.DocFieldTheme})
+			return err
+		}},
+		{"delete", func(ctx context.Context, w wrapped) error {
+			return w.docs.Delete(ctx, 7)
+		}},
+		{"transition", func(ctx context.Context, w wrapped) error {
+			_, _, err := w.docs.Transition(ctx, &entities.Actor{}, 7, entities.StatusTopicApproved, &entities.TransitionParams{})
+			return err
+		}},
+		{"attachment upload", func(ctx context.Context, w wrapped) error {
+			_, err := w.attachments.Upload(ctx, &entities.Actor{}, &entities.Attachment{DocID: 7}, []byte("text"))
+			return err
+		}},
+		{"attachment delete", func(ctx context.Context, w wrapped) error {
+			return w.attachments.Delete(ctx, &entities.Actor{}, 7, 1)
+		}},
+		{"order attach", func(ctx context.Context, w wrapped) error {
+			_, err := w.orders.AttachDocs(ctx, &entities.Actor{}, 1, []int{7})
+			return err
+		}},
+		{"order detach", func(ctx context.Context, w wrapped) error {
+			_, err := w.orders.DetachDocs(ctx, &entities.Actor{}, 1, []int{7})
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			cached, docs := newTestDocs()
+			attachments := &fakeAttachments{}
+			orders := &fakeOrders{}
+			w := wrapped{
+				docs:        cached,
+				attachments: InvalidateOnAttachments(attachments, cached),
+				orders:      InvalidateOnOrders(orders, cached),
+			}
+
+			_, err := cached.GetByID(ctx, 7)
+			assert.NoError(t, err)
+			_, _, err = cached.Search(ctx, "диплом", entities.SearchContent)
+			assert.NoError(t, err)
+
+			// A failed change leaves the cache alone
+			docs.err, attachments.err, orders.err = errFake, errFake, errFake
+			assert.ErrorIs(t, tt.change(ctx, w), errFake)
+			docs.err, attachments.err, orders.err = nil, nil, nil
+
+			_, err = cached.GetByID(ctx, 7)
+			assert.NoError(t, err)
+			assert.Equal(t, 2, docs.reads)
+
+			assert.NoError(t, tt.change(ctx, w))
+
+			doc, err := cached.GetByID(ctx, 7)
+			assert.NoError(t, err)
+			assert.Equal(t, 3, doc.Version)
+			_, _, err = cached.Search(ctx, "диплом", entities.SearchContent)
+			assert.NoError(t, err)
+			assert.Equal(t, 4, docs.reads)
+		})
+	}
+}
+
+func TestCachedDocsGenerationGuard(t *testing.T) {
+	ctx := context.Background()
+	cached, docs := newTestDocs()
+
+	// A change lands while the read is loading the old doc
+	docs.onRead = func() { cached.Invalidate(ctx) }
+	doc, err := cached.GetByID(ctx, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, doc.Version)
+
+	docs.onRead = nil
+	doc, err = cached.GetByID(ctx, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, doc.Version)
+
+	// Nothing changed during this read, so it's kept
+	doc, err = cached.GetByID(ctx, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, doc.Version)
+}
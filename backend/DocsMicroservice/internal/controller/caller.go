@@ -0,0 +1,118 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/lib/jwt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// mdAuthorization carries the access token of the user, "Bearer <token>".
+const mdAuthorization = "authorization"
+
+var (
+	errUnknownCaller = newStatus(codes.Unauthenticated, "CALLER_UNAUTHENTICATED",
+		"caller must present an allowed client certificate or a user token")
+	errCallerTokenExpired = newStatus(codes.Unauthenticated, "TOKEN_EXPIRED", "token expired")
+	errCallerBadToken     = newStatus(codes.Unauthenticated, "BAD_TOKEN", "bad token")
+)
+
+// CallerPolicy sets who may call the service.
+type CallerPolicy struct {
+	// AllowedCallers are the names, common name or DNS SAN, of the client
+	// certificates that are trusted to pass the user in metadata.
+	AllowedCallers []string
+	// JWTSecret verifies the access tokens of the users. The user is then
+	// taken from the token, not from the metadata.
+	JWTSecret string
+	// Required rejects the calls of unknown callers. Off, they pass as
+	// before, which is only meant for local setups without certificates.
+	Required bool
+}
+
+// CallerInterceptor runs every call, except health checks, only for an
+// allowed caller or a user with a valid access token.
+func CallerInterceptor(policy CallerPolicy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if strings.HasPrefix(info.FullMethod, "/"+healthpb.Health_ServiceDesc.ServiceName+"/") {
+			return handler(ctx, req)
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		md = md.Copy()
+
+		trusted := policy.trustedCaller(ctx)
+
+		if token := bearerToken(md); token != "" && policy.JWTSecret != "" {
+			actor, err := jwt.ParseActor(token, policy.JWTSecret)
+			switch {
+			case err == nil:
+				// The token is the proof, so it overrides whatever was sent
+				md.Set(mdUserID, strconv.Itoa(actor.UserID))
+				md.Set(mdUsername, actor.Username)
+				md.Set(mdUserRole, actor.Role)
+				return handler(metadata.NewIncomingContext(ctx, md), req)
+			// The gateway also forwards API tokens, which aren't JWTs
+			case trusted:
+			case errors.Is(err, jwt.ErrTokenExpired):
+				return nil, errCallerTokenExpired
+			default:
+				return nil, errCallerBadToken
+			}
+		}
+
+		if !trusted && policy.Required {
+			return nil, errUnknownCaller
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// trustedCaller reports whether the caller presented a verified client
+// certificate with an allowed name.
+func (p CallerPolicy) trustedCaller(ctx context.Context) bool {
+	pr, ok := peer.FromContext(ctx)
+	if !ok {
+		return false
+	}
+	info, ok := pr.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.PeerCertificates) == 0 {
+		return false
+	}
+
+	cert := info.State.PeerCertificates[0]
+	if slices.Contains(p.AllowedCallers, cert.Subject.CommonName) {
+		return true
+	}
+	for _, name := range cert.DNSNames {
+		if slices.Contains(p.AllowedCallers, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func bearerToken(md metadata.MD) string {
+	v := md.Get(mdAuthorization)
+	if len(v) == 0 {
+		return ""
+	}
+
+	token, ok := strings.CutPrefix(v[0], "Bearer ")
+	if !ok {
+		return ""
+	}
+
+	return strings.TrimSpace(token)
+}
@@ -0,0 +1,175 @@
+package controller
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const testSecret = "secret"
+
+func userToken(t *testing.T, secret string, exp time.Time) string {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"uid":      7,
+		"username": "ivanov",
+		"role":     "supervisor",
+		"exp":      exp.Unix(),
+	}).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return token
+}
+
+// withCaller makes the call come over mTLS with a verified certificate
+// of the common name and DNS names.
+func withCaller(ctx context.Context, commonName string, dnsNames ...string) context.Context {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: commonName}, DNSNames: dnsNames}
+
+	return peer.NewContext(ctx, &peer.Peer{AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{cert},
+		VerifiedChains:   [][]*x509.Certificate{{cert}},
+	}}})
+}
+
+func withMetadata(ctx context.Context, kv ...string) context.Context {
+	return metadata.NewIncomingContext(ctx, metadata.Pairs(kv...))
+}
+
+func TestCallerInterceptor(t *testing.T) {
+	policy := CallerPolicy{AllowedCallers: []string{"api-gateway"}, JWTSecret: testSecret, Required: true}
+	valid := "Bearer " + userToken(t, testSecret, time.Now().Add(time.Hour))
+	expired := "Bearer " + userToken(t, testSecret, time.Now().Add(-time.Hour))
+	forged := "Bearer " + userToken(t, "other", time.Now().Add(time.Hour))
+
+	tests := []struct {
+		name string
+		// policy replaces the default one when set
+		policy *CallerPolicy
+		method string
+		ctx    context.Context
+		err    error
+		// user is the user id the handler sees, "" for none
+		user string
+	}{
+		{
+			name: "allowed certificate passes the user on",
+			ctx:  withMetadata(withCaller(context.Background(), "api-gateway"), mdUserID, "3"),
+			user: "3",
+		},
+		{
+			name: "allowed by DNS name",
+			ctx:  withMetadata(withCaller(context.Background(), "gw", "api-gateway"), mdUserID, "3"),
+			user: "3",
+		},
+		{
+			name: "unknown certificate",
+			ctx:  withMetadata(withCaller(context.Background(), "intruder"), mdUserID, "3"),
+			err:  errUnknownCaller,
+		},
+		{
+			name: "nothing presented",
+			ctx:  withMetadata(context.Background(), mdUserID, "3"),
+			err:  errUnknownCaller,
+		},
+		{
+			name: "user token overrides the metadata",
+			ctx:  withMetadata(context.Background(), mdAuthorization, valid, mdUserID, "1", mdUserRole, "admin"),
+			user: "7",
+		},
+		{
+			name: "expired token",
+			ctx:  withMetadata(context.Background(), mdAuthorization, expired),
+			err:  errCallerTokenExpired,
+		},
+		{
+			name: "token of another secret",
+			ctx:  withMetadata(context.Background(), mdAuthorization, forged),
+			err:  errCallerBadToken,
+		},
+		{
+			name: "api token from an allowed caller",
+			ctx:  withMetadata(withCaller(context.Background(), "api-gateway"), mdAuthorization, "Bearer ofs_api", mdUserID, "3"),
+			user: "3",
+		},
+		{
+			name:   "tokens are not checked without a secret",
+			policy: &CallerPolicy{AllowedCallers: []string{"api-gateway"}, Required: true},
+			ctx:    withMetadata(context.Background(), mdAuthorization, valid),
+			err:    errUnknownCaller,
+		},
+		{
+			name:   "not required",
+			policy: &CallerPolicy{},
+			ctx:    withMetadata(context.Background(), mdUserID, "3"),
+			user:   "3",
+		},
+		{
+			name:   "health checks are always allowed",
+			method: "/" + healthpb.Health_ServiceDesc.ServiceName + "/Check",
+			ctx:    context.Background(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := policy
+			if tt.policy != nil {
+				p = *tt.policy
+			}
+			method := tt.method
+			if method == "" {
+				method = "/docs.Docs/GetDoc"
+			}
+
+			called := false
+			var user string
+			handler := func(ctx context.Context, req any) (any, error) {
+				called = true
+				md, _ := metadata.FromIncomingContext(ctx)
+				if v := md.Get(mdUserID); len(v) > 0 {
+					user = v[0]
+				}
+				return req, nil
+			}
+
+			_, err := CallerInterceptor(p)(tt.ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+
+			if tt.err != nil {
+				assert.Equal(t, tt.err, err)
+				assert.False(t, called)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, called)
+			assert.Equal(t, tt.user, user)
+		})
+	}
+}
+
+func TestCallerInterceptorUnverifiedCertificate(t *testing.T) {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "api-gateway"}}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{cert},
+	}}})
+
+	policy := CallerPolicy{AllowedCallers: []string{"api-gateway"}, Required: true}
+	_, err := CallerInterceptor(policy)(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/docs.Docs/GetDoc"},
+		func(context.Context, any) (any, error) { return nil, nil })
+
+	assert.Equal(t, errUnknownCaller, err)
+}
@@ -3,100 +3,163 @@ package controller
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
 	"github.com/Homyakadze14/DocsMicroservice/internal/services"
 	docv1 "github.com/Homyakadze14/DocsMicroservice/proto/gen/docs"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 type serverAPI struct {
 	docv1.UnimplementedDocsServer
-	docs Docs
+	docs       Docs
+	schedule   Schedule
+	assessment Assessment
+	attachment Attachment
+	order      Order
 }
 
 type Docs interface {
-	Create(ctx context.Context, doc *entities.Doc) (int, error)
+	Create(ctx context.Context, doc *entities.Doc, force bool) (int, []*entities.Workload, error)
+	GetByID(ctx context.Context, id int) (*entities.Doc, error)
 	GetFiltered(ctx context.Context, doc *entities.Doc) ([]*entities.Doc, error)
 	Delete(ctx context.Context, id int) error
-	Search(ctx context.Context, search_line string) ([]*entities.Doc, error)
-	Update(ctx context.Context, doc *entities.Doc) (id int, err error)
+	Search(ctx context.Context, search_line string, scope string) ([]*entities.Doc, []*entities.ContentMatch, error)
+	Update(ctx context.Context, doc *entities.Doc, fields []string) (*entities.Doc, []*entities.Workload, error)
+	Transition(ctx context.Context, actor *entities.Actor, id int, to string, params *entities.TransitionParams) (*entities.Doc, *entities.Transition, error)
+	GetTransitions(ctx context.Context, docID int) ([]*entities.Transition, error)
+	FindSimilarThemes(ctx context.Context, theme string, threshold float64, limit int) ([]*entities.SimilarTheme, error)
+	GetStats(ctx context.Context, q *entities.StatsQuery) (*entities.Stats, error)
+	GetWorkload(ctx context.Context, person string, year int) ([]*entities.Workload, error)
+	SetWorkloadLimit(ctx context.Context, actor *entities.Actor, person, role string, limit int) error
+	ResetWorkloadLimit(ctx context.Context, actor *entities.Actor, person, role string) error
+	SuggestReviewers(ctx context.Context, docID, limit int) ([]*entities.ReviewerSuggestion, error)
+	SuggestReviewerAssignments(ctx context.Context, year int) ([]*entities.ReviewerAssignment, error)
 }
 
-func Register(gRPCServer *grpc.Server, docs Docs) {
-	docv1.RegisterDocsServer(gRPCServer, &serverAPI{docs: docs})
+func Register(
+	gRPCServer *grpc.Server,
+	docs Docs,
+	schedule Schedule,
+	assessment Assessment,
+	attachment Attachment,
+	order Order,
+) {
+	docv1.RegisterDocsServer(gRPCServer, &serverAPI{
+		docs:       docs,
+		schedule:   schedule,
+		assessment: assessment,
+		attachment: attachment,
+		order:      order,
+	})
+}
+
+func docToProto(doc *entities.Doc) *docv1.Doc {
+	resp := &docv1.Doc{
+		Id:         int64(doc.ID),
+		Type:       doc.Type,
+		Group:      doc.Group,
+		Fio:        doc.FIO,
+		Theme:      doc.Theme,
+		Director:   doc.Director,
+		Year:       int32(doc.Year),
+		Order:      doc.Order,
+		Reviewer:   doc.Reviewer,
+		Discipline: doc.Discipline,
+		Version:    int64(doc.Version),
+		UpdatedAt:  doc.UpdatedAt.Unix(),
+		Status:     doc.Status,
+		Grade:      int32(doc.Grade),
+	}
+	if doc.DefenseDate != nil {
+		resp.DefenseDate = doc.DefenseDate.Format(time.DateOnly)
+	}
+	for _, p := range doc.Participants {
+		resp.Participants = append(resp.Participants, &docv1.Participant{
+			Role:     p.Role,
+			Name:     p.Name,
+			Position: int32(p.Position),
+			UserId:   int64(p.UserID),
+		})
+	}
+
+	return resp
+}
+
+func participantsFromProto(in []*docv1.Participant) []*entities.Participant {
+	participants := make([]*entities.Participant, 0, len(in))
+	for _, p := range in {
+		participants = append(participants, &entities.Participant{
+			Role:   p.GetRole(),
+			Name:   p.GetName(),
+			UserID: int(p.GetUserId()),
+		})
+	}
+
+	return participants
 }
 
 func (s *serverAPI) Create(
 	ctx context.Context,
 	in *docv1.CreateRequest,
-) (*docv1.SuccessResponse, error) {
+) (*docv1.CreateResponse, error) {
 	data := &entities.Doc{
-		Type:       in.Type,
-		Group:      in.Group,
-		FIO:        in.Fio,
-		Theme:      in.Theme,
-		Director:   in.Director,
-		Year:       int(in.Year),
-		Order:      in.Order,
-		Reviewer:   in.Reviewer,
-		Discipline: in.Discipline,
+		Type:         in.Type,
+		Group:        in.Group,
+		FIO:          in.Fio,
+		Theme:        in.Theme,
+		Director:     in.Director,
+		Year:         int(in.Year),
+		Order:        in.Order,
+		Reviewer:     in.Reviewer,
+		Discipline:   in.Discipline,
+		Participants: participantsFromProto(in.Participants),
 	}
-	_, err := s.docs.Create(ctx, data)
+	id, warnings, err := s.docs.Create(ctx, data, in.Force)
 	if err != nil {
-		if errors.Is(err, services.ErrDocAlreadyExists) {
-			return nil, status.Error(codes.AlreadyExists, "document with theme already exists")
+		var sErr *services.SimilarThemesError
+		if errors.As(err, &sErr) {
+			return &docv1.CreateResponse{
+				Success:       false,
+				SimilarThemes: similarThemesToProto(sErr.Similar),
+			}, nil
 		}
 
-		return nil, status.Error(codes.Internal, "failed to create")
+		return nil, statusErr(err, "failed to create")
 	}
 
-	return &docv1.SuccessResponse{
-		Success: true,
+	return &docv1.CreateResponse{
+		Success:          true,
+		Id:               int64(id),
+		WorkloadWarnings: workloadToProto(warnings),
 	}, nil
 }
 
+func (s *serverAPI) GetByID(
+	ctx context.Context,
+	in *docv1.GetByIDRequest,
+) (*docv1.Doc, error) {
+	doc, err := s.docs.GetByID(ctx, int(in.Id))
+	if err != nil {
+		return nil, statusErr(err, "failed to get")
+	}
+
+	return docToProto(doc), nil
+}
+
 func (s *serverAPI) GetFiltered(
 	ctx context.Context,
 	in *docv1.GetFilteredRequest,
 ) (*docv1.GetResponse, error) {
-	data := &entities.Doc{
-		Type:       in.Type,
-		Group:      in.Group,
-		FIO:        in.Fio,
-		Theme:      in.Theme,
-		Director:   in.Director,
-		Year:       int(in.Year),
-		Order:      in.Order,
-		Reviewer:   in.Reviewer,
-		Discipline: in.Discipline,
-	}
-	docs, err := s.docs.GetFiltered(ctx, data)
+	docs, err := s.docs.GetFiltered(ctx, filterFromProto(in))
 	if err != nil {
-		if errors.Is(err, services.ErrDocNotFound) {
-			return nil, status.Error(codes.AlreadyExists, "documents not found")
-		}
-
-		return nil, status.Error(codes.Internal, "failed to get")
+		return nil, statusErr(err, "failed to get")
 	}
 
 	resp := make([]*docv1.Doc, 0, len(docs))
 	for _, doc := range docs {
-		resp_doc := &docv1.Doc{
-			Id:         int64(doc.ID),
-			Type:       doc.Type,
-			Group:      doc.Group,
-			Fio:        doc.FIO,
-			Theme:      doc.Theme,
-			Director:   doc.Director,
-			Year:       int32(doc.Year),
-			Order:      doc.Order,
-			Reviewer:   doc.Reviewer,
-			Discipline: doc.Discipline,
-		}
-		resp = append(resp, resp_doc)
+		resp = append(resp, docToProto(doc))
 	}
 
 	return &docv1.GetResponse{
@@ -107,26 +170,30 @@ func (s *serverAPI) GetFiltered(
 func (s *serverAPI) Update(
 	ctx context.Context,
 	in *docv1.UpdateRequest,
-) (*docv1.SuccessResponse, error) {
+) (*docv1.UpdateResponse, error) {
 	data := &entities.Doc{
-		ID:         int(in.Id),
-		Type:       in.Type,
-		Group:      in.Group,
-		FIO:        in.Fio,
-		Theme:      in.Theme,
-		Director:   in.Director,
-		Year:       int(in.Year),
-		Order:      in.Order,
-		Reviewer:   in.Reviewer,
-		Discipline: in.Discipline,
+		ID:           int(in.Id),
+		Type:         in.Type,
+		Group:        in.Group,
+		FIO:          in.Fio,
+		Theme:        in.Theme,
+		Director:     in.Director,
+		Year:         int(in.Year),
+		Order:        in.Order,
+		Reviewer:     in.Reviewer,
+		Discipline:   in.Discipline,
+		Version:      int(in.Version),
+		Participants: participantsFromProto(in.Participants),
 	}
-	_, err := s.docs.Update(ctx, data)
+	doc, warnings, err := s.docs.Update(ctx, data, in.GetUpdateMask().GetPaths())
 	if err != nil {
-		return nil, status.Error(codes.Internal, "failed to update")
+		return nil, statusErr(err, "failed to update")
 	}
 
-	return &docv1.SuccessResponse{
-		Success: true,
+	return &docv1.UpdateResponse{
+		Success:          true,
+		Doc:              docToProto(doc),
+		WorkloadWarnings: workloadToProto(warnings),
 	}, nil
 }
 
@@ -134,34 +201,19 @@ func (s *serverAPI) Search(
 	ctx context.Context,
 	in *docv1.SearchRequest,
 ) (*docv1.GetResponse, error) {
-	docs, err := s.docs.Search(ctx, in.SearchLine)
+	docs, matches, err := s.docs.Search(ctx, in.SearchLine, in.Scope)
 	if err != nil {
-		if errors.Is(err, services.ErrDocNotFound) {
-			return nil, status.Error(codes.AlreadyExists, "documents not found")
-		}
-
-		return nil, status.Error(codes.Internal, "failed to get")
+		return nil, statusErr(err, "failed to get")
 	}
 
 	resp := make([]*docv1.Doc, 0, len(docs))
 	for _, doc := range docs {
-		resp_doc := &docv1.Doc{
-			Id:         int64(doc.ID),
-			Type:       doc.Type,
-			Group:      doc.Group,
-			Fio:        doc.FIO,
-			Theme:      doc.Theme,
-			Director:   doc.Director,
-			Year:       int32(doc.Year),
-			Order:      doc.Order,
-			Reviewer:   doc.Reviewer,
-			Discipline: doc.Discipline,
-		}
-		resp = append(resp, resp_doc)
+		resp = append(resp, docToProto(doc))
 	}
 
 	return &docv1.GetResponse{
-		Docs: resp,
+		Docs:    resp,
+		Matches: contentMatchesToProto(matches),
 	}, nil
 }
 
@@ -171,7 +223,7 @@ func (s *serverAPI) Delete(
 ) (*docv1.SuccessResponse, error) {
 	err := s.docs.Delete(ctx, int(in.Id))
 	if err != nil {
-		return nil, status.Error(codes.Internal, "failed to delete")
+		return nil, statusErr(err, "failed to delete")
 	}
 
 	return &docv1.SuccessResponse{
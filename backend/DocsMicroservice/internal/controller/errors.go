@@ -0,0 +1,204 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/services"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// errorDomain is the ErrorInfo domain of every error of the service.
+const errorDomain = "docs"
+
+// Reasons of the errors that don't come from the services.
+const (
+	reasonUnauthenticated = "UNAUTHENTICATED"
+	reasonInvalidArgument = "INVALID_ARGUMENT"
+	reasonInternal        = "INTERNAL"
+)
+
+// catalogEntry gives a service error its gRPC code and stable reason.
+// The message replaces the error text when set.
+type catalogEntry struct {
+	err     error
+	code    codes.Code
+	reason  string
+	message string
+}
+
+// errorCatalog lists the errors the service returns to clients. Reasons are
+// sent as ErrorInfo and clients match on them, so never change one.
+var errorCatalog = []catalogEntry{
+	// Docs
+	{services.ErrDocNotFound, codes.NotFound, "DOC_NOT_FOUND", ""},
+	{services.ErrDocAlreadyExists, codes.AlreadyExists, "THEME_TAKEN", ""},
+	{services.ErrVersionConflict, codes.Aborted, "VERSION_CONFLICT",
+		"document was changed by someone else, reload it and try again"},
+	{services.ErrUnknownField, codes.InvalidArgument, "UNKNOWN_FIELD", "update mask contains unknown field"},
+	{services.ErrVersionRequired, codes.FailedPrecondition, "VERSION_REQUIRED", ""},
+	{services.ErrEmptyUpdate, codes.InvalidArgument, "EMPTY_UPDATE_MASK", ""},
+	{services.ErrInvalidDoc, codes.InvalidArgument, "DOC_INVALID", ""},
+	{services.ErrBadThreshold, codes.InvalidArgument, "BAD_THRESHOLD", ""},
+	{services.ErrBadSearchScope, codes.InvalidArgument, "BAD_SEARCH_SCOPE", ""},
+	{services.ErrUnknownParticipantRole, codes.InvalidArgument, "PARTICIPANT_ROLE_UNKNOWN", ""},
+	{services.ErrBadParticipant, codes.InvalidArgument, "PARTICIPANT_NAME_REQUIRED", ""},
+	{services.ErrDuplicateParticipant, codes.InvalidArgument, "PARTICIPANT_DUPLICATE", ""},
+	{services.ErrBadParticipantAccount, codes.InvalidArgument, "PARTICIPANT_USER_INVALID", ""},
+
+	// Status workflow
+	{services.ErrBadTransition, codes.FailedPrecondition, "TRANSITION_NOT_ALLOWED", ""},
+	{services.ErrTransitionForbidden, codes.PermissionDenied, "TRANSITION_FORBIDDEN",
+		"only staff or participants of the doc linked to your account may make this transition"},
+	{services.ErrStatusConflict, codes.Aborted, "STATUS_CONFLICT",
+		"document status was changed by someone else, reload it and try again"},
+	{services.ErrMissingOrder, codes.InvalidArgument, "ORDER_NUMBER_REQUIRED", ""},
+	{services.ErrBadGrade, codes.InvalidArgument, "GRADE_INVALID", ""},
+	{services.ErrMissingDefenseDate, codes.InvalidArgument, "DEFENSE_DATE_REQUIRED", ""},
+
+	// Statistics
+	{services.ErrUnknownDimension, codes.InvalidArgument, "STATS_DIMENSION_UNKNOWN", ""},
+	{services.ErrStatsDimensions, codes.InvalidArgument, "STATS_DIMENSIONS_INVALID", ""},
+	{services.ErrBadTop, codes.InvalidArgument, "STATS_TOP_INVALID", ""},
+	{services.ErrCompareWithoutYear, codes.InvalidArgument, "STATS_COMPARE_WITHOUT_YEAR", ""},
+	{services.ErrCompareByYear, codes.InvalidArgument, "STATS_COMPARE_BY_YEAR", ""},
+
+	// Workload
+	{services.ErrWorkloadExceeded, codes.FailedPrecondition, "WORKLOAD_EXCEEDED", ""},
+	{services.ErrUnknownWorkloadRole, codes.InvalidArgument, "WORKLOAD_ROLE_UNKNOWN", ""},
+	{services.ErrBadWorkloadLimit, codes.InvalidArgument, "WORKLOAD_LIMIT_INVALID", ""},
+	{services.ErrMissingWorkloadPerson, codes.InvalidArgument, "WORKLOAD_PERSON_REQUIRED", ""},
+	{services.ErrWorkloadForbidden, codes.PermissionDenied, "WORKLOAD_FORBIDDEN", ""},
+
+	// Schedule
+	{services.ErrCommissionNotFound, codes.NotFound, "COMMISSION_NOT_FOUND", ""},
+	{services.ErrSessionNotFound, codes.NotFound, "SESSION_NOT_FOUND", ""},
+	{services.ErrSlotNotFound, codes.NotFound, "SLOT_NOT_FOUND", ""},
+	{services.ErrDuplicateMember, codes.InvalidArgument, "COMMISSION_MEMBER_DUPLICATE", ""},
+	{services.ErrBadCommission, codes.InvalidArgument, "COMMISSION_INVALID", ""},
+	{services.ErrBadSession, codes.InvalidArgument, "SESSION_INVALID", ""},
+	{services.ErrDocAlreadyScheduled, codes.AlreadyExists, "DOC_ALREADY_SCHEDULED", ""},
+	{services.ErrScheduleForbidden, codes.PermissionDenied, "SCHEDULE_FORBIDDEN",
+		"your role is not allowed to change the schedule"},
+	{services.ErrScheduleConflict, codes.FailedPrecondition, "SCHEDULE_CONFLICT", "schedule has conflicts"},
+
+	// Assessment
+	{services.ErrReviewNotFound, codes.NotFound, "REVIEW_NOT_FOUND", ""},
+	{services.ErrReviewFileNotFound, codes.NotFound, "REVIEW_FILE_NOT_FOUND", ""},
+	{services.ErrFeedbackNotFound, codes.NotFound, "FEEDBACK_NOT_FOUND", ""},
+	{services.ErrProtocolNotFound, codes.NotFound, "PROTOCOL_NOT_FOUND", ""},
+	{services.ErrProtocolNumberTaken, codes.AlreadyExists, "PROTOCOL_NUMBER_TAKEN", ""},
+	{services.ErrAssessmentForbidden, codes.PermissionDenied, "ASSESSMENT_FORBIDDEN", ""},
+	{services.ErrEmptyReview, codes.InvalidArgument, "REVIEW_EMPTY", ""},
+	{services.ErrReviewFileTooLarge, codes.ResourceExhausted, "REVIEW_FILE_TOO_LARGE", ""},
+	{services.ErrEmptyFeedback, codes.InvalidArgument, "FEEDBACK_TEXT_REQUIRED", ""},
+	{services.ErrMissingProtocolNumber, codes.InvalidArgument, "PROTOCOL_NUMBER_REQUIRED", ""},
+	{services.ErrNotScheduled, codes.FailedPrecondition, "DOC_NOT_SCHEDULED", ""},
+
+	// Attachments
+	{services.ErrAttachmentNotFound, codes.NotFound, "ATTACHMENT_NOT_FOUND", ""},
+	{services.ErrEmptyAttachment, codes.InvalidArgument, "ATTACHMENT_EMPTY", ""},
+	{services.ErrAttachmentTooLarge, codes.ResourceExhausted, "ATTACHMENT_TOO_LARGE", ""},
+	{services.ErrMissingAttachmentName, codes.InvalidArgument, "FILE_NAME_REQUIRED", ""},
+	{services.ErrLongAttachmentName, codes.InvalidArgument, "FILE_NAME_TOO_LONG", ""},
+	{services.ErrAttachmentForbidden, codes.PermissionDenied, "ATTACHMENT_FORBIDDEN", ""},
+
+	// Orders
+	{services.ErrOrderNotFound, codes.NotFound, "ORDER_NOT_FOUND", ""},
+	{services.ErrOrderFileNotFound, codes.NotFound, "ORDER_FILE_NOT_FOUND", ""},
+	{services.ErrOrderAlreadyExists, codes.AlreadyExists, "ORDER_NUMBER_TAKEN", ""},
+	{services.ErrBadOrder, codes.InvalidArgument, "ORDER_INVALID", ""},
+	{services.ErrUnknownOrderType, codes.InvalidArgument, "ORDER_TYPE_UNKNOWN", ""},
+	{services.ErrOrderFileTooLarge, codes.ResourceExhausted, "ORDER_FILE_TOO_LARGE", ""},
+	{services.ErrNoOrderDocs, codes.InvalidArgument, "ORDER_DOCS_REQUIRED", ""},
+	{services.ErrOrderYearMismatch, codes.InvalidArgument, "ORDER_YEAR_MISMATCH", ""},
+	{services.ErrOrderForbidden, codes.PermissionDenied, "ORDER_FORBIDDEN", ""},
+}
+
+// errNoActor is returned when the gateway hasn't passed the user metadata.
+var errNoActor = newStatus(codes.Unauthenticated, reasonUnauthenticated, "user metadata is required")
+
+// newStatus returns the status error with ErrorInfo of the reason followed
+// by the details.
+func newStatus(code codes.Code, reason, msg string, details ...protoadapt.MessageV1) error {
+	st := status.New(code, msg)
+
+	info := &errdetails.ErrorInfo{Reason: reason, Domain: errorDomain}
+	stWithDetails, err := st.WithDetails(append([]protoadapt.MessageV1{info}, details...)...)
+	if err != nil {
+		return st.Err()
+	}
+
+	return stWithDetails.Err()
+}
+
+// invalidArgument is for requests refused before reaching the services.
+func invalidArgument(msg string) error {
+	return newStatus(codes.InvalidArgument, reasonInvalidArgument, msg)
+}
+
+// statusErr maps the service error to its status from the catalog. Field
+// violations, exceeded workload limits and schedule conflicts are attached
+// as details. Unknown errors become Internal with the fallback message.
+func statusErr(err error, fallback string) error {
+	for _, e := range errorCatalog {
+		if !errors.Is(err, e.err) {
+			continue
+		}
+
+		msg := e.message
+		if msg == "" {
+			msg = e.err.Error()
+		}
+
+		return newStatus(e.code, e.reason, msg, errDetails(err)...)
+	}
+
+	return newStatus(codes.Internal, reasonInternal, fallback)
+}
+
+func errDetails(err error) []protoadapt.MessageV1 {
+	var vErr *services.ValidationError
+	if errors.As(err, &vErr) {
+		br := &errdetails.BadRequest{}
+		for _, v := range vErr.Violations {
+			br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       v.Field,
+				Description: v.Message,
+				Reason:      v.Rule,
+			})
+		}
+		return []protoadapt.MessageV1{br}
+	}
+
+	var wErr *services.WorkloadError
+	if errors.As(err, &wErr) {
+		pf := &errdetails.PreconditionFailure{}
+		for _, w := range wErr.Exceeded {
+			pf.Violations = append(pf.Violations, &errdetails.PreconditionFailure_Violation{
+				Type:        "workload",
+				Subject:     w.Person,
+				Description: fmt.Sprintf("%s: %d of %d in %d", w.Role, w.Count, w.Limit, w.Year),
+			})
+		}
+		return []protoadapt.MessageV1{pf}
+	}
+
+	var cErr *services.ConflictError
+	if errors.As(err, &cErr) {
+		pf := &errdetails.PreconditionFailure{}
+		for _, c := range cErr.Conflicts {
+			pf.Violations = append(pf.Violations, &errdetails.PreconditionFailure_Violation{
+				Type:        c.Kind,
+				Subject:     c.Subject,
+				Description: c.Description,
+			})
+		}
+		return []protoadapt.MessageV1{pf}
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+	"github.com/Homyakadze14/DocsMicroservice/internal/services"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func errorInfo(t *testing.T, st *status.Status) *errdetails.ErrorInfo {
+	t.Helper()
+
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			return info
+		}
+	}
+	t.Fatalf("no ErrorInfo in %v", st)
+	return nil
+}
+
+func TestErrorCatalog(t *testing.T) {
+	reasonPattern := regexp.MustCompile(`^[A-Z]+(_[A-Z]+)*$`)
+
+	reasons := make(map[string]bool, len(errorCatalog))
+	for _, e := range errorCatalog {
+		t.Run(e.reason, func(t *testing.T) {
+			assert.Regexp(t, reasonPattern, e.reason)
+			assert.False(t, reasons[e.reason], "reason is used twice")
+			reasons[e.reason] = true
+
+			// Services wrap their errors with the op
+			st := status.Convert(statusErr(fmt.Errorf("Docs.Op: %w", e.err), "fallback"))
+
+			assert.Equal(t, e.code, st.Code())
+			info := errorInfo(t, st)
+			assert.Equal(t, e.reason, info.Reason)
+			assert.Equal(t, errorDomain, info.Domain)
+			if e.message != "" {
+				assert.Equal(t, e.message, st.Message())
+			} else {
+				assert.Equal(t, e.err.Error(), st.Message())
+			}
+		})
+	}
+}
+
+func TestStatusErrUnknown(t *testing.T) {
+	st := status.Convert(statusErr(errors.New("connection refused"), "failed to get"))
+
+	assert.Equal(t, codes.Internal, st.Code())
+	assert.Equal(t, "failed to get", st.Message())
+	assert.Equal(t, reasonInternal, errorInfo(t, st).Reason)
+}
+
+func TestStatusErrDetails(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		code   codes.Code
+		detail func(t *testing.T, details []any)
+	}{
+		{
+			name: "field violations",
+			err: &services.ValidationError{Violations: []*services.FieldViolation{
+				{Field: "fio", Rule: services.RuleFIOFormat, Message: "must be a full name"},
+			}},
+			code: codes.InvalidArgument,
+			detail: func(t *testing.T, details []any) {
+				br := details[1].(*errdetails.BadRequest)
+				assert.Equal(t, "fio", br.FieldViolations[0].Field)
+				assert.Equal(t, services.RuleFIOFormat, br.FieldViolations[0].Reason)
+				assert.Equal(t, "must be a full name", br.FieldViolations[0].Description)
+			},
+		},
+		{
+			name: "exceeded workload",
+			err: &services.WorkloadError{Exceeded: []*entities.Workload{
+				{Person: "петров", Role: entities.WorkloadSupervisor, Year: 2024, Count: 9, Limit: 8},
+			}},
+			code: codes.FailedPrecondition,
+			detail: func(t *testing.T, details []any) {
+				pf := details[1].(*errdetails.PreconditionFailure)
+				assert.Equal(t, "workload", pf.Violations[0].Type)
+				assert.Equal(t, "петров", pf.Violations[0].Subject)
+				assert.Equal(t, entities.WorkloadSupervisor+": 9 of 8 in 2024", pf.Violations[0].Description)
+			},
+		},
+		{
+			name: "schedule conflicts",
+			err: &services.ConflictError{Conflicts: []*entities.Conflict{
+				{Kind: entities.ConflictRoom, Subject: "101", Description: "room 101 is taken"},
+			}},
+			code: codes.FailedPrecondition,
+			detail: func(t *testing.T, details []any) {
+				pf := details[1].(*errdetails.PreconditionFailure)
+				assert.Equal(t, entities.ConflictRoom, pf.Violations[0].Type)
+				assert.Equal(t, "101", pf.Violations[0].Subject)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := status.Convert(statusErr(fmt.Errorf("Docs.Op: %w", tt.err), "fallback"))
+
+			assert.Equal(t, tt.code, st.Code())
+			details := st.Details()
+			if assert.Len(t, details, 2) {
+				assert.IsType(t, &errdetails.ErrorInfo{}, details[0])
+				tt.detail(t, details)
+			}
+		})
+	}
+}
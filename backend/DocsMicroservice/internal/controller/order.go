@@ -0,0 +1,162 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+	docv1 "github.com/Homyakadze14/DocsMicroservice/proto/gen/docs"
+)
+
+type Order interface {
+	Create(ctx context.Context, actor *entities.Actor, o *entities.Order, content []byte) (*entities.Order, error)
+	GetOrder(ctx context.Context, id int) (*entities.Order, error)
+	GetOrders(ctx context.Context, year int, orderType string, docID int) ([]*entities.Order, error)
+	GetOrderFile(ctx context.Context, id int) (*entities.File, error)
+	AttachDocs(ctx context.Context, actor *entities.Actor, orderID int, docIDs []int) (int, error)
+	DetachDocs(ctx context.Context, actor *entities.Actor, orderID int, docIDs []int) (int, error)
+}
+
+func orderToProto(o *entities.Order) *docv1.Order {
+	resp := &docv1.Order{
+		Id:         int64(o.ID),
+		Number:     o.Number,
+		Date:       o.Date.Format(time.DateOnly),
+		Type:       o.Type,
+		Year:       int32(o.Year),
+		Title:      o.Title,
+		FileName:   o.FileName,
+		FileType:   o.FileType,
+		FileSize:   int64(o.FileSize),
+		DocsCount:  int32(o.DocsCount),
+		AuthorId:   int64(o.AuthorID),
+		AuthorName: o.AuthorName,
+		CreatedAt:  o.CreatedAt.Unix(),
+	}
+	for _, doc := range o.Docs {
+		resp.Docs = append(resp.Docs, docToProto(doc))
+	}
+
+	return resp
+}
+
+func docIDs(ids []int64) []int {
+	resp := make([]int, 0, len(ids))
+	for _, id := range ids {
+		resp = append(resp, int(id))
+	}
+
+	return resp
+}
+
+func (s *serverAPI) CreateOrder(
+	ctx context.Context,
+	in *docv1.CreateOrderRequest,
+) (*docv1.Order, error) {
+	actor := actorFromContext(ctx)
+	if actor == nil {
+		return nil, errNoActor
+	}
+
+	date, err := time.Parse(time.DateOnly, in.Date)
+	if err != nil {
+		return nil, invalidArgument("date must be in YYYY-MM-DD format")
+	}
+
+	data := &entities.Order{
+		Number:   in.Number,
+		Date:     date,
+		Type:     in.Type,
+		Year:     int(in.Year),
+		Title:    in.Title,
+		FileName: in.FileName,
+		FileType: in.FileType,
+	}
+	o, err := s.order.Create(ctx, actor, data, in.Content)
+	if err != nil {
+		return nil, statusErr(err, "failed to create order")
+	}
+
+	return orderToProto(o), nil
+}
+
+func (s *serverAPI) GetOrder(
+	ctx context.Context,
+	in *docv1.GetOrderRequest,
+) (*docv1.Order, error) {
+	o, err := s.order.GetOrder(ctx, int(in.Id))
+	if err != nil {
+		return nil, statusErr(err, "failed to get order")
+	}
+
+	return orderToProto(o), nil
+}
+
+func (s *serverAPI) GetOrders(
+	ctx context.Context,
+	in *docv1.GetOrdersRequest,
+) (*docv1.GetOrdersResponse, error) {
+	orders, err := s.order.GetOrders(ctx, int(in.Year), in.Type, int(in.DocId))
+	if err != nil {
+		return nil, statusErr(err, "failed to get orders")
+	}
+
+	resp := make([]*docv1.Order, 0, len(orders))
+	for _, o := range orders {
+		resp = append(resp, orderToProto(o))
+	}
+
+	return &docv1.GetOrdersResponse{
+		Orders: resp,
+	}, nil
+}
+
+func (s *serverAPI) GetOrderFile(
+	ctx context.Context,
+	in *docv1.GetOrderRequest,
+) (*docv1.File, error) {
+	file, err := s.order.GetOrderFile(ctx, int(in.Id))
+	if err != nil {
+		return nil, statusErr(err, "failed to get order file")
+	}
+
+	return fileToProto(file), nil
+}
+
+func (s *serverAPI) AttachDocsToOrder(
+	ctx context.Context,
+	in *docv1.OrderDocsRequest,
+) (*docv1.OrderDocsResponse, error) {
+	actor := actorFromContext(ctx)
+	if actor == nil {
+		return nil, errNoActor
+	}
+
+	changed, err := s.order.AttachDocs(ctx, actor, int(in.OrderId), docIDs(in.DocIds))
+	if err != nil {
+		return nil, statusErr(err, "failed to attach documents to order")
+	}
+
+	return &docv1.OrderDocsResponse{
+		Changed: int32(changed),
+	}, nil
+}
+
+func (s *serverAPI) DetachDocsFromOrder(
+	ctx context.Context,
+	in *docv1.OrderDocsRequest,
+) (*docv1.OrderDocsResponse, error) {
+	actor := actorFromContext(ctx)
+	if actor == nil {
+		return nil, errNoActor
+	}
+
+	changed, err := s.order.DetachDocs(ctx, actor, int(in.OrderId), docIDs(in.DocIds))
+	if err != nil {
+		return nil, statusErr(err, "failed to detach documents from order")
+	}
+
+	return &docv1.OrderDocsResponse{
+		Changed: int32(changed),
+	}, nil
+}
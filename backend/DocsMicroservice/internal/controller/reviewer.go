@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+	docv1 "github.com/Homyakadze14/DocsMicroservice/proto/gen/docs"
+)
+
+func reviewerSuggestionsToProto(suggestions []*entities.ReviewerSuggestion) []*docv1.ReviewerSuggestion {
+	resp := make([]*docv1.ReviewerSuggestion, 0, len(suggestions))
+	for _, s := range suggestions {
+		resp = append(resp, &docv1.ReviewerSuggestion{
+			Person:          s.Person,
+			Score:           s.Score,
+			DisciplineMatch: s.DisciplineMatch,
+			ThemeSimilarity: s.ThemeSimilarity,
+			Workload:        workloadToProto([]*entities.Workload{s.Workload})[0],
+		})
+	}
+
+	return resp
+}
+
+func (s *serverAPI) SuggestReviewers(
+	ctx context.Context,
+	in *docv1.SuggestReviewersRequest,
+) (*docv1.SuggestReviewersResponse, error) {
+	suggestions, err := s.docs.SuggestReviewers(ctx, int(in.DocId), int(in.Limit))
+	if err != nil {
+		return nil, statusErr(err, "failed to suggest reviewers")
+	}
+
+	return &docv1.SuggestReviewersResponse{
+		Suggestions: reviewerSuggestionsToProto(suggestions),
+	}, nil
+}
+
+func (s *serverAPI) SuggestReviewerAssignments(
+	ctx context.Context,
+	in *docv1.SuggestReviewerAssignmentsRequest,
+) (*docv1.SuggestReviewerAssignmentsResponse, error) {
+	assignments, err := s.docs.SuggestReviewerAssignments(ctx, int(in.Year))
+	if err != nil {
+		return nil, statusErr(err, "failed to suggest reviewer assignments")
+	}
+
+	resp := make([]*docv1.ReviewerAssignment, 0, len(assignments))
+	for _, a := range assignments {
+		resp = append(resp, &docv1.ReviewerAssignment{
+			DocId:        int64(a.DocID),
+			Fio:          a.FIO,
+			Theme:        a.Theme,
+			Reviewer:     a.Reviewer,
+			Alternatives: reviewerSuggestionsToProto(a.Alternatives),
+		})
+	}
+
+	return &docv1.SuggestReviewerAssignmentsResponse{
+		Assignments: resp,
+	}, nil
+}
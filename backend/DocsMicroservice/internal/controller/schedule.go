@@ -0,0 +1,240 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+	docv1 "github.com/Homyakadze14/DocsMicroservice/proto/gen/docs"
+)
+
+type Schedule interface {
+	CreateCommission(ctx context.Context, actor *entities.Actor, c *entities.Commission) (*entities.Commission, error)
+	GetCommissions(ctx context.Context, year int) ([]*entities.Commission, error)
+	CreateSession(ctx context.Context, actor *entities.Actor, session *entities.Session, slotsCount int) (*entities.Session, error)
+	GetSession(ctx context.Context, id int) (*entities.Session, error)
+	GetSessions(ctx context.Context, from, to time.Time) ([]*entities.Session, error)
+	DeleteSession(ctx context.Context, actor *entities.Actor, id int) error
+	AssignSlot(ctx context.Context, actor *entities.Actor, slotID, docID int) (*entities.Slot, error)
+}
+
+func commissionToProto(c *entities.Commission) *docv1.Commission {
+	members := make([]*docv1.CommissionMember, 0, len(c.Members))
+	for _, m := range c.Members {
+		members = append(members, &docv1.CommissionMember{
+			Id:     int64(m.ID),
+			Name:   m.Name,
+			Role:   m.Role,
+			UserId: int64(m.UserID),
+		})
+	}
+
+	return &docv1.Commission{
+		Id:        int64(c.ID),
+		Name:      c.Name,
+		Year:      int32(c.Year),
+		Members:   members,
+		CreatedAt: c.CreatedAt.Unix(),
+	}
+}
+
+func slotToProto(slot *entities.Slot) *docv1.Slot {
+	resp := &docv1.Slot{
+		Id:        int64(slot.ID),
+		SessionId: int64(slot.SessionID),
+		Position:  int32(slot.Position),
+		StartsAt:  slot.StartsAt.Unix(),
+		EndsAt:    slot.EndsAt.Unix(),
+		DocId:     int64(slot.DocID),
+	}
+	if slot.Doc != nil {
+		resp.Doc = docToProto(slot.Doc)
+	}
+
+	return resp
+}
+
+func sessionToProto(session *entities.Session) *docv1.Session {
+	resp := &docv1.Session{
+		Id:           int64(session.ID),
+		CommissionId: int64(session.CommissionID),
+		Room:         session.Room,
+		StartsAt:     session.StartsAt.Unix(),
+		EndsAt:       session.EndsAt.Unix(),
+		SlotMinutes:  int32(session.SlotMinutes),
+	}
+	if session.Commission != nil {
+		resp.Commission = commissionToProto(session.Commission)
+	}
+	for _, slot := range session.Slots {
+		resp.Slots = append(resp.Slots, slotToProto(slot))
+	}
+
+	return resp
+}
+
+func (s *serverAPI) CreateCommission(
+	ctx context.Context,
+	in *docv1.CreateCommissionRequest,
+) (*docv1.Commission, error) {
+	actor := actorFromContext(ctx)
+	if actor == nil {
+		return nil, errNoActor
+	}
+
+	if in.Name == "" {
+		return nil, invalidArgument("name is required")
+	}
+
+	data := &entities.Commission{
+		Name: in.Name,
+		Year: int(in.Year),
+	}
+	for _, m := range in.Members {
+		if m.Name == "" {
+			return nil, invalidArgument("member name is required")
+		}
+		if m.UserId < 0 {
+			return nil, invalidArgument("member user_id must not be negative")
+		}
+		data.Members = append(data.Members, &entities.CommissionMember{
+			Name:   m.Name,
+			Role:   m.Role,
+			UserID: int(m.UserId),
+		})
+	}
+
+	c, err := s.schedule.CreateCommission(ctx, actor, data)
+	if err != nil {
+		return nil, statusErr(err, "failed to create commission")
+	}
+
+	return commissionToProto(c), nil
+}
+
+func (s *serverAPI) GetCommissions(
+	ctx context.Context,
+	in *docv1.GetCommissionsRequest,
+) (*docv1.GetCommissionsResponse, error) {
+	commissions, err := s.schedule.GetCommissions(ctx, int(in.Year))
+	if err != nil {
+		return nil, statusErr(err, "failed to get commissions")
+	}
+
+	resp := make([]*docv1.Commission, 0, len(commissions))
+	for _, c := range commissions {
+		resp = append(resp, commissionToProto(c))
+	}
+
+	return &docv1.GetCommissionsResponse{
+		Commissions: resp,
+	}, nil
+}
+
+func (s *serverAPI) CreateSession(
+	ctx context.Context,
+	in *docv1.CreateSessionRequest,
+) (*docv1.Session, error) {
+	actor := actorFromContext(ctx)
+	if actor == nil {
+		return nil, errNoActor
+	}
+
+	if in.StartsAt <= 0 {
+		return nil, invalidArgument("start time is required")
+	}
+
+	data := &entities.Session{
+		CommissionID: int(in.CommissionId),
+		Room:         in.Room,
+		StartsAt:     time.Unix(in.StartsAt, 0),
+		SlotMinutes:  int(in.SlotMinutes),
+	}
+
+	session, err := s.schedule.CreateSession(ctx, actor, data, int(in.SlotsCount))
+	if err != nil {
+		return nil, statusErr(err, "failed to create session")
+	}
+
+	return sessionToProto(session), nil
+}
+
+func (s *serverAPI) GetSession(
+	ctx context.Context,
+	in *docv1.GetSessionRequest,
+) (*docv1.Session, error) {
+	session, err := s.schedule.GetSession(ctx, int(in.Id))
+	if err != nil {
+		return nil, statusErr(err, "failed to get session")
+	}
+
+	return sessionToProto(session), nil
+}
+
+func (s *serverAPI) GetSessions(
+	ctx context.Context,
+	in *docv1.GetSessionsRequest,
+) (*docv1.GetSessionsResponse, error) {
+	from := time.Unix(in.From, 0)
+	to := time.Unix(in.To, 0)
+	if in.To == 0 {
+		to = time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
+	}
+	if !from.Before(to) {
+		return nil, invalidArgument("from must be before to")
+	}
+
+	sessions, err := s.schedule.GetSessions(ctx, from, to)
+	if err != nil {
+		return nil, statusErr(err, "failed to get sessions")
+	}
+
+	resp := make([]*docv1.Session, 0, len(sessions))
+	for _, session := range sessions {
+		resp = append(resp, sessionToProto(session))
+	}
+
+	return &docv1.GetSessionsResponse{
+		Sessions: resp,
+	}, nil
+}
+
+func (s *serverAPI) DeleteSession(
+	ctx context.Context,
+	in *docv1.DeleteSessionRequest,
+) (*docv1.SuccessResponse, error) {
+	actor := actorFromContext(ctx)
+	if actor == nil {
+		return nil, errNoActor
+	}
+
+	err := s.schedule.DeleteSession(ctx, actor, int(in.Id))
+	if err != nil {
+		return nil, statusErr(err, "failed to delete session")
+	}
+
+	return &docv1.SuccessResponse{
+		Success: true,
+	}, nil
+}
+
+func (s *serverAPI) AssignSlot(
+	ctx context.Context,
+	in *docv1.AssignSlotRequest,
+) (*docv1.Slot, error) {
+	actor := actorFromContext(ctx)
+	if actor == nil {
+		return nil, errNoActor
+	}
+
+	if in.DocId < 0 {
+		return nil, invalidArgument("doc id must not be negative")
+	}
+
+	slot, err := s.schedule.AssignSlot(ctx, actor, int(in.SlotId), int(in.DocId))
+	if err != nil {
+		return nil, statusErr(err, "failed to assign slot")
+	}
+
+	return slotToProto(slot), nil
+}
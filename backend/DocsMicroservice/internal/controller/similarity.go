@@ -0,0 +1,42 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+	docv1 "github.com/Homyakadze14/DocsMicroservice/proto/gen/docs"
+)
+
+func similarThemesToProto(similar []*entities.SimilarTheme) []*docv1.SimilarTheme {
+	resp := make([]*docv1.SimilarTheme, 0, len(similar))
+	for _, st := range similar {
+		resp = append(resp, &docv1.SimilarTheme{
+			DocId: int64(st.DocID),
+			Type:  st.Type,
+			Year:  int32(st.Year),
+			Fio:   st.FIO,
+			Theme: st.Theme,
+			Score: st.Score,
+		})
+	}
+
+	return resp
+}
+
+func (s *serverAPI) FindSimilarThemes(
+	ctx context.Context,
+	in *docv1.FindSimilarThemesRequest,
+) (*docv1.FindSimilarThemesResponse, error) {
+	if in.Theme == "" {
+		return nil, invalidArgument("theme is required")
+	}
+
+	similar, err := s.docs.FindSimilarThemes(ctx, in.Theme, in.Threshold, int(in.Limit))
+	if err != nil {
+		return nil, statusErr(err, "failed to find similar themes")
+	}
+
+	return &docv1.FindSimilarThemesResponse{
+		SimilarThemes: similarThemesToProto(similar),
+	}, nil
+}
@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+	docv1 "github.com/Homyakadze14/DocsMicroservice/proto/gen/docs"
+)
+
+func filterFromProto(in *docv1.GetFilteredRequest) *entities.Doc {
+	doc := &entities.Doc{
+		Type:       in.GetType(),
+		Group:      in.GetGroup(),
+		FIO:        in.GetFio(),
+		Theme:      in.GetTheme(),
+		Director:   in.GetDirector(),
+		Year:       int(in.GetYear()),
+		Order:      in.GetOrder(),
+		Reviewer:   in.GetReviewer(),
+		Discipline: in.GetDiscipline(),
+		Status:     in.GetStatus(),
+	}
+	if in.GetParticipant() != "" {
+		doc.Participants = []*entities.Participant{{Name: in.GetParticipant()}}
+	}
+
+	return doc
+}
+
+func (s *serverAPI) GetStats(
+	ctx context.Context,
+	in *docv1.GetStatsRequest,
+) (*docv1.GetStatsResponse, error) {
+	q := &entities.StatsQuery{
+		GroupBy:             in.GroupBy,
+		Filter:              filterFromProto(in.Filter),
+		Top:                 int(in.Top),
+		ComparePreviousYear: in.ComparePreviousYear,
+	}
+
+	stats, err := s.docs.GetStats(ctx, q)
+	if err != nil {
+		return nil, statusErr(err, "failed to get stats")
+	}
+
+	buckets := make([]*docv1.StatsBucket, 0, len(stats.Buckets))
+	for _, b := range stats.Buckets {
+		buckets = append(buckets, &docv1.StatsBucket{
+			Keys:          b.Keys,
+			Count:         int64(b.Count),
+			PreviousCount: int64(b.PreviousCount),
+		})
+	}
+
+	return &docv1.GetStatsResponse{
+		GroupBy:       stats.GroupBy,
+		Buckets:       buckets,
+		Total:         int64(stats.Total),
+		PreviousTotal: int64(stats.PreviousTotal),
+	}, nil
+}
@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+	docv1 "github.com/Homyakadze14/DocsMicroservice/proto/gen/docs"
+)
+
+func transitionToProto(tr *entities.Transition) *docv1.Transition {
+	return &docv1.Transition{
+		Id:         int64(tr.ID),
+		DocId:      int64(tr.DocID),
+		FromStatus: tr.FromStatus,
+		ToStatus:   tr.ToStatus,
+		ActorId:    int64(tr.ActorID),
+		ActorName:  tr.ActorName,
+		ActorRole:  tr.ActorRole,
+		Comment:    tr.Comment,
+		CreatedAt:  tr.CreatedAt.Unix(),
+	}
+}
+
+func (s *serverAPI) TransitionDoc(
+	ctx context.Context,
+	in *docv1.TransitionDocRequest,
+) (*docv1.TransitionDocResponse, error) {
+	actor := actorFromContext(ctx)
+	if actor == nil {
+		return nil, errNoActor
+	}
+
+	params := &entities.TransitionParams{
+		Comment: in.Comment,
+		Order:   in.Order,
+		Grade:   int(in.Grade),
+	}
+	if in.DefenseDate != "" {
+		date, err := time.Parse(time.DateOnly, in.DefenseDate)
+		if err != nil {
+			return nil, invalidArgument("defense date must be in YYYY-MM-DD format")
+		}
+		params.DefenseDate = &date
+	}
+
+	doc, tr, err := s.docs.Transition(ctx, actor, int(in.Id), in.ToStatus, params)
+	if err != nil {
+		return nil, statusErr(err, "failed to transition")
+	}
+
+	return &docv1.TransitionDocResponse{
+		Doc:        docToProto(doc),
+		Transition: transitionToProto(tr),
+	}, nil
+}
+
+func (s *serverAPI) GetTransitions(
+	ctx context.Context,
+	in *docv1.GetTransitionsRequest,
+) (*docv1.GetTransitionsResponse, error) {
+	transitions, err := s.docs.GetTransitions(ctx, int(in.DocId))
+	if err != nil {
+		return nil, statusErr(err, "failed to get transitions")
+	}
+
+	resp := make([]*docv1.Transition, 0, len(transitions))
+	for _, tr := range transitions {
+		resp = append(resp, transitionToProto(tr))
+	}
+
+	return &docv1.GetTransitionsResponse{
+		Transitions: resp,
+	}, nil
+}
@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+	docv1 "github.com/Homyakadze14/DocsMicroservice/proto/gen/docs"
+)
+
+func workloadToProto(workload []*entities.Workload) []*docv1.Workload {
+	resp := make([]*docv1.Workload, 0, len(workload))
+	for _, w := range workload {
+		resp = append(resp, &docv1.Workload{
+			Person:   w.Person,
+			Role:     w.Role,
+			Year:     int32(w.Year),
+			Count:    int32(w.Count),
+			Limit:    int32(w.Limit),
+			Personal: w.Personal,
+		})
+	}
+
+	return resp
+}
+
+func (s *serverAPI) GetWorkload(
+	ctx context.Context,
+	in *docv1.GetWorkloadRequest,
+) (*docv1.GetWorkloadResponse, error) {
+	workload, err := s.docs.GetWorkload(ctx, in.Person, int(in.Year))
+	if err != nil {
+		return nil, statusErr(err, "failed to get workload")
+	}
+
+	return &docv1.GetWorkloadResponse{
+		Workload: workloadToProto(workload),
+	}, nil
+}
+
+func (s *serverAPI) SetWorkloadLimit(
+	ctx context.Context,
+	in *docv1.SetWorkloadLimitRequest,
+) (*docv1.SuccessResponse, error) {
+	actor := actorFromContext(ctx)
+	if actor == nil {
+		return nil, errNoActor
+	}
+
+	var err error
+	if in.Reset_ {
+		err = s.docs.ResetWorkloadLimit(ctx, actor, in.Person, in.Role)
+	} else {
+		err = s.docs.SetWorkloadLimit(ctx, actor, in.Person, in.Role, int(in.Limit))
+	}
+	if err != nil {
+		return nil, statusErr(err, "failed to set workload limit")
+	}
+
+	return &docv1.SuccessResponse{
+		Success: true,
+	}, nil
+}
@@ -0,0 +1,66 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// Review is the reviewer's conclusion on the work. It holds a text, a file or both.
+type Review struct {
+	DocID      int
+	AuthorID   int
+	AuthorName string
+	Grade      int
+	Text       string
+	FileName   string
+	FileType   string
+	FileSize   int
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (r Review) String() string {
+	return fmt.Sprintf("DocID: %v; AuthorName: %v; Grade: %v; FileName: %v; FileSize: %v",
+		r.DocID, r.AuthorName, r.Grade, r.FileName, r.FileSize)
+}
+
+// Feedback is the supervisor's opinion of the student's work.
+type Feedback struct {
+	DocID      int
+	AuthorID   int
+	AuthorName string
+	Text       string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Protocol records the commission's decision on the defense.
+type Protocol struct {
+	DocID      int
+	Number     string
+	Grade      int
+	SessionID  int
+	Questions  string
+	Notes      string
+	AuthorID   int
+	AuthorName string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (p Protocol) String() string {
+	return fmt.Sprintf("DocID: %v; Number: %v; Grade: %v; SessionID: %v", p.DocID, p.Number, p.Grade, p.SessionID)
+}
+
+// Assessment collects everything submitted about the doc. Missing parts are nil.
+type Assessment struct {
+	Review   *Review
+	Feedback *Feedback
+	Protocol *Protocol
+}
+
+type File struct {
+	Name    string
+	Type    string
+	Content []byte
+}
@@ -0,0 +1,57 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// Text extraction results of an attachment.
+const (
+	TextIndexed     = "indexed"
+	TextUnsupported = "unsupported"
+	TextFailed      = "failed"
+)
+
+// Search scopes.
+const (
+	SearchMetadata = "metadata"
+	SearchContent  = "content"
+	SearchBoth     = "both"
+)
+
+// Attachment is a file of the work, like the thesis itself.
+type Attachment struct {
+	ID         int
+	DocID      int
+	Name       string
+	Type       string
+	Size       int
+	TextStatus string
+	TextError  string
+	// Parts counts the indexed pages or paragraphs.
+	Parts      int
+	AuthorID   int
+	AuthorName string
+	CreatedAt  time.Time
+}
+
+func (a Attachment) String() string {
+	return fmt.Sprintf("ID: %v; DocID: %v; Name: %v; Type: %v; Size: %v", a.ID, a.DocID, a.Name, a.Type, a.Size)
+}
+
+// TextPart is a page or a paragraph of the attachment's text.
+type TextPart struct {
+	Unit   string
+	Number int
+	Text   string
+}
+
+// ContentMatch points to the place of the attachment the search matched.
+type ContentMatch struct {
+	DocID        int
+	AttachmentID int
+	FileName     string
+	Unit         string
+	Number       int
+	Snippet      string
+}
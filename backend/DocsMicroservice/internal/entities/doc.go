@@ -2,22 +2,63 @@ package entities
 
 import (
 	"fmt"
+	"time"
 )
 
+// Doc fields that can be changed by a partial update.
+const (
+	DocFieldType       = "type"
+	DocFieldGroup      = "group"
+	DocFieldFIO        = "fio"
+	DocFieldTheme      = "theme"
+	DocFieldDirector   = "director"
+	DocFieldYear       = "year"
+	DocFieldOrder      = "order"
+	DocFieldReviewer   = "reviewer"
+	DocFieldDiscipline = "discipline"
+	// DocFieldParticipants replaces all participants. It's not part of
+	// DocFields, so an update without a mask leaves them to the scalar fields.
+	DocFieldParticipants = "participants"
+)
+
+var DocFields = []string{
+	DocFieldType, DocFieldGroup, DocFieldFIO, DocFieldTheme, DocFieldDirector,
+	DocFieldYear, DocFieldOrder, DocFieldReviewer, DocFieldDiscipline,
+}
+
 type Doc struct {
-	ID         int
-	Type       string
-	Group      string
-	FIO        string
-	Theme      string
-	Director   string
-	Year       int
-	Order      string
-	Reviewer   string
-	Discipline string
+	ID          int
+	Type        string
+	Group       string
+	FIO         string
+	Theme       string
+	Director    string
+	Year        int
+	Order       string
+	Reviewer    string
+	Discipline  string
+	Version     int
+	UpdatedAt   time.Time
+	Status      string
+	Grade       int
+	DefenseDate *time.Time
+	// Participants by role and position. FIO, Director and Reviewer are
+	// the first student, supervisor and reviewer.
+	Participants []*Participant
 }
 
 func (a Doc) String() string {
-	return fmt.Sprintf("ID: %v; Type: %v; Group: %v; FIO: %v; Theme: %v; Director: %v; Year: %v; Order: %v; Reviewer: %v; Discipline: %v",
-		a.ID, a.Type, a.Group, a.FIO, a.Theme, a.Director, a.Year, a.Order, a.Reviewer, a.Discipline)
+	return fmt.Sprintf("ID: %v; Type: %v; Group: %v; FIO: %v; Theme: %v; Director: %v; Year: %v; Order: %v; Reviewer: %v; Discipline: %v; Version: %v; Status: %v; Participants: %v",
+		a.ID, a.Type, a.Group, a.FIO, a.Theme, a.Director, a.Year, a.Order, a.Reviewer, a.Discipline, a.Version, a.Status, a.Participants)
+}
+
+// SimilarTheme is an existing doc whose theme looks like the given one.
+// Score is from 0 to 1, where 1 means the same words.
+type SimilarTheme struct {
+	DocID int
+	Type  string
+	Year  int
+	FIO   string
+	Theme string
+	Score float64
 }
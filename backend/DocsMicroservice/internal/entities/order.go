@@ -0,0 +1,40 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// Order types.
+const (
+	OrderThemeApproval      = "theme_approval"
+	OrderReviewerAssignment = "reviewer_assignment"
+	OrderCommission         = "commission"
+)
+
+var OrderTypes = []string{OrderThemeApproval, OrderReviewerAssignment, OrderCommission}
+
+// Order is a university order covering docs of one academic year.
+// The signed file is optional.
+type Order struct {
+	ID       int
+	Number   string
+	Date     time.Time
+	Type     string
+	Year     int
+	Title    string
+	FileName string
+	FileType string
+	FileSize int
+	// DocsCount is set in lists, Docs when a single order is read
+	DocsCount  int
+	Docs       []*Doc
+	AuthorID   int
+	AuthorName string
+	CreatedAt  time.Time
+}
+
+func (o Order) String() string {
+	return fmt.Sprintf("ID: %v; Number: %v; Date: %v; Type: %v; Year: %v",
+		o.ID, o.Number, o.Date.Format(time.DateOnly), o.Type, o.Year)
+}
@@ -0,0 +1,42 @@
+package entities
+
+import "fmt"
+
+// Participant roles.
+const (
+	ParticipantStudent      = "student"
+	ParticipantSupervisor   = "supervisor"
+	ParticipantCoSupervisor = "co_supervisor"
+	ParticipantConsultant   = "consultant"
+	ParticipantReviewer     = "reviewer"
+)
+
+var ParticipantRoles = []string{
+	ParticipantStudent, ParticipantSupervisor, ParticipantCoSupervisor, ParticipantConsultant, ParticipantReviewer,
+}
+
+// SupervisingRoles are the participants who attend the defense of the doc,
+// so their time must not clash with other commissions.
+var SupervisingRoles = []string{ParticipantSupervisor, ParticipantCoSupervisor, ParticipantReviewer}
+
+// LegacyParticipantFields map the scalar doc fields to the role whose first
+// participant they hold.
+var LegacyParticipantFields = map[string]string{
+	DocFieldFIO:      ParticipantStudent,
+	DocFieldDirector: ParticipantSupervisor,
+	DocFieldReviewer: ParticipantReviewer,
+}
+
+// Participant is a person taking part in a doc. Position orders the
+// participants of one role starting from 1. UserID links the account of the
+// person and is 0 when none is linked.
+type Participant struct {
+	Role     string
+	Name     string
+	Position int
+	UserID   int
+}
+
+func (p Participant) String() string {
+	return fmt.Sprintf("%v #%v: %v", p.Role, p.Position, p.Name)
+}
@@ -0,0 +1,52 @@
+package entities
+
+import "fmt"
+
+// AssignedDoc is a doc reviewers are proposed for. FIO is its first student.
+type AssignedDoc struct {
+	ID    int
+	FIO   string
+	Theme string
+	Year  int
+}
+
+// ReviewerCandidate sums up what a past reviewer did before, as seen from a
+// doc. Only the docs the person reviewed or supervised count, the doc
+// itself never does.
+type ReviewerCandidate struct {
+	Person string
+	// Experience counts the docs, SameDiscipline those in the doc's discipline
+	Experience     int
+	SameDiscipline int
+	// Best theme similarity among the docs, from 0 to 1
+	ThemeSimilarity float64
+	// Reviews of the doc's year
+	Reviews int
+}
+
+// ReviewerSuggestion is a candidate reviewer of a doc. Score grows with the
+// discipline and theme matches and falls with the workload.
+type ReviewerSuggestion struct {
+	Person string
+	Score  float64
+	// Share of the docs the person reviewed or supervised in the doc's discipline
+	DisciplineMatch float64
+	// Best theme similarity among those docs, from 0 to 1
+	ThemeSimilarity float64
+	// Reviews of the doc's year, without the doc itself
+	Workload *Workload
+}
+
+func (s ReviewerSuggestion) String() string {
+	return fmt.Sprintf("Person: %v; Score: %.3f", s.Person, s.Score)
+}
+
+// ReviewerAssignment proposes a reviewer for a doc without one.
+// Reviewer is empty when there is no candidate left.
+type ReviewerAssignment struct {
+	DocID        int
+	FIO          string
+	Theme        string
+	Reviewer     string
+	Alternatives []*ReviewerSuggestion
+}
@@ -0,0 +1,87 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// Commission member roles.
+const (
+	MemberChair     = "chair"
+	MemberSecretary = "secretary"
+	MemberRegular   = "member"
+)
+
+var MemberRoles = []string{MemberChair, MemberSecretary, MemberRegular}
+
+// Kinds of scheduling conflicts.
+const (
+	ConflictRoom         = "room"
+	ConflictMember       = "member"
+	ConflictSupervisor   = "supervisor"
+	ConflictCoSupervisor = "co_supervisor"
+	ConflictReviewer     = "reviewer"
+)
+
+// CommissionMember is a person of the commission. UserID links the account
+// of the person and is 0 when none is linked.
+type CommissionMember struct {
+	ID           int
+	CommissionID int
+	Name         string
+	Role         string
+	UserID       int
+}
+
+type Commission struct {
+	ID        int
+	Name      string
+	Year      int
+	Members   []*CommissionMember
+	CreatedAt time.Time
+}
+
+func (c Commission) String() string {
+	return fmt.Sprintf("ID: %v; Name: %v; Year: %v; Members: %v", c.ID, c.Name, c.Year, len(c.Members))
+}
+
+type Slot struct {
+	ID        int
+	SessionID int
+	Position  int
+	StartsAt  time.Time
+	EndsAt    time.Time
+	// Zero when no doc is assigned.
+	DocID int
+	Doc   *Doc
+}
+
+type Session struct {
+	ID           int
+	CommissionID int
+	Room         string
+	StartsAt     time.Time
+	EndsAt       time.Time
+	SlotMinutes  int
+	Commission   *Commission
+	Slots        []*Slot
+}
+
+func (s Session) String() string {
+	return fmt.Sprintf("ID: %v; CommissionID: %v; Room: %v; StartsAt: %v; EndsAt: %v; SlotMinutes: %v",
+		s.ID, s.CommissionID, s.Room, s.StartsAt, s.EndsAt, s.SlotMinutes)
+}
+
+// Conflict describes why a session or slot assignment can't be scheduled.
+type Conflict struct {
+	Kind        string
+	Subject     string
+	Description string
+}
+
+// BusyMember is a person sitting on the commission of an overlapping session.
+type BusyMember struct {
+	Name      string
+	SessionID int
+	Room      string
+}
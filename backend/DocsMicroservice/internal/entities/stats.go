@@ -0,0 +1,31 @@
+package entities
+
+// StatsDimensions are the doc fields statistics can be grouped by.
+var StatsDimensions = []string{
+	DocFieldYear, DocFieldType, DocFieldGroup, DocFieldDirector, DocFieldReviewer, DocFieldDiscipline,
+}
+
+// StatsQuery counts the docs matching Filter in groups of the GroupBy fields.
+type StatsQuery struct {
+	GroupBy []string
+	Filter  *Doc
+	// Top keeps only the largest groups, 0 keeps all.
+	Top int
+	// ComparePreviousYear also counts the year before Filter.Year.
+	ComparePreviousYear bool
+}
+
+// StatsBucket is one group. Keys follow the order of StatsQuery.GroupBy.
+type StatsBucket struct {
+	Keys          []string
+	Count         int
+	PreviousCount int
+}
+
+type Stats struct {
+	GroupBy []string
+	Buckets []*StatsBucket
+	// Totals count all groups, not only the top ones.
+	Total         int
+	PreviousTotal int
+}
@@ -0,0 +1,66 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// Doc statuses in lifecycle order.
+const (
+	StatusTopicProposed = "topic_proposed"
+	StatusTopicApproved = "topic_approved"
+	StatusInProgress    = "in_progress"
+	StatusSubmitted     = "submitted"
+	StatusReviewed      = "reviewed"
+	StatusDefended      = "defended"
+	StatusArchived      = "archived"
+)
+
+var Statuses = []string{
+	StatusTopicProposed, StatusTopicApproved, StatusInProgress, StatusSubmitted,
+	StatusReviewed, StatusDefended, StatusArchived,
+}
+
+// Roles of the users behind requests. They are assigned in the auth service.
+const (
+	RoleStudent    = "student"
+	RoleSupervisor = "supervisor"
+	RoleSecretary  = "secretary"
+	RoleAdmin      = "admin"
+)
+
+// Actor is the user on whose behalf the gateway calls the service.
+type Actor struct {
+	UserID   int
+	Username string
+	Role     string
+}
+
+func (a Actor) String() string {
+	return fmt.Sprintf("UserID: %v; Username: %v; Role: %v", a.UserID, a.Username, a.Role)
+}
+
+// TransitionParams carries data some transitions require:
+// the order number for topic approval, grade and date for defense.
+type TransitionParams struct {
+	Comment     string
+	Order       string
+	Grade       int
+	DefenseDate *time.Time
+}
+
+type Transition struct {
+	ID         int
+	DocID      int
+	FromStatus string
+	ToStatus   string
+	ActorID    int
+	ActorName  string
+	ActorRole  string
+	Comment    string
+	CreatedAt  time.Time
+}
+
+func (t Transition) String() string {
+	return fmt.Sprintf("ID: %v; DocID: %v; From: %v; To: %v; ActorID: %v", t.ID, t.DocID, t.FromStatus, t.ToStatus, t.ActorID)
+}
@@ -0,0 +1,38 @@
+package entities
+
+import "fmt"
+
+// Workload roles of a person in docs.
+const (
+	WorkloadSupervisor = "supervisor"
+	WorkloadReviewer   = "reviewer"
+)
+
+var WorkloadRoles = []string{WorkloadSupervisor, WorkloadReviewer}
+
+// WorkloadParticipants lists the participant roles counted in every workload
+// role, co-supervisors share the supervisor's.
+var WorkloadParticipants = map[string][]string{
+	WorkloadSupervisor: {ParticipantSupervisor, ParticipantCoSupervisor},
+	WorkloadReviewer:   {ParticipantReviewer},
+}
+
+// Workload counts the docs of a year a person supervises or reviews.
+// Limit 0 means there is no limit.
+type Workload struct {
+	Person string
+	Role   string
+	Year   int
+	Count  int
+	Limit  int
+	// Personal is set when the limit is set for this person.
+	Personal bool
+}
+
+func (w Workload) Exceeded() bool {
+	return w.Limit > 0 && w.Count > w.Limit
+}
+
+func (w Workload) String() string {
+	return fmt.Sprintf("%s %s has %d of %d docs in %d", w.Role, w.Person, w.Count, w.Limit, w.Year)
+}
@@ -0,0 +1,61 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/config"
+)
+
+// Backends of the cache.
+const (
+	BackendMemory = "memory"
+	BackendNone   = "none"
+)
+
+var (
+	ErrMiss           = errors.New("cache miss")
+	ErrUnknownBackend = errors.New("unknown cache backend")
+)
+
+// Cache stores encoded values by key. An external cache, e.g. Redis, only
+// has to implement it to be used instead of the in-process one.
+type Cache interface {
+	// Get returns ErrMiss when there is no entry or it has expired.
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Clear drops every entry. A shared cache may do it by switching
+	// to a new key prefix instead of deleting them.
+	Clear(ctx context.Context) error
+}
+
+// New returns the cache of the configured backend.
+func New(cfg *config.CacheConfig) (Cache, error) {
+	const op = "cache.New"
+
+	switch cfg.Backend {
+	case BackendMemory:
+		return NewLRU(cfg.Size), nil
+	case BackendNone:
+		return Nop{}, nil
+	}
+
+	return nil, fmt.Errorf("%s: %w: %q", op, ErrUnknownBackend, cfg.Backend)
+}
+
+// Nop caches nothing.
+type Nop struct{}
+
+func (Nop) Get(context.Context, string) ([]byte, error) {
+	return nil, ErrMiss
+}
+
+func (Nop) Set(context.Context, string, []byte, time.Duration) error {
+	return nil
+}
+
+func (Nop) Clear(context.Context) error {
+	return nil
+}
@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestLRU returns a cache on a fake clock and the function moving it.
+func newTestLRU(size int) (*LRU, func(time.Duration)) {
+	now := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	c := NewLRU(size)
+	c.now = func() time.Time { return now }
+
+	return c, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	c, _ := newTestLRU(2)
+
+	assert.NoError(t, c.Set(ctx, "a", []byte("1"), time.Minute))
+	assert.NoError(t, c.Set(ctx, "b", []byte("2"), time.Minute))
+	// Reading a makes b the least recently used
+	_, err := c.Get(ctx, "a")
+	assert.NoError(t, err)
+	assert.NoError(t, c.Set(ctx, "c", []byte("3"), time.Minute))
+
+	assert.Equal(t, 2, c.Len())
+	_, err = c.Get(ctx, "b")
+	assert.ErrorIs(t, err, ErrMiss)
+	for key, want := range map[string]string{"a": "1", "c": "3"} {
+		value, err := c.Get(ctx, key)
+		assert.NoError(t, err)
+		assert.Equal(t, want, string(value))
+	}
+}
+
+func TestLRUSetReplaces(t *testing.T) {
+	ctx := context.Background()
+	c, _ := newTestLRU(2)
+
+	assert.NoError(t, c.Set(ctx, "a", []byte("1"), time.Minute))
+	assert.NoError(t, c.Set(ctx, "b", []byte("2"), time.Minute))
+	assert.NoError(t, c.Set(ctx, "a", []byte("new"), time.Minute))
+	assert.NoError(t, c.Set(ctx, "c", []byte("3"), time.Minute))
+
+	value, err := c.Get(ctx, "a")
+	assert.NoError(t, err)
+	assert.Equal(t, "new", string(value))
+	_, err = c.Get(ctx, "b")
+	assert.ErrorIs(t, err, ErrMiss)
+}
+
+func TestLRUExpires(t *testing.T) {
+	ctx := context.Background()
+	c, advance := newTestLRU(2)
+
+	assert.NoError(t, c.Set(ctx, "a", []byte("1"), time.Minute))
+
+	advance(59 * time.Second)
+	_, err := c.Get(ctx, "a")
+	assert.NoError(t, err)
+
+	advance(time.Second)
+	_, err = c.Get(ctx, "a")
+	assert.ErrorIs(t, err, ErrMiss)
+	assert.Zero(t, c.Len())
+}
+
+func TestLRUClear(t *testing.T) {
+	ctx := context.Background()
+	c, _ := newTestLRU(2)
+
+	assert.NoError(t, c.Set(ctx, "a", []byte("1"), time.Minute))
+	assert.NoError(t, c.Clear(ctx))
+
+	assert.Zero(t, c.Len())
+	_, err := c.Get(ctx, "a")
+	assert.ErrorIs(t, err, ErrMiss)
+}
+
+func TestNew(t *testing.T) {
+	c, err := New(&config.CacheConfig{Backend: BackendMemory, Size: 10})
+	assert.NoError(t, err)
+	assert.IsType(t, &LRU{}, c)
+
+	c, err = New(&config.CacheConfig{Backend: BackendNone})
+	assert.NoError(t, err)
+	assert.Equal(t, Nop{}, c)
+
+	_, err = New(&config.CacheConfig{Backend: "redis"})
+	assert.ErrorIs(t, err, ErrUnknownBackend)
+}
@@ -0,0 +1,88 @@
+// Package extract pulls plain text out of PDF, DOCX and ODT files.
+// Text is split into pages for PDF and into paragraphs for the office formats,
+// so a search match can point to where it was found.
+package extract
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const (
+	UnitPage      = "page"
+	UnitParagraph = "paragraph"
+)
+
+var (
+	ErrUnsupported = errors.New("unsupported file format")
+	ErrEncrypted   = errors.New("file is encrypted")
+	ErrMalformed   = errors.New("file is malformed")
+	ErrTooComplex  = errors.New("file is too complex")
+)
+
+// Part is a page or a paragraph of the file. Number starts from 1.
+type Part struct {
+	Unit   string
+	Number int
+	Text   string
+}
+
+// Format names the file format by its content, or returns "" when unknown.
+func Format(content []byte) string {
+	switch {
+	case bytes.HasPrefix(content, []byte("%PDF-")):
+		return "pdf"
+	case bytes.HasPrefix(content, []byte("PK\x03\x04")):
+		return officeFormat(content)
+	}
+
+	return ""
+}
+
+// Text extracts the parts of the file that contain any text.
+// Extraction stops with the context, so a deadline bounds the time
+// a hostile file can take.
+func Text(ctx context.Context, content []byte) (parts []Part, err error) {
+	// A parser bug must fail the file, not the service
+	defer func() {
+		if r := recover(); r != nil {
+			parts, err = nil, fmt.Errorf("%w: %v", ErrMalformed, r)
+		}
+	}()
+
+	return text(ctx, content)
+}
+
+func text(ctx context.Context, content []byte) ([]Part, error) {
+	switch Format(content) {
+	case "pdf":
+		return pdfText(ctx, content)
+	case "docx":
+		return docxText(content)
+	case "odt":
+		return odtText(content)
+	}
+
+	return nil, ErrUnsupported
+}
+
+// clean collapses runs of spaces and drops control characters,
+// keeping line breaks.
+func clean(s string) string {
+	lines := strings.Split(s, "\n")
+	out := lines[:0]
+	for _, line := range lines {
+		line = strings.Join(strings.FieldsFunc(line, func(r rune) bool {
+			return unicode.IsSpace(r) || unicode.IsControl(r) || r == unicode.ReplacementChar
+		}), " ")
+		if line != "" {
+			out = append(out, line)
+		}
+	}
+
+	return strings.Join(out, "\n")
+}
@@ -0,0 +1,65 @@
+package extract
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTextUnsupported(t *testing.T) {
+	for _, content := range [][]byte{nil, []byte("plain text"), []byte("\x89PNG\r\n")} {
+		_, err := Text(context.Background(), content)
+
+		assert.ErrorIs(t, err, ErrUnsupported)
+	}
+}
+
+func TestClean(t *testing.T) {
+	assert.Equal(t, "a b\nc", clean("  a \t b\x00 \n\n � \n c  "))
+}
+
+// FuzzText feeds the parsers directly, without the recover of Text,
+// so any panic fails the run. The seeds cover each format and the
+// broken files the tests above are made of.
+func FuzzText(f *testing.F) {
+	seeds := [][]byte{
+		simplePDF("BT /F1 12 Tf [(Hel) -20 (lo) -250 (world)] TJ 0 -14 Td (next) Tj ET"),
+		simplePDF("BT /F1 12 Tf (a) Tj ET BI /W 1 ID \x00EI EI", "q 1 0 0 1 0 0 cm Q"),
+		pdfDoc(
+			"<< /Type /Catalog /Pages 2 0 R >>",
+			"<< /Type /Pages /Kids [2 0 R 2 0 R 3 0 R] >>",
+			"<< /Type /Page /Resources << /Font << /F1 4 0 R >> >> /Contents [5 0 R 5 0 R] >>",
+			"<< /Type /Font /Subtype /Type0 /ToUnicode 6 0 R >>",
+			pdfStream("/Filter /FlateDecode", deflate("BT /F1 12 Tf <00010002> Tj ET")),
+			pdfStream("", "1 begincodespacerange <0000> <FFFF> endcodespacerange 1 beginbfrange <0001> <0002> <0430> endbfrange"),
+		),
+		pdfDoc(
+			"<< /Type /Catalog /Pages 2 0 R >>",
+			"<< /Type /Pages /Kids [3 0 R] >>",
+			"",
+			pdfStream("/Type /ObjStm /N 1 /First 4 /Filter /FlateDecode", deflate("3 0 << /Type /Page /Contents 4 0 R >>")),
+		),
+		[]byte("%PDF-1.4\n1 0 obj << /Type /Catalog /Pages 1 0 R /Kids [1 0 R] >> endobj"),
+		[]byte("%PDF-1.4\n1 0 obj << /Length 99 >> stream\nabc"),
+		[]byte("%PDF-1.4\n1 0 obj [[[[[[[[(unclosed"),
+		docx("<w:p><w:r><w:t>абзац</w:t><w:tab/><w:br/></w:r></w:p>"),
+		odt(`<text:h>заголовок</text:h><text:p>a<text:s text:c="3"/>b</text:p>`),
+		zipFiles("word/document.xml", "<broken"),
+	}
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, content []byte) {
+		parts, err := text(context.Background(), content)
+		if err != nil {
+			return
+		}
+		for i, p := range parts {
+			if p.Text == "" || p.Number < 1 || (i > 0 && p.Number <= parts[i-1].Number) {
+				t.Fatalf("bad part %d: %+v", i, p)
+			}
+		}
+	})
+}
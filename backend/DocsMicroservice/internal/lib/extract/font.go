@@ -0,0 +1,266 @@
+package extract
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// maxRange caps a bfrange so a broken CMap can't allocate without end.
+const maxRange = 1 << 16
+
+// font turns the bytes of shown strings into text.
+type font struct {
+	// toUnicode maps character codes to text. Codes are kept as raw bytes
+	// since their length depends on the code space.
+	toUnicode map[string]string
+	lengths   []int
+	// simple fonts use one byte per code and fall back to the encoding.
+	simple   bool
+	encoding [256]string
+}
+
+func (f *pdfFile) newFont(d dict) *font {
+	fnt := &font{simple: d["Subtype"] != name("Type0")}
+	if fnt.simple {
+		fnt.encoding = f.simpleEncoding(d)
+	}
+
+	if s, ok := f.resolve(d["ToUnicode"]).(*stream); ok {
+		if data, err := f.decode(s); err == nil {
+			fnt.parseCMap(data)
+		}
+	}
+	if len(fnt.lengths) == 0 {
+		fnt.lengths = []int{2}
+		if fnt.simple {
+			fnt.lengths = []int{1}
+		}
+	}
+
+	return fnt
+}
+
+func (fnt *font) decode(s []byte) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		found := false
+		for _, n := range fnt.lengths {
+			if i+n > len(s) {
+				break
+			}
+			if text, ok := fnt.toUnicode[string(s[i:i+n])]; ok {
+				b.WriteString(text)
+				i += n
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+
+		// Composite fonts without a mapping carry glyph ids, which are no text
+		if fnt.simple {
+			b.WriteString(fnt.encoding[s[i]])
+		}
+		i += fnt.lengths[0]
+	}
+
+	return b.String()
+}
+
+// parseCMap reads the codespace, bfchar and bfrange sections of a ToUnicode CMap.
+func (fnt *font) parseCMap(data []byte) {
+	fnt.toUnicode = make(map[string]string)
+	l := &lexer{data: data}
+	var operands []any
+	for {
+		v, ok := l.object(0)
+		if !ok {
+			break
+		}
+		op, isOp := v.(keyword)
+		if !isOp {
+			operands = append(operands, v)
+			continue
+		}
+
+		switch op {
+		case "endcodespacerange":
+			for i := 0; i+1 < len(operands); i += 2 {
+				if lo, ok := operands[i].([]byte); ok && len(lo) > 0 {
+					fnt.lengths = append(fnt.lengths, len(lo))
+				}
+			}
+		case "endbfchar":
+			for i := 0; i+1 < len(operands); i += 2 {
+				src, ok1 := operands[i].([]byte)
+				dst, ok2 := operands[i+1].([]byte)
+				if ok1 && ok2 {
+					fnt.toUnicode[string(src)] = utf16BE(dst)
+				}
+			}
+		case "endbfrange":
+			for i := 0; i+2 < len(operands); i += 3 {
+				lo, ok1 := operands[i].([]byte)
+				hi, ok2 := operands[i+1].([]byte)
+				if ok1 && ok2 && len(lo) == len(hi) {
+					fnt.addRange(lo, hi, operands[i+2])
+				}
+			}
+		}
+		operands = operands[:0]
+	}
+
+	slices.Sort(fnt.lengths)
+	fnt.lengths = slices.Compact(fnt.lengths)
+	if len(fnt.lengths) == 0 {
+		for code := range fnt.toUnicode {
+			fnt.lengths = append(fnt.lengths, len(code))
+		}
+		slices.Sort(fnt.lengths)
+		fnt.lengths = slices.Compact(fnt.lengths)
+	}
+}
+
+func (fnt *font) addRange(lo, hi []byte, dst any) {
+	from, to := codeValue(lo), codeValue(hi)
+	if to < from || to-from >= maxRange {
+		return
+	}
+
+	for code := from; code <= to; code++ {
+		key := codeBytes(code, len(lo))
+		switch d := dst.(type) {
+		case []byte:
+			if len(d) < 2 {
+				continue
+			}
+			// The last UTF-16 unit is incremented along the range
+			next := slices.Clone(d)
+			unit := uint32(next[len(next)-2])<<8 | uint32(next[len(next)-1])
+			unit += code - from
+			next[len(next)-2], next[len(next)-1] = byte(unit>>8), byte(unit)
+			fnt.toUnicode[key] = utf16BE(next)
+		case array:
+			if i := int(code - from); i < len(d) {
+				if s, ok := d[i].([]byte); ok {
+					fnt.toUnicode[key] = utf16BE(s)
+				}
+			}
+		}
+	}
+}
+
+func codeValue(b []byte) uint32 {
+	var v uint32
+	for _, c := range b {
+		v = v<<8 | uint32(c)
+	}
+	return v
+}
+
+func codeBytes(v uint32, n int) string {
+	b := make([]byte, n)
+	for i := n - 1; i >= 0; i-- {
+		b[i] = byte(v)
+		v >>= 8
+	}
+	return string(b)
+}
+
+func utf16BE(b []byte) string {
+	units := make([]uint16, 0, len(b)/2)
+	for i := 0; i+1 < len(b); i += 2 {
+		units = append(units, uint16(b[i])<<8|uint16(b[i+1]))
+	}
+	return string(utf16.Decode(units))
+}
+
+// simpleEncoding builds the byte to rune table of a simple font
+// from its base encoding and the differences to it.
+func (f *pdfFile) simpleEncoding(d dict) [256]string {
+	var enc [256]string
+	cm := charmap.Windows1252
+	base := f.resolve(d["Encoding"])
+	encDict := f.dict(base)
+	if encDict != nil {
+		base = f.resolve(encDict["BaseEncoding"])
+	}
+	if base == name("MacRomanEncoding") {
+		cm = charmap.Macintosh
+	}
+	for i := range enc {
+		enc[i] = string(cm.DecodeByte(byte(i)))
+	}
+
+	if encDict == nil {
+		return enc
+	}
+	diffs, _ := f.resolve(encDict["Differences"]).(array)
+	code := 0
+	for _, v := range diffs {
+		switch t := v.(type) {
+		case int:
+			code = t
+		case name:
+			if code >= 0 && code < len(enc) {
+				if text, ok := ligatures[string(t)]; ok {
+					enc[code] = text
+				} else if r := glyphRune(string(t)); r != 0 {
+					enc[code] = string(r)
+				}
+			}
+			code++
+		}
+	}
+
+	return enc
+}
+
+// ligatures are spelled out so the words stay searchable.
+var ligatures = map[string]string{
+	"fi": "fi", "fl": "fl", "ff": "ff", "ffi": "ffi", "ffl": "ffl",
+}
+
+// glyphRune knows the glyph names used for Cyrillic and the uniXXXX form.
+// Other names are left to the base encoding.
+func glyphRune(glyph string) rune {
+	if len(glyph) == 1 {
+		return rune(glyph[0])
+	}
+	if hex, ok := strings.CutPrefix(glyph, "uni"); ok && len(hex) == 4 {
+		if v, err := strconv.ParseUint(hex, 16, 16); err == nil {
+			return rune(v)
+		}
+	}
+
+	afii, ok := strings.CutPrefix(glyph, "afii")
+	if !ok {
+		return 0
+	}
+	n, err := strconv.Atoi(afii)
+	if err != nil {
+		return 0
+	}
+	switch {
+	case n >= 10017 && n <= 10022:
+		return rune(0x0410 + n - 10017)
+	case n == 10023:
+		return 'Ё'
+	case n >= 10024 && n <= 10049:
+		return rune(0x0416 + n - 10024)
+	case n >= 10065 && n <= 10070:
+		return rune(0x0430 + n - 10065)
+	case n == 10071:
+		return 'ё'
+	case n >= 10072 && n <= 10097:
+		return rune(0x0436 + n - 10072)
+	}
+
+	return 0
+}
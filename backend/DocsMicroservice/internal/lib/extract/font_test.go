@@ -0,0 +1,99 @@
+package extract
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCMap(t *testing.T) {
+	cmap := `/CIDInit /ProcSet findresource begin
+12 dict begin
+begincmap
+1 begincodespacerange <0000> <FFFF> endcodespacerange
+2 beginbfchar
+<0003> <0020>
+<0010> <0041 0301>
+endbfchar
+2 beginbfrange
+<0020> <0022> <0430>
+<0030> <0031> [<0061> <0062>]
+endbfrange
+endcmap`
+
+	fnt := &font{}
+	fnt.parseCMap([]byte(cmap))
+
+	assert.Equal(t, []int{2}, fnt.lengths)
+	assert.Equal(t, " ", fnt.toUnicode["\x00\x03"])
+	assert.Equal(t, "Á", fnt.toUnicode["\x00\x10"])
+	assert.Equal(t, "абв", fnt.decode([]byte("\x00\x20\x00\x21\x00\x22")))
+	assert.Equal(t, "ab", fnt.decode([]byte("\x00\x30\x00\x31")))
+	// Composite fonts without a mapping carry glyph ids
+	assert.Equal(t, "а", fnt.decode([]byte("\x00\x20\x01\x99")))
+}
+
+func TestParseCMapBrokenRange(t *testing.T) {
+	fnt := &font{}
+	fnt.parseCMap([]byte("1 beginbfrange <0000> <FFFFFFFF> <0041> endbfrange 1 beginbfrange <0010> <0001> <0041> endbfrange"))
+
+	assert.Empty(t, fnt.toUnicode)
+}
+
+func TestParseCMapLengthsFromCodes(t *testing.T) {
+	fnt := &font{}
+	fnt.parseCMap([]byte("1 beginbfchar <41> <0042> endbfchar"))
+
+	assert.Equal(t, []int{1}, fnt.lengths)
+	assert.Equal(t, "B", fnt.decode([]byte("A")))
+}
+
+func TestSimpleEncoding(t *testing.T) {
+	f := &pdfFile{ctx: context.Background(), objects: map[int]any{
+		1: dict{
+			"BaseEncoding": name("WinAnsiEncoding"),
+			"Differences":  array{192, name("afii10017"), name("afii10018"), 200, name("uni0451"), name("fi"), name("x")},
+		},
+	}}
+
+	fnt := f.newFont(dict{"Subtype": name("Type1"), "Encoding": ref{1, 0}})
+
+	assert.True(t, fnt.simple)
+	assert.Equal(t, "Hi АБё fix", fnt.decode([]byte("Hi \xc0\xc1\xc8 \xc9\xca")))
+	// Codes past the differences keep the base encoding
+	assert.Equal(t, "€", fnt.decode([]byte{0x80}))
+}
+
+func TestSimpleEncodingMacRoman(t *testing.T) {
+	f := &pdfFile{ctx: context.Background(), objects: map[int]any{}}
+
+	fnt := f.newFont(dict{"Subtype": name("TrueType"), "Encoding": name("MacRomanEncoding")})
+
+	assert.Equal(t, "é", fnt.decode([]byte{0x8e}))
+}
+
+func TestGlyphRune(t *testing.T) {
+	tests := []struct {
+		glyph string
+		want  rune
+	}{
+		{"a", 'a'},
+		{"uni0416", 'Ж'},
+		{"afii10017", 'А'},
+		{"afii10023", 'Ё'},
+		{"afii10049", 'Я'},
+		{"afii10065", 'а'},
+		{"afii10071", 'ё'},
+		{"afii10097", 'я'},
+		{"afii57636", 0},
+		{"uniZZZZ", 0},
+		{"bullet", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.glyph, func(t *testing.T) {
+			assert.Equal(t, tt.want, glyphRune(tt.glyph))
+		})
+	}
+}
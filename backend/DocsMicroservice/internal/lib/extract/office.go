@@ -0,0 +1,208 @@
+package extract
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	nsWord = "http://schemas.openxmlformats.org/wordprocessingml/2006/main"
+	nsODT  = "urn:oasis:names:tc:opendocument:xmlns:text:1.0"
+
+	odtMimeType = "application/vnd.oasis.opendocument.text"
+)
+
+// maxUnpacked guards against archives that inflate far beyond their size.
+const maxUnpacked = 64 << 20
+
+func officeFormat(content []byte) string {
+	zr, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return ""
+	}
+
+	for _, f := range zr.File {
+		switch f.Name {
+		case "word/document.xml":
+			return "docx"
+		case "mimetype":
+			data, err := readZipFile(f)
+			if err == nil && strings.TrimSpace(string(data)) == odtMimeType {
+				return "odt"
+			}
+		}
+	}
+
+	return ""
+}
+
+func readZipFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, maxUnpacked+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxUnpacked {
+		return nil, fmt.Errorf("%s: %w", f.Name, ErrMalformed)
+	}
+
+	return data, nil
+}
+
+func openZipFile(content []byte, name string) ([]byte, error) {
+	zr, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMalformed, err)
+	}
+
+	for _, f := range zr.File {
+		if f.Name == name {
+			return readZipFile(f)
+		}
+	}
+
+	return nil, fmt.Errorf("%s is missing: %w", name, ErrMalformed)
+}
+
+// paragraphs collects the text of the XML paragraph elements. Paragraphs nested
+// into others, like text boxes and notes, are merged into the outer one.
+type paragraphs struct {
+	parts []Part
+	depth int
+	buf   strings.Builder
+}
+
+func (p *paragraphs) start() {
+	if p.depth > 0 {
+		p.buf.WriteByte(' ')
+	}
+	p.depth++
+}
+
+func (p *paragraphs) end() {
+	p.depth--
+	if p.depth > 0 {
+		p.buf.WriteByte(' ')
+		return
+	}
+
+	text := clean(p.buf.String())
+	p.buf.Reset()
+	if text != "" {
+		p.parts = append(p.parts, Part{Unit: UnitParagraph, Number: len(p.parts) + 1, Text: text})
+	}
+}
+
+func (p *paragraphs) write(s string) {
+	if p.depth > 0 {
+		p.buf.WriteString(s)
+	}
+}
+
+func docxText(content []byte) ([]Part, error) {
+	data, err := openZipFile(content, "word/document.xml")
+	if err != nil {
+		return nil, err
+	}
+
+	var p paragraphs
+	inText := false
+	d := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrMalformed, err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Space != nsWord {
+				continue
+			}
+			switch t.Name.Local {
+			case "p":
+				p.start()
+			case "t":
+				inText = true
+			case "tab":
+				p.write("\t")
+			case "br", "cr":
+				p.write("\n")
+			}
+		case xml.EndElement:
+			if t.Name.Space != nsWord {
+				continue
+			}
+			switch t.Name.Local {
+			case "p":
+				p.end()
+			case "t":
+				inText = false
+			}
+		case xml.CharData:
+			if inText {
+				p.write(string(t))
+			}
+		}
+	}
+
+	return p.parts, nil
+}
+
+func odtText(content []byte) ([]Part, error) {
+	data, err := openZipFile(content, "content.xml")
+	if err != nil {
+		return nil, err
+	}
+
+	var p paragraphs
+	d := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrMalformed, err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Space != nsODT {
+				continue
+			}
+			switch t.Name.Local {
+			case "p", "h":
+				p.start()
+			case "s":
+				// The count of spaces doesn't matter, clean collapses them
+				p.write(" ")
+			case "tab":
+				p.write("\t")
+			case "line-break":
+				p.write("\n")
+			}
+		case xml.EndElement:
+			if t.Name.Space == nsODT && (t.Name.Local == "p" || t.Name.Local == "h") {
+				p.end()
+			}
+		case xml.CharData:
+			p.write(string(t))
+		}
+	}
+
+	return p.parts, nil
+}
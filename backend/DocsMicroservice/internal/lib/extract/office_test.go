@@ -0,0 +1,110 @@
+package extract
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// zipFiles packs name and content pairs into an archive.
+func zipFiles(files ...string) []byte {
+	var b bytes.Buffer
+	zw := zip.NewWriter(&b)
+	for i := 0; i+1 < len(files); i += 2 {
+		w, _ := zw.Create(files[i])
+		w.Write([]byte(files[i+1]))
+	}
+	zw.Close()
+
+	return b.Bytes()
+}
+
+func docx(body string) []byte {
+	return zipFiles("word/document.xml", `<?xml version="1.0" encoding="UTF-8"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>`+body+`</w:body></w:document>`)
+}
+
+func odt(body string) []byte {
+	return zipFiles(
+		"mimetype", odtMimeType,
+		"content.xml", `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0"
+ xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0"><office:body><office:text>`+body+`</office:text></office:body></office:document-content>`,
+	)
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		content []byte
+		want    string
+	}{
+		{"pdf", []byte("%PDF-1.7\n"), "pdf"},
+		{"docx", docx(""), "docx"},
+		{"odt", odt(""), "odt"},
+		{"other archive", zipFiles("mimetype", "application/zip"), ""},
+		{"broken archive", []byte("PK\x03\x04broken"), ""},
+		{"text", []byte("plain text"), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Format(tt.content))
+		})
+	}
+}
+
+func TestDocxText(t *testing.T) {
+	parts, err := Text(context.Background(), docx(`
+<w:p><w:r><w:t>Глава</w:t></w:r><w:r><w:tab/><w:t xml:space="preserve">первая  </w:t></w:r></w:p>
+<w:p><w:r><w:t>   </w:t></w:r></w:p>
+<w:p><w:r><w:t>строка</w:t><w:br/><w:t>ещё</w:t></w:r></w:p>
+<w:p><w:r><w:t>снаружи</w:t></w:r><w:r><w:txbxContent><w:p><w:r><w:t>в рамке</w:t></w:r></w:p></w:txbxContent></w:r></w:p>
+<w:sectPr><w:t>вне абзаца</w:t></w:sectPr>`))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Part{
+		{Unit: UnitParagraph, Number: 1, Text: "Глава первая"},
+		{Unit: UnitParagraph, Number: 2, Text: "строка\nещё"},
+		{Unit: UnitParagraph, Number: 3, Text: "снаружи в рамке"},
+	}, parts)
+}
+
+func TestOdtText(t *testing.T) {
+	parts, err := Text(context.Background(), odt(`
+<text:h>Введение</text:h>
+<text:p>слово<text:s text:c="1000000000"/>слово<text:tab/>и<text:line-break/>строка</text:p>
+<text:p/>
+<text:p>с <text:span>выделением</text:span></text:p>`))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Part{
+		{Unit: UnitParagraph, Number: 1, Text: "Введение"},
+		{Unit: UnitParagraph, Number: 2, Text: "слово слово и\nстрока"},
+		{Unit: UnitParagraph, Number: 3, Text: "с выделением"},
+	}, parts)
+}
+
+func TestOfficeTextErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		text func([]byte) ([]Part, error)
+		data []byte
+	}{
+		{"docx without document", docxText, zipFiles("word/styles.xml", "<w:styles/>")},
+		{"docx with broken xml", docxText, docx("<w:p><w:t>open")},
+		{"odt without content", odtText, zipFiles("mimetype", odtMimeType)},
+		{"not an archive", odtText, []byte("PK\x03\x04broken")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.text(tt.data)
+
+			assert.ErrorIs(t, err, ErrMalformed)
+		})
+	}
+}
@@ -0,0 +1,479 @@
+package extract
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"encoding/ascii85"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	objStart   = regexp.MustCompile(`(\d+)\s+\d+\s+obj\b`)
+	rootRef    = regexp.MustCompile(`/Root\s+(\d+)\s+\d+\s+R`)
+	encryptRef = regexp.MustCompile(`/Encrypt\s*(<<|\d+\s+\d+\s+R)`)
+	hyphenated = regexp.MustCompile(`(\pL)-\n(\p{Ll})`)
+)
+
+const (
+	// maxDepth limits how deep page trees and references are followed.
+	maxDepth = 32
+	// maxObjects and maxPages cap what a single file may hold.
+	maxObjects = 1 << 18
+	maxPages   = 5000
+)
+
+// pdfFile is a PDF read without its cross-reference table: objects are found
+// by scanning the body, which also copes with slightly broken files.
+type pdfFile struct {
+	ctx     context.Context
+	objects map[int]any
+	fonts   map[any]*font
+}
+
+func pdfText(ctx context.Context, content []byte) ([]Part, error) {
+	if encryptRef.Match(content) {
+		return nil, ErrEncrypted
+	}
+
+	f := &pdfFile{
+		ctx:     ctx,
+		objects: make(map[int]any),
+		fonts:   make(map[any]*font),
+	}
+	if err := f.scan(content); err != nil {
+		return nil, err
+	}
+	if err := f.unpackObjectStreams(); err != nil {
+		return nil, err
+	}
+
+	root, ok := f.root(content)
+	if !ok {
+		return nil, fmt.Errorf("document catalog is missing: %w", ErrMalformed)
+	}
+
+	tree := &pageTree{f: f, seen: make(map[int]bool)}
+	if err := tree.walk(root["Pages"], nil, 0); err != nil {
+		return nil, err
+	}
+
+	parts := make([]Part, 0, len(tree.pages))
+	for i, p := range tree.pages {
+		raw := f.pageText(p)
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("page %d: %w", i+1, err)
+		}
+		text := clean(hyphenated.ReplaceAllString(raw, "$1$2"))
+		if text != "" {
+			parts = append(parts, Part{Unit: UnitPage, Number: i + 1, Text: text})
+		}
+	}
+
+	return parts, nil
+}
+
+// scan reads every "n g obj" of the body. Later objects replace earlier ones
+// the same way incremental updates do.
+func (f *pdfFile) scan(data []byte) error {
+	pos := 0
+	for pos < len(data) {
+		loc := objStart.FindSubmatchIndex(data[pos:])
+		if loc == nil {
+			return nil
+		}
+		num, _ := strconv.Atoi(string(data[pos+loc[2] : pos+loc[3]]))
+		l := &lexer{data: data, pos: pos + loc[1]}
+
+		v, ok := l.object(0)
+		if !ok {
+			pos = l.pos
+			continue
+		}
+		if d, isDict := v.(dict); isDict {
+			save := l.pos
+			if tok, ok := l.token(); ok && tok == keyword("stream") {
+				length, isInt := d["Length"].(int)
+				if !isInt {
+					length = -1
+				}
+				body, end := streamData(data, l.pos, length)
+				v = &stream{dict: d, data: body}
+				l.pos = end
+			} else {
+				l.pos = save
+			}
+		}
+
+		f.objects[num] = v
+		if len(f.objects) > maxObjects {
+			return fmt.Errorf("more than %d objects: %w", maxObjects, ErrTooComplex)
+		}
+		pos = l.pos
+	}
+
+	return nil
+}
+
+// unpackObjectStreams adds the objects compressed into object streams.
+func (f *pdfFile) unpackObjectStreams() error {
+	var streams []*stream
+	for _, v := range f.objects {
+		if s, ok := v.(*stream); ok && s.dict["Type"] == name("ObjStm") {
+			streams = append(streams, s)
+		}
+	}
+
+	for _, s := range streams {
+		if err := f.ctx.Err(); err != nil {
+			return err
+		}
+		data, err := f.decode(s)
+		if err != nil {
+			continue
+		}
+		n, _ := f.resolve(s.dict["N"]).(int)
+		first, _ := f.resolve(s.dict["First"]).(int)
+		if first <= 0 || first > len(data) {
+			continue
+		}
+
+		header := &lexer{data: data[:first]}
+		for i := 0; i < n; i++ {
+			num, ok1 := header.token()
+			offset, ok2 := header.token()
+			objNum, isInt1 := num.(int)
+			off, isInt2 := offset.(int)
+			if !ok1 || !ok2 || !isInt1 || !isInt2 {
+				break
+			}
+			if _, exists := f.objects[objNum]; exists || first+off >= len(data) {
+				continue
+			}
+			l := &lexer{data: data, pos: first + off}
+			if v, ok := l.object(0); ok {
+				f.objects[objNum] = v
+			}
+			if len(f.objects) > maxObjects {
+				return fmt.Errorf("more than %d objects: %w", maxObjects, ErrTooComplex)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (f *pdfFile) root(data []byte) (dict, bool) {
+	if m := rootRef.FindAllSubmatch(data, -1); len(m) > 0 {
+		num, _ := strconv.Atoi(string(m[len(m)-1][1]))
+		if d, ok := f.resolve(ref{num: num}).(dict); ok {
+			return d, true
+		}
+	}
+
+	for _, v := range f.objects {
+		if d, ok := v.(dict); ok && d["Type"] == name("Catalog") {
+			return d, true
+		}
+	}
+
+	return nil, false
+}
+
+// resolve follows references to the value they point to.
+func (f *pdfFile) resolve(v any) any {
+	for i := 0; i < maxDepth; i++ {
+		r, ok := v.(ref)
+		if !ok {
+			return v
+		}
+		v = f.objects[r.num]
+	}
+
+	return nil
+}
+
+func (f *pdfFile) dict(v any) dict {
+	switch d := f.resolve(v).(type) {
+	case dict:
+		return d
+	case *stream:
+		return d.dict
+	}
+
+	return nil
+}
+
+func (f *pdfFile) decode(s *stream) ([]byte, error) {
+	var filters []any
+	switch v := f.resolve(s.dict["Filter"]).(type) {
+	case name:
+		filters = []any{v}
+	case array:
+		filters = v
+	}
+
+	data := s.data
+	for _, filter := range filters {
+		var r io.Reader
+		switch f.resolve(filter) {
+		case name("FlateDecode"), name("Fl"):
+			zr, err := zlib.NewReader(bytes.NewReader(data))
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrMalformed, err)
+			}
+			r = zr
+		case name("ASCIIHexDecode"), name("AHx"):
+			r = bytes.NewReader((&lexer{data: append([]byte{'<'}, data...)}).hex())
+		case name("ASCII85Decode"), name("A85"):
+			if end := bytes.Index(data, []byte("~>")); end >= 0 {
+				data = data[:end]
+			}
+			r = ascii85.NewDecoder(bytes.NewReader(bytes.TrimPrefix(bytes.TrimSpace(data), []byte("<~"))))
+		default:
+			return nil, fmt.Errorf("filter %v: %w", filter, ErrUnsupported)
+		}
+
+		out, err := io.ReadAll(io.LimitReader(r, maxUnpacked))
+		// Truncated streams are common, what was read is still useful
+		if err != nil && len(out) == 0 {
+			return nil, fmt.Errorf("%w: %w", ErrMalformed, err)
+		}
+		data = out
+	}
+
+	return data, nil
+}
+
+type page struct {
+	dict      dict
+	resources dict
+}
+
+// pageTree collects the leaves of the page tree in order.
+type pageTree struct {
+	f     *pdfFile
+	seen  map[int]bool
+	pages []*page
+}
+
+// walk follows the node and its kids. Resources are inherited from the
+// parent nodes. Every referenced object is followed once, so a tree that
+// points back into itself can't make the walk loop or blow up.
+func (t *pageTree) walk(node any, resources dict, depth int) error {
+	if depth > maxDepth || !t.follow(node) {
+		return nil
+	}
+	d := t.f.dict(node)
+	if d == nil {
+		return nil
+	}
+	if res := t.f.dict(d["Resources"]); res != nil {
+		resources = res
+	}
+
+	if t.follow(d["Kids"]) {
+		if kids, ok := t.f.resolve(d["Kids"]).(array); ok {
+			for _, kid := range kids {
+				if err := t.walk(kid, resources, depth+1); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+	if d["Type"] == name("Page") || d["Contents"] != nil {
+		if len(t.pages) == maxPages {
+			return fmt.Errorf("more than %d pages: %w", maxPages, ErrTooComplex)
+		}
+		t.pages = append(t.pages, &page{dict: d, resources: resources})
+	}
+
+	return t.f.ctx.Err()
+}
+
+// follow reports whether v may be visited, marking references as seen.
+func (t *pageTree) follow(v any) bool {
+	r, ok := v.(ref)
+	if !ok {
+		return true
+	}
+	if t.seen[r.num] {
+		return false
+	}
+	t.seen[r.num] = true
+
+	return true
+}
+
+func (f *pdfFile) pageText(p *page) string {
+	var content []byte
+	switch c := f.resolve(p.dict["Contents"]).(type) {
+	case *stream:
+		content, _ = f.decode(c)
+	case array:
+		for _, part := range c {
+			if f.ctx.Err() != nil {
+				break
+			}
+			if s, ok := f.resolve(part).(*stream); ok {
+				data, err := f.decode(s)
+				if err == nil {
+					content = append(append(content, data...), '\n')
+				}
+			}
+		}
+	}
+
+	fonts := f.dict(p.resources["Font"])
+	t := &textRun{fontOf: func(n name) *font {
+		return f.font(fonts[n])
+	}}
+	t.run(content)
+
+	return t.buf.String()
+}
+
+// font returns the decoder for the font object, cached per object.
+func (f *pdfFile) font(v any) *font {
+	key := v
+	if _, ok := v.(ref); !ok {
+		key = fmt.Sprintf("%p", f.dict(v))
+	}
+	if fnt, ok := f.fonts[key]; ok {
+		return fnt
+	}
+
+	fnt := f.newFont(f.dict(v))
+	f.fonts[key] = fnt
+
+	return fnt
+}
+
+func number(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+// textRun follows the text operators of a content stream.
+type textRun struct {
+	fontOf func(name) *font
+	font   *font
+	buf    strings.Builder
+	lastY  float64
+	hasY   bool
+}
+
+// kernSpace is the TJ offset, in thousandths of em, taken for a word gap.
+// Kerning inside words stays well above it.
+const kernSpace = -180
+
+func (t *textRun) newline() {
+	s := t.buf.String()
+	if s != "" && !strings.HasSuffix(s, "\n") {
+		t.buf.WriteByte('\n')
+	}
+}
+
+func (t *textRun) space() {
+	s := t.buf.String()
+	if s != "" && !strings.HasSuffix(s, " ") && !strings.HasSuffix(s, "\n") {
+		t.buf.WriteByte(' ')
+	}
+}
+
+func (t *textRun) show(v any) {
+	if s, ok := v.([]byte); ok && t.font != nil {
+		t.buf.WriteString(t.font.decode(s))
+	}
+}
+
+func (t *textRun) run(content []byte) {
+	l := &lexer{data: content}
+	var operands []any
+	for {
+		v, ok := l.object(0)
+		if !ok {
+			return
+		}
+		op, isOp := v.(keyword)
+		if !isOp {
+			operands = append(operands, v)
+			continue
+		}
+
+		last := func(i int) any {
+			if len(operands) < i {
+				return nil
+			}
+			return operands[len(operands)-i]
+		}
+
+		switch op {
+		case "BI":
+			l.pos = skipInlineImage(content, l.pos)
+		case "Tf":
+			if n, ok := last(2).(name); ok {
+				t.font = t.fontOf(n)
+			}
+		case "Tj":
+			t.show(last(1))
+		case "'", "\"":
+			t.newline()
+			t.show(last(1))
+		case "TJ":
+			arr, _ := last(1).(array)
+			for _, item := range arr {
+				if n, ok := number(item); ok {
+					if n <= kernSpace {
+						t.space()
+					}
+					continue
+				}
+				t.show(item)
+			}
+		case "Td", "TD":
+			// A move along the line mostly starts the next word in another font
+			tx, _ := number(last(2))
+			if ty, ok := number(last(1)); ok && ty != 0 {
+				t.newline()
+			} else if tx > 0 {
+				t.space()
+			}
+		case "T*":
+			t.newline()
+		case "Tm":
+			if y, ok := number(last(1)); ok {
+				if t.hasY && y != t.lastY {
+					t.newline()
+				}
+				t.lastY, t.hasY = y, true
+			}
+		}
+		operands = operands[:0]
+	}
+}
+
+// skipInlineImage returns the position after the "EI" that ends the image data.
+func skipInlineImage(data []byte, pos int) int {
+	for {
+		i := bytes.Index(data[pos:], []byte("EI"))
+		if i < 0 {
+			return len(data)
+		}
+		end := pos + i + 2
+		if pos+i > 0 && isSpace(data[pos+i-1]) && (end == len(data) || isSpace(data[end])) {
+			return end
+		}
+		pos = end
+	}
+}
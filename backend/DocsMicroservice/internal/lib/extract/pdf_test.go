@@ -0,0 +1,244 @@
+package extract
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// pdfDoc builds a PDF body of the objects numbered from 1, leaving out the
+// empty ones. The cross-reference table is left out too, the reader doesn't
+// need it.
+func pdfDoc(objects ...string) []byte {
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.4\n")
+	for i, obj := range objects {
+		if obj == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	b.WriteString("trailer\n<< /Root 1 0 R >>\n%%EOF\n")
+
+	return b.Bytes()
+}
+
+func pdfStream(dict, data string) string {
+	return fmt.Sprintf("<< %s /Length %d >>\nstream\n%s\nendstream", dict, len(data), data)
+}
+
+func deflate(data string) string {
+	var b bytes.Buffer
+	zw := zlib.NewWriter(&b)
+	zw.Write([]byte(data))
+	zw.Close()
+
+	return b.String()
+}
+
+// simplePDF has a page per content stream, all set in Helvetica.
+func simplePDF(contents ...string) []byte {
+	kids := make([]string, len(contents))
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+	}
+	for i, c := range contents {
+		num := len(objects) + 1
+		kids[i] = fmt.Sprintf("%d 0 R", num)
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /Contents %d 0 R >>", num+1),
+			pdfStream("", c),
+		)
+	}
+	objects[1] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d /Resources << /Font << /F1 3 0 R >> >> >>",
+		strings.Join(kids, " "), len(contents))
+
+	return pdfDoc(objects...)
+}
+
+func TestPDFText(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"shown string", "BT /F1 12 Tf (Hello world) Tj ET", "Hello world"},
+		{"kerning and word gaps", "BT /F1 12 Tf [(Hel) -20 (lo) -250 (world)] TJ ET", "Hello world"},
+		{"next line", "BT /F1 12 Tf (first) Tj 0 -14 Td (second) Tj T* (third) Tj ET", "first\nsecond\nthird"},
+		{"moved along the line", "BT /F1 12 Tf (one) Tj 30 0 Td (two) Tj ET", "one two"},
+		{"text matrix", "BT /F1 12 Tf 1 0 0 1 0 700 Tm (top) Tj 1 0 0 1 0 680 Tm (bottom) Tj ET", "top\nbottom"},
+		{"hyphenated word", "BT /F1 12 Tf (docu-) Tj T* (ment) Tj ET", "document"},
+		{"escapes", `BT /F1 12 Tf (\(a\) \101) Tj ET`, "(a) A"},
+		{"inline image", "BT /F1 12 Tf (before) Tj ET BI /W 1 /H 1 ID \x00EI) EI BT T* (after) Tj ET", "before\nafter"},
+		{"no font", "BT (lost) Tj ET", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parts, err := Text(context.Background(), simplePDF(tt.content))
+
+			assert.NoError(t, err)
+			if tt.want == "" {
+				assert.Empty(t, parts)
+				return
+			}
+			assert.Equal(t, []Part{{Unit: UnitPage, Number: 1, Text: tt.want}}, parts)
+		})
+	}
+}
+
+func TestPDFTextPages(t *testing.T) {
+	parts, err := Text(context.Background(), simplePDF(
+		"BT /F1 12 Tf (one) Tj ET",
+		"",
+		"BT /F1 12 Tf (three) Tj ET",
+	))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Part{
+		{Unit: UnitPage, Number: 1, Text: "one"},
+		{Unit: UnitPage, Number: 3, Text: "three"},
+	}, parts)
+}
+
+func TestPDFTextCompressed(t *testing.T) {
+	content := "BT /F1 12 Tf (packed) Tj ET"
+	data := pdfDoc(
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Resources << /Font << /F1 5 0 R >> >> /Contents [4 0 R 6 0 R] >>",
+		pdfStream("/Filter /FlateDecode", deflate(content)),
+		"<< /Type /Font /Subtype /Type1 >>",
+		pdfStream("/Filter [/ASCIIHexDecode]", "425420542a2028746578742920546a204554>"),
+	)
+
+	parts, err := Text(context.Background(), data)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Part{{Unit: UnitPage, Number: 1, Text: "packed\ntext"}}, parts)
+}
+
+func TestPDFTextObjectStream(t *testing.T) {
+	// Objects 3 and 4 live in the object stream
+	header := "3 0 4 64 "
+	body := fmt.Sprintf("%-64s", "<< /Type /Page /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>") +
+		"<< /Type /Font /Subtype /Type1 >>"
+	data := pdfDoc(
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"",
+		"",
+		pdfStream("", "BT /F1 12 Tf (inside) Tj ET"),
+		pdfStream(fmt.Sprintf("/Type /ObjStm /N 2 /First %d /Filter /FlateDecode", len(header)), deflate(header+body)),
+	)
+
+	parts, err := Text(context.Background(), data)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Part{{Unit: UnitPage, Number: 1, Text: "inside"}}, parts)
+}
+
+func TestPDFTextCyclicPageTree(t *testing.T) {
+	tests := []struct {
+		name  string
+		pages string
+	}{
+		{"node is its own kid", "<< /Type /Pages /Kids [2 0 R 3 0 R] >>"},
+		// Each level doubles the walk unless nodes are seen once
+		{"kids repeat the node", "<< /Type /Pages /Kids [2 0 R 2 0 R 2 0 R 3 0 R] >>"},
+		{"kids array refers back", "<< /Type /Pages /Kids 5 0 R >>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := pdfDoc(
+				"<< /Type /Catalog /Pages 2 0 R >>",
+				tt.pages,
+				"<< /Type /Page /Resources << /Font << /F1 4 0 R >> >> /Contents 6 0 R >>",
+				"<< /Type /Font /Subtype /Type1 >>",
+				"[<< /Kids 5 0 R >> << /Kids 5 0 R >> 3 0 R 2 0 R]",
+				pdfStream("", "BT /F1 12 Tf (once) Tj ET"),
+			)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			parts, err := Text(ctx, data)
+
+			assert.NoError(t, err)
+			assert.Equal(t, []Part{{Unit: UnitPage, Number: 1, Text: "once"}}, parts)
+		})
+	}
+}
+
+func TestPDFTextTooManyPages(t *testing.T) {
+	contents := make([]string, maxPages+1)
+
+	_, err := Text(context.Background(), simplePDF(contents...))
+
+	assert.ErrorIs(t, err, ErrTooComplex)
+}
+
+func TestPDFTextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := Text(ctx, simplePDF("BT /F1 12 Tf (text) Tj ET"))
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestPDFTextErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		err  error
+	}{
+		{
+			name: "encrypted",
+			data: []byte("%PDF-1.4\ntrailer << /Root 1 0 R /Encrypt 9 0 R >>"),
+			err:  ErrEncrypted,
+		},
+		{
+			name: "no catalog",
+			data: []byte("%PDF-1.4\n1 0 obj << /Type /Pages /Kids [] >> endobj"),
+			err:  ErrMalformed,
+		},
+		{
+			name: "header only",
+			data: []byte("%PDF-1.7"),
+			err:  ErrMalformed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Text(context.Background(), tt.data)
+
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestPDFDecodeErrors(t *testing.T) {
+	f := &pdfFile{ctx: context.Background(), objects: map[int]any{}}
+
+	_, err := f.decode(&stream{dict: dict{"Filter": name("DCTDecode")}, data: []byte{1}})
+	assert.ErrorIs(t, err, ErrUnsupported)
+
+	_, err = f.decode(&stream{dict: dict{"Filter": name("FlateDecode")}, data: []byte("not zlib")})
+	assert.ErrorIs(t, err, ErrMalformed)
+
+	// A truncated stream still gives what was read
+	packed := deflate("truncated text")
+	data, err := f.decode(&stream{dict: dict{"Filter": name("Fl")}, data: []byte(packed[:len(packed)-6])})
+	assert.NoError(t, err)
+	assert.Equal(t, "truncated text", string(data))
+}
@@ -0,0 +1,313 @@
+package extract
+
+import (
+	"bytes"
+	"strconv"
+)
+
+// PDF objects are kept as plain Go values: nil, bool, int, float64,
+// name, []byte for strings, array, dict, ref, *stream and keyword.
+type (
+	name    string
+	keyword string
+	array   []any
+	dict    map[name]any
+	ref     struct{ num, gen int }
+	stream  struct {
+		dict dict
+		data []byte
+	}
+)
+
+const maxNesting = 64
+
+func isSpace(c byte) bool {
+	switch c {
+	case 0, '\t', '\n', '\f', '\r', ' ':
+		return true
+	}
+	return false
+}
+
+func isDelim(c byte) bool {
+	switch c {
+	case '(', ')', '<', '>', '[', ']', '{', '}', '/', '%':
+		return true
+	}
+	return false
+}
+
+// lexer reads PDF tokens. It serves both the file body and content streams.
+type lexer struct {
+	data []byte
+	pos  int
+}
+
+func (l *lexer) skipSpace() {
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		switch {
+		case isSpace(c):
+			l.pos++
+		case c == '%':
+			for l.pos < len(l.data) && l.data[l.pos] != '\n' && l.data[l.pos] != '\r' {
+				l.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// token returns the next token: a value, a keyword or one of the
+// "<<", ">>", "[", "]" delimiters as a keyword. ok is false at the end.
+func (l *lexer) token() (tok any, ok bool) {
+	l.skipSpace()
+	if l.pos >= len(l.data) {
+		return nil, false
+	}
+
+	c := l.data[l.pos]
+	switch {
+	case c == '/':
+		return l.name(), true
+	case c == '(':
+		return l.literal(), true
+	case c == '<':
+		if l.pos+1 < len(l.data) && l.data[l.pos+1] == '<' {
+			l.pos += 2
+			return keyword("<<"), true
+		}
+		return l.hex(), true
+	case c == '>':
+		l.pos++
+		if l.pos < len(l.data) && l.data[l.pos] == '>' {
+			l.pos++
+		}
+		return keyword(">>"), true
+	case c == '[' || c == ']' || c == '{' || c == '}' || c == ')':
+		l.pos++
+		return keyword(l.data[l.pos-1 : l.pos]), true
+	}
+
+	start := l.pos
+	for l.pos < len(l.data) && !isSpace(l.data[l.pos]) && !isDelim(l.data[l.pos]) {
+		l.pos++
+	}
+	word := string(l.data[start:l.pos])
+
+	if n, err := strconv.Atoi(word); err == nil {
+		return n, true
+	}
+	if f, err := strconv.ParseFloat(word, 64); err == nil {
+		return f, true
+	}
+	switch word {
+	case "true":
+		return true, true
+	case "false":
+		return false, true
+	case "null":
+		return nil, true
+	}
+
+	return keyword(word), true
+}
+
+func (l *lexer) name() name {
+	l.pos++
+	var b []byte
+	for l.pos < len(l.data) && !isSpace(l.data[l.pos]) && !isDelim(l.data[l.pos]) {
+		c := l.data[l.pos]
+		if c == '#' && l.pos+2 < len(l.data) {
+			if v, err := strconv.ParseUint(string(l.data[l.pos+1:l.pos+3]), 16, 8); err == nil {
+				b = append(b, byte(v))
+				l.pos += 3
+				continue
+			}
+		}
+		b = append(b, c)
+		l.pos++
+	}
+
+	return name(b)
+}
+
+func (l *lexer) literal() []byte {
+	l.pos++
+	var b []byte
+	depth := 1
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		l.pos++
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return b
+			}
+		case '\\':
+			if l.pos >= len(l.data) {
+				return b
+			}
+			c = l.data[l.pos]
+			l.pos++
+			switch c {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case 'b':
+				c = '\b'
+			case 'f':
+				c = '\f'
+			case '\r':
+				if l.pos < len(l.data) && l.data[l.pos] == '\n' {
+					l.pos++
+				}
+				continue
+			case '\n':
+				continue
+			default:
+				if c >= '0' && c <= '7' {
+					v := int(c - '0')
+					for i := 0; i < 2 && l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '7'; i++ {
+						v = v*8 + int(l.data[l.pos]-'0')
+						l.pos++
+					}
+					c = byte(v)
+				}
+			}
+		}
+		b = append(b, c)
+	}
+
+	return b
+}
+
+func (l *lexer) hex() []byte {
+	l.pos++
+	var b []byte
+	var digits []byte
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		l.pos++
+		if c == '>' {
+			break
+		}
+		if v, ok := hexDigit(c); ok {
+			digits = append(digits, v)
+		}
+	}
+	if len(digits)%2 == 1 {
+		digits = append(digits, 0)
+	}
+	for i := 0; i < len(digits); i += 2 {
+		b = append(b, digits[i]<<4|digits[i+1])
+	}
+
+	return b
+}
+
+func hexDigit(c byte) (byte, bool) {
+	switch {
+	case c >= '0' && c <= '9':
+		return c - '0', true
+	case c >= 'a' && c <= 'f':
+		return c - 'a' + 10, true
+	case c >= 'A' && c <= 'F':
+		return c - 'A' + 10, true
+	}
+	return 0, false
+}
+
+// object reads a whole value, resolving "n g R" into a ref.
+// Keywords other than delimiters are returned as they are.
+func (l *lexer) object(depth int) (any, bool) {
+	tok, ok := l.token()
+	if !ok {
+		return nil, false
+	}
+
+	switch t := tok.(type) {
+	case int:
+		save := l.pos
+		if gen, ok := l.token(); ok {
+			if g, isInt := gen.(int); isInt {
+				if r, ok := l.token(); ok && r == keyword("R") {
+					return ref{t, g}, true
+				}
+			}
+		}
+		l.pos = save
+		return t, true
+	case keyword:
+		if depth > maxNesting {
+			return nil, false
+		}
+		switch t {
+		case "[":
+			var arr array
+			for {
+				save := l.pos
+				if tok, ok := l.token(); !ok || tok == keyword("]") {
+					return arr, true
+				}
+				l.pos = save
+				v, ok := l.object(depth + 1)
+				if !ok {
+					return arr, true
+				}
+				arr = append(arr, v)
+			}
+		case "<<":
+			d := dict{}
+			for {
+				tok, ok := l.token()
+				if !ok || tok == keyword(">>") {
+					return d, true
+				}
+				key, isName := tok.(name)
+				if !isName {
+					continue
+				}
+				v, ok := l.object(depth + 1)
+				if !ok {
+					return d, true
+				}
+				d[key] = v
+			}
+		}
+	}
+
+	return tok, true
+}
+
+// streamData cuts out the stream that follows "stream" at pos.
+func streamData(data []byte, pos, length int) ([]byte, int) {
+	if pos < len(data) && data[pos] == '\r' {
+		pos++
+	}
+	if pos < len(data) && data[pos] == '\n' {
+		pos++
+	}
+
+	if length >= 0 && pos+length <= len(data) {
+		rest := bytes.TrimLeft(data[pos+length:min(pos+length+32, len(data))], "\r\n \t")
+		if bytes.HasPrefix(rest, []byte("endstream")) {
+			return data[pos : pos+length], pos + length
+		}
+	}
+
+	end := bytes.Index(data[pos:], []byte("endstream"))
+	if end < 0 {
+		return data[pos:], len(data)
+	}
+	body := bytes.TrimRight(data[pos:pos+end], "\r\n")
+
+	return body, pos + end
+}
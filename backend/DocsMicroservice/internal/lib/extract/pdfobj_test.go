@@ -0,0 +1,91 @@
+package extract
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexerObject(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  any
+	}{
+		{"integer", "42", 42},
+		{"real", "-.5", -0.5},
+		{"boolean", "true", true},
+		{"null", "null", nil},
+		{"name with escapes", "/A#20B", name("A B")},
+		{"literal with escapes", `(a\(b\)\n\101\\)`, []byte("a(b)\nA\\")},
+		{"nested parentheses", "(a (b) c)", []byte("a (b) c")},
+		{"line continuation", "(a\\\nb)", []byte("ab")},
+		{"hex string", "<48 65 6c6C6f>", []byte("Hello")},
+		{"odd hex string", "<414>", []byte("A@")},
+		{"reference", "12 0 R", ref{12, 0}},
+		{"number then keyword", "12 0 obj", 12},
+		{"array", "[1 (a) /B 2 0 R]", array{1, []byte("a"), name("B"), ref{2, 0}}},
+		{"dict", "<< /Type /Page /Kids [1 0 R] % comment\n /Count 1 >>", dict{
+			"Type":  name("Page"),
+			"Kids":  array{ref{1, 0}},
+			"Count": 1,
+		}},
+		{"unclosed array", "[1 2", array{1, 2}},
+		{"unclosed dict", "<< /A 1", dict{"A": 1}},
+		{"keyword", "BT", keyword("BT")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, ok := (&lexer{data: []byte(tt.input)}).object(0)
+
+			assert.True(t, ok)
+			assert.Equal(t, tt.want, v)
+		})
+	}
+}
+
+func TestLexerObjectNesting(t *testing.T) {
+	deep := strings.Repeat("[", 10000) + strings.Repeat("]", 10000)
+
+	v, ok := (&lexer{data: []byte(deep)}).object(0)
+
+	assert.True(t, ok)
+	depth := 0
+	for arr, isArray := v.(array); isArray && len(arr) > 0; arr, isArray = arr[0].(array) {
+		depth++
+	}
+	assert.LessOrEqual(t, depth, maxNesting+1)
+}
+
+func TestLexerEnd(t *testing.T) {
+	_, ok := (&lexer{data: []byte("  % only a comment")}).object(0)
+
+	assert.False(t, ok)
+}
+
+func TestStreamData(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		length   int
+		want     string
+		wantRest string
+	}{
+		{"length is right", "\r\nabc\nendstream", 3, "abc", "\nendstream"},
+		{"length is wrong", "\nabcdef\r\nendstream", 2, "abcdef", "endstream"},
+		{"length is unknown", "\nabc\nendstream", -1, "abc", "endstream"},
+		{"length is past the end", "\nabc\nendstream", 100, "abc", "endstream"},
+		{"no endstream", "\nabc", 3, "abc", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, end := streamData([]byte(tt.data), 0, tt.length)
+
+			assert.Equal(t, tt.want, string(body))
+			assert.Equal(t, tt.wantRest, tt.data[end:])
+		})
+	}
+}
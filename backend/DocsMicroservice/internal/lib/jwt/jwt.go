@@ -0,0 +1,50 @@
+package jwt
+
+import (
+	"errors"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrTokenExpired = errors.New("token expired")
+	ErrBadToken     = errors.New("bad token")
+)
+
+// ParseActor validates the access token issued by the auth service and
+// returns the user from its claims.
+func ParseActor(token, secret string) (*entities.Actor, error) {
+	jwtToken, err := jwt.Parse(token, func(t *jwt.Token) (interface{}, error) {
+		return []byte(secret), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) || errors.Is(err, jwt.ErrTokenNotValidYet) {
+			return nil, ErrTokenExpired
+		}
+		return nil, ErrBadToken
+	}
+
+	claims, ok := jwtToken.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, ErrBadToken
+	}
+
+	uid, ok := claims["uid"].(float64)
+	if !ok || uid <= 0 {
+		return nil, ErrBadToken
+	}
+	username, _ := claims["username"].(string)
+
+	// Tokens issued before roles were introduced have no role claim
+	role, _ := claims["role"].(string)
+	if role == "" {
+		role = entities.RoleStudent
+	}
+
+	return &entities.Actor{
+		UserID:   int(uid),
+		Username: username,
+		Role:     role,
+	}, nil
+}
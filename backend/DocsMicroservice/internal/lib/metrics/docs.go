@@ -0,0 +1,98 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	docsgrpc "github.com/Homyakadze14/DocsMicroservice/internal/controller"
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Results of the search.
+const (
+	ResultSuccess = "success"
+	ResultError   = "error"
+)
+
+// DocsMetrics count the changes of docs and time the searches.
+type DocsMetrics struct {
+	created  prometheus.Counter
+	updated  prometheus.Counter
+	deleted  prometheus.Counter
+	searches *prometheus.HistogramVec
+}
+
+func NewDocsMetrics(reg prometheus.Registerer) *DocsMetrics {
+	m := &DocsMetrics{
+		created: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "docs_documents_created_total",
+			Help: "Number of documents created.",
+		}),
+		updated: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "docs_documents_updated_total",
+			Help: "Number of documents updated.",
+		}),
+		deleted: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "docs_documents_deleted_total",
+			Help: "Number of documents deleted.",
+		}),
+		searches: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "docs_search_duration_seconds",
+			Help:    "Time taken to search the docs, by result.",
+			Buckets: []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+		}, []string{"result"}),
+	}
+	reg.MustRegister(m.created, m.updated, m.deleted, m.searches)
+
+	return m
+}
+
+type instrumentedDocs struct {
+	docsgrpc.Docs
+	m *DocsMetrics
+}
+
+// InstrumentDocs counts the docs created, updated and deleted through the
+// service and times the searches.
+func InstrumentDocs(docs docsgrpc.Docs, m *DocsMetrics) docsgrpc.Docs {
+	return &instrumentedDocs{Docs: docs, m: m}
+}
+
+func (d *instrumentedDocs) Create(ctx context.Context, doc *entities.Doc, force bool) (int, []*entities.Workload, error) {
+	id, workload, err := d.Docs.Create(ctx, doc, force)
+	if err == nil {
+		d.m.created.Inc()
+	}
+	return id, workload, err
+}
+
+func (d *instrumentedDocs) Update(ctx context.Context, doc *entities.Doc, fields []string) (*entities.Doc, []*entities.Workload, error) {
+	updated, workload, err := d.Docs.Update(ctx, doc, fields)
+	if err == nil {
+		d.m.updated.Inc()
+	}
+	return updated, workload, err
+}
+
+func (d *instrumentedDocs) Delete(ctx context.Context, id int) error {
+	err := d.Docs.Delete(ctx, id)
+	if err == nil {
+		d.m.deleted.Inc()
+	}
+	return err
+}
+
+func (d *instrumentedDocs) Search(ctx context.Context, searchLine string, scope string) ([]*entities.Doc, []*entities.ContentMatch, error) {
+	start := time.Now()
+
+	docs, matches, err := d.Docs.Search(ctx, searchLine, scope)
+
+	res := ResultSuccess
+	if err != nil {
+		res = ResultError
+	}
+	d.m.searches.WithLabelValues(res).Observe(time.Since(start).Seconds())
+
+	return docs, matches, err
+}
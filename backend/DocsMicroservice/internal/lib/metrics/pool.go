@@ -0,0 +1,90 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PoolCollector reports the connection statistics of the pgx pool.
+type PoolCollector struct {
+	pool *pgxpool.Pool
+
+	acquired        *prometheus.Desc
+	idle            *prometheus.Desc
+	total           *prometheus.Desc
+	max             *prometheus.Desc
+	acquires        *prometheus.Desc
+	acquireDuration *prometheus.Desc
+	emptyAcquires   *prometheus.Desc
+	canceled        *prometheus.Desc
+}
+
+func NewPoolCollector(pool *pgxpool.Pool) *PoolCollector {
+	return &PoolCollector{
+		pool: pool,
+		acquired: prometheus.NewDesc(
+			"pgxpool_acquired_conns",
+			"Number of connections currently in use.",
+			nil, nil,
+		),
+		idle: prometheus.NewDesc(
+			"pgxpool_idle_conns",
+			"Number of idle connections in the pool.",
+			nil, nil,
+		),
+		total: prometheus.NewDesc(
+			"pgxpool_total_conns",
+			"Number of connections in the pool, including the ones being opened.",
+			nil, nil,
+		),
+		max: prometheus.NewDesc(
+			"pgxpool_max_conns",
+			"Maximum size of the pool.",
+			nil, nil,
+		),
+		acquires: prometheus.NewDesc(
+			"pgxpool_acquire_total",
+			"Number of successful connection acquires.",
+			nil, nil,
+		),
+		acquireDuration: prometheus.NewDesc(
+			"pgxpool_acquire_duration_seconds_total",
+			"Total time spent acquiring connections.",
+			nil, nil,
+		),
+		emptyAcquires: prometheus.NewDesc(
+			"pgxpool_empty_acquire_total",
+			"Number of acquires that had to wait for a connection.",
+			nil, nil,
+		),
+		canceled: prometheus.NewDesc(
+			"pgxpool_canceled_acquire_total",
+			"Number of acquires canceled by their context.",
+			nil, nil,
+		),
+	}
+}
+
+func (c *PoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.acquired
+	ch <- c.idle
+	ch <- c.total
+	ch <- c.max
+	ch <- c.acquires
+	ch <- c.acquireDuration
+	ch <- c.emptyAcquires
+	ch <- c.canceled
+}
+
+func (c *PoolCollector) Collect(ch chan<- prometheus.Metric) {
+	s := c.pool.Stat()
+
+	ch <- prometheus.MustNewConstMetric(c.acquired, prometheus.GaugeValue, float64(s.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idle, prometheus.GaugeValue, float64(s.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.total, prometheus.GaugeValue, float64(s.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.max, prometheus.GaugeValue, float64(s.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquires, prometheus.CounterValue, float64(s.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquireDuration, prometheus.CounterValue, s.AcquireDuration().Seconds())
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquires, prometheus.CounterValue, float64(s.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.canceled, prometheus.CounterValue, float64(s.CanceledAcquireCount()))
+}
@@ -0,0 +1,111 @@
+package pdf
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// Font is a TrueType font embedded into every document that uses it.
+// It is safe for concurrent use.
+type Font struct {
+	sfnt       *sfnt.Font
+	raw        []byte
+	name       string
+	unitsPerEm int
+	// Metrics below are in 1/1000 of the font size.
+	bbox      [4]int
+	ascent    int
+	descent   int
+	capHeight int
+}
+
+// LoadFont reads a TrueType (.ttf) font. It must cover every character
+// the documents use, e.g. DejaVu Sans for Cyrillic.
+func LoadFont(path string) (*Font, error) {
+	const op = "pdf.LoadFont"
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	f, err := ParseFont(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return f, nil
+}
+
+func ParseFont(raw []byte) (*Font, error) {
+	const op = "pdf.ParseFont"
+
+	sf, err := sfnt.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	f := &Font{
+		sfnt:       sf,
+		raw:        raw,
+		unitsPerEm: int(sf.UnitsPerEm()),
+	}
+
+	var buf sfnt.Buffer
+	ppem := fixed.I(f.unitsPerEm)
+
+	name, err := sf.Name(&buf, sfnt.NameIDPostScript)
+	if err != nil || name == "" {
+		name = "EmbeddedFont"
+	}
+	f.name = strings.ReplaceAll(name, " ", "")
+
+	// sfnt's y axis points down, PDF's points up.
+	bounds, err := sf.Bounds(&buf, ppem, font.HintingNone)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	f.bbox = [4]int{
+		f.scale(bounds.Min.X), f.scale(-bounds.Max.Y),
+		f.scale(bounds.Max.X), f.scale(-bounds.Min.Y),
+	}
+
+	metrics, err := sf.Metrics(&buf, ppem, font.HintingNone)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	f.ascent = f.scale(metrics.Ascent)
+	f.descent = -f.scale(metrics.Descent)
+	f.capHeight = f.scale(metrics.CapHeight)
+	if f.capHeight == 0 {
+		f.capHeight = f.ascent
+	}
+
+	return f, nil
+}
+
+// scale converts font units read at ppem=unitsPerEm to 1/1000 of the font size.
+func (f *Font) scale(v fixed.Int26_6) int {
+	return int(int64(v) * 1000 / int64(fixed.I(f.unitsPerEm)))
+}
+
+// glyph returns the glyph of r and its advance in 1/1000 of the font size.
+// Characters missing in the font map to .notdef.
+func (f *Font) glyph(buf *sfnt.Buffer, r rune) (sfnt.GlyphIndex, int, error) {
+	gid, err := f.sfnt.GlyphIndex(buf, r)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	adv, err := f.sfnt.GlyphAdvance(buf, gid, fixed.I(f.unitsPerEm), font.HintingNone)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return gid, f.scale(adv), nil
+}
@@ -0,0 +1,338 @@
+// Package pdf writes simple text documents: A4 pages with wrapped
+// paragraphs in one embedded TrueType font.
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf16"
+
+	"golang.org/x/image/font/sfnt"
+)
+
+// A4 in points.
+const (
+	pageWidth  = 595.28
+	pageHeight = 841.89
+	margin     = 56.0
+
+	lineSpacing = 1.4
+)
+
+type Align int
+
+const (
+	AlignLeft Align = iota
+	AlignCenter
+)
+
+type glyph struct {
+	r     rune
+	width int
+}
+
+// Document lays out text top to bottom and starts new pages when needed.
+// It is not safe for concurrent use.
+type Document struct {
+	font  *Font
+	buf   sfnt.Buffer
+	used  map[sfnt.GlyphIndex]glyph
+	pages []*bytes.Buffer
+	y     float64
+	err   error
+}
+
+func New(f *Font) *Document {
+	d := &Document{
+		font: f,
+		used: make(map[sfnt.GlyphIndex]glyph),
+	}
+	d.newPage()
+
+	return d
+}
+
+func (d *Document) newPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.y = pageHeight - margin
+}
+
+func (d *Document) page() *bytes.Buffer {
+	return d.pages[len(d.pages)-1]
+}
+
+// Space moves the cursor down by h points.
+func (d *Document) Space(h float64) {
+	d.y -= h
+	if d.y < margin {
+		d.newPage()
+	}
+}
+
+// Text writes the text wrapped to the page width. Newlines start new lines.
+func (d *Document) Text(text string, size float64, align Align) {
+	width := pageWidth - 2*margin
+	lineHeight := size * lineSpacing
+
+	for _, para := range strings.Split(text, "\n") {
+		for _, line := range d.wrap(para, size, width) {
+			if d.y-lineHeight < margin {
+				d.newPage()
+			}
+			d.y -= lineHeight
+
+			x := margin
+			if align == AlignCenter {
+				x += (width - d.width(line, size)) / 2
+			}
+			d.line(line, size, x, d.y)
+		}
+	}
+}
+
+func (d *Document) line(text string, size, x, y float64) {
+	hex := &strings.Builder{}
+	for _, r := range text {
+		gid := d.glyphOf(r)
+		fmt.Fprintf(hex, "%04X", uint16(gid))
+	}
+
+	fmt.Fprintf(d.page(), "BT /F1 %.2f Tf %.2f %.2f Td <%s> Tj ET\n", size, x, y, hex.String())
+}
+
+func (d *Document) glyphOf(r rune) sfnt.GlyphIndex {
+	gid, width, err := d.font.glyph(&d.buf, r)
+	if err != nil {
+		if d.err == nil {
+			d.err = fmt.Errorf("glyph %q: %w", r, err)
+		}
+		return 0
+	}
+	if _, ok := d.used[gid]; !ok {
+		d.used[gid] = glyph{r: r, width: width}
+	}
+
+	return gid
+}
+
+func (d *Document) width(text string, size float64) float64 {
+	total := 0
+	for _, r := range text {
+		gid := d.glyphOf(r)
+		total += d.used[gid].width
+	}
+
+	return float64(total) * size / 1000
+}
+
+// wrap splits the paragraph into lines not wider than width.
+// Words longer than a line are broken between characters.
+func (d *Document) wrap(text string, size, width float64) []string {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return []string{""}
+	}
+
+	var lines []string
+	cur := ""
+	for _, word := range words {
+		candidate := word
+		if cur != "" {
+			candidate = cur + " " + word
+		}
+		if d.width(candidate, size) <= width {
+			cur = candidate
+			continue
+		}
+
+		if cur != "" {
+			lines = append(lines, cur)
+			cur = ""
+		}
+		for d.width(word, size) > width {
+			runes := []rune(word)
+			n := len(runes) - 1
+			for n > 1 && d.width(string(runes[:n]), size) > width {
+				n--
+			}
+			lines = append(lines, string(runes[:n]))
+			word = string(runes[n:])
+		}
+		cur = word
+	}
+
+	return append(lines, cur)
+}
+
+// Encode writes the document to w.
+func (d *Document) Encode(w io.Writer) error {
+	const op = "pdf.Document.Encode"
+
+	if d.err != nil {
+		return fmt.Errorf("%s: %w", op, d.err)
+	}
+
+	out := &writer{buf: &bytes.Buffer{}}
+	out.header()
+
+	const (
+		catalogObj = iota + 1
+		pagesObj
+		fontObj
+		cidFontObj
+		descriptorObj
+		fontFileObj
+		toUnicodeObj
+		firstPageObj
+	)
+
+	kids := make([]string, 0, len(d.pages))
+	for i := range d.pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", firstPageObj+2*i))
+	}
+
+	out.object(catalogObj, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pagesObj))
+	out.object(pagesObj, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+
+	f := d.font
+	out.object(fontObj, fmt.Sprintf(
+		"<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /Identity-H /DescendantFonts [%d 0 R] /ToUnicode %d 0 R >>",
+		f.name, cidFontObj, toUnicodeObj))
+	out.object(cidFontObj, fmt.Sprintf(
+		"<< /Type /Font /Subtype /CIDFontType2 /BaseFont /%s "+
+			"/CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> "+
+			"/FontDescriptor %d 0 R /CIDToGIDMap /Identity /DW 1000 /W [%s] >>",
+		f.name, descriptorObj, d.widths()))
+	out.object(descriptorObj, fmt.Sprintf(
+		"<< /Type /FontDescriptor /FontName /%s /Flags 32 /FontBBox [%d %d %d %d] /ItalicAngle 0 "+
+			"/Ascent %d /Descent %d /CapHeight %d /StemV 80 /FontFile2 %d 0 R >>",
+		f.name, f.bbox[0], f.bbox[1], f.bbox[2], f.bbox[3], f.ascent, f.descent, f.capHeight, fontFileObj))
+	if err := out.stream(fontFileObj, fmt.Sprintf("/Length1 %d", len(f.raw)), f.raw); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := out.stream(toUnicodeObj, "", d.toUnicode()); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for i, content := range d.pages {
+		pageObj := firstPageObj + 2*i
+		out.object(pageObj, fmt.Sprintf(
+			"<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 %d 0 R >> >> /Contents %d 0 R >>",
+			pagesObj, pageWidth, pageHeight, fontObj, pageObj+1))
+		if err := out.stream(pageObj+1, "", content.Bytes()); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	out.trailer(catalogObj)
+
+	if _, err := w.Write(out.buf.Bytes()); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (d *Document) sortedGlyphs() []sfnt.GlyphIndex {
+	gids := make([]sfnt.GlyphIndex, 0, len(d.used))
+	for gid := range d.used {
+		gids = append(gids, gid)
+	}
+	sort.Slice(gids, func(i, j int) bool { return gids[i] < gids[j] })
+
+	return gids
+}
+
+func (d *Document) widths() string {
+	b := &strings.Builder{}
+	for _, gid := range d.sortedGlyphs() {
+		fmt.Fprintf(b, "%d [%d] ", gid, d.used[gid].width)
+	}
+
+	return strings.TrimSpace(b.String())
+}
+
+// toUnicode maps glyphs back to characters so the text can be searched and copied.
+func (d *Document) toUnicode() []byte {
+	b := &bytes.Buffer{}
+	b.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n" +
+		"/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n" +
+		"/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n" +
+		"1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n")
+
+	gids := d.sortedGlyphs()
+	// A bfchar block holds at most 100 entries.
+	for start := 0; start < len(gids); start += 100 {
+		end := min(start+100, len(gids))
+		fmt.Fprintf(b, "%d beginbfchar\n", end-start)
+		for _, gid := range gids[start:end] {
+			fmt.Fprintf(b, "<%04X> <", uint16(gid))
+			for _, u := range utf16.Encode([]rune{d.used[gid].r}) {
+				fmt.Fprintf(b, "%04X", u)
+			}
+			b.WriteString(">\n")
+		}
+		b.WriteString("endbfchar\n")
+	}
+
+	b.WriteString("endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend\n")
+
+	return b.Bytes()
+}
+
+// writer keeps object offsets for the cross-reference table.
+type writer struct {
+	buf     *bytes.Buffer
+	offsets []int
+}
+
+func (w *writer) header() {
+	// The binary comment tells transfer tools the file is not text.
+	w.buf.WriteString("%PDF-1.7\n%\xe2\xe3\xcf\xd3\n")
+}
+
+func (w *writer) begin(num int) {
+	for len(w.offsets) < num {
+		w.offsets = append(w.offsets, 0)
+	}
+	w.offsets[num-1] = w.buf.Len()
+	fmt.Fprintf(w.buf, "%d 0 obj\n", num)
+}
+
+func (w *writer) object(num int, body string) {
+	w.begin(num)
+	w.buf.WriteString(body)
+	w.buf.WriteString("\nendobj\n")
+}
+
+// stream writes a flate-compressed stream object. extra goes into its dictionary.
+func (w *writer) stream(num int, extra string, data []byte) error {
+	compressed := &bytes.Buffer{}
+	zw := zlib.NewWriter(compressed)
+	if _, err := zw.Write(data); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+
+	w.begin(num)
+	fmt.Fprintf(w.buf, "<< /Length %d /Filter /FlateDecode %s >>\nstream\n", compressed.Len(), extra)
+	w.buf.Write(compressed.Bytes())
+	w.buf.WriteString("\nendstream\nendobj\n")
+
+	return nil
+}
+
+func (w *writer) trailer(root int) {
+	xref := w.buf.Len()
+	fmt.Fprintf(w.buf, "xref\n0 %d\n0000000000 65535 f \n", len(w.offsets)+1)
+	for _, off := range w.offsets {
+		fmt.Fprintf(w.buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(w.buf, "trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(w.offsets)+1, root, xref)
+}
@@ -0,0 +1,81 @@
+// Package similarity compares document themes by their normalized words.
+package similarity
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// stopWords carry no meaning in a theme.
+var stopWords = map[string]struct{}{
+	"и": {}, "в": {}, "во": {}, "на": {}, "с": {}, "со": {}, "по": {}, "для": {}, "к": {}, "о": {}, "об": {},
+	"от": {}, "из": {}, "при": {}, "за": {}, "под": {}, "над": {}, "как": {}, "а": {}, "или": {}, "их": {},
+	"the": {}, "of": {}, "and": {}, "for": {}, "in": {}, "on": {}, "a": {}, "an": {}, "to": {}, "with": {},
+}
+
+// endings are cut off Russian words so different forms of one word match.
+// Longer endings go first.
+var endings = []string{
+	"иями", "ями", "ами", "ого", "его", "ому", "ему", "ыми", "ими", "ых", "их",
+	"ой", "ей", "ий", "ый", "ая", "яя", "ое", "ее", "ые", "ие", "ов", "ев",
+	"ах", "ях", "ам", "ям", "ом", "ем", "ию", "ия", "ии",
+	"а", "я", "о", "е", "ы", "и", "у", "ю", "ь",
+}
+
+// minStem keeps short words from being cut down to nothing.
+const minStem = 3
+
+func stem(word string) string {
+	for _, end := range endings {
+		if strings.HasSuffix(word, end) && utf8.RuneCountInString(word)-utf8.RuneCountInString(end) >= minStem {
+			return strings.TrimSuffix(word, end)
+		}
+	}
+
+	return word
+}
+
+// Tokens returns the sorted unique stems of the theme's meaningful words.
+// Case, "ё", punctuation and hyphens are ignored.
+func Tokens(theme string) []string {
+	theme = strings.ReplaceAll(strings.ToLower(theme), "ё", "е")
+	words := strings.FieldsFunc(theme, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	tokens := make([]string, 0, len(words))
+	for _, w := range words {
+		if _, ok := stopWords[w]; ok {
+			continue
+		}
+		tokens = append(tokens, stem(w))
+	}
+	slices.Sort(tokens)
+
+	return slices.Compact(tokens)
+}
+
+// Jaccard is the share of tokens two sorted token sets have in common.
+func Jaccard(a, b []string) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 0
+	}
+
+	common := 0
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] == b[j]:
+			common++
+			i++
+			j++
+		case a[i] < b[j]:
+			i++
+		default:
+			j++
+		}
+	}
+
+	return float64(common) / float64(len(a)+len(b)-common)
+}
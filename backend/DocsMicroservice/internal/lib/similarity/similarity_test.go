@@ -0,0 +1,74 @@
+package similarity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokens(t *testing.T) {
+	tests := []struct {
+		name  string
+		theme string
+		want  []string
+	}{
+		{
+			name:  "word forms share a stem",
+			theme: "Разработка мобильного приложения для магазина",
+			want:  []string{"магазин", "мобильн", "приложен", "разработк"},
+		},
+		{
+			name:  "case, ё and punctuation are ignored",
+			theme: "УЧЁТ заявок: учет, Заявок!",
+			want:  []string{"заявок", "учет"},
+		},
+		{
+			name:  "hyphens split words and english stop words are dropped",
+			theme: "the Design of a web-service",
+			want:  []string{"design", "service", "web"},
+		},
+		{
+			name:  "short words are not stemmed",
+			theme: "Анализ данных о продажах",
+			want:  []string{"анализ", "данн", "продаж"},
+		},
+		{
+			name:  "only stop words",
+			theme: "и в на",
+			want:  []string{},
+		},
+		{
+			name:  "blank",
+			theme: "  ",
+			want:  []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Tokens(tt.theme))
+		})
+	}
+}
+
+func TestJaccard(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []string
+		want float64
+	}{
+		{"same", []string{"a", "b"}, []string{"a", "b"}, 1},
+		{"disjoint", []string{"a", "b"}, []string{"c", "d"}, 0},
+		{"half", []string{"a", "b", "c"}, []string{"b", "c", "d"}, 0.5},
+		{"one empty", []string{"a"}, []string{}, 0},
+		{"both empty", []string{}, []string{}, 0},
+		{"subset", []string{"a", "b", "c", "d"}, []string{"b", "d"}, 0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, Jaccard(tt.a, tt.b), 1e-9)
+			assert.InDelta(t, tt.want, Jaccard(tt.b, tt.a), 1e-9)
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+	"github.com/Homyakadze14/DocsMicroservice/internal/services"
+	"github.com/Homyakadze14/DocsMicroservice/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+type AssessmentRepository struct {
+	*postgres.Postgres
+}
+
+func NewAssessmentRepository(pg *postgres.Postgres) *AssessmentRepository {
+	return &AssessmentRepository{pg}
+}
+
+// SaveReview creates the review or replaces the previous one, file included.
+func (r *AssessmentRepository) SaveReview(ctx context.Context, review *entities.Review, file []byte) error {
+	const op = "repositories.AssessmentRepository.SaveReview"
+
+	row := r.Pool.QueryRow(
+		ctx,
+		`INSERT INTO doc_review(doc_id, author_id, author_name, grade, text, file_name, file_type, file, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $9)
+		ON CONFLICT (doc_id) DO UPDATE SET author_id=EXCLUDED.author_id, author_name=EXCLUDED.author_name,
+		grade=EXCLUDED.grade, text=EXCLUDED.text, file_name=EXCLUDED.file_name, file_type=EXCLUDED.file_type,
+		file=EXCLUDED.file, updated_at=EXCLUDED.updated_at
+		RETURNING created_at`,
+		review.DocID, review.AuthorID, review.AuthorName, review.Grade, review.Text,
+		review.FileName, review.FileType, file, review.UpdatedAt)
+
+	err := row.Scan(&review.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *AssessmentRepository) GetReview(ctx context.Context, docID int) (*entities.Review, error) {
+	const op = "repositories.AssessmentRepository.GetReview"
+
+	review := &entities.Review{}
+	err := r.Pool.QueryRow(
+		ctx,
+		`SELECT doc_id, author_id, author_name, grade, text, file_name, file_type, COALESCE(octet_length(file), 0),
+		created_at, updated_at FROM doc_review WHERE doc_id=$1`,
+		docID).Scan(&review.DocID, &review.AuthorID, &review.AuthorName, &review.Grade, &review.Text,
+		&review.FileName, &review.FileType, &review.FileSize, &review.CreatedAt, &review.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, services.ErrReviewNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return review, nil
+}
+
+func (r *AssessmentRepository) GetReviewFile(ctx context.Context, docID int) (*entities.File, error) {
+	const op = "repositories.AssessmentRepository.GetReviewFile"
+
+	file := &entities.File{}
+	err := r.Pool.QueryRow(
+		ctx,
+		"SELECT file_name, file_type, file FROM doc_review WHERE doc_id=$1",
+		docID).Scan(&file.Name, &file.Type, &file.Content)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, services.ErrReviewNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if file.Content == nil {
+		return nil, services.ErrReviewFileNotFound
+	}
+
+	return file, nil
+}
+
+func (r *AssessmentRepository) SaveFeedback(ctx context.Context, feedback *entities.Feedback) error {
+	const op = "repositories.AssessmentRepository.SaveFeedback"
+
+	row := r.Pool.QueryRow(
+		ctx,
+		`INSERT INTO doc_feedback(doc_id, author_id, author_name, text, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $5)
+		ON CONFLICT (doc_id) DO UPDATE SET author_id=EXCLUDED.author_id, author_name=EXCLUDED.author_name,
+		text=EXCLUDED.text, updated_at=EXCLUDED.updated_at
+		RETURNING created_at`,
+		feedback.DocID, feedback.AuthorID, feedback.AuthorName, feedback.Text, feedback.UpdatedAt)
+
+	err := row.Scan(&feedback.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *AssessmentRepository) GetFeedback(ctx context.Context, docID int) (*entities.Feedback, error) {
+	const op = "repositories.AssessmentRepository.GetFeedback"
+
+	feedback := &entities.Feedback{}
+	err := r.Pool.QueryRow(
+		ctx,
+		"SELECT doc_id, author_id, author_name, text, created_at, updated_at FROM doc_feedback WHERE doc_id=$1",
+		docID).Scan(&feedback.DocID, &feedback.AuthorID, &feedback.AuthorName, &feedback.Text,
+		&feedback.CreatedAt, &feedback.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, services.ErrFeedbackNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return feedback, nil
+}
+
+func (r *AssessmentRepository) SaveProtocol(ctx context.Context, protocol *entities.Protocol) error {
+	const op = "repositories.AssessmentRepository.SaveProtocol"
+
+	row := r.Pool.QueryRow(
+		ctx,
+		`INSERT INTO defense_protocol(doc_id, number, grade, session_id, questions, notes, author_id, author_name,
+		created_at, updated_at)
+		VALUES ($1, $2, $3, NULLIF($4, 0), $5, $6, $7, $8, $9, $9)
+		ON CONFLICT (doc_id) DO UPDATE SET number=EXCLUDED.number, grade=EXCLUDED.grade,
+		session_id=EXCLUDED.session_id, questions=EXCLUDED.questions, notes=EXCLUDED.notes,
+		author_id=EXCLUDED.author_id, author_name=EXCLUDED.author_name, updated_at=EXCLUDED.updated_at
+		RETURNING created_at`,
+		protocol.DocID, protocol.Number, protocol.Grade, protocol.SessionID, protocol.Questions, protocol.Notes,
+		protocol.AuthorID, protocol.AuthorName, protocol.UpdatedAt)
+
+	err := row.Scan(&protocol.CreatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "SQLSTATE 23505") {
+			return services.ErrProtocolNumberTaken
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *AssessmentRepository) GetProtocol(ctx context.Context, docID int) (*entities.Protocol, error) {
+	const op = "repositories.AssessmentRepository.GetProtocol"
+
+	protocol := &entities.Protocol{}
+	err := r.Pool.QueryRow(
+		ctx,
+		`SELECT doc_id, number, grade, COALESCE(session_id, 0), questions, notes, author_id, author_name,
+		created_at, updated_at FROM defense_protocol WHERE doc_id=$1`,
+		docID).Scan(&protocol.DocID, &protocol.Number, &protocol.Grade, &protocol.SessionID, &protocol.Questions,
+		&protocol.Notes, &protocol.AuthorID, &protocol.AuthorName, &protocol.CreatedAt, &protocol.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, services.ErrProtocolNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return protocol, nil
+}
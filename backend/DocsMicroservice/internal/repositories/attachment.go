@@ -0,0 +1,154 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+	"github.com/Homyakadze14/DocsMicroservice/internal/services"
+	"github.com/Homyakadze14/DocsMicroservice/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+type AttachmentRepository struct {
+	*postgres.Postgres
+}
+
+func NewAttachmentRepository(pg *postgres.Postgres) *AttachmentRepository {
+	return &AttachmentRepository{pg}
+}
+
+const attachmentColumns = "a.id, a.doc_id, a.name, a.type, octet_length(a.content), a.text_status, a.text_error, " +
+	"(SELECT count(*) FROM doc_attachment_text t WHERE t.attachment_id = a.id), a.author_id, a.author_name, a.created_at"
+
+func getAttachment(op string, row pgx.Row) (*entities.Attachment, error) {
+	a := &entities.Attachment{}
+	err := row.Scan(&a.ID, &a.DocID, &a.Name, &a.Type, &a.Size, &a.TextStatus, &a.TextError,
+		&a.Parts, &a.AuthorID, &a.AuthorName, &a.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, services.ErrAttachmentNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return a, nil
+}
+
+// Create stores the file together with its text, indexed for search.
+func (r *AttachmentRepository) Create(
+	ctx context.Context,
+	a *entities.Attachment,
+	content []byte,
+	parts []*entities.TextPart,
+) error {
+	const op = "repositories.AttachmentRepository.Create"
+
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(
+		ctx,
+		`INSERT INTO doc_attachment(doc_id, name, type, content, text_status, text_error, author_id, author_name, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`,
+		a.DocID, a.Name, a.Type, content, a.TextStatus, a.TextError, a.AuthorID, a.AuthorName, a.CreatedAt).
+		Scan(&a.ID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	batch := &pgx.Batch{}
+	for _, p := range parts {
+		batch.Queue(
+			`INSERT INTO doc_attachment_text(attachment_id, doc_id, unit, number, text, tsv)
+			VALUES ($1, $2, $3, $4, $5, to_tsvector($6::regconfig, $5))`,
+			a.ID, a.DocID, p.Unit, p.Number, p.Text, textSearchConfig)
+	}
+	if batch.Len() > 0 {
+		err = tx.SendBatch(ctx, batch).Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	a.Parts = len(parts)
+
+	return nil
+}
+
+func (r *AttachmentRepository) GetAttachments(ctx context.Context, docID int) ([]*entities.Attachment, error) {
+	const op = "repositories.AttachmentRepository.GetAttachments"
+
+	rows, err := r.Pool.Query(
+		ctx,
+		`SELECT `+attachmentColumns+` FROM doc_attachment a WHERE a.doc_id=$1 ORDER BY a.id`,
+		docID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	attachments := make([]*entities.Attachment, 0)
+	for rows.Next() {
+		a, err := getAttachment(op, rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		attachments = append(attachments, a)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return attachments, nil
+}
+
+func (r *AttachmentRepository) GetAttachment(ctx context.Context, id int) (*entities.Attachment, error) {
+	const op = "repositories.AttachmentRepository.GetAttachment"
+
+	return getAttachment(op, r.Pool.QueryRow(
+		ctx,
+		`SELECT `+attachmentColumns+` FROM doc_attachment a WHERE a.id=$1`,
+		id))
+}
+
+func (r *AttachmentRepository) GetAttachmentFile(ctx context.Context, id int) (*entities.File, error) {
+	const op = "repositories.AttachmentRepository.GetAttachmentFile"
+
+	file := &entities.File{}
+	err := r.Pool.QueryRow(
+		ctx,
+		"SELECT name, type, content FROM doc_attachment WHERE id=$1",
+		id).Scan(&file.Name, &file.Type, &file.Content)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, services.ErrAttachmentNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return file, nil
+}
+
+// Delete removes the attachment with its indexed text.
+func (r *AttachmentRepository) Delete(ctx context.Context, id int) error {
+	const op = "repositories.AttachmentRepository.Delete"
+
+	tag, err := r.Pool.Exec(ctx, "DELETE FROM doc_attachment WHERE id=$1", id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return services.ErrAttachmentNotFound
+	}
+
+	return nil
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+	"github.com/Homyakadze14/DocsMicroservice/internal/lib/similarity"
 	"github.com/Homyakadze14/DocsMicroservice/internal/services"
 	"github.com/Homyakadze14/DocsMicroservice/pkg/postgres"
 	sq "github.com/Masterminds/squirrel"
@@ -21,10 +24,53 @@ func NewDocRepository(pg *postgres.Postgres) *DocRepository {
 	return &DocRepository{pg}
 }
 
+// docOrderColumn is the number of the latest theme approval order of the doc.
+const docOrderColumn = "COALESCE((SELECT o.number FROM doc_order x JOIN orders o ON o.id = x.order_id " +
+	"WHERE x.doc_id = docs.id AND o.type = '" + entities.OrderThemeApproval + "' " +
+	"ORDER BY o.order_date DESC, o.id DESC LIMIT 1), '')"
+
+const docColumns = "id, type, group_name, fio, theme, director, year, " + docOrderColumn + ", reviewer, discipline, " +
+	"version, updated_at, status, COALESCE(grade, 0), defense_date"
+
+// docFieldColumns maps partial update fields to table columns.
+var docFieldColumns = map[string]string{
+	entities.DocFieldType:       "type",
+	entities.DocFieldGroup:      "group_name",
+	entities.DocFieldFIO:        "fio",
+	entities.DocFieldTheme:      "theme",
+	entities.DocFieldDirector:   "director",
+	entities.DocFieldYear:       "year",
+	entities.DocFieldReviewer:   "reviewer",
+	entities.DocFieldDiscipline: "discipline",
+}
+
+func docFieldValue(doc *entities.Doc, field string) any {
+	switch field {
+	case entities.DocFieldType:
+		return strings.ToLower(doc.Type)
+	case entities.DocFieldGroup:
+		return strings.ToLower(doc.Group)
+	case entities.DocFieldFIO:
+		return strings.ToLower(doc.FIO)
+	case entities.DocFieldTheme:
+		return strings.ToLower(doc.Theme)
+	case entities.DocFieldDirector:
+		return strings.ToLower(doc.Director)
+	case entities.DocFieldYear:
+		return doc.Year
+	case entities.DocFieldReviewer:
+		return strings.ToLower(doc.Reviewer)
+	case entities.DocFieldDiscipline:
+		return strings.ToLower(doc.Discipline)
+	}
+	return nil
+}
+
 func getDoc(op string, row pgx.Row) (*entities.Doc, error) {
 	doc := &entities.Doc{}
 	err := row.Scan(&doc.ID, &doc.Type, &doc.Group, &doc.FIO, &doc.Theme,
-		&doc.Director, &doc.Year, &doc.Order, &doc.Reviewer, &doc.Discipline)
+		&doc.Director, &doc.Year, &doc.Order, &doc.Reviewer, &doc.Discipline, &doc.Version, &doc.UpdatedAt,
+		&doc.Status, &doc.Grade, &doc.DefenseDate)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, services.ErrDocNotFound
@@ -35,15 +81,24 @@ func getDoc(op string, row pgx.Row) (*entities.Doc, error) {
 	return doc, nil
 }
 
+// Create adds the doc with its participants. A named order links the doc to
+// the theme approval order of that number, created if there's none.
 func (r *DocRepository) Create(ctx context.Context, doc *entities.Doc) (id int, err error) {
 	const op = "repositories.DocRepository.Create"
 
-	row := r.Pool.QueryRow(
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return -1, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	row := tx.QueryRow(
 		ctx,
-		`INSERT INTO docs(type, group_name, fio, theme, director, year, order_name, reviewer, discipline)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`,
+		`INSERT INTO docs(type, group_name, fio, theme, director, year, reviewer, discipline, updated_at, theme_tokens)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id`,
 		strings.ToLower(doc.Type), strings.ToLower(doc.Group), strings.ToLower(doc.FIO), strings.ToLower(doc.Theme), strings.ToLower(doc.Director),
-		doc.Year, strings.ToLower(doc.Order), strings.ToLower(doc.Reviewer), strings.ToLower(doc.Discipline))
+		doc.Year, strings.ToLower(doc.Reviewer), strings.ToLower(doc.Discipline), time.Now(),
+		similarity.Tokens(doc.Theme))
 
 	err = row.Scan(&id)
 	if err != nil {
@@ -53,43 +108,124 @@ func (r *DocRepository) Create(ctx context.Context, doc *entities.Doc) (id int,
 		return -1, fmt.Errorf("%s: %w", op, err)
 	}
 
+	err = replaceParticipants(ctx, tx, id, doc.Participants)
+	if err != nil {
+		return -1, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if doc.Order != "" {
+		_, err = setThemeOrder(ctx, tx, id, doc.Year, doc.Order)
+		if err != nil {
+			return -1, err
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return -1, fmt.Errorf("%s: %w", op, err)
+	}
+
 	return id, nil
 }
 
-func (r *DocRepository) GetFiltered(ctx context.Context, doc *entities.Doc) ([]*entities.Doc, error) {
-	const op = "repositories.DocRepository.GetFiltered"
-	arraySize := 20
+func (r *DocRepository) GetByID(ctx context.Context, id int) (*entities.Doc, error) {
+	const op = "repositories.DocRepository.GetByID"
 
-	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
-	filter := psql.Select("*").From("docs")
+	row := r.Pool.QueryRow(
+		ctx,
+		`SELECT `+docColumns+` FROM docs WHERE id=$1`,
+		id)
+
+	doc, err := getDoc(op, row)
+	if err != nil {
+		return nil, err
+	}
+
+	err = loadParticipants(ctx, r.Pool, doc)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return doc, nil
+}
+
+// participantLike matches docs having a participant of one of the roles,
+// or of any role when none is given, whose name contains the value.
+func participantLike(value string, roles ...string) sq.Sqlizer {
+	cond := sq.And{
+		sq.Expr("p.doc_id = docs.id"),
+		sq.Like{"p.name": "%" + strings.ToLower(value) + "%"},
+	}
+	if len(roles) > 0 {
+		cond = append(cond, sq.Eq{"p.role": roles})
+	}
+
+	sql, args, _ := cond.ToSql()
+
+	return sq.Expr("EXISTS (SELECT 1 FROM doc_participant p WHERE "+sql+")", args...)
+}
+
+// orderLike matches docs linked to an order whose number contains the value.
+func orderLike(value string) sq.Sqlizer {
+	return sq.Expr(
+		"EXISTS (SELECT 1 FROM doc_order x JOIN orders o ON o.id = x.order_id WHERE x.doc_id = docs.id AND lower(o.number) LIKE ?)",
+		"%"+strings.ToLower(value)+"%")
+}
+
+// filterDocs adds the conditions of the non-empty doc fields to the query.
+// Text fields match by substring, year and status exactly. People match
+// any participant of their role: fio any student, director any supervisor
+// or co-supervisor and reviewer any reviewer. Names of doc.Participants
+// match participants of their role, or of any role when it's empty.
+func filterDocs(query sq.SelectBuilder, doc *entities.Doc) sq.SelectBuilder {
 	if doc.Type != "" {
-		filter = filter.Where(sq.Like{"type": ("%" + strings.ToLower(doc.Type) + "%")})
+		query = query.Where(sq.Like{"type": ("%" + strings.ToLower(doc.Type) + "%")})
 	}
 	if doc.Group != "" {
-		filter = filter.Where(sq.Like{"group_name": ("%" + strings.ToLower(doc.Group) + "%")})
+		query = query.Where(sq.Like{"group_name": ("%" + strings.ToLower(doc.Group) + "%")})
 	}
 	if doc.FIO != "" {
-		filter = filter.Where(sq.Like{"fio": ("%" + strings.ToLower(doc.FIO) + "%")})
+		query = query.Where(participantLike(doc.FIO, entities.ParticipantStudent))
 	}
 	if doc.Theme != "" {
-		filter = filter.Where(sq.Like{"theme": ("%" + strings.ToLower(doc.Theme) + "%")})
+		query = query.Where(sq.Like{"theme": ("%" + strings.ToLower(doc.Theme) + "%")})
 	}
 	if doc.Director != "" {
-		filter = filter.Where(sq.Like{"director": ("%" + strings.ToLower(doc.Director) + "%")})
+		query = query.Where(participantLike(doc.Director, entities.ParticipantSupervisor, entities.ParticipantCoSupervisor))
 	}
 	if doc.Year != 0 {
-		filter = filter.Where(sq.Eq{"year": doc.Year})
+		query = query.Where(sq.Eq{"year": doc.Year})
 	}
 	if doc.Order != "" {
-		filter = filter.Where(sq.Like{"order_name": ("%" + strings.ToLower(doc.Order) + "%")})
+		query = query.Where(orderLike(doc.Order))
 	}
 	if doc.Reviewer != "" {
-		filter = filter.Where(sq.Like{"reviewer": ("%" + strings.ToLower(doc.Reviewer) + "%")})
+		query = query.Where(participantLike(doc.Reviewer, entities.ParticipantReviewer))
 	}
 	if doc.Discipline != "" {
-		filter = filter.Where(sq.Like{"discipline": ("%" + strings.ToLower(doc.Discipline) + "%")})
+		query = query.Where(sq.Like{"discipline": ("%" + strings.ToLower(doc.Discipline) + "%")})
+	}
+	if doc.Status != "" {
+		query = query.Where(sq.Eq{"status": doc.Status})
+	}
+	for _, p := range doc.Participants {
+		if p.Role != "" {
+			query = query.Where(participantLike(p.Name, p.Role))
+		} else {
+			query = query.Where(participantLike(p.Name))
+		}
 	}
 
+	return query
+}
+
+func (r *DocRepository) GetFiltered(ctx context.Context, doc *entities.Doc) ([]*entities.Doc, error) {
+	const op = "repositories.DocRepository.GetFiltered"
+	arraySize := 20
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	filter := filterDocs(psql.Select(docColumns).From("docs"), doc)
+
 	sql, args, err := filter.ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
@@ -109,18 +245,27 @@ func (r *DocRepository) GetFiltered(ctx context.Context, doc *entities.Doc) ([]*
 		docs = append(docs, doc)
 	}
 
+	err = loadParticipants(ctx, r.Pool, docs...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	return docs, nil
 }
 
 func (r *DocRepository) Delete(ctx context.Context, id int) error {
 	const op = "repositories.DocRepository.Delete"
 
-	_, err := r.Pool.Exec(ctx, `DELETE FROM docs WHERE id=$1`, id)
+	tag, err := r.Pool.Exec(ctx, `DELETE FROM docs WHERE id=$1`, id)
 
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	if tag.RowsAffected() == 0 {
+		return services.ErrDocNotFound
+	}
+
 	return nil
 }
 
@@ -130,9 +275,12 @@ func (r *DocRepository) Search(ctx context.Context, search_line string) ([]*enti
 
 	rows, err := r.Pool.Query(
 		ctx,
-		`SELECT * FROM docs WHERE type LIKE $1 OR group_name LIKE $2 OR fio LIKE $3 OR theme LIKE $4 OR director LIKE $5 OR order_name LIKE $6 OR reviewer LIKE $7 OR discipline LIKE $8`,
+		`SELECT `+docColumns+` FROM docs WHERE type LIKE $1 OR group_name LIKE $2 OR fio LIKE $3 OR theme LIKE $4 OR director LIKE $5 OR reviewer LIKE $7 OR discipline LIKE $8
+		OR EXISTS (SELECT 1 FROM doc_participant p WHERE p.doc_id = docs.id AND p.name LIKE $9)
+		OR EXISTS (SELECT 1 FROM doc_order x JOIN orders o ON o.id = x.order_id WHERE x.doc_id = docs.id AND lower(o.number) LIKE $6)`,
 		("%" + strings.ToLower(search_line) + "%"), ("%" + strings.ToLower(search_line) + "%"), ("%" + strings.ToLower(search_line) + "%"), ("%" + strings.ToLower(search_line) + "%"),
-		("%" + strings.ToLower(search_line) + "%"), ("%" + strings.ToLower(search_line) + "%"), ("%" + strings.ToLower(search_line) + "%"), ("%" + strings.ToLower(search_line) + "%"))
+		("%" + strings.ToLower(search_line) + "%"), ("%" + strings.ToLower(search_line) + "%"), ("%" + strings.ToLower(search_line) + "%"), ("%" + strings.ToLower(search_line) + "%"),
+		("%" + strings.ToLower(search_line) + "%"))
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -146,21 +294,261 @@ func (r *DocRepository) Search(ctx context.Context, search_line string) ([]*enti
 		docs = append(docs, doc)
 	}
 
+	err = loadParticipants(ctx, r.Pool, docs...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	return docs, nil
 }
 
-func (r *DocRepository) Update(ctx context.Context, doc *entities.Doc) (id int, err error) {
+// textSearchConfig is the Postgres text search configuration for the
+// attachments text. It stems Russian words and keeps the rest as they are.
+const textSearchConfig = "russian"
+
+// SearchContent finds the pages and paragraphs of attachments matching the
+// query, best ranked first. The query takes the web search syntax:
+// quoted phrases, "or" and "-" to exclude a word.
+func (r *DocRepository) SearchContent(ctx context.Context, query string, limit int) ([]*entities.ContentMatch, error) {
+	const op = "repositories.DocRepository.SearchContent"
+
+	rows, err := r.Pool.Query(
+		ctx,
+		`SELECT t.doc_id, t.attachment_id, a.name, t.unit, t.number,
+		ts_headline($2::regconfig, t.text, q, 'MaxFragments=1, MaxWords=30, MinWords=10')
+		FROM doc_attachment_text t
+		JOIN doc_attachment a ON a.id = t.attachment_id,
+		websearch_to_tsquery($2::regconfig, $1) q
+		WHERE t.tsv @@ q
+		ORDER BY ts_rank(t.tsv, q) DESC, t.doc_id, t.attachment_id, t.number
+		LIMIT $3`,
+		query, textSearchConfig, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	matches := make([]*entities.ContentMatch, 0, limit)
+	for rows.Next() {
+		m := &entities.ContentMatch{}
+		err = rows.Scan(&m.DocID, &m.AttachmentID, &m.FileName, &m.Unit, &m.Number, &m.Snippet)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		matches = append(matches, m)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return matches, nil
+}
+
+// GetByIDs returns the docs in the order of ids. Missing ids are skipped.
+func (r *DocRepository) GetByIDs(ctx context.Context, ids []int) ([]*entities.Doc, error) {
+	const op = "repositories.DocRepository.GetByIDs"
+
+	rows, err := r.Pool.Query(ctx, `SELECT `+docColumns+` FROM docs WHERE id = ANY($1)`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	byID := make(map[int]*entities.Doc, len(ids))
+	for rows.Next() {
+		doc, err := getDoc(op, rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		byID[doc.ID] = doc
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	docs := make([]*entities.Doc, 0, len(byID))
+	for _, id := range ids {
+		if doc, ok := byID[id]; ok {
+			docs = append(docs, doc)
+		}
+	}
+
+	err = loadParticipants(ctx, r.Pool, docs...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return docs, nil
+}
+
+// Update changes only the given fields, and only if nobody has changed the
+// row since doc.Version. The
+// participants field replaces all participants, while the legacy fio,
+// director and reviewer fields replace only the first one of their role.
+// The order field relinks the doc to another theme approval order.
+func (r *DocRepository) Update(ctx context.Context, doc *entities.Doc, fields []string) (*entities.Doc, error) {
 	const op = "repositories.DocRepository.Update"
 
-	_, err = r.Pool.Exec(
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	update := psql.Update("docs").
+		Set("version", sq.Expr("version + 1")).
+		Set("updated_at", time.Now()).
+		Where(sq.Eq{"id": doc.ID, "version": doc.Version}).
+		Suffix("RETURNING " + docColumns)
+	for _, field := range fields {
+		column, ok := docFieldColumns[field]
+		if !ok {
+			continue
+		}
+		update = update.Set(column, docFieldValue(doc, field))
+		if field == entities.DocFieldTheme {
+			update = update.Set("theme_tokens", similarity.Tokens(doc.Theme))
+		}
+	}
+	sql, args, err := update.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	updated, err := getDoc(op, tx.QueryRow(ctx, sql, args...))
+	if err != nil {
+		if strings.Contains(err.Error(), "SQLSTATE 23505") {
+			return nil, services.ErrDocAlreadyExists
+		}
+		if !errors.Is(err, services.ErrDocNotFound) {
+			return nil, err
+		}
+
+		// Tell a missing row from a stale version
+		var exists bool
+		err = r.Pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM docs WHERE id=$1)`, doc.ID).Scan(&exists)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if exists {
+			return nil, services.ErrVersionConflict
+		}
+		return nil, services.ErrDocNotFound
+	}
+
+	if slices.Contains(fields, entities.DocFieldParticipants) {
+		err = replaceParticipants(ctx, tx, doc.ID, doc.Participants)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	} else {
+		for field, role := range entities.LegacyParticipantFields {
+			if !slices.Contains(fields, field) {
+				continue
+			}
+			err = setFirstParticipant(ctx, tx, doc.ID, role, docFieldValue(doc, field).(string))
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+		}
+	}
+
+	if slices.Contains(fields, entities.DocFieldOrder) {
+		updated.Order, err = setThemeOrder(ctx, tx, doc.ID, updated.Year, doc.Order)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = loadParticipants(ctx, tx, updated)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return updated, nil
+}
+
+// FindSimilarThemes returns docs sharing at least the threshold share of theme
+// words with the given theme, most similar first.
+func (r *DocRepository) FindSimilarThemes(
+	ctx context.Context,
+	theme string,
+	threshold float64,
+	limit int,
+) ([]*entities.SimilarTheme, error) {
+	const op = "repositories.DocRepository.FindSimilarThemes"
+
+	tokens := similarity.Tokens(theme)
+	if len(tokens) == 0 {
+		return []*entities.SimilarTheme{}, nil
+	}
+
+	rows, err := r.Pool.Query(
 		ctx,
-		`UPDATE docs SET type=$1, group_name=$2, fio=$3, theme=$4, director=$5, year=$6, order_name=$7, reviewer=$8, discipline=$9 WHERE id=$10`,
-		strings.ToLower(doc.Type), strings.ToLower(doc.Group), strings.ToLower(doc.FIO), strings.ToLower(doc.Theme), strings.ToLower(doc.Director),
-		doc.Year, strings.ToLower(doc.Order), strings.ToLower(doc.Reviewer), strings.ToLower(doc.Discipline), doc.ID)
+		`SELECT id, type, year, fio, theme, score FROM (
+			SELECT id, type, year, fio, theme,
+			(SELECT count(*) FROM (SELECT unnest(theme_tokens) INTERSECT SELECT unnest($1::text[])) i)::float8 /
+			(SELECT count(*) FROM (SELECT unnest(theme_tokens) UNION SELECT unnest($1::text[])) u) AS score
+			FROM docs WHERE theme_tokens && $1::text[]
+		) s WHERE score >= $2 ORDER BY score DESC, id LIMIT $3`,
+		tokens, threshold, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	similar := make([]*entities.SimilarTheme, 0)
+	for rows.Next() {
+		st := &entities.SimilarTheme{}
+		err := rows.Scan(&st.DocID, &st.Type, &st.Year, &st.FIO, &st.Theme, &st.Score)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		similar = append(similar, st)
+	}
+
+	return similar, nil
+}
+
+// BackfillThemeTokens fills theme tokens of docs created before they were introduced.
+func (r *DocRepository) BackfillThemeTokens(ctx context.Context) (int, error) {
+	const op = "repositories.DocRepository.BackfillThemeTokens"
 
+	rows, err := r.Pool.Query(ctx, "SELECT id, theme FROM docs WHERE theme_tokens IS NULL")
 	if err != nil {
-		return -1, fmt.Errorf("%s: %w", op, err)
+		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return id, nil
+	themes := make(map[int]string)
+	for rows.Next() {
+		var id int
+		var theme string
+		if err := rows.Scan(&id, &theme); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+		themes[id] = theme
+	}
+	rows.Close()
+
+	batch := &pgx.Batch{}
+	for id, theme := range themes {
+		batch.Queue("UPDATE docs SET theme_tokens=$1 WHERE id=$2", similarity.Tokens(theme), id)
+	}
+	if batch.Len() == 0 {
+		return 0, nil
+	}
+
+	err = r.Pool.SendBatch(ctx, batch).Close()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return len(themes), nil
 }
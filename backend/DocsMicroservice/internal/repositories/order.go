@@ -0,0 +1,305 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+	"github.com/Homyakadze14/DocsMicroservice/internal/services"
+	"github.com/Homyakadze14/DocsMicroservice/pkg/postgres"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+type OrderRepository struct {
+	*postgres.Postgres
+}
+
+func NewOrderRepository(pg *postgres.Postgres) *OrderRepository {
+	return &OrderRepository{pg}
+}
+
+const orderColumns = "o.id, o.number, o.order_date, o.type, o.year, o.title, o.file_name, o.file_type, " +
+	"COALESCE(octet_length(o.file_content), 0), (SELECT count(*) FROM doc_order d WHERE d.order_id = o.id), " +
+	"o.author_id, o.author_name, o.created_at"
+
+func getOrder(op string, row pgx.Row) (*entities.Order, error) {
+	o := &entities.Order{}
+	err := row.Scan(&o.ID, &o.Number, &o.Date, &o.Type, &o.Year, &o.Title, &o.FileName, &o.FileType,
+		&o.FileSize, &o.DocsCount, &o.AuthorID, &o.AuthorName, &o.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, services.ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return o, nil
+}
+
+func (r *OrderRepository) Create(ctx context.Context, o *entities.Order, content []byte) (id int, err error) {
+	const op = "repositories.OrderRepository.Create"
+
+	var file any
+	if len(content) > 0 {
+		file = content
+	}
+
+	row := r.Pool.QueryRow(
+		ctx,
+		`INSERT INTO orders(number, order_date, type, year, title, file_name, file_type, file_content, author_id, author_name, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING id`,
+		o.Number, o.Date, o.Type, o.Year, o.Title, o.FileName, o.FileType, file, o.AuthorID, o.AuthorName, o.CreatedAt)
+
+	err = row.Scan(&id)
+	if err != nil {
+		if strings.Contains(err.Error(), "SQLSTATE 23505") {
+			return -1, services.ErrOrderAlreadyExists
+		}
+		return -1, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+// GetOrder returns the order with its docs.
+func (r *OrderRepository) GetOrder(ctx context.Context, id int) (*entities.Order, error) {
+	const op = "repositories.OrderRepository.GetOrder"
+
+	o, err := getOrder(op, r.Pool.QueryRow(ctx, `SELECT `+orderColumns+` FROM orders o WHERE o.id=$1`, id))
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.Pool.Query(
+		ctx,
+		`SELECT `+docColumns+` FROM docs WHERE id IN (SELECT doc_id FROM doc_order WHERE order_id=$1) ORDER BY id`,
+		id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	o.Docs = make([]*entities.Doc, 0, o.DocsCount)
+	for rows.Next() {
+		doc, err := getDoc(op, rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		o.Docs = append(o.Docs, doc)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	rows.Close()
+
+	err = loadParticipants(ctx, r.Pool, o.Docs...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return o, nil
+}
+
+// GetOrders lists the orders by date. Zero filter fields match everything.
+func (r *OrderRepository) GetOrders(ctx context.Context, year int, orderType string, docID int) ([]*entities.Order, error) {
+	const op = "repositories.OrderRepository.GetOrders"
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query := psql.Select(orderColumns).From("orders o").OrderBy("o.order_date", "o.id")
+	if year != 0 {
+		query = query.Where(sq.Eq{"o.year": year})
+	}
+	if orderType != "" {
+		query = query.Where(sq.Eq{"o.type": orderType})
+	}
+	if docID != 0 {
+		query = query.Where("o.id IN (SELECT order_id FROM doc_order WHERE doc_id=?)", docID)
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := r.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	orders := make([]*entities.Order, 0)
+	for rows.Next() {
+		o, err := getOrder(op, rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		orders = append(orders, o)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return orders, nil
+}
+
+func (r *OrderRepository) GetOrderFile(ctx context.Context, id int) (*entities.File, error) {
+	const op = "repositories.OrderRepository.GetOrderFile"
+
+	file := &entities.File{}
+	err := r.Pool.QueryRow(
+		ctx,
+		"SELECT file_name, file_type, file_content FROM orders WHERE id=$1",
+		id).Scan(&file.Name, &file.Type, &file.Content)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, services.ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(file.Content) == 0 {
+		return nil, services.ErrOrderFileNotFound
+	}
+
+	return file, nil
+}
+
+// AttachDocs links the docs to the order. Docs already linked are skipped,
+// the number of newly linked ones is returned. The linked docs get a new
+// version, as their order is part of them.
+func (r *OrderRepository) AttachDocs(ctx context.Context, orderID int, docIDs []int) (int, error) {
+	const op = "repositories.OrderRepository.AttachDocs"
+
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(
+		ctx,
+		`INSERT INTO doc_order(doc_id, order_id) SELECT unnest($1::int[]), $2 ON CONFLICT DO NOTHING RETURNING doc_id`,
+		docIDs, orderID)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	linked, err := pgx.CollectRows(rows, pgx.RowTo[int])
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = bumpDocVersions(ctx, tx, linked)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return len(linked), nil
+}
+
+// DetachDocs unlinks the docs from the order and returns how many were
+// linked. The unlinked docs get a new version.
+func (r *OrderRepository) DetachDocs(ctx context.Context, orderID int, docIDs []int) (int, error) {
+	const op = "repositories.OrderRepository.DetachDocs"
+
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(
+		ctx,
+		"DELETE FROM doc_order WHERE order_id=$1 AND doc_id = ANY($2) RETURNING doc_id",
+		orderID, docIDs)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	unlinked, err := pgx.CollectRows(rows, pgx.RowTo[int])
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = bumpDocVersions(ctx, tx, unlinked)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return len(unlinked), nil
+}
+
+// bumpDocVersions gives the docs a new version, so clients holding the old
+// one see them changed.
+func bumpDocVersions(ctx context.Context, tx pgx.Tx, docIDs []int) error {
+	if len(docIDs) == 0 {
+		return nil
+	}
+
+	_, err := tx.Exec(
+		ctx,
+		"UPDATE docs SET version = version + 1, updated_at = $2 WHERE id = ANY($1)",
+		docIDs, time.Now())
+	return err
+}
+
+// setThemeOrder links the doc to the latest theme approval order of the year
+// with the number, replacing its previous one, and returns the number as
+// the order has it. An order named for the first time is created, dated
+// today, as docs took free text orders before. An empty number only
+// removes the link.
+func setThemeOrder(ctx context.Context, tx pgx.Tx, docID, year int, number string) (string, error) {
+	const op = "repositories.setThemeOrder"
+
+	_, err := tx.Exec(
+		ctx,
+		"DELETE FROM doc_order x USING orders o WHERE o.id = x.order_id AND x.doc_id=$1 AND o.type=$2",
+		docID, entities.OrderThemeApproval)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	if number == "" {
+		return "", nil
+	}
+
+	number = strings.TrimSpace(number)
+
+	var orderID int
+	err = tx.QueryRow(
+		ctx,
+		`SELECT id, number FROM orders WHERE type=$1 AND year=$2 AND lower(number)=lower($3)
+		ORDER BY order_date DESC, id DESC LIMIT 1`,
+		entities.OrderThemeApproval, year, number).Scan(&orderID, &number)
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = tx.QueryRow(
+			ctx,
+			`INSERT INTO orders(number, order_date, type, year, author_id, author_name, created_at)
+			VALUES ($1, current_date, $2, $3, 0, '', now()) RETURNING id`,
+			number, entities.OrderThemeApproval, year).Scan(&orderID)
+		if err != nil && strings.Contains(err.Error(), "SQLSTATE 23505") {
+			// Another order of this number is dated today
+			return "", services.ErrOrderAlreadyExists
+		}
+	}
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, "INSERT INTO doc_order(doc_id, order_id) VALUES ($1, $2)", docID, orderID)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return number, nil
+}
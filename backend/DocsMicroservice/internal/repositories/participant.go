@@ -0,0 +1,126 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+	"github.com/jackc/pgx/v5"
+)
+
+// querier is either the pool or a transaction.
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// loadParticipants fills the participants of the docs in one query.
+func loadParticipants(ctx context.Context, q querier, docs ...*entities.Doc) error {
+	const op = "repositories.loadParticipants"
+
+	if len(docs) == 0 {
+		return nil
+	}
+
+	byID := make(map[int]*entities.Doc, len(docs))
+	ids := make([]int, 0, len(docs))
+	for _, doc := range docs {
+		doc.Participants = make([]*entities.Participant, 0)
+		byID[doc.ID] = doc
+		ids = append(ids, doc.ID)
+	}
+
+	rows, err := q.Query(
+		ctx,
+		`SELECT doc_id, role, position, name, COALESCE(user_id, 0) FROM doc_participant WHERE doc_id = ANY($1)
+		ORDER BY doc_id, array_position($2::text[], role::text), position`,
+		ids, entities.ParticipantRoles)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var docID int
+		p := &entities.Participant{}
+		if err = rows.Scan(&docID, &p.Role, &p.Position, &p.Name, &p.UserID); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		byID[docID].Participants = append(byID[docID].Participants, p)
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// replaceParticipants sets the participants of the doc to the given ones.
+// A participant without an account is linked to the only account whose
+// username is the participant's name, as migration 14 does for old docs.
+func replaceParticipants(ctx context.Context, tx pgx.Tx, docID int, participants []*entities.Participant) error {
+	const op = "repositories.replaceParticipants"
+
+	_, err := tx.Exec(ctx, "DELETE FROM doc_participant WHERE doc_id=$1", docID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	batch := &pgx.Batch{}
+	for _, p := range participants {
+		batch.Queue(
+			`INSERT INTO doc_participant(doc_id, role, position, name, user_id)
+			VALUES ($1, $2, $3, $4, COALESCE(NULLIF($5, 0), (
+				SELECT min(id) FROM account WHERE lower(username) = $4 HAVING count(*) = 1
+			)))`,
+			docID, p.Role, p.Position, strings.ToLower(p.Name), p.UserID)
+	}
+	if batch.Len() > 0 {
+		err = tx.SendBatch(ctx, batch).Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return nil
+}
+
+// setFirstParticipant replaces the first participant of the role, as the
+// scalar doc fields do. An empty name removes it. The account stays linked
+// while the name doesn't change, a new name is linked as in replaceParticipants.
+func setFirstParticipant(ctx context.Context, tx pgx.Tx, docID int, role, name string) error {
+	const op = "repositories.setFirstParticipant"
+
+	name = strings.ToLower(name)
+
+	var oldName string
+	var userID int
+	err := tx.QueryRow(
+		ctx,
+		`DELETE FROM doc_participant WHERE doc_id=$1 AND role=$2 AND position=1
+		RETURNING name, COALESCE(user_id, 0)`,
+		docID, role).Scan(&oldName, &userID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if name == "" {
+		return nil
+	}
+	if oldName != name {
+		userID = 0
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`INSERT INTO doc_participant(doc_id, role, position, name, user_id)
+		VALUES ($1, $2, 1, $3, COALESCE(NULLIF($4, 0), (
+			SELECT min(id) FROM account WHERE lower(username) = $3 HAVING count(*) = 1
+		)))`,
+		docID, role, name, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+)
+
+// GetUnreviewedDocs returns the docs of the year without a reviewer.
+func (r *DocRepository) GetUnreviewedDocs(ctx context.Context, year int) ([]*entities.AssignedDoc, error) {
+	const op = "repositories.DocRepository.GetUnreviewedDocs"
+
+	rows, err := r.Pool.Query(
+		ctx,
+		`SELECT id, fio, theme, year FROM docs d
+		WHERE year = $1 AND NOT EXISTS (
+			SELECT 1 FROM doc_participant p WHERE p.doc_id = d.id AND p.role = $2
+		) ORDER BY id`,
+		year, entities.ParticipantReviewer)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	docs := make([]*entities.AssignedDoc, 0)
+	for rows.Next() {
+		d := &entities.AssignedDoc{}
+		err = rows.Scan(&d.ID, &d.FIO, &d.Theme, &d.Year)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		docs = append(docs, d)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return docs, nil
+}
+
+// GetReviewerCandidates returns, by doc id, everyone who has reviewed a doc
+// before with the sums of their reviewed and supervised docs. People on the
+// doc itself, reviewers aside, and the supervisors and co-supervisors of its
+// students' other docs are in conflict and left out.
+func (r *DocRepository) GetReviewerCandidates(ctx context.Context, docIDs []int) (map[int][]*entities.ReviewerCandidate, error) {
+	const op = "repositories.DocRepository.GetReviewerCandidates"
+
+	rows, err := r.Pool.Query(
+		ctx,
+		`WITH target AS (
+			SELECT id, year, COALESCE(discipline, '') AS discipline, COALESCE(theme_tokens, '{}') AS tokens
+			FROM docs WHERE id = ANY($1)
+		),
+		candidate AS (
+			SELECT DISTINCT name FROM doc_participant WHERE role = $2
+		),
+		work AS (
+			SELECT p.name, p.role, d.id, d.year, COALESCE(d.discipline, '') AS discipline,
+			COALESCE(d.theme_tokens, '{}') AS tokens
+			FROM doc_participant p JOIN docs d ON d.id = p.doc_id
+			WHERE p.role = $2 OR p.role = ANY($3)
+		),
+		conflict AS (
+			SELECT doc_id, lower(name) AS name FROM doc_participant
+			WHERE doc_id = ANY($1) AND role <> $2
+			UNION
+			SELECT s.doc_id, lower(sup.name) FROM doc_participant s
+			JOIN doc_participant other ON other.role = $4 AND other.doc_id <> s.doc_id AND lower(other.name) = lower(s.name)
+			JOIN doc_participant sup ON sup.doc_id = other.doc_id AND sup.role = ANY($3)
+			WHERE s.doc_id = ANY($1) AND s.role = $4
+		)
+		SELECT t.id, c.name,
+		count(DISTINCT w.id),
+		count(DISTINCT w.id) FILTER (WHERE t.discipline <> '' AND lower(w.discipline) = lower(t.discipline)),
+		COALESCE(max(
+			(SELECT count(*) FROM (SELECT unnest(w.tokens) INTERSECT SELECT unnest(t.tokens)) i)::float8 /
+			NULLIF((SELECT count(*) FROM (SELECT unnest(w.tokens) UNION SELECT unnest(t.tokens)) u), 0)
+		), 0),
+		count(DISTINCT w.id) FILTER (WHERE w.role = $2 AND w.year = t.year)
+		FROM target t
+		CROSS JOIN candidate c
+		LEFT JOIN work w ON w.name = c.name AND w.id <> t.id
+		WHERE NOT EXISTS (SELECT 1 FROM conflict x WHERE x.doc_id = t.id AND x.name = lower(c.name))
+		GROUP BY t.id, c.name
+		ORDER BY t.id, c.name`,
+		docIDs, entities.ParticipantReviewer,
+		[]string{entities.ParticipantSupervisor, entities.ParticipantCoSupervisor}, entities.ParticipantStudent)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	candidates := make(map[int][]*entities.ReviewerCandidate, len(docIDs))
+	for rows.Next() {
+		var docID int
+		c := &entities.ReviewerCandidate{}
+		err = rows.Scan(&docID, &c.Person, &c.Experience, &c.SameDiscipline, &c.ThemeSimilarity, &c.Reviews)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		candidates[docID] = append(candidates[docID], c)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return candidates, nil
+}
@@ -0,0 +1,511 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+	"github.com/Homyakadze14/DocsMicroservice/internal/services"
+	"github.com/Homyakadze14/DocsMicroservice/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+type ScheduleRepository struct {
+	*postgres.Postgres
+}
+
+func NewScheduleRepository(pg *postgres.Postgres) *ScheduleRepository {
+	return &ScheduleRepository{pg}
+}
+
+func (r *ScheduleRepository) CreateCommission(ctx context.Context, c *entities.Commission) (id int, err error) {
+	const op = "repositories.ScheduleRepository.CreateCommission"
+
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return -1, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	row := tx.QueryRow(
+		ctx,
+		"INSERT INTO commission(name, year, created_at) VALUES ($1, $2, $3) RETURNING id",
+		c.Name, c.Year, c.CreatedAt)
+
+	err = row.Scan(&id)
+	if err != nil {
+		return -1, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, m := range c.Members {
+		m.CommissionID = id
+		row := tx.QueryRow(
+			ctx,
+			`INSERT INTO commission_member(commission_id, name, role, user_id)
+			VALUES ($1, $2, $3, NULLIF($4, 0)) RETURNING id`,
+			id, strings.ToLower(m.Name), m.Role, m.UserID)
+
+		err = row.Scan(&m.ID)
+		if err != nil {
+			if strings.Contains(err.Error(), "SQLSTATE 23505") {
+				return -1, services.ErrDuplicateMember
+			}
+			return -1, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return -1, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (r *ScheduleRepository) getMembers(ctx context.Context, commissionIDs []int) (map[int][]*entities.CommissionMember, error) {
+	const op = "repositories.ScheduleRepository.getMembers"
+
+	rows, err := r.Pool.Query(
+		ctx,
+		`SELECT id, commission_id, name, role, COALESCE(user_id, 0) FROM commission_member
+		WHERE commission_id = ANY($1) ORDER BY id`,
+		commissionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	members := make(map[int][]*entities.CommissionMember)
+	for rows.Next() {
+		m := &entities.CommissionMember{}
+		err := rows.Scan(&m.ID, &m.CommissionID, &m.Name, &m.Role, &m.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		members[m.CommissionID] = append(members[m.CommissionID], m)
+	}
+
+	return members, nil
+}
+
+func (r *ScheduleRepository) GetCommission(ctx context.Context, id int) (*entities.Commission, error) {
+	const op = "repositories.ScheduleRepository.GetCommission"
+
+	c := &entities.Commission{}
+	err := r.Pool.QueryRow(
+		ctx,
+		"SELECT id, name, year, created_at FROM commission WHERE id=$1",
+		id).Scan(&c.ID, &c.Name, &c.Year, &c.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, services.ErrCommissionNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	members, err := r.getMembers(ctx, []int{c.ID})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	c.Members = members[c.ID]
+
+	return c, nil
+}
+
+func (r *ScheduleRepository) GetCommissions(ctx context.Context, year int) ([]*entities.Commission, error) {
+	const op = "repositories.ScheduleRepository.GetCommissions"
+
+	rows, err := r.Pool.Query(
+		ctx,
+		"SELECT id, name, year, created_at FROM commission WHERE $1 = 0 OR year = $1 ORDER BY id",
+		year)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	commissions := make([]*entities.Commission, 0)
+	ids := make([]int, 0)
+	for rows.Next() {
+		c := &entities.Commission{}
+		err := rows.Scan(&c.ID, &c.Name, &c.Year, &c.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		commissions = append(commissions, c)
+		ids = append(ids, c.ID)
+	}
+	rows.Close()
+
+	members, err := r.getMembers(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	for _, c := range commissions {
+		c.Members = members[c.ID]
+	}
+
+	return commissions, nil
+}
+
+func (r *ScheduleRepository) CreateSession(ctx context.Context, s *entities.Session) (id int, err error) {
+	const op = "repositories.ScheduleRepository.CreateSession"
+
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return -1, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	row := tx.QueryRow(
+		ctx,
+		`INSERT INTO defense_session(commission_id, room, starts_at, ends_at, slot_minutes)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+		s.CommissionID, s.Room, s.StartsAt, s.EndsAt, s.SlotMinutes)
+
+	err = row.Scan(&id)
+	if err != nil {
+		if strings.Contains(err.Error(), "SQLSTATE 23P01") {
+			return -1, roomTakenErr(s)
+		}
+		return -1, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, slot := range s.Slots {
+		slot.SessionID = id
+		row := tx.QueryRow(
+			ctx,
+			`INSERT INTO defense_slot(session_id, position, starts_at, ends_at)
+			VALUES ($1, $2, $3, $4) RETURNING id`,
+			id, slot.Position, slot.StartsAt, slot.EndsAt)
+
+		err = row.Scan(&slot.ID)
+		if err != nil {
+			return -1, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return -1, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+// roomTakenErr is returned when the room exclusion constraint refuses the
+// session, which happens when another session took the room after the
+// conflicts had been checked.
+func roomTakenErr(s *entities.Session) error {
+	return &services.ConflictError{Conflicts: []*entities.Conflict{{
+		Kind:    entities.ConflictRoom,
+		Subject: s.Room,
+		Description: fmt.Sprintf("room %s is taken by another session from %s to %s",
+			s.Room, s.StartsAt.Format(time.DateTime), s.EndsAt.Format(time.DateTime)),
+	}}}
+}
+
+func getSession(op string, row pgx.Row) (*entities.Session, error) {
+	s := &entities.Session{}
+	err := row.Scan(&s.ID, &s.CommissionID, &s.Room, &s.StartsAt, &s.EndsAt, &s.SlotMinutes)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, services.ErrSessionNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return s, nil
+}
+
+// GetSession returns the session with its commission and slots.
+// Slots with an assigned doc have Doc filled.
+func (r *ScheduleRepository) GetSession(ctx context.Context, id int) (*entities.Session, error) {
+	const op = "repositories.ScheduleRepository.GetSession"
+
+	row := r.Pool.QueryRow(
+		ctx,
+		"SELECT id, commission_id, room, starts_at, ends_at, slot_minutes FROM defense_session WHERE id=$1",
+		id)
+
+	s, err := getSession(op, row)
+	if err != nil {
+		return nil, err
+	}
+
+	s.Commission, err = r.GetCommission(ctx, s.CommissionID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := r.Pool.Query(
+		ctx,
+		`SELECT id, session_id, position, starts_at, ends_at, COALESCE(doc_id, 0)
+		FROM defense_slot WHERE session_id=$1 ORDER BY position`,
+		id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		slot := &entities.Slot{}
+		err := rows.Scan(&slot.ID, &slot.SessionID, &slot.Position, &slot.StartsAt, &slot.EndsAt, &slot.DocID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		s.Slots = append(s.Slots, slot)
+	}
+	rows.Close()
+
+	err = r.loadSlotDocs(ctx, s.Slots)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return s, nil
+}
+
+// loadSlotDocs fills Doc of the assigned slots in one query.
+func (r *ScheduleRepository) loadSlotDocs(ctx context.Context, slots []*entities.Slot) error {
+	const op = "repositories.ScheduleRepository.loadSlotDocs"
+
+	bySlot := make(map[int]*entities.Slot, len(slots))
+	ids := make([]int, 0, len(slots))
+	for _, slot := range slots {
+		if slot.DocID != 0 {
+			bySlot[slot.DocID] = slot
+			ids = append(ids, slot.DocID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	rows, err := r.Pool.Query(ctx, `SELECT `+docColumns+` FROM docs WHERE id = ANY($1)`, ids)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	docs := make([]*entities.Doc, 0, len(ids))
+	for rows.Next() {
+		doc, err := getDoc(op, rows)
+		if err != nil {
+			return err
+		}
+		bySlot[doc.ID].Doc = doc
+		docs = append(docs, doc)
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	rows.Close()
+
+	return loadParticipants(ctx, r.Pool, docs...)
+}
+
+func (r *ScheduleRepository) GetSessions(ctx context.Context, from, to time.Time) ([]*entities.Session, error) {
+	const op = "repositories.ScheduleRepository.GetSessions"
+
+	rows, err := r.Pool.Query(
+		ctx,
+		`SELECT id, commission_id, room, starts_at, ends_at, slot_minutes FROM defense_session
+		WHERE starts_at < $2 AND ends_at > $1 ORDER BY starts_at, id`,
+		from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	sessions := make([]*entities.Session, 0)
+	for rows.Next() {
+		s, err := getSession(op, rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, s)
+	}
+
+	return sessions, nil
+}
+
+func (r *ScheduleRepository) DeleteSession(ctx context.Context, id int) error {
+	const op = "repositories.ScheduleRepository.DeleteSession"
+
+	tag, err := r.Pool.Exec(ctx, "DELETE FROM defense_session WHERE id=$1", id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return services.ErrSessionNotFound
+	}
+
+	return nil
+}
+
+func (r *ScheduleRepository) GetSlot(ctx context.Context, id int) (*entities.Slot, error) {
+	const op = "repositories.ScheduleRepository.GetSlot"
+
+	slot := &entities.Slot{}
+	err := r.Pool.QueryRow(
+		ctx,
+		"SELECT id, session_id, position, starts_at, ends_at, COALESCE(doc_id, 0) FROM defense_slot WHERE id=$1",
+		id).Scan(&slot.ID, &slot.SessionID, &slot.Position, &slot.StartsAt, &slot.EndsAt, &slot.DocID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, services.ErrSlotNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return slot, nil
+}
+
+// GetDocSlot returns the slot the doc is assigned to.
+func (r *ScheduleRepository) GetDocSlot(ctx context.Context, docID int) (*entities.Slot, error) {
+	const op = "repositories.ScheduleRepository.GetDocSlot"
+
+	slot := &entities.Slot{}
+	err := r.Pool.QueryRow(
+		ctx,
+		"SELECT id, session_id, position, starts_at, ends_at, doc_id FROM defense_slot WHERE doc_id=$1",
+		docID).Scan(&slot.ID, &slot.SessionID, &slot.Position, &slot.StartsAt, &slot.EndsAt, &slot.DocID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, services.ErrSlotNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return slot, nil
+}
+
+// AssignSlot puts the doc into the slot. Zero docID frees the slot.
+func (r *ScheduleRepository) AssignSlot(ctx context.Context, slotID, docID int) error {
+	const op = "repositories.ScheduleRepository.AssignSlot"
+
+	tag, err := r.Pool.Exec(
+		ctx,
+		"UPDATE defense_slot SET doc_id=NULLIF($1, 0) WHERE id=$2",
+		docID, slotID)
+	if err != nil {
+		if strings.Contains(err.Error(), "SQLSTATE 23505") {
+			return services.ErrDocAlreadyScheduled
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return services.ErrSlotNotFound
+	}
+
+	return nil
+}
+
+// FindRoomSessions returns sessions in the room overlapping [from, to).
+func (r *ScheduleRepository) FindRoomSessions(ctx context.Context, room string, from, to time.Time) ([]*entities.Session, error) {
+	const op = "repositories.ScheduleRepository.FindRoomSessions"
+
+	rows, err := r.Pool.Query(
+		ctx,
+		`SELECT id, commission_id, room, starts_at, ends_at, slot_minutes FROM defense_session
+		WHERE lower(room) = lower($1) AND starts_at < $3 AND ends_at > $2`,
+		room, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	sessions := make([]*entities.Session, 0)
+	for rows.Next() {
+		s, err := getSession(op, rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, s)
+	}
+
+	return sessions, nil
+}
+
+// FindBusyMembers returns which of the names sit on a commission of a session
+// overlapping [from, to), not counting the excluded session.
+func (r *ScheduleRepository) FindBusyMembers(
+	ctx context.Context,
+	names []string,
+	from, to time.Time,
+	excludeSessionID int,
+) ([]*entities.BusyMember, error) {
+	const op = "repositories.ScheduleRepository.FindBusyMembers"
+
+	rows, err := r.Pool.Query(
+		ctx,
+		`SELECT m.name, s.id, s.room FROM defense_session s
+		JOIN commission_member m ON m.commission_id = s.commission_id
+		WHERE m.name = ANY($1) AND s.starts_at < $3 AND s.ends_at > $2 AND s.id <> $4
+		ORDER BY s.starts_at, m.name`,
+		names, from, to, excludeSessionID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	busy := make([]*entities.BusyMember, 0)
+	for rows.Next() {
+		b := &entities.BusyMember{}
+		err := rows.Scan(&b.Name, &b.SessionID, &b.Room)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		busy = append(busy, b)
+	}
+
+	return busy, nil
+}
+
+// FindSupervisedSlots returns assigned slots overlapping [from, to) whose doc
+// has one of the names as a supervisor, co-supervisor or reviewer. Slot.Doc
+// is filled with the matching participants only.
+func (r *ScheduleRepository) FindSupervisedSlots(ctx context.Context, names []string, from, to time.Time) ([]*entities.Slot, error) {
+	const op = "repositories.ScheduleRepository.FindSupervisedSlots"
+
+	rows, err := r.Pool.Query(
+		ctx,
+		`SELECT sl.id, sl.session_id, sl.position, sl.starts_at, sl.ends_at, sl.doc_id,
+		d.id, d.fio, d.theme, p.role, p.name
+		FROM defense_slot sl
+		JOIN docs d ON d.id = sl.doc_id
+		JOIN doc_participant p ON p.doc_id = d.id
+		WHERE p.role = ANY($4) AND p.name = ANY($1) AND sl.starts_at < $3 AND sl.ends_at > $2
+		ORDER BY sl.starts_at, sl.id, p.role, p.position`,
+		names, from, to, entities.SupervisingRoles)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	slots := make([]*entities.Slot, 0)
+	for rows.Next() {
+		slot := &entities.Slot{Doc: &entities.Doc{}}
+		p := &entities.Participant{}
+		err := rows.Scan(&slot.ID, &slot.SessionID, &slot.Position, &slot.StartsAt, &slot.EndsAt, &slot.DocID,
+			&slot.Doc.ID, &slot.Doc.FIO, &slot.Doc.Theme, &p.Role, &p.Name)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if last := len(slots) - 1; last >= 0 && slots[last].ID == slot.ID {
+			slots[last].Doc.Participants = append(slots[last].Doc.Participants, p)
+			continue
+		}
+		slot.Doc.Participants = []*entities.Participant{p}
+		slots = append(slots, slot)
+	}
+
+	return slots, nil
+}
@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+	sq "github.com/Masterminds/squirrel"
+)
+
+// GetStats counts docs in groups. Totals are summed over all groups by a window
+// function, so they stay right when only the top groups are returned.
+func (r *DocRepository) GetStats(ctx context.Context, q *entities.StatsQuery) (*entities.Stats, error) {
+	const op = "repositories.DocRepository.GetStats"
+
+	columns := make([]string, 0, len(q.GroupBy))
+	for _, field := range q.GroupBy {
+		columns = append(columns, docFieldColumns[field])
+	}
+
+	filter := *q.Filter
+	year := filter.Year
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query := psql.Select()
+	for _, col := range columns {
+		query = query.Column("COALESCE(" + col + "::text, '')")
+	}
+	if q.ComparePreviousYear {
+		filter.Year = 0
+		query = query.
+			Column(sq.Expr("count(*) FILTER (WHERE year = ?)", year)).
+			Column(sq.Expr("count(*) FILTER (WHERE year = ?)", year-1)).
+			Column(sq.Expr("(sum(count(*) FILTER (WHERE year = ?)) OVER ())::bigint", year)).
+			Column(sq.Expr("(sum(count(*) FILTER (WHERE year = ?)) OVER ())::bigint", year-1)).
+			Where(sq.Eq{"year": []int{year, year - 1}})
+	} else {
+		query = query.Columns("count(*)", "0", "(sum(count(*)) OVER ())::bigint", "0")
+	}
+	query = filterDocs(query.From("docs"), &filter).GroupBy(columns...)
+
+	// The largest groups go first for top-N, otherwise groups are sorted by keys
+	if q.Top > 0 {
+		query = query.OrderBy(fmt.Sprintf("%d DESC", len(columns)+1)).Limit(uint64(q.Top))
+	}
+	query = query.OrderBy(columns...)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := r.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	stats := &entities.Stats{
+		GroupBy: q.GroupBy,
+		Buckets: make([]*entities.StatsBucket, 0),
+	}
+	for rows.Next() {
+		b := &entities.StatsBucket{Keys: make([]string, len(columns))}
+		dest := make([]any, 0, len(columns)+4)
+		for i := range b.Keys {
+			dest = append(dest, &b.Keys[i])
+		}
+		dest = append(dest, &b.Count, &b.PreviousCount, &stats.Total, &stats.PreviousTotal)
+
+		err = rows.Scan(dest...)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		stats.Buckets = append(stats.Buckets, b)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return stats, nil
+}
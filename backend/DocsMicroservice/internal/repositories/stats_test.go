@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"testing"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+// The dimensions are put into the query as column names, so every one of
+// them must map to a column.
+func TestStatsDimensionsHaveColumns(t *testing.T) {
+	for _, dim := range entities.StatsDimensions {
+		assert.NotEmpty(t, docFieldColumns[dim], dim)
+	}
+}
@@ -0,0 +1,115 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+	"github.com/Homyakadze14/DocsMicroservice/internal/services"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+// Transition moves the doc from one status to doc.Status and records it
+// in one transaction. It fails with ErrStatusConflict if the doc status
+// was changed concurrently. Approving the topic links the doc to the theme
+// approval order named by doc.Order.
+func (r *DocRepository) Transition(ctx context.Context, doc *entities.Doc, from string, tr *entities.Transition) (*entities.Doc, error) {
+	const op = "repositories.DocRepository.Transition"
+
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	update := psql.Update("docs").
+		Set("status", doc.Status).
+		Set("grade", sq.Expr("NULLIF(?, 0)", doc.Grade)).
+		Set("defense_date", doc.DefenseDate).
+		Set("version", sq.Expr("version + 1")).
+		Set("updated_at", tr.CreatedAt).
+		Where(sq.Eq{"id": doc.ID, "status": from}).
+		Suffix("RETURNING " + docColumns)
+
+	sql, args, err := update.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	updated, err := getDoc(op, tx.QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, services.ErrDocNotFound) {
+			return nil, services.ErrStatusConflict
+		}
+		return nil, err
+	}
+
+	if doc.Status == entities.StatusTopicApproved {
+		updated.Order, err = setThemeOrder(ctx, tx, doc.ID, updated.Year, doc.Order)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	row := tx.QueryRow(
+		ctx,
+		`INSERT INTO doc_transition(doc_id, from_status, to_status, actor_id, actor_name, actor_role, comment, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`,
+		tr.DocID, tr.FromStatus, tr.ToStatus, tr.ActorID, tr.ActorName, tr.ActorRole, tr.Comment, tr.CreatedAt)
+
+	err = row.Scan(&tr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = loadParticipants(ctx, tx, updated)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return updated, nil
+}
+
+func getTransition(op string, row pgx.Row) (*entities.Transition, error) {
+	tr := &entities.Transition{}
+	err := row.Scan(&tr.ID, &tr.DocID, &tr.FromStatus, &tr.ToStatus, &tr.ActorID,
+		&tr.ActorName, &tr.ActorRole, &tr.Comment, &tr.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tr, nil
+}
+
+func (r *DocRepository) GetTransitions(ctx context.Context, docID int) ([]*entities.Transition, error) {
+	const op = "repositories.DocRepository.GetTransitions"
+
+	rows, err := r.Pool.Query(
+		ctx,
+		`SELECT id, doc_id, from_status, to_status, actor_id, actor_name, actor_role, comment, created_at
+		FROM doc_transition WHERE doc_id=$1 ORDER BY created_at, id`,
+		docID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	transitions := make([]*entities.Transition, 0)
+	for rows.Next() {
+		tr, err := getTransition(op, rows)
+		if err != nil {
+			return nil, err
+		}
+		transitions = append(transitions, tr)
+	}
+
+	return transitions, nil
+}
@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+	"github.com/Homyakadze14/DocsMicroservice/internal/services"
+	"github.com/Homyakadze14/DocsMicroservice/pkg/postgres"
+)
+
+type WorkloadRepository struct {
+	*postgres.Postgres
+}
+
+func NewWorkloadRepository(pg *postgres.Postgres) *WorkloadRepository {
+	return &WorkloadRepository{pg}
+}
+
+// CountDocs counts the docs of the year where the person takes part in one of
+// the participant roles of the workload role. The doc with excludeID is left
+// out, so an updated doc isn't counted twice.
+func (r *WorkloadRepository) CountDocs(ctx context.Context, role, person string, year, excludeID int) (int, error) {
+	const op = "repositories.WorkloadRepository.CountDocs"
+
+	roles, ok := entities.WorkloadParticipants[role]
+	if !ok {
+		return 0, services.ErrUnknownWorkloadRole
+	}
+
+	var count int
+	err := r.Pool.QueryRow(
+		ctx,
+		`SELECT count(DISTINCT d.id) FROM docs d JOIN doc_participant p ON p.doc_id = d.id
+		WHERE p.role = ANY($1) AND p.name = $2 AND d.year = $3 AND d.id <> $4`,
+		roles, strings.ToLower(person), year, excludeID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return count, nil
+}
+
+// GetPersonalLimits returns the limits set for the person by role.
+func (r *WorkloadRepository) GetPersonalLimits(ctx context.Context, person string) (map[string]int, error) {
+	const op = "repositories.WorkloadRepository.GetPersonalLimits"
+
+	rows, err := r.Pool.Query(
+		ctx,
+		"SELECT role, max_docs FROM workload_limit WHERE person=$1",
+		strings.ToLower(person))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	limits := make(map[string]int)
+	for rows.Next() {
+		var role string
+		var limit int
+		if err = rows.Scan(&role, &limit); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		limits[role] = limit
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return limits, nil
+}
+
+func (r *WorkloadRepository) SetPersonalLimit(ctx context.Context, person, role string, limit int) error {
+	const op = "repositories.WorkloadRepository.SetPersonalLimit"
+
+	_, err := r.Pool.Exec(
+		ctx,
+		`INSERT INTO workload_limit(person, role, max_docs) VALUES ($1, $2, $3)
+		ON CONFLICT (person, role) DO UPDATE SET max_docs=EXCLUDED.max_docs`,
+		strings.ToLower(person), role, limit)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// DeletePersonalLimit brings the person back to the configured limit.
+func (r *WorkloadRepository) DeletePersonalLimit(ctx context.Context, person, role string) error {
+	const op = "repositories.WorkloadRepository.DeletePersonalLimit"
+
+	_, err := r.Pool.Exec(
+		ctx,
+		"DELETE FROM workload_limit WHERE person=$1 AND role=$2",
+		strings.ToLower(person), role)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetLimits returns the personal limits of everyone in the role.
+func (r *WorkloadRepository) GetLimits(ctx context.Context, role string) (map[string]int, error) {
+	const op = "repositories.WorkloadRepository.GetLimits"
+
+	rows, err := r.Pool.Query(ctx, "SELECT person, max_docs FROM workload_limit WHERE role=$1", role)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	limits := make(map[string]int)
+	for rows.Next() {
+		var person string
+		var limit int
+		if err = rows.Scan(&person, &limit); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		limits[person] = limit
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return limits, nil
+}
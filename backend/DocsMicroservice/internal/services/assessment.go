@@ -0,0 +1,357 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+	"github.com/Homyakadze14/DocsMicroservice/internal/lib/pdf"
+)
+
+var (
+	ErrReviewNotFound        = errors.New("review not found")
+	ErrReviewFileNotFound    = errors.New("review has no file")
+	ErrFeedbackNotFound      = errors.New("supervisor feedback not found")
+	ErrProtocolNotFound      = errors.New("defense protocol not found")
+	ErrProtocolNumberTaken   = errors.New("protocol number is already used")
+	ErrAssessmentForbidden   = errors.New("only the assigned person is allowed to submit it")
+	ErrEmptyReview           = errors.New("review must have a text or a file")
+	ErrReviewFileTooLarge    = errors.New("review file is too large")
+	ErrEmptyFeedback         = errors.New("feedback text is required")
+	ErrMissingProtocolNumber = errors.New("protocol number is required")
+	ErrNotScheduled          = errors.New("document is not assigned to a defense slot")
+)
+
+// MaxReviewFileSize keeps a review with its file under the default gRPC message limit.
+const MaxReviewFileSize = 3 << 20
+
+type AssessmentRepo interface {
+	SaveReview(ctx context.Context, review *entities.Review, file []byte) error
+	GetReview(ctx context.Context, docID int) (*entities.Review, error)
+	GetReviewFile(ctx context.Context, docID int) (*entities.File, error)
+	SaveFeedback(ctx context.Context, feedback *entities.Feedback) error
+	GetFeedback(ctx context.Context, docID int) (*entities.Feedback, error)
+	SaveProtocol(ctx context.Context, protocol *entities.Protocol) error
+	GetProtocol(ctx context.Context, docID int) (*entities.Protocol, error)
+}
+
+// DocSchedule finds where the doc is defended.
+type DocSchedule interface {
+	GetDocSlot(ctx context.Context, docID int) (*entities.Slot, error)
+	GetSession(ctx context.Context, id int) (*entities.Session, error)
+}
+
+type AssessmentService struct {
+	log            *slog.Logger
+	assessmentRepo AssessmentRepo
+	docRepo        DocRepo
+	schedule       DocSchedule
+	font           *pdf.Font
+}
+
+func NewAssessmentService(
+	log *slog.Logger,
+	assessmentRepo AssessmentRepo,
+	docRepo DocRepo,
+	schedule DocSchedule,
+	font *pdf.Font,
+) *AssessmentService {
+	return &AssessmentService{
+		log:            log,
+		assessmentRepo: assessmentRepo,
+		docRepo:        docRepo,
+		schedule:       schedule,
+		font:           font,
+	}
+}
+
+func (s *AssessmentService) SubmitReview(
+	ctx context.Context,
+	actor *entities.Actor,
+	review *entities.Review,
+	file []byte,
+) (*entities.Review, error) {
+	const op = "Assessment.SubmitReview"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("review", review.String()),
+		slog.String("actor", actor.String()),
+	)
+
+	if review.Grade < minGrade || review.Grade > maxGrade {
+		return nil, fmt.Errorf("%s: %w", op, ErrBadGrade)
+	}
+	if strings.TrimSpace(review.Text) == "" && len(file) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, ErrEmptyReview)
+	}
+	if len(file) > MaxReviewFileSize {
+		return nil, fmt.Errorf("%s: %w", op, ErrReviewFileTooLarge)
+	}
+
+	doc, err := s.docRepo.GetByID(ctx, review.DocID)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !isParticipant(doc, actor, entities.ParticipantReviewer) && !slices.Contains(staffRoles, actor.Role) {
+		log.Error("actor is not the reviewer")
+		return nil, fmt.Errorf("%s: %w", op, ErrAssessmentForbidden)
+	}
+
+	if len(file) == 0 {
+		review.FileName, review.FileType = "", ""
+		file = nil
+	}
+	review.AuthorID = actor.UserID
+	review.AuthorName = actor.Username
+	review.FileSize = len(file)
+	review.UpdatedAt = time.Now()
+
+	err = s.assessmentRepo.SaveReview(ctx, review, file)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	log.Info("review has been submitted")
+
+	return review, nil
+}
+
+func (s *AssessmentService) SubmitFeedback(
+	ctx context.Context,
+	actor *entities.Actor,
+	feedback *entities.Feedback,
+) (*entities.Feedback, error) {
+	const op = "Assessment.SubmitFeedback"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.Int("doc_id", feedback.DocID),
+		slog.String("actor", actor.String()),
+	)
+
+	if strings.TrimSpace(feedback.Text) == "" {
+		return nil, fmt.Errorf("%s: %w", op, ErrEmptyFeedback)
+	}
+
+	doc, err := s.docRepo.GetByID(ctx, feedback.DocID)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	supervisor := isParticipant(doc, actor, entities.ParticipantSupervisor, entities.ParticipantCoSupervisor)
+	if !supervisor && !slices.Contains(staffRoles, actor.Role) {
+		log.Error("actor is not the supervisor")
+		return nil, fmt.Errorf("%s: %w", op, ErrAssessmentForbidden)
+	}
+
+	feedback.AuthorID = actor.UserID
+	feedback.AuthorName = actor.Username
+	feedback.UpdatedAt = time.Now()
+
+	err = s.assessmentRepo.SaveFeedback(ctx, feedback)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	log.Info("feedback has been submitted")
+
+	return feedback, nil
+}
+
+// SubmitProtocol records the commission's decision. Only members of the
+// commission of the session the doc is defended in may submit it, matched by
+// their linked accounts, and the staff.
+func (s *AssessmentService) SubmitProtocol(
+	ctx context.Context,
+	actor *entities.Actor,
+	protocol *entities.Protocol,
+) (*entities.Protocol, error) {
+	const op = "Assessment.SubmitProtocol"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("protocol", protocol.String()),
+		slog.String("actor", actor.String()),
+	)
+
+	protocol.Number = strings.TrimSpace(protocol.Number)
+	if protocol.Number == "" {
+		return nil, fmt.Errorf("%s: %w", op, ErrMissingProtocolNumber)
+	}
+	if protocol.Grade < minGrade || protocol.Grade > maxGrade {
+		return nil, fmt.Errorf("%s: %w", op, ErrBadGrade)
+	}
+
+	_, err := s.docRepo.GetByID(ctx, protocol.DocID)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	slot, err := s.schedule.GetDocSlot(ctx, protocol.DocID)
+	if err != nil {
+		log.Error(err.Error())
+		if errors.Is(err, ErrSlotNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, ErrNotScheduled)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	session, err := s.schedule.GetSession(ctx, slot.SessionID)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	member := actor.UserID > 0 && slices.ContainsFunc(session.Commission.Members, func(m *entities.CommissionMember) bool {
+		return m.UserID == actor.UserID
+	})
+	if !member && !slices.Contains(staffRoles, actor.Role) {
+		log.Error("actor is not a commission member")
+		return nil, fmt.Errorf("%s: %w", op, ErrAssessmentForbidden)
+	}
+
+	protocol.SessionID = session.ID
+	protocol.AuthorID = actor.UserID
+	protocol.AuthorName = actor.Username
+	protocol.UpdatedAt = time.Now()
+
+	err = s.assessmentRepo.SaveProtocol(ctx, protocol)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	log.Info("protocol has been submitted")
+
+	return protocol, nil
+}
+
+// GetAssessment returns the review, feedback and protocol submitted so far.
+func (s *AssessmentService) GetAssessment(ctx context.Context, docID int) (*entities.Assessment, error) {
+	const op = "Assessment.GetAssessment"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.Int("doc_id", docID),
+	)
+
+	_, err := s.docRepo.GetByID(ctx, docID)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	a, err := s.assessment(ctx, docID)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return a, nil
+}
+
+func (s *AssessmentService) assessment(ctx context.Context, docID int) (*entities.Assessment, error) {
+	a := &entities.Assessment{}
+
+	var err error
+	a.Review, err = s.assessmentRepo.GetReview(ctx, docID)
+	if err != nil && !errors.Is(err, ErrReviewNotFound) {
+		return nil, err
+	}
+
+	a.Feedback, err = s.assessmentRepo.GetFeedback(ctx, docID)
+	if err != nil && !errors.Is(err, ErrFeedbackNotFound) {
+		return nil, err
+	}
+
+	a.Protocol, err = s.assessmentRepo.GetProtocol(ctx, docID)
+	if err != nil && !errors.Is(err, ErrProtocolNotFound) {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+func (s *AssessmentService) GetReviewFile(ctx context.Context, docID int) (*entities.File, error) {
+	const op = "Assessment.GetReviewFile"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.Int("doc_id", docID),
+	)
+
+	file, err := s.assessmentRepo.GetReviewFile(ctx, docID)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return file, nil
+}
+
+// GetProtocolPDF fills the defense protocol form from the collected data.
+func (s *AssessmentService) GetProtocolPDF(ctx context.Context, docID int) (*entities.File, error) {
+	const op = "Assessment.GetProtocolPDF"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.Int("doc_id", docID),
+	)
+
+	doc, err := s.docRepo.GetByID(ctx, docID)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	a, err := s.assessment(ctx, docID)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if a.Protocol == nil {
+		return nil, fmt.Errorf("%s: %w", op, ErrProtocolNotFound)
+	}
+
+	var session *entities.Session
+	if a.Protocol.SessionID != 0 {
+		session, err = s.schedule.GetSession(ctx, a.Protocol.SessionID)
+		if err != nil && !errors.Is(err, ErrSessionNotFound) {
+			log.Error(err.Error())
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	content, err := renderProtocol(s.font, doc, a, session)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &entities.File{
+		Name:    "protocol-" + fileSafe(a.Protocol.Number) + ".pdf",
+		Type:    "application/pdf",
+		Content: content,
+	}, nil
+}
+
+// fileSafe keeps letters and digits so the name can go into a header as is.
+func fileSafe(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return r
+		}
+		return '-'
+	}, s)
+}
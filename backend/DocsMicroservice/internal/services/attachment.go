@@ -0,0 +1,223 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+	"github.com/Homyakadze14/DocsMicroservice/internal/lib/extract"
+)
+
+var (
+	ErrAttachmentNotFound    = errors.New("attachment not found")
+	ErrEmptyAttachment       = errors.New("attachment file is empty")
+	ErrAttachmentTooLarge    = errors.New("attachment file is too large")
+	ErrMissingAttachmentName = errors.New("attachment file name is required")
+	ErrLongAttachmentName    = errors.New("attachment file name must not exceed 250 characters")
+	ErrAttachmentForbidden   = errors.New("only the uploader is allowed to delete the attachment")
+)
+
+// MaxAttachmentSize is the largest file accepted. The gRPC server limits
+// messages to fit it.
+const MaxAttachmentSize = 20 << 20
+
+const maxAttachmentName = 250
+
+// extractTimeout bounds the text extraction, which runs within the upload
+// request. A file that takes longer is stored without its text.
+const extractTimeout = 10 * time.Second
+
+type AttachmentRepo interface {
+	Create(ctx context.Context, a *entities.Attachment, content []byte, parts []*entities.TextPart) error
+	GetAttachments(ctx context.Context, docID int) ([]*entities.Attachment, error)
+	GetAttachment(ctx context.Context, id int) (*entities.Attachment, error)
+	GetAttachmentFile(ctx context.Context, id int) (*entities.File, error)
+	Delete(ctx context.Context, id int) error
+}
+
+type AttachmentService struct {
+	log            *slog.Logger
+	attachmentRepo AttachmentRepo
+	docRepo        DocRepo
+}
+
+func NewAttachmentService(
+	log *slog.Logger,
+	attachmentRepo AttachmentRepo,
+	docRepo DocRepo,
+) *AttachmentService {
+	return &AttachmentService{
+		log:            log,
+		attachmentRepo: attachmentRepo,
+		docRepo:        docRepo,
+	}
+}
+
+// Upload attaches the file to the doc and indexes its text. Files of unknown
+// formats or with broken text are still attached, only not searchable.
+func (s *AttachmentService) Upload(
+	ctx context.Context,
+	actor *entities.Actor,
+	a *entities.Attachment,
+	content []byte,
+) (*entities.Attachment, error) {
+	const op = "Attachment.Upload"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("attachment", a.String()),
+		slog.String("actor", actor.String()),
+	)
+
+	a.Name = filepath.Base(strings.TrimSpace(a.Name))
+	if a.Name == "" || a.Name == "." || a.Name == string(filepath.Separator) {
+		return nil, fmt.Errorf("%s: %w", op, ErrMissingAttachmentName)
+	}
+	if utf8.RuneCountInString(a.Name) > maxAttachmentName {
+		return nil, fmt.Errorf("%s: %w", op, ErrLongAttachmentName)
+	}
+	if len(content) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, ErrEmptyAttachment)
+	}
+	if len(content) > MaxAttachmentSize {
+		return nil, fmt.Errorf("%s: %w", op, ErrAttachmentTooLarge)
+	}
+
+	_, err := s.docRepo.GetByID(ctx, a.DocID)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	extractCtx, cancel := context.WithTimeout(ctx, extractTimeout)
+	parts, err := extract.Text(extractCtx, content)
+	cancel()
+	switch {
+	case err == nil:
+		a.TextStatus = entities.TextIndexed
+	case errors.Is(err, extract.ErrUnsupported):
+		a.TextStatus = entities.TextUnsupported
+	default:
+		log.Warn("failed to extract text", slog.String("err", err.Error()))
+		a.TextStatus = entities.TextFailed
+		a.TextError = err.Error()
+	}
+
+	textParts := make([]*entities.TextPart, 0, len(parts))
+	for _, p := range parts {
+		textParts = append(textParts, &entities.TextPart{Unit: p.Unit, Number: p.Number, Text: p.Text})
+	}
+
+	a.Size = len(content)
+	a.AuthorID = actor.UserID
+	a.AuthorName = actor.Username
+	a.CreatedAt = time.Now()
+
+	err = s.attachmentRepo.Create(ctx, a, content, textParts)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	log.Info("attachment has been uploaded", slog.String("text_status", a.TextStatus), slog.Int("parts", a.Parts))
+
+	return a, nil
+}
+
+func (s *AttachmentService) GetAttachments(ctx context.Context, docID int) ([]*entities.Attachment, error) {
+	const op = "Attachment.GetAttachments"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.Int("doc_id", docID),
+	)
+
+	_, err := s.docRepo.GetByID(ctx, docID)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	attachments, err := s.attachmentRepo.GetAttachments(ctx, docID)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return attachments, nil
+}
+
+// attachment returns the attachment if it belongs to the doc.
+func (s *AttachmentService) attachment(ctx context.Context, docID, id int) (*entities.Attachment, error) {
+	a, err := s.attachmentRepo.GetAttachment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if a.DocID != docID {
+		return nil, ErrAttachmentNotFound
+	}
+
+	return a, nil
+}
+
+func (s *AttachmentService) GetAttachmentFile(ctx context.Context, docID, id int) (*entities.File, error) {
+	const op = "Attachment.GetAttachmentFile"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.Int("doc_id", docID),
+		slog.Int("id", id),
+	)
+
+	_, err := s.attachment(ctx, docID, id)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	file, err := s.attachmentRepo.GetAttachmentFile(ctx, id)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return file, nil
+}
+
+// Delete removes the attachment. Besides the uploader, secretaries and
+// admins may do it.
+func (s *AttachmentService) Delete(ctx context.Context, actor *entities.Actor, docID, id int) error {
+	const op = "Attachment.Delete"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.Int("doc_id", docID),
+		slog.Int("id", id),
+		slog.String("actor", actor.String()),
+	)
+
+	a, err := s.attachment(ctx, docID, id)
+	if err != nil {
+		log.Error(err.Error())
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if a.AuthorID != actor.UserID && actor.Role != entities.RoleSecretary && actor.Role != entities.RoleAdmin {
+		log.Error("actor is not the uploader")
+		return fmt.Errorf("%s: %w", op, ErrAttachmentForbidden)
+	}
+
+	err = s.attachmentRepo.Delete(ctx, id)
+	if err != nil {
+		log.Error(err.Error())
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	log.Info("attachment has been deleted")
+
+	return nil
+}
@@ -5,57 +5,171 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 
 	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
 )
 
 var (
-	ErrDocAlreadyExists = errors.New("document with this theme already exists")
+	ErrDocAlreadyExists = errors.New("document with this theme already exists for the year and type")
 	ErrDocNotFound      = errors.New("document not found")
+	ErrVersionConflict  = errors.New("document was changed by another request")
+	ErrUnknownField     = errors.New("unknown document field")
+	ErrVersionRequired  = errors.New("document version is required to update it")
+	ErrEmptyUpdate      = errors.New("update mask must list the fields to change")
+	ErrSimilarThemes    = errors.New("documents with similar themes exist")
+	ErrBadThreshold     = errors.New("similarity threshold must be from 0 to 1")
+	ErrBadSearchScope   = errors.New("search scope must be metadata, content or both")
 )
 
+// Similar themes search defaults.
+const (
+	DefaultSimilarityThreshold = 0.5
+	defaultSimilarLimit        = 10
+	maxSimilarLimit            = 50
+)
+
+// contentMatchLimit caps the pages and paragraphs returned by a content search.
+const contentMatchLimit = 100
+
+// SimilarThemesError lists the docs a new theme is too close to.
+type SimilarThemesError struct {
+	Similar []*entities.SimilarTheme
+}
+
+func (e *SimilarThemesError) Error() string {
+	return fmt.Sprintf("%s: %d found", ErrSimilarThemes, len(e.Similar))
+}
+
+func (e *SimilarThemesError) Is(target error) bool {
+	return target == ErrSimilarThemes
+}
+
 type DocRepo interface {
 	Create(ctx context.Context, doc *entities.Doc) (id int, err error)
+	GetByID(ctx context.Context, id int) (*entities.Doc, error)
 	GetFiltered(ctx context.Context, doc *entities.Doc) ([]*entities.Doc, error)
 	Delete(ctx context.Context, id int) error
 	Search(ctx context.Context, search_line string) ([]*entities.Doc, error)
-	Update(ctx context.Context, doc *entities.Doc) (id int, err error)
+	SearchContent(ctx context.Context, query string, limit int) ([]*entities.ContentMatch, error)
+	GetByIDs(ctx context.Context, ids []int) ([]*entities.Doc, error)
+	Update(ctx context.Context, doc *entities.Doc, fields []string) (*entities.Doc, error)
+	Transition(ctx context.Context, doc *entities.Doc, from string, tr *entities.Transition) (*entities.Doc, error)
+	GetTransitions(ctx context.Context, docID int) ([]*entities.Transition, error)
+	FindSimilarThemes(ctx context.Context, theme string, threshold float64, limit int) ([]*entities.SimilarTheme, error)
+	BackfillThemeTokens(ctx context.Context) (int, error)
+	GetStats(ctx context.Context, q *entities.StatsQuery) (*entities.Stats, error)
+	GetUnreviewedDocs(ctx context.Context, year int) ([]*entities.AssignedDoc, error)
+	GetReviewerCandidates(ctx context.Context, docIDs []int) (map[int][]*entities.ReviewerCandidate, error)
 }
 
 type DocService struct {
-	log     *slog.Logger
-	docRepo DocRepo
+	log          *slog.Logger
+	docRepo      DocRepo
+	workloadRepo WorkloadRepo
+	limits       WorkloadLimits
+	rules        DocRules
 }
 
 func NewDocService(
 	log *slog.Logger,
 	docRepo DocRepo,
+	workloadRepo WorkloadRepo,
+	limits WorkloadLimits,
+	rules DocRules,
 ) *DocService {
 	return &DocService{
-		log:     log,
-		docRepo: docRepo,
+		log:          log,
+		docRepo:      docRepo,
+		workloadRepo: workloadRepo,
+		limits:       limits,
+		rules:        rules,
 	}
 }
 
-func (s *DocService) Create(ctx context.Context, doc *entities.Doc) (id int, err error) {
-	const op = "Auth.Create"
+// Create adds the doc. Unless force is set, it's refused with SimilarThemesError
+// when other docs have a similar theme. Exceeded workload limits of the
+// supervisor and reviewer are returned as warnings or refuse it with
+// WorkloadError when enforced. Without participants they're made of the fio,
+// director and reviewer fields, otherwise those are set from the participants.
+// Invalid fields refuse it with ValidationError.
+func (s *DocService) Create(
+	ctx context.Context,
+	doc *entities.Doc,
+	force bool,
+) (id int, warnings []*entities.Workload, err error) {
+	const op = "Doc.Create"
 
 	log := s.log.With(
 		slog.String("op", op),
 		slog.String("doc", doc.String()),
+		slog.Bool("force", force),
 	)
 
+	fields := entities.DocFields
+	if len(doc.Participants) > 0 {
+		err = normalizeParticipants(doc.Participants)
+		if err != nil {
+			return -1, nil, fmt.Errorf("%s: %w", op, err)
+		}
+		setLegacyFields(doc)
+		fields = append(slices.Clone(fields), entities.DocFieldParticipants)
+	}
+	err = s.validateDoc(doc, fields)
+	if err != nil {
+		log.Info("document is invalid", slog.String("err", err.Error()))
+		return -1, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(doc.Participants) == 0 {
+		doc.Participants = legacyParticipants(doc)
+	}
+
+	if !force {
+		similar, err := s.docRepo.FindSimilarThemes(ctx, doc.Theme, DefaultSimilarityThreshold, defaultSimilarLimit)
+		if err != nil {
+			log.Error(err.Error())
+			return -1, nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if len(similar) > 0 {
+			log.Info("similar themes found", slog.Int("count", len(similar)))
+			return -1, nil, fmt.Errorf("%s: %w", op, &SimilarThemesError{Similar: similar})
+		}
+	}
+
+	warnings, err = s.checkWorkload(ctx, doc, workloadPersons(doc))
+	if err != nil {
+		log.Error(err.Error())
+		return -1, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	id, err = s.docRepo.Create(ctx, doc)
 	if err != nil {
 		log.Error(err.Error())
-		return -1, fmt.Errorf("%s: %w", op, err)
+		return -1, nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return id, nil
+	return id, warnings, nil
+}
+
+func (s *DocService) GetByID(ctx context.Context, id int) (*entities.Doc, error) {
+	const op = "Doc.GetByID"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.Int("id", id),
+	)
+
+	doc, err := s.docRepo.GetByID(ctx, id)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return doc, nil
 }
 
 func (s *DocService) GetFiltered(ctx context.Context, doc *entities.Doc) (docs []*entities.Doc, err error) {
-	const op = "Auth.GetFiltered"
+	const op = "Doc.GetFiltered"
 
 	log := s.log.With(
 		slog.String("op", op),
@@ -72,7 +186,7 @@ func (s *DocService) GetFiltered(ctx context.Context, doc *entities.Doc) (docs [
 }
 
 func (s *DocService) Delete(ctx context.Context, id int) error {
-	const op = "Auth.Delete"
+	const op = "Doc.Delete"
 
 	log := s.log.With(
 		slog.String("op", op),
@@ -88,36 +202,209 @@ func (s *DocService) Delete(ctx context.Context, id int) error {
 	return nil
 }
 
-func (s *DocService) Search(ctx context.Context, search_line string) (docs []*entities.Doc, err error) {
-	const op = "Auth.Search"
+// Search looks for the line in the doc fields, in the text of the attachments
+// or in both. Content matches tell the page or paragraph they were found in.
+func (s *DocService) Search(
+	ctx context.Context,
+	search_line string,
+	scope string,
+) (docs []*entities.Doc, matches []*entities.ContentMatch, err error) {
+	const op = "Doc.Search"
 
 	log := s.log.With(
 		slog.String("op", op),
 		slog.String("search_line", search_line),
+		slog.String("scope", scope),
 	)
 
-	docs, err = s.docRepo.Search(ctx, search_line)
+	if scope == "" {
+		scope = entities.SearchMetadata
+	}
+	if scope != entities.SearchMetadata && scope != entities.SearchContent && scope != entities.SearchBoth {
+		return nil, nil, fmt.Errorf("%s: %w", op, ErrBadSearchScope)
+	}
+
+	docs = make([]*entities.Doc, 0)
+	if scope != entities.SearchContent {
+		docs, err = s.docRepo.Search(ctx, search_line)
+		if err != nil {
+			log.Error(err.Error())
+			return nil, nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	if scope == entities.SearchMetadata {
+		return docs, nil, nil
+	}
+
+	matches, err = s.docRepo.SearchContent(ctx, search_line, contentMatchLimit)
 	if err != nil {
 		log.Error(err.Error())
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return docs, nil
+	// Docs found by their fields go first, then by the best content match
+	ids := make([]int, 0, len(matches))
+	for _, m := range matches {
+		if !slices.Contains(ids, m.DocID) && !slices.ContainsFunc(docs, func(d *entities.Doc) bool {
+			return d.ID == m.DocID
+		}) {
+			ids = append(ids, m.DocID)
+		}
+	}
+	if len(ids) > 0 {
+		found, err := s.docRepo.GetByIDs(ctx, ids)
+		if err != nil {
+			log.Error(err.Error())
+			return nil, nil, fmt.Errorf("%s: %w", op, err)
+		}
+		docs = append(docs, found...)
+	}
+
+	return docs, matches, nil
 }
 
-func (s *DocService) Update(ctx context.Context, doc *entities.Doc) (id int, err error) {
-	const op = "Auth.Update"
+// Update changes the listed fields of the doc. Both the fields and
+// doc.Version are required, ErrEmptyUpdate and ErrVersionRequired refuse it
+// without them, and the version must match the stored one. Workload
+// limits are checked when the supervisor, reviewer or year changes.
+// The participants field replaces all of them and the fio, director and
+// reviewer fields along, while those alone change the first participant
+// of their role. Invalid fields refuse it with ValidationError.
+func (s *DocService) Update(
+	ctx context.Context,
+	doc *entities.Doc,
+	fields []string,
+) (*entities.Doc, []*entities.Workload, error) {
+	const op = "Doc.Update"
 
 	log := s.log.With(
 		slog.String("op", op),
 		slog.String("doc", doc.String()),
+		slog.Any("fields", fields),
+	)
+
+	// Updates are always conditional and partial, so a stale client can't
+	// overwrite what others changed
+	if doc.Version <= 0 {
+		return nil, nil, fmt.Errorf("%s: %w", op, ErrVersionRequired)
+	}
+	if len(fields) == 0 {
+		return nil, nil, fmt.Errorf("%s: %w", op, ErrEmptyUpdate)
+	}
+	for _, field := range fields {
+		if !slices.Contains(entities.DocFields, field) && field != entities.DocFieldParticipants {
+			log.Error(fmt.Sprintf("unknown field %q", field))
+			return nil, nil, fmt.Errorf("%s: %w: %s", op, ErrUnknownField, field)
+		}
+	}
+	if slices.Contains(fields, entities.DocFieldParticipants) {
+		err := normalizeParticipants(doc.Participants)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", op, err)
+		}
+		setLegacyFields(doc)
+		fields = append(slices.Clone(fields), entities.DocFieldFIO, entities.DocFieldDirector, entities.DocFieldReviewer)
+	}
+	err := s.validateDoc(doc, fields)
+	if err != nil {
+		log.Info("document is invalid", slog.String("err", err.Error()))
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	warnings := make([]*entities.Workload, 0)
+	if slices.ContainsFunc(fields, func(f string) bool {
+		return f == entities.DocFieldDirector || f == entities.DocFieldReviewer || f == entities.DocFieldYear
+	}) {
+		current, err := s.docRepo.GetByID(ctx, doc.ID)
+		if err != nil {
+			log.Error(err.Error())
+			return nil, nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		next := *current
+		if slices.Contains(fields, entities.DocFieldParticipants) {
+			next.Participants = doc.Participants
+		} else {
+			for _, field := range fields {
+				switch field {
+				case entities.DocFieldDirector:
+					next.Participants = withFirstParticipant(next.Participants, entities.ParticipantSupervisor, doc.Director)
+				case entities.DocFieldReviewer:
+					next.Participants = withFirstParticipant(next.Participants, entities.ParticipantReviewer, doc.Reviewer)
+				}
+			}
+		}
+		if slices.Contains(fields, entities.DocFieldYear) {
+			next.Year = doc.Year
+		}
+
+		warnings, err = s.checkWorkload(ctx, &next, addedWorkload(current, &next))
+		if err != nil {
+			log.Error(err.Error())
+			return nil, nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	updated, err := s.docRepo.Update(ctx, doc, slices.Compact(slices.Sorted(slices.Values(fields))))
+	if err != nil {
+		log.Error(err.Error())
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return updated, warnings, nil
+}
+
+// FindSimilarThemes returns docs whose themes look like the given one.
+// Zero threshold and limit mean defaults.
+func (s *DocService) FindSimilarThemes(
+	ctx context.Context,
+	theme string,
+	threshold float64,
+	limit int,
+) ([]*entities.SimilarTheme, error) {
+	const op = "Doc.FindSimilarThemes"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("theme", theme),
 	)
 
-	id, err = s.docRepo.Update(ctx, doc)
+	if threshold < 0 || threshold > 1 {
+		return nil, fmt.Errorf("%s: %w", op, ErrBadThreshold)
+	}
+	if threshold == 0 {
+		threshold = DefaultSimilarityThreshold
+	}
+	if limit <= 0 {
+		limit = defaultSimilarLimit
+	}
+	limit = min(limit, maxSimilarLimit)
+
+	similar, err := s.docRepo.FindSimilarThemes(ctx, theme, threshold, limit)
 	if err != nil {
 		log.Error(err.Error())
-		return -1, fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return id, nil
+	return similar, nil
+}
+
+// ReindexThemes prepares docs created before similarity search for it.
+func (s *DocService) ReindexThemes(ctx context.Context) error {
+	const op = "Doc.ReindexThemes"
+
+	log := s.log.With(
+		slog.String("op", op),
+	)
+
+	n, err := s.docRepo.BackfillThemeTokens(ctx)
+	if err != nil {
+		log.Error(err.Error())
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if n > 0 {
+		log.Info("themes have been reindexed", slog.Int("count", n))
+	}
+
+	return nil
 }
@@ -0,0 +1,274 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+)
+
+var (
+	ErrOrderNotFound      = errors.New("order not found")
+	ErrOrderFileNotFound  = errors.New("order has no file")
+	ErrOrderAlreadyExists = errors.New("order with this number and date already exists")
+	ErrBadOrder           = errors.New("order must have a number, a date and a year")
+	ErrUnknownOrderType   = errors.New("order type must be theme_approval, reviewer_assignment or commission")
+	ErrOrderFileTooLarge  = errors.New("order file is too large")
+	ErrNoOrderDocs        = errors.New("at least one document is required")
+	ErrOrderYearMismatch  = errors.New("documents must be of the order's year")
+	ErrOrderForbidden     = errors.New("only secretaries and admins are allowed to change orders")
+)
+
+const (
+	maxOrderNumber = 100
+	maxOrderTitle  = 500
+)
+
+type OrderRepo interface {
+	Create(ctx context.Context, o *entities.Order, content []byte) (id int, err error)
+	GetOrder(ctx context.Context, id int) (*entities.Order, error)
+	GetOrders(ctx context.Context, year int, orderType string, docID int) ([]*entities.Order, error)
+	GetOrderFile(ctx context.Context, id int) (*entities.File, error)
+	AttachDocs(ctx context.Context, orderID int, docIDs []int) (int, error)
+	DetachDocs(ctx context.Context, orderID int, docIDs []int) (int, error)
+}
+
+type OrderService struct {
+	log       *slog.Logger
+	orderRepo OrderRepo
+	docRepo   DocRepo
+}
+
+func NewOrderService(
+	log *slog.Logger,
+	orderRepo OrderRepo,
+	docRepo DocRepo,
+) *OrderService {
+	return &OrderService{
+		log:       log,
+		orderRepo: orderRepo,
+		docRepo:   docRepo,
+	}
+}
+
+func canChangeOrders(actor *entities.Actor) bool {
+	return actor.Role == entities.RoleSecretary || actor.Role == entities.RoleAdmin
+}
+
+func validateOrder(o *entities.Order, content []byte) error {
+	o.Number = strings.TrimSpace(o.Number)
+	o.Title = strings.TrimSpace(o.Title)
+	if o.Number == "" || utf8.RuneCountInString(o.Number) > maxOrderNumber ||
+		utf8.RuneCountInString(o.Title) > maxOrderTitle || o.Date.IsZero() || o.Year <= 0 {
+		return ErrBadOrder
+	}
+	if !slices.Contains(entities.OrderTypes, o.Type) {
+		return ErrUnknownOrderType
+	}
+	if len(content) > MaxAttachmentSize {
+		return ErrOrderFileTooLarge
+	}
+	if len(content) > 0 {
+		o.FileName = filepath.Base(strings.TrimSpace(o.FileName))
+		if o.FileName == "" || o.FileName == "." || utf8.RuneCountInString(o.FileName) > maxAttachmentName {
+			return ErrMissingAttachmentName
+		}
+	} else {
+		o.FileName, o.FileType = "", ""
+	}
+
+	return nil
+}
+
+// Create records the order with its signed file, if any.
+func (s *OrderService) Create(
+	ctx context.Context,
+	actor *entities.Actor,
+	o *entities.Order,
+	content []byte,
+) (*entities.Order, error) {
+	const op = "Order.Create"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("order", o.String()),
+		slog.String("actor", actor.String()),
+	)
+
+	if !canChangeOrders(actor) {
+		log.Error("role is not allowed")
+		return nil, fmt.Errorf("%s: %w", op, ErrOrderForbidden)
+	}
+	err := validateOrder(o, content)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	o.AuthorID = actor.UserID
+	o.AuthorName = actor.Username
+	o.CreatedAt = time.Now()
+	o.ID, err = s.orderRepo.Create(ctx, o, content)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	log.Info("order has been created")
+
+	return s.GetOrder(ctx, o.ID)
+}
+
+func (s *OrderService) GetOrder(ctx context.Context, id int) (*entities.Order, error) {
+	const op = "Order.GetOrder"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.Int("id", id),
+	)
+
+	o, err := s.orderRepo.GetOrder(ctx, id)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return o, nil
+}
+
+// GetOrders lists the orders of the year, of one type or of one doc.
+// Zero values match everything.
+func (s *OrderService) GetOrders(ctx context.Context, year int, orderType string, docID int) ([]*entities.Order, error) {
+	const op = "Order.GetOrders"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.Int("year", year),
+		slog.String("type", orderType),
+		slog.Int("doc_id", docID),
+	)
+
+	if orderType != "" && !slices.Contains(entities.OrderTypes, orderType) {
+		return nil, fmt.Errorf("%s: %w", op, ErrUnknownOrderType)
+	}
+
+	orders, err := s.orderRepo.GetOrders(ctx, year, orderType, docID)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return orders, nil
+}
+
+func (s *OrderService) GetOrderFile(ctx context.Context, id int) (*entities.File, error) {
+	const op = "Order.GetOrderFile"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.Int("id", id),
+	)
+
+	file, err := s.orderRepo.GetOrderFile(ctx, id)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return file, nil
+}
+
+// AttachDocs links the docs to the order in one go. All docs must exist and
+// be of the order's year, otherwise none is linked. It returns how many were
+// linked anew.
+func (s *OrderService) AttachDocs(ctx context.Context, actor *entities.Actor, orderID int, docIDs []int) (int, error) {
+	const op = "Order.AttachDocs"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.Int("order_id", orderID),
+		slog.Any("doc_ids", docIDs),
+		slog.String("actor", actor.String()),
+	)
+
+	if !canChangeOrders(actor) {
+		log.Error("role is not allowed")
+		return 0, fmt.Errorf("%s: %w", op, ErrOrderForbidden)
+	}
+	docIDs = slices.Compact(slices.Sorted(slices.Values(docIDs)))
+	if len(docIDs) == 0 {
+		return 0, fmt.Errorf("%s: %w", op, ErrNoOrderDocs)
+	}
+
+	o, err := s.orderRepo.GetOrder(ctx, orderID)
+	if err != nil {
+		log.Error(err.Error())
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	docs, err := s.docRepo.GetByIDs(ctx, docIDs)
+	if err != nil {
+		log.Error(err.Error())
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(docs) != len(docIDs) {
+		missing := slices.DeleteFunc(slices.Clone(docIDs), func(id int) bool {
+			return slices.ContainsFunc(docs, func(d *entities.Doc) bool { return d.ID == id })
+		})
+		return 0, fmt.Errorf("%s: %w: %v", op, ErrDocNotFound, missing)
+	}
+	for _, d := range docs {
+		if d.Year != o.Year {
+			return 0, fmt.Errorf("%s: %w: document %d is of %d", op, ErrOrderYearMismatch, d.ID, d.Year)
+		}
+	}
+
+	attached, err := s.orderRepo.AttachDocs(ctx, orderID, docIDs)
+	if err != nil {
+		log.Error(err.Error())
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	log.Info("docs have been attached to the order", slog.Int("attached", attached))
+
+	return attached, nil
+}
+
+// DetachDocs unlinks the docs from the order and returns how many were linked.
+func (s *OrderService) DetachDocs(ctx context.Context, actor *entities.Actor, orderID int, docIDs []int) (int, error) {
+	const op = "Order.DetachDocs"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.Int("order_id", orderID),
+		slog.Any("doc_ids", docIDs),
+		slog.String("actor", actor.String()),
+	)
+
+	if !canChangeOrders(actor) {
+		log.Error("role is not allowed")
+		return 0, fmt.Errorf("%s: %w", op, ErrOrderForbidden)
+	}
+	if len(docIDs) == 0 {
+		return 0, fmt.Errorf("%s: %w", op, ErrNoOrderDocs)
+	}
+
+	_, err := s.orderRepo.GetOrder(ctx, orderID)
+	if err != nil {
+		log.Error(err.Error())
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	detached, err := s.orderRepo.DetachDocs(ctx, orderID, docIDs)
+	if err != nil {
+		log.Error(err.Error())
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	log.Info("docs have been detached from the order", slog.Int("detached", detached))
+
+	return detached, nil
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+)
+
+var (
+	ErrUnknownParticipantRole = errors.New("participant role must be student, supervisor, co_supervisor, consultant or reviewer")
+	ErrBadParticipant         = errors.New("participant name is required")
+	ErrDuplicateParticipant   = errors.New("participant is listed twice in the same role")
+	ErrBadParticipantAccount  = errors.New("participant user id must not be negative")
+)
+
+// normalizeParticipants checks the participants and numbers them within
+// their role in the given order.
+func normalizeParticipants(participants []*entities.Participant) error {
+	positions := make(map[string]int, len(entities.ParticipantRoles))
+	seen := make(map[string]bool, len(participants))
+	for _, p := range participants {
+		p.Name = strings.TrimSpace(p.Name)
+		if !slices.Contains(entities.ParticipantRoles, p.Role) {
+			return fmt.Errorf("%w: %q", ErrUnknownParticipantRole, p.Role)
+		}
+		if p.Name == "" {
+			return ErrBadParticipant
+		}
+		if p.UserID < 0 {
+			return ErrBadParticipantAccount
+		}
+
+		key := p.Role + "\x00" + strings.ToLower(p.Name)
+		if seen[key] {
+			return fmt.Errorf("%w: %s", ErrDuplicateParticipant, p.Name)
+		}
+		seen[key] = true
+
+		positions[p.Role]++
+		p.Position = positions[p.Role]
+	}
+
+	return nil
+}
+
+// legacyParticipants builds the participants from the scalar doc fields.
+func legacyParticipants(doc *entities.Doc) []*entities.Participant {
+	names := map[string]string{
+		entities.ParticipantStudent:    doc.FIO,
+		entities.ParticipantSupervisor: doc.Director,
+		entities.ParticipantReviewer:   doc.Reviewer,
+	}
+
+	participants := make([]*entities.Participant, 0, len(names))
+	for _, role := range entities.ParticipantRoles {
+		name := strings.TrimSpace(names[role])
+		if name != "" {
+			participants = append(participants, &entities.Participant{Role: role, Name: name, Position: 1})
+		}
+	}
+
+	return participants
+}
+
+// withFirstParticipant returns the participants with the first one of the
+// role replaced by name, as setting a scalar doc field does. An empty name
+// removes it. The given slice is left as it is.
+func withFirstParticipant(participants []*entities.Participant, role, name string) []*entities.Participant {
+	name = strings.TrimSpace(name)
+
+	replaced := make([]*entities.Participant, 0, len(participants)+1)
+	found := false
+	for _, p := range participants {
+		if p.Role == role && p.Position == 1 {
+			found = true
+			if name == "" {
+				continue
+			}
+			p = &entities.Participant{Role: role, Name: name, Position: 1}
+		}
+		replaced = append(replaced, p)
+	}
+	if !found && name != "" {
+		replaced = append(replaced, &entities.Participant{Role: role, Name: name, Position: 1})
+	}
+
+	return replaced
+}
+
+// setLegacyFields sets the scalar doc fields to the first participant of
+// their role, so clients unaware of participants keep seeing them.
+func setLegacyFields(doc *entities.Doc) {
+	first := make(map[string]string, len(entities.ParticipantRoles))
+	for _, p := range doc.Participants {
+		if _, ok := first[p.Role]; !ok {
+			first[p.Role] = p.Name
+		}
+	}
+
+	doc.FIO = first[entities.ParticipantStudent]
+	doc.Director = first[entities.ParticipantSupervisor]
+	doc.Reviewer = first[entities.ParticipantReviewer]
+}
+
+// isParticipant reports whether the actor's account is linked to a
+// participant of the doc in one of the roles. Names are never compared here,
+// as anyone can register under any name: a participant is linked to the
+// account of the same username only when the doc is saved.
+func isParticipant(doc *entities.Doc, actor *entities.Actor, roles ...string) bool {
+	if actor.UserID <= 0 {
+		return false
+	}
+
+	return slices.ContainsFunc(doc.Participants, func(p *entities.Participant) bool {
+		return p.UserID == actor.UserID && slices.Contains(roles, p.Role)
+	})
+}
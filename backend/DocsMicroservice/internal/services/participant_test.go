@@ -0,0 +1,65 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsParticipant(t *testing.T) {
+	doc := &entities.Doc{
+		Reviewer: "petrov",
+		Participants: []*entities.Participant{
+			{Role: entities.ParticipantStudent, Name: "ivanov", UserID: 1},
+			{Role: entities.ParticipantSupervisor, Name: "sidorov", UserID: 2},
+			{Role: entities.ParticipantReviewer, Name: "petrov"},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		actor *entities.Actor
+		roles []string
+		want  bool
+	}{
+		{
+			name:  "linked account in the role",
+			actor: &entities.Actor{UserID: 2, Username: "someone"},
+			roles: []string{entities.ParticipantSupervisor, entities.ParticipantCoSupervisor},
+			want:  true,
+		},
+		{
+			name:  "linked account in another role",
+			actor: &entities.Actor{UserID: 1},
+			roles: []string{entities.ParticipantSupervisor},
+			want:  false,
+		},
+		{
+			name:  "same name without a linked account",
+			actor: &entities.Actor{UserID: 3, Username: "petrov"},
+			roles: []string{entities.ParticipantReviewer},
+			want:  false,
+		},
+		{
+			name:  "no account matches unlinked participants",
+			actor: &entities.Actor{Username: "petrov"},
+			roles: []string{entities.ParticipantReviewer},
+			want:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isParticipant(doc, tt.actor, tt.roles...))
+		})
+	}
+}
+
+func TestNormalizeParticipantsNegativeAccount(t *testing.T) {
+	err := normalizeParticipants([]*entities.Participant{
+		{Role: entities.ParticipantStudent, Name: "ivanov", UserID: -1},
+	})
+
+	assert.ErrorIs(t, err, ErrBadParticipantAccount)
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+	"github.com/Homyakadze14/DocsMicroservice/internal/lib/pdf"
+)
+
+var gradeNames = map[int]string{
+	2: "неудовлетворительно",
+	3: "удовлетворительно",
+	4: "хорошо",
+	5: "отлично",
+}
+
+func gradeText(grade int) string {
+	return fmt.Sprintf("%s (%d)", gradeNames[grade], grade)
+}
+
+// orDash keeps empty form fields visible.
+func orDash(s string) string {
+	if strings.TrimSpace(s) == "" {
+		return "—"
+	}
+	return s
+}
+
+// renderProtocol fills the defense protocol form. Session is nil
+// when the slot was removed after the protocol had been submitted.
+func renderProtocol(
+	font *pdf.Font,
+	doc *entities.Doc,
+	a *entities.Assessment,
+	session *entities.Session,
+) ([]byte, error) {
+	const (
+		titleSize = 16
+		textSize  = 11
+		gap       = 8
+	)
+
+	p := a.Protocol
+	d := pdf.New(font)
+
+	d.Text("ПРОТОКОЛ № "+p.Number, titleSize, pdf.AlignCenter)
+	d.Text("заседания экзаменационной комиссии по защите выпускной квалификационной работы", textSize, pdf.AlignCenter)
+	d.Space(gap * 2)
+
+	if session != nil {
+		d.Text(fmt.Sprintf("Дата и время: %s", session.StartsAt.Format("02.01.2006 15:04")), textSize, pdf.AlignLeft)
+		d.Text("Аудитория: "+session.Room, textSize, pdf.AlignLeft)
+		if c := session.Commission; c != nil {
+			d.Text(fmt.Sprintf("Комиссия: %s (%d)", c.Name, c.Year), textSize, pdf.AlignLeft)
+			for _, m := range c.Members {
+				d.Text(fmt.Sprintf("    %s — %s", memberRoleName(m.Role), m.Name), textSize, pdf.AlignLeft)
+			}
+		}
+		d.Space(gap)
+	}
+
+	d.Text("Студент: "+doc.FIO, textSize, pdf.AlignLeft)
+	d.Text("Группа: "+orDash(doc.Group), textSize, pdf.AlignLeft)
+	d.Text("Тема работы: "+doc.Theme, textSize, pdf.AlignLeft)
+	d.Space(gap)
+
+	d.Text("Руководитель: "+doc.Director, textSize, pdf.AlignLeft)
+	if a.Feedback != nil {
+		d.Text("Отзыв руководителя: "+a.Feedback.Text, textSize, pdf.AlignLeft)
+	} else {
+		d.Text("Отзыв руководителя: не представлен", textSize, pdf.AlignLeft)
+	}
+	d.Space(gap)
+
+	d.Text("Рецензент: "+orDash(doc.Reviewer), textSize, pdf.AlignLeft)
+	if r := a.Review; r != nil {
+		d.Text("Оценка рецензента: "+gradeText(r.Grade), textSize, pdf.AlignLeft)
+		if r.Text != "" {
+			d.Text("Рецензия: "+r.Text, textSize, pdf.AlignLeft)
+		}
+		if r.FileName != "" {
+			d.Text("Файл рецензии: "+r.FileName, textSize, pdf.AlignLeft)
+		}
+	} else {
+		d.Text("Рецензия: не представлена", textSize, pdf.AlignLeft)
+	}
+	d.Space(gap)
+
+	d.Text("Вопросы, заданные студенту: "+orDash(p.Questions), textSize, pdf.AlignLeft)
+	d.Text("Особые мнения членов комиссии: "+orDash(p.Notes), textSize, pdf.AlignLeft)
+	d.Space(gap)
+
+	d.Text("Решение комиссии: признать, что работа выполнена и защищена с оценкой «"+gradeText(p.Grade)+"»",
+		textSize+1, pdf.AlignLeft)
+	d.Space(gap * 3)
+
+	d.Text("Председатель комиссии ____________________ "+commissionMember(session, entities.MemberChair), textSize, pdf.AlignLeft)
+	d.Space(gap)
+	d.Text("Секретарь комиссии ____________________ "+commissionMember(session, entities.MemberSecretary), textSize, pdf.AlignLeft)
+	d.Space(gap * 2)
+	d.Text("Сформирован "+p.UpdatedAt.Format("02.01.2006")+" по данным системы; дата печати "+
+		time.Now().Format("02.01.2006"), textSize-2, pdf.AlignLeft)
+
+	b := &bytes.Buffer{}
+	if err := d.Encode(b); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+func memberRoleName(role string) string {
+	switch role {
+	case entities.MemberChair:
+		return "председатель"
+	case entities.MemberSecretary:
+		return "секретарь"
+	default:
+		return "член комиссии"
+	}
+}
+
+func commissionMember(session *entities.Session, role string) string {
+	if session == nil || session.Commission == nil {
+		return ""
+	}
+	for _, m := range session.Commission.Members {
+		if m.Role == role {
+			return m.Name
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+)
+
+// Reviewer suggestion weights. Matches add up to 1, the workload takes off
+// up to its weight at the limit.
+const (
+	disciplineWeight = 0.5
+	themeWeight      = 0.5
+	workloadWeight   = 0.3
+)
+
+// Reviewer suggestion defaults.
+const (
+	defaultSuggestionLimit = 5
+	maxSuggestionLimit     = 50
+	// assignmentAlternatives are the runners-up shown with a bulk proposal
+	assignmentAlternatives = 3
+)
+
+// reviewerPool ranks the candidate reviewers of the docs.
+type reviewerPool struct {
+	// candidates by doc id
+	candidates map[int][]*entities.ReviewerCandidate
+	// personal reviewer limits
+	limits map[string]int
+	// config limits
+	workload WorkloadLimits
+	// proposed counts the reviews proposed so far by year, so a bulk
+	// proposal spreads them
+	proposed map[string]map[int]int
+}
+
+func (s *DocService) reviewerPool(ctx context.Context, docIDs []int) (*reviewerPool, error) {
+	candidates, err := s.docRepo.GetReviewerCandidates(ctx, docIDs)
+	if err != nil {
+		return nil, err
+	}
+	limits, err := s.workloadRepo.GetLimits(ctx, entities.WorkloadReviewer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &reviewerPool{
+		candidates: candidates,
+		limits:     limits,
+		workload:   s.limits,
+		proposed:   make(map[string]map[int]int),
+	}, nil
+}
+
+func (p *reviewerPool) propose(person string, year int) {
+	if p.proposed[person] == nil {
+		p.proposed[person] = make(map[int]int)
+	}
+	p.proposed[person][year]++
+}
+
+// rank returns the candidates for the doc, best first. Those the doc would
+// put over an enforced limit are left out.
+func (p *reviewerPool) rank(docID, year int) []*entities.ReviewerSuggestion {
+	candidates := p.candidates[docID]
+	suggestions := make([]*entities.ReviewerSuggestion, 0, len(candidates))
+	busiest := 1
+	for _, c := range candidates {
+		w := &entities.Workload{
+			Person: c.Person,
+			Role:   entities.WorkloadReviewer,
+			Year:   year,
+			Count:  c.Reviews + p.proposed[c.Person][year],
+			Limit:  p.workload.Reviewer,
+		}
+		if limit, ok := p.limits[c.Person]; ok {
+			w.Limit, w.Personal = limit, true
+		}
+
+		if p.workload.Enforce && w.Limit > 0 && w.Count+1 > w.Limit {
+			continue
+		}
+
+		s := &entities.ReviewerSuggestion{
+			Person:          c.Person,
+			ThemeSimilarity: c.ThemeSimilarity,
+			Workload:        w,
+		}
+		if c.Experience > 0 {
+			s.DisciplineMatch = float64(c.SameDiscipline) / float64(c.Experience)
+		}
+		suggestions = append(suggestions, s)
+		busiest = max(busiest, w.Count)
+	}
+
+	for _, s := range suggestions {
+		// Without a limit the load is measured against the busiest candidate
+		load := float64(s.Workload.Count) / float64(busiest)
+		if s.Workload.Limit > 0 {
+			load = min(float64(s.Workload.Count)/float64(s.Workload.Limit), 1.5)
+		}
+		s.Score = disciplineWeight*s.DisciplineMatch + themeWeight*s.ThemeSimilarity - workloadWeight*load
+	}
+	slices.SortStableFunc(suggestions, func(a, b *entities.ReviewerSuggestion) int {
+		return cmp.Or(cmp.Compare(b.Score, a.Score), cmp.Compare(a.Person, b.Person))
+	})
+
+	return suggestions
+}
+
+// SuggestReviewers ranks the candidate reviewers of the doc among the people
+// who have reviewed docs before. Zero limit means the default.
+func (s *DocService) SuggestReviewers(ctx context.Context, docID, limit int) ([]*entities.ReviewerSuggestion, error) {
+	const op = "Doc.SuggestReviewers"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.Int("doc_id", docID),
+		slog.Int("limit", limit),
+	)
+
+	if limit <= 0 {
+		limit = defaultSuggestionLimit
+	}
+	limit = min(limit, maxSuggestionLimit)
+
+	doc, err := s.docRepo.GetByID(ctx, docID)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	pool, err := s.reviewerPool(ctx, []int{docID})
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	suggestions := pool.rank(doc.ID, doc.Year)
+
+	return suggestions[:min(limit, len(suggestions))], nil
+}
+
+// SuggestReviewerAssignments proposes a reviewer for every doc of the year
+// without one. Each proposal counts towards the workload of the following
+// ones. Nothing is saved. Zero year means the current one.
+func (s *DocService) SuggestReviewerAssignments(ctx context.Context, year int) ([]*entities.ReviewerAssignment, error) {
+	const op = "Doc.SuggestReviewerAssignments"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.Int("year", year),
+	)
+
+	if year == 0 {
+		year = time.Now().Year()
+	}
+
+	docs, err := s.docRepo.GetUnreviewedDocs(ctx, year)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	docIDs := make([]int, 0, len(docs))
+	for _, doc := range docs {
+		docIDs = append(docIDs, doc.ID)
+	}
+	pool, err := s.reviewerPool(ctx, docIDs)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	assignments := make([]*entities.ReviewerAssignment, 0, len(docs))
+	for _, doc := range docs {
+		a := &entities.ReviewerAssignment{
+			DocID: doc.ID,
+			FIO:   doc.FIO,
+			Theme: doc.Theme,
+		}
+		ranked := pool.rank(doc.ID, doc.Year)
+		if len(ranked) > 0 {
+			a.Reviewer = ranked[0].Person
+			a.Alternatives = ranked[1:min(len(ranked), assignmentAlternatives+1)]
+			pool.propose(a.Reviewer, year)
+		}
+		assignments = append(assignments, a)
+	}
+	log.Info("reviewer assignments proposed", slog.Int("docs", len(assignments)))
+
+	return assignments, nil
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+	"github.com/Homyakadze14/DocsMicroservice/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func persons(suggestions []*entities.ReviewerSuggestion) []string {
+	names := make([]string, 0, len(suggestions))
+	for _, s := range suggestions {
+		names = append(names, s.Person)
+	}
+	return names
+}
+
+func TestSuggestReviewers(t *testing.T) {
+	ctx := context.Background()
+	doc := &entities.Doc{ID: 7, Year: 2024}
+
+	docRepo := &mocks.DocRepo{}
+	docRepo.On("GetByID", ctx, doc.ID).Return(doc, nil).Once()
+	docRepo.On("GetReviewerCandidates", ctx, []int{doc.ID}).Return(map[int][]*entities.ReviewerCandidate{
+		doc.ID: {
+			{Person: "busy", Experience: 4, SameDiscipline: 4, ThemeSimilarity: 0.8, Reviews: 10},
+			{Person: "close", Experience: 4, SameDiscipline: 3, ThemeSimilarity: 0.6, Reviews: 2},
+			{Person: "new", Experience: 0},
+			{Person: "far", Experience: 2, ThemeSimilarity: 0.1, Reviews: 1},
+		},
+	}, nil).Once()
+
+	workloadRepo := &mocks.WorkloadRepo{}
+	workloadRepo.On("GetLimits", ctx, entities.WorkloadReviewer).Return(map[string]int{"far": 3}, nil).Once()
+
+	service := NewDocService(testLog, docRepo, workloadRepo, WorkloadLimits{}, DocRules{})
+	suggestions, err := service.SuggestReviewers(ctx, doc.ID, 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"close", "busy", "new"}, persons(suggestions))
+
+	first := suggestions[0]
+	assert.Equal(t, 0.75, first.DisciplineMatch)
+	assert.Equal(t, 0.6, first.ThemeSimilarity)
+	assert.Equal(t, &entities.Workload{Person: "close", Role: entities.WorkloadReviewer, Year: 2024, Count: 2}, first.Workload)
+	// Without a limit the load is measured against the busiest candidate
+	assert.InDelta(t, 0.5*0.75+0.5*0.6-0.3*0.2, first.Score, 1e-9)
+	assert.Zero(t, suggestions[2].DisciplineMatch)
+}
+
+func TestSuggestReviewersEnforcedLimits(t *testing.T) {
+	ctx := context.Background()
+	doc := &entities.Doc{ID: 7, Year: 2024}
+
+	docRepo := &mocks.DocRepo{}
+	docRepo.On("GetByID", ctx, doc.ID).Return(doc, nil).Once()
+	docRepo.On("GetReviewerCandidates", ctx, []int{doc.ID}).Return(map[int][]*entities.ReviewerCandidate{
+		doc.ID: {
+			{Person: "full", Reviews: 12},
+			{Person: "free", Reviews: 11},
+			{Person: "personal", Reviews: 2},
+			{Person: "raised", Reviews: 14},
+		},
+	}, nil).Once()
+
+	workloadRepo := &mocks.WorkloadRepo{}
+	workloadRepo.On("GetLimits", ctx, entities.WorkloadReviewer).
+		Return(map[string]int{"personal": 2, "raised": 20}, nil).Once()
+
+	service := NewDocService(testLog, docRepo, workloadRepo, WorkloadLimits{Reviewer: 12, Enforce: true}, DocRules{})
+	suggestions, err := service.SuggestReviewers(ctx, doc.ID, 0)
+
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"free", "raised"}, persons(suggestions))
+	for _, s := range suggestions {
+		if s.Person == "raised" {
+			assert.True(t, s.Workload.Personal)
+			assert.Equal(t, 20, s.Workload.Limit)
+		}
+	}
+}
+
+func TestSuggestReviewersDocNotFound(t *testing.T) {
+	ctx := context.Background()
+
+	docRepo := &mocks.DocRepo{}
+	docRepo.On("GetByID", ctx, 7).Return(nil, ErrDocNotFound).Once()
+
+	service := NewDocService(testLog, docRepo, &mocks.WorkloadRepo{}, WorkloadLimits{}, DocRules{})
+	_, err := service.SuggestReviewers(ctx, 7, 0)
+
+	assert.ErrorIs(t, err, ErrDocNotFound)
+	docRepo.AssertNotCalled(t, "GetReviewerCandidates", mock.Anything, mock.Anything)
+}
+
+func TestSuggestReviewerAssignments(t *testing.T) {
+	ctx := context.Background()
+	docs := []*entities.AssignedDoc{
+		{ID: 1, FIO: "Иванов Иван Иванович", Theme: "first", Year: 2024},
+		{ID: 2, FIO: "Петров Пётр Петрович", Theme: "second", Year: 2024},
+		{ID: 3, FIO: "Сидоров Сидор Сидорович", Theme: "third", Year: 2024},
+	}
+
+	docRepo := &mocks.DocRepo{}
+	docRepo.On("GetUnreviewedDocs", ctx, 2024).Return(docs, nil).Once()
+	docRepo.On("GetReviewerCandidates", ctx, []int{1, 2, 3}).Return(map[int][]*entities.ReviewerCandidate{
+		1: {
+			{Person: "best", Experience: 1, SameDiscipline: 1, ThemeSimilarity: 1, Reviews: 1},
+			{Person: "second", Experience: 1, SameDiscipline: 1, Reviews: 1},
+		},
+		2: {
+			{Person: "best", Experience: 1, SameDiscipline: 1, ThemeSimilarity: 1, Reviews: 1},
+			{Person: "second", Experience: 1, SameDiscipline: 1, Reviews: 1},
+		},
+		// Everyone is in conflict with the third doc
+	}, nil).Once()
+
+	workloadRepo := &mocks.WorkloadRepo{}
+	workloadRepo.On("GetLimits", ctx, entities.WorkloadReviewer).Return(map[string]int{}, nil).Once()
+
+	service := NewDocService(testLog, docRepo, workloadRepo, WorkloadLimits{Reviewer: 2, Enforce: true}, DocRules{})
+	assignments, err := service.SuggestReviewerAssignments(ctx, 2024)
+
+	assert.NoError(t, err)
+	if assert.Len(t, assignments, 3) {
+		assert.Equal(t, "best", assignments[0].Reviewer)
+		assert.Equal(t, []string{"second"}, persons(assignments[0].Alternatives))
+		// The first proposal takes the last review "best" had left
+		assert.Equal(t, "second", assignments[1].Reviewer)
+		assert.Empty(t, assignments[1].Alternatives)
+		assert.Equal(t, 3, assignments[2].DocID)
+		assert.Empty(t, assignments[2].Reviewer)
+	}
+}
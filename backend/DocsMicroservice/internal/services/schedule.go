@@ -0,0 +1,418 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+)
+
+var (
+	ErrCommissionNotFound  = errors.New("commission not found")
+	ErrSessionNotFound     = errors.New("defense session not found")
+	ErrSlotNotFound        = errors.New("defense slot not found")
+	ErrDuplicateMember     = errors.New("member is listed in the commission twice")
+	ErrBadCommission       = errors.New("commission must have one chair, at most one secretary and known member roles")
+	ErrBadSession          = errors.New("session must have a room and at least one slot of positive length")
+	ErrDocAlreadyScheduled = errors.New("document is already assigned to another slot")
+	ErrScheduleForbidden   = errors.New("role is not allowed to change the schedule")
+	ErrScheduleConflict    = errors.New("schedule conflict")
+)
+
+// scheduleRoles may change commissions, sessions and slots.
+var scheduleRoles = []string{entities.RoleSecretary, entities.RoleAdmin}
+
+// ConflictError lists everything that prevents a session or slot from being scheduled.
+type ConflictError struct {
+	Conflicts []*entities.Conflict
+}
+
+func (e *ConflictError) Error() string {
+	msgs := make([]string, 0, len(e.Conflicts))
+	for _, c := range e.Conflicts {
+		msgs = append(msgs, c.Description)
+	}
+
+	return fmt.Sprintf("%s: %s", ErrScheduleConflict, strings.Join(msgs, "; "))
+}
+
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrScheduleConflict
+}
+
+type ScheduleRepo interface {
+	CreateCommission(ctx context.Context, c *entities.Commission) (id int, err error)
+	GetCommission(ctx context.Context, id int) (*entities.Commission, error)
+	GetCommissions(ctx context.Context, year int) ([]*entities.Commission, error)
+	CreateSession(ctx context.Context, s *entities.Session) (id int, err error)
+	GetSession(ctx context.Context, id int) (*entities.Session, error)
+	GetSessions(ctx context.Context, from, to time.Time) ([]*entities.Session, error)
+	DeleteSession(ctx context.Context, id int) error
+	GetSlot(ctx context.Context, id int) (*entities.Slot, error)
+	AssignSlot(ctx context.Context, slotID, docID int) error
+	FindRoomSessions(ctx context.Context, room string, from, to time.Time) ([]*entities.Session, error)
+	FindBusyMembers(ctx context.Context, names []string, from, to time.Time, excludeSessionID int) ([]*entities.BusyMember, error)
+	FindSupervisedSlots(ctx context.Context, names []string, from, to time.Time) ([]*entities.Slot, error)
+}
+
+type ScheduleService struct {
+	log          *slog.Logger
+	scheduleRepo ScheduleRepo
+	docRepo      DocRepo
+}
+
+func NewScheduleService(
+	log *slog.Logger,
+	scheduleRepo ScheduleRepo,
+	docRepo DocRepo,
+) *ScheduleService {
+	return &ScheduleService{
+		log:          log,
+		scheduleRepo: scheduleRepo,
+		docRepo:      docRepo,
+	}
+}
+
+func canSchedule(actor *entities.Actor) bool {
+	return actor != nil && slices.Contains(scheduleRoles, actor.Role)
+}
+
+func validateCommission(c *entities.Commission) error {
+	chairs, secretaries := 0, 0
+	for _, m := range c.Members {
+		switch m.Role {
+		case entities.MemberChair:
+			chairs++
+		case entities.MemberSecretary:
+			secretaries++
+		case entities.MemberRegular:
+		default:
+			return ErrBadCommission
+		}
+	}
+	if chairs != 1 || secretaries > 1 {
+		return ErrBadCommission
+	}
+
+	return nil
+}
+
+func (s *ScheduleService) CreateCommission(
+	ctx context.Context,
+	actor *entities.Actor,
+	c *entities.Commission,
+) (*entities.Commission, error) {
+	const op = "Schedule.CreateCommission"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("commission", c.String()),
+	)
+
+	if !canSchedule(actor) {
+		log.Error("role is not allowed")
+		return nil, fmt.Errorf("%s: %w", op, ErrScheduleForbidden)
+	}
+
+	err := validateCommission(c)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	c.CreatedAt = time.Now()
+	c.ID, err = s.scheduleRepo.CreateCommission(ctx, c)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	log.Info("commission has been created")
+
+	return s.scheduleRepo.GetCommission(ctx, c.ID)
+}
+
+func (s *ScheduleService) GetCommissions(ctx context.Context, year int) ([]*entities.Commission, error) {
+	const op = "Schedule.GetCommissions"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.Int("year", year),
+	)
+
+	commissions, err := s.scheduleRepo.GetCommissions(ctx, year)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return commissions, nil
+}
+
+// conflictKinds gives the conflict kind of the supervising participant roles.
+var conflictKinds = map[string]string{
+	entities.ParticipantSupervisor:   entities.ConflictSupervisor,
+	entities.ParticipantCoSupervisor: entities.ConflictCoSupervisor,
+	entities.ParticipantReviewer:     entities.ConflictReviewer,
+}
+
+func memberNames(c *entities.Commission) []string {
+	names := make([]string, 0, len(c.Members))
+	for _, m := range c.Members {
+		names = append(names, m.Name)
+	}
+
+	return names
+}
+
+// sessionConflicts checks the new session against the room plan,
+// the other commissions and the docs defended at the same time.
+func (s *ScheduleService) sessionConflicts(ctx context.Context, session *entities.Session) ([]*entities.Conflict, error) {
+	conflicts := make([]*entities.Conflict, 0)
+
+	sessions, err := s.scheduleRepo.FindRoomSessions(ctx, session.Room, session.StartsAt, session.EndsAt)
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range sessions {
+		conflicts = append(conflicts, &entities.Conflict{
+			Kind:    entities.ConflictRoom,
+			Subject: other.Room,
+			Description: fmt.Sprintf("room %s is taken by session %d from %s to %s",
+				other.Room, other.ID, other.StartsAt.Format(time.DateTime), other.EndsAt.Format(time.DateTime)),
+		})
+	}
+
+	names := memberNames(session.Commission)
+
+	busy, err := s.scheduleRepo.FindBusyMembers(ctx, names, session.StartsAt, session.EndsAt, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range busy {
+		conflicts = append(conflicts, &entities.Conflict{
+			Kind:        entities.ConflictMember,
+			Subject:     b.Name,
+			Description: fmt.Sprintf("%s sits on the commission of session %d in room %s", b.Name, b.SessionID, b.Room),
+		})
+	}
+
+	slots, err := s.scheduleRepo.FindSupervisedSlots(ctx, names, session.StartsAt, session.EndsAt)
+	if err != nil {
+		return nil, err
+	}
+	for _, slot := range slots {
+		for _, p := range slot.Doc.Participants {
+			kind := conflictKinds[p.Role]
+			conflicts = append(conflicts, &entities.Conflict{
+				Kind:    kind,
+				Subject: p.Name,
+				Description: fmt.Sprintf("%s is the %s of %q defended in session %d at %s",
+					p.Name, kind, slot.Doc.Theme, slot.SessionID, slot.StartsAt.Format(time.DateTime)),
+			})
+		}
+	}
+
+	return conflicts, nil
+}
+
+// CreateSession splits the session into slotsCount ordered slots of slotMinutes each.
+func (s *ScheduleService) CreateSession(
+	ctx context.Context,
+	actor *entities.Actor,
+	session *entities.Session,
+	slotsCount int,
+) (*entities.Session, error) {
+	const op = "Schedule.CreateSession"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("session", session.String()),
+	)
+
+	if !canSchedule(actor) {
+		log.Error("role is not allowed")
+		return nil, fmt.Errorf("%s: %w", op, ErrScheduleForbidden)
+	}
+
+	session.Room = strings.TrimSpace(session.Room)
+	if session.Room == "" || session.SlotMinutes <= 0 || slotsCount <= 0 {
+		return nil, fmt.Errorf("%s: %w", op, ErrBadSession)
+	}
+
+	commission, err := s.scheduleRepo.GetCommission(ctx, session.CommissionID)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	session.Commission = commission
+
+	slotLen := time.Duration(session.SlotMinutes) * time.Minute
+	session.Slots = make([]*entities.Slot, 0, slotsCount)
+	for i := range slotsCount {
+		start := session.StartsAt.Add(time.Duration(i) * slotLen)
+		session.Slots = append(session.Slots, &entities.Slot{
+			Position: i + 1,
+			StartsAt: start,
+			EndsAt:   start.Add(slotLen),
+		})
+	}
+	session.EndsAt = session.StartsAt.Add(time.Duration(slotsCount) * slotLen)
+
+	conflicts, err := s.sessionConflicts(ctx, session)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(conflicts) > 0 {
+		log.Error("session has conflicts", slog.Int("conflicts", len(conflicts)))
+		return nil, fmt.Errorf("%s: %w", op, &ConflictError{Conflicts: conflicts})
+	}
+
+	session.ID, err = s.scheduleRepo.CreateSession(ctx, session)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	log.Info("session has been created")
+
+	return session, nil
+}
+
+func (s *ScheduleService) GetSession(ctx context.Context, id int) (*entities.Session, error) {
+	const op = "Schedule.GetSession"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.Int("id", id),
+	)
+
+	session, err := s.scheduleRepo.GetSession(ctx, id)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return session, nil
+}
+
+func (s *ScheduleService) GetSessions(ctx context.Context, from, to time.Time) ([]*entities.Session, error) {
+	const op = "Schedule.GetSessions"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.Time("from", from),
+		slog.Time("to", to),
+	)
+
+	sessions, err := s.scheduleRepo.GetSessions(ctx, from, to)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return sessions, nil
+}
+
+func (s *ScheduleService) DeleteSession(ctx context.Context, actor *entities.Actor, id int) error {
+	const op = "Schedule.DeleteSession"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.Int("id", id),
+	)
+
+	if !canSchedule(actor) {
+		log.Error("role is not allowed")
+		return fmt.Errorf("%s: %w", op, ErrScheduleForbidden)
+	}
+
+	err := s.scheduleRepo.DeleteSession(ctx, id)
+	if err != nil {
+		log.Error(err.Error())
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	log.Info("session has been deleted")
+
+	return nil
+}
+
+// AssignSlot puts the doc into the slot. Zero docID frees the slot.
+// The doc's supervisors and reviewer must not sit on another commission at that time.
+func (s *ScheduleService) AssignSlot(
+	ctx context.Context,
+	actor *entities.Actor,
+	slotID, docID int,
+) (*entities.Slot, error) {
+	const op = "Schedule.AssignSlot"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.Int("slot_id", slotID),
+		slog.Int("doc_id", docID),
+	)
+
+	if !canSchedule(actor) {
+		log.Error("role is not allowed")
+		return nil, fmt.Errorf("%s: %w", op, ErrScheduleForbidden)
+	}
+
+	slot, err := s.scheduleRepo.GetSlot(ctx, slotID)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if docID != 0 {
+		doc, err := s.docRepo.GetByID(ctx, docID)
+		if err != nil {
+			log.Error(err.Error())
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		slot.Doc = doc
+
+		kinds := make(map[string]string)
+		names := make([]string, 0)
+		for _, p := range doc.Participants {
+			if !slices.Contains(entities.SupervisingRoles, p.Role) {
+				continue
+			}
+			if _, ok := kinds[p.Name]; !ok {
+				kinds[p.Name] = conflictKinds[p.Role]
+				names = append(names, p.Name)
+			}
+		}
+
+		busy, err := s.scheduleRepo.FindBusyMembers(ctx, names, slot.StartsAt, slot.EndsAt, slot.SessionID)
+		if err != nil {
+			log.Error(err.Error())
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if len(busy) > 0 {
+			conflicts := make([]*entities.Conflict, 0, len(busy))
+			for _, b := range busy {
+				conflicts = append(conflicts, &entities.Conflict{
+					Kind:    kinds[b.Name],
+					Subject: b.Name,
+					Description: fmt.Sprintf("%s is the %s of %q and sits on the commission of session %d in room %s",
+						b.Name, kinds[b.Name], doc.Theme, b.SessionID, b.Room),
+				})
+			}
+			log.Error("slot has conflicts", slog.Int("conflicts", len(conflicts)))
+			return nil, fmt.Errorf("%s: %w", op, &ConflictError{Conflicts: conflicts})
+		}
+	}
+
+	err = s.scheduleRepo.AssignSlot(ctx, slotID, docID)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	slot.DocID = docID
+	log.Info("slot has been assigned")
+
+	return slot, nil
+}
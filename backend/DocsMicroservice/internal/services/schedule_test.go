@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+	"github.com/Homyakadze14/DocsMicroservice/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testLog = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+func TestAssignSlotChecksCoSupervisors(t *testing.T) {
+	ctx := context.Background()
+	secretary := &entities.Actor{UserID: 1, Role: entities.RoleSecretary}
+
+	doc := &entities.Doc{
+		ID:    7,
+		Theme: "theme",
+		Participants: []*entities.Participant{
+			{Role: entities.ParticipantStudent, Name: "ivanov"},
+			{Role: entities.ParticipantSupervisor, Name: "sidorov"},
+			{Role: entities.ParticipantCoSupervisor, Name: "kuznetsov"},
+			{Role: entities.ParticipantReviewer, Name: "petrov"},
+		},
+	}
+	slot := &entities.Slot{ID: 3, SessionID: 2}
+
+	docRepo := &mocks.DocRepo{}
+	docRepo.On("GetByID", ctx, doc.ID).Return(doc, nil).Once()
+
+	scheduleRepo := &mocks.ScheduleRepo{}
+	scheduleRepo.On("GetSlot", ctx, slot.ID).Return(slot, nil).Once()
+	scheduleRepo.On("FindBusyMembers", ctx, []string{"sidorov", "kuznetsov", "petrov"}, slot.StartsAt, slot.EndsAt, slot.SessionID).
+		Return([]*entities.BusyMember{{Name: "kuznetsov", SessionID: 5, Room: "101"}}, nil).Once()
+
+	service := NewScheduleService(testLog, scheduleRepo, docRepo)
+	_, err := service.AssignSlot(ctx, secretary, slot.ID, doc.ID)
+
+	assert.ErrorIs(t, err, ErrScheduleConflict)
+	scheduleRepo.AssertNotCalled(t, "AssignSlot", mock.Anything, mock.Anything, mock.Anything)
+
+	var cErr *ConflictError
+	if assert.ErrorAs(t, err, &cErr) && assert.Len(t, cErr.Conflicts, 1) {
+		assert.Equal(t, entities.ConflictCoSupervisor, cErr.Conflicts[0].Kind)
+		assert.Equal(t, "kuznetsov", cErr.Conflicts[0].Subject)
+	}
+}
+
+func TestAssignSlotForbidden(t *testing.T) {
+	ctx := context.Background()
+	student := &entities.Actor{UserID: 1, Role: entities.RoleStudent}
+
+	scheduleRepo := &mocks.ScheduleRepo{}
+	service := NewScheduleService(testLog, scheduleRepo, &mocks.DocRepo{})
+	_, err := service.AssignSlot(ctx, student, 3, 7)
+
+	assert.ErrorIs(t, err, ErrScheduleForbidden)
+	scheduleRepo.AssertNotCalled(t, "AssignSlot", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+	"github.com/Homyakadze14/DocsMicroservice/internal/lib/similarity"
+	"github.com/Homyakadze14/DocsMicroservice/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newDocService(docRepo DocRepo) *DocService {
+	return NewDocService(testLog, docRepo, nil, WorkloadLimits{}, DocRules{})
+}
+
+func TestDefaultSimilarityThreshold(t *testing.T) {
+	const theme = "Разработка информационной системы для учёта заявок"
+
+	tests := []struct {
+		name    string
+		other   string
+		similar bool
+	}{
+		{"other word forms", "Разработка информационных систем учета заявок", true},
+		{"one word replaced", "Разработка информационной системы учёта кадров", true},
+		{"same first word only", "Разработка мобильного приложения для магазина", false},
+		{"unrelated", "Анализ данных о продажах", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score := similarity.Jaccard(similarity.Tokens(theme), similarity.Tokens(tt.other))
+			assert.Equal(t, tt.similar, score >= DefaultSimilarityThreshold, "score %.2f", score)
+		})
+	}
+}
+
+func TestFindSimilarThemesDefaults(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		threshold     float64
+		limit         int
+		wantThreshold float64
+		wantLimit     int
+	}{
+		{"defaults", 0, 0, DefaultSimilarityThreshold, defaultSimilarLimit},
+		{"given", 0.8, 5, 0.8, 5},
+		{"limit capped", 1, 1000, 1, maxSimilarLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			docRepo := &mocks.DocRepo{}
+			docRepo.On("FindSimilarThemes", ctx, "theme", tt.wantThreshold, tt.wantLimit).
+				Return([]*entities.SimilarTheme{}, nil).Once()
+
+			_, err := newDocService(docRepo).FindSimilarThemes(ctx, "theme", tt.threshold, tt.limit)
+
+			assert.NoError(t, err)
+			docRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFindSimilarThemesBadThreshold(t *testing.T) {
+	ctx := context.Background()
+
+	for _, threshold := range []float64{-0.1, 1.1} {
+		docRepo := &mocks.DocRepo{}
+		_, err := newDocService(docRepo).FindSimilarThemes(ctx, "theme", threshold, 0)
+
+		assert.ErrorIs(t, err, ErrBadThreshold)
+		docRepo.AssertNotCalled(t, "FindSimilarThemes", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	}
+}
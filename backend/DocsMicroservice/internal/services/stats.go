@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+)
+
+var (
+	ErrUnknownDimension   = errors.New("unknown stats dimension")
+	ErrStatsDimensions    = errors.New("from one to three distinct group by dimensions are required")
+	ErrBadTop             = errors.New("top must be from 0 to 100")
+	ErrCompareWithoutYear = errors.New("year is required to compare with the previous one")
+	ErrCompareByYear      = errors.New("year-over-year comparison can't be grouped by year")
+)
+
+const (
+	maxStatsDimensions = 3
+	maxStatsTop        = 100
+)
+
+// GetStats counts the docs in groups for charts, e.g. theses per supervisor
+// and year or the load of reviewers.
+func (s *DocService) GetStats(ctx context.Context, q *entities.StatsQuery) (*entities.Stats, error) {
+	const op = "Doc.GetStats"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.Any("group_by", q.GroupBy),
+		slog.Int("top", q.Top),
+		slog.Bool("compare", q.ComparePreviousYear),
+	)
+
+	if len(q.GroupBy) == 0 || len(q.GroupBy) > maxStatsDimensions {
+		return nil, fmt.Errorf("%s: %w", op, ErrStatsDimensions)
+	}
+	for i, dim := range q.GroupBy {
+		if !slices.Contains(entities.StatsDimensions, dim) {
+			return nil, fmt.Errorf("%s: %w: %s", op, ErrUnknownDimension, dim)
+		}
+		if slices.Contains(q.GroupBy[:i], dim) {
+			return nil, fmt.Errorf("%s: %w", op, ErrStatsDimensions)
+		}
+	}
+	if q.Top < 0 || q.Top > maxStatsTop {
+		return nil, fmt.Errorf("%s: %w", op, ErrBadTop)
+	}
+	if q.Filter == nil {
+		q.Filter = &entities.Doc{}
+	}
+	if q.ComparePreviousYear {
+		if q.Filter.Year == 0 {
+			return nil, fmt.Errorf("%s: %w", op, ErrCompareWithoutYear)
+		}
+		if slices.Contains(q.GroupBy, entities.DocFieldYear) {
+			return nil, fmt.Errorf("%s: %w", op, ErrCompareByYear)
+		}
+	}
+
+	stats, err := s.docRepo.GetStats(ctx, q)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return stats, nil
+}
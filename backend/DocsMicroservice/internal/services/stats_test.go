@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+	"github.com/Homyakadze14/DocsMicroservice/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetStatsQuery(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		query *entities.StatsQuery
+		err   error
+	}{
+		{
+			name:  "three dimensions",
+			query: &entities.StatsQuery{GroupBy: []string{entities.DocFieldDirector, entities.DocFieldYear, entities.DocFieldType}},
+		},
+		{
+			name:  "compare with the previous year",
+			query: &entities.StatsQuery{GroupBy: []string{entities.DocFieldReviewer}, Filter: &entities.Doc{Year: 2024}, ComparePreviousYear: true},
+		},
+		{
+			name:  "no dimensions",
+			query: &entities.StatsQuery{},
+			err:   ErrStatsDimensions,
+		},
+		{
+			name: "too many dimensions",
+			query: &entities.StatsQuery{GroupBy: []string{
+				entities.DocFieldYear, entities.DocFieldType, entities.DocFieldGroup, entities.DocFieldDiscipline,
+			}},
+			err: ErrStatsDimensions,
+		},
+		{
+			name:  "repeated dimension",
+			query: &entities.StatsQuery{GroupBy: []string{entities.DocFieldType, entities.DocFieldType}},
+			err:   ErrStatsDimensions,
+		},
+		{
+			name:  "column outside the whitelist",
+			query: &entities.StatsQuery{GroupBy: []string{"theme"}},
+			err:   ErrUnknownDimension,
+		},
+		{
+			name:  "sql in a dimension",
+			query: &entities.StatsQuery{GroupBy: []string{"year; DROP TABLE docs"}},
+			err:   ErrUnknownDimension,
+		},
+		{
+			name:  "negative top",
+			query: &entities.StatsQuery{GroupBy: []string{entities.DocFieldYear}, Top: -1},
+			err:   ErrBadTop,
+		},
+		{
+			name:  "top above the limit",
+			query: &entities.StatsQuery{GroupBy: []string{entities.DocFieldYear}, Top: maxStatsTop + 1},
+			err:   ErrBadTop,
+		},
+		{
+			name:  "compare without a year",
+			query: &entities.StatsQuery{GroupBy: []string{entities.DocFieldType}, ComparePreviousYear: true},
+			err:   ErrCompareWithoutYear,
+		},
+		{
+			name:  "compare grouped by year",
+			query: &entities.StatsQuery{GroupBy: []string{entities.DocFieldYear}, Filter: &entities.Doc{Year: 2024}, ComparePreviousYear: true},
+			err:   ErrCompareByYear,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			docRepo := &mocks.DocRepo{}
+			docRepo.On("GetStats", ctx, tt.query).Return(&entities.Stats{}, nil).Maybe()
+
+			_, err := newDocService(docRepo).GetStats(ctx, tt.query)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				docRepo.AssertNotCalled(t, "GetStats", mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			docRepo.AssertExpectations(t)
+		})
+	}
+}
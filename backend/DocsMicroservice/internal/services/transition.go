@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+)
+
+var (
+	ErrBadTransition       = errors.New("transition is not allowed from the current status")
+	ErrTransitionForbidden = errors.New("actor is not allowed to make this transition")
+	ErrStatusConflict      = errors.New("document status was changed by another request")
+	ErrMissingOrder        = errors.New("order number is required to approve the topic")
+	ErrBadGrade            = errors.New("grade must be from 2 to 5")
+	ErrMissingDefenseDate  = errors.New("defense date is required")
+)
+
+const (
+	minGrade = 2
+	maxGrade = 5
+)
+
+// transitionRule tells who may make a move of the lifecycle. Staff roles may
+// make it on any doc; other accounts only on docs they take part in, as one
+// of the participant roles.
+type transitionRule struct {
+	staff        []string
+	participants []string
+}
+
+var (
+	staffRoles = []string{entities.RoleSecretary, entities.RoleAdmin}
+
+	staffOnly      = transitionRule{staff: staffRoles}
+	staffOrAuthors = transitionRule{
+		staff: staffRoles,
+		participants: []string{
+			entities.ParticipantStudent, entities.ParticipantSupervisor, entities.ParticipantCoSupervisor,
+		},
+	}
+)
+
+// transitions lists who may make every move of the lifecycle.
+var transitions = map[string]map[string]transitionRule{
+	entities.StatusTopicProposed: {
+		entities.StatusTopicApproved: staffOnly,
+	},
+	entities.StatusTopicApproved: {
+		entities.StatusInProgress: staffOrAuthors,
+	},
+	entities.StatusInProgress: {
+		entities.StatusSubmitted: staffOrAuthors,
+	},
+	entities.StatusSubmitted: {
+		entities.StatusReviewed: {
+			staff: staffRoles,
+			participants: []string{
+				entities.ParticipantSupervisor, entities.ParticipantCoSupervisor, entities.ParticipantReviewer,
+			},
+		},
+	},
+	entities.StatusReviewed: {
+		entities.StatusDefended: staffOnly,
+	},
+	entities.StatusDefended: {
+		entities.StatusArchived: staffOnly,
+	},
+}
+
+// allows reports whether the actor may make the move on the doc.
+func (r transitionRule) allows(doc *entities.Doc, actor *entities.Actor) bool {
+	return slices.Contains(r.staff, actor.Role) || isParticipant(doc, actor, r.participants...)
+}
+
+func (s *DocService) Transition(
+	ctx context.Context,
+	actor *entities.Actor,
+	id int,
+	to string,
+	params *entities.TransitionParams,
+) (*entities.Doc, *entities.Transition, error) {
+	const op = "Doc.Transition"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.Int("id", id),
+		slog.String("to", to),
+		slog.String("actor", actor.String()),
+	)
+
+	log.Info("trying to transition doc")
+	doc, err := s.docRepo.GetByID(ctx, id)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rule, ok := transitions[doc.Status][to]
+	if !ok {
+		log.Error(fmt.Sprintf("no transition from %q", doc.Status))
+		return nil, nil, fmt.Errorf("%s: %w: %s -> %s", op, ErrBadTransition, doc.Status, to)
+	}
+	if !rule.allows(doc, actor) {
+		log.Error("actor is not allowed")
+		return nil, nil, fmt.Errorf("%s: %w", op, ErrTransitionForbidden)
+	}
+
+	from := doc.Status
+	doc.Status = to
+	switch to {
+	case entities.StatusTopicApproved:
+		if params.Order != "" {
+			doc.Order = strings.TrimSpace(params.Order)
+		}
+		if doc.Order == "" {
+			return nil, nil, fmt.Errorf("%s: %w", op, ErrMissingOrder)
+		}
+	case entities.StatusDefended:
+		if params.Grade < minGrade || params.Grade > maxGrade {
+			return nil, nil, fmt.Errorf("%s: %w", op, ErrBadGrade)
+		}
+		if params.DefenseDate == nil {
+			return nil, nil, fmt.Errorf("%s: %w", op, ErrMissingDefenseDate)
+		}
+		doc.Grade = params.Grade
+		doc.DefenseDate = params.DefenseDate
+	}
+
+	tr := &entities.Transition{
+		DocID:      doc.ID,
+		FromStatus: from,
+		ToStatus:   to,
+		ActorID:    actor.UserID,
+		ActorName:  actor.Username,
+		ActorRole:  actor.Role,
+		Comment:    params.Comment,
+		CreatedAt:  time.Now(),
+	}
+
+	doc, err = s.docRepo.Transition(ctx, doc, from, tr)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	log.Info("doc has been transitioned")
+
+	return doc, tr, nil
+}
+
+func (s *DocService) GetTransitions(ctx context.Context, docID int) ([]*entities.Transition, error) {
+	const op = "Doc.GetTransitions"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.Int("doc_id", docID),
+	)
+
+	_, err := s.docRepo.GetByID(ctx, docID)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	history, err := s.docRepo.GetTransitions(ctx, docID)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return history, nil
+}
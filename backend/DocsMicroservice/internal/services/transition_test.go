@@ -0,0 +1,84 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransitionRules(t *testing.T) {
+	doc := &entities.Doc{
+		Participants: []*entities.Participant{
+			{Role: entities.ParticipantStudent, Name: "ivanov", UserID: 1},
+			{Role: entities.ParticipantSupervisor, Name: "sidorov", UserID: 2},
+			{Role: entities.ParticipantReviewer, Name: "petrov", UserID: 3},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		from  string
+		to    string
+		actor *entities.Actor
+		want  bool
+	}{
+		{
+			name:  "student of the doc starts work",
+			from:  entities.StatusTopicApproved,
+			to:    entities.StatusInProgress,
+			actor: &entities.Actor{UserID: 1, Role: entities.RoleStudent},
+			want:  true,
+		},
+		{
+			name:  "student of another doc",
+			from:  entities.StatusTopicApproved,
+			to:    entities.StatusInProgress,
+			actor: &entities.Actor{UserID: 4, Username: "ivanov", Role: entities.RoleStudent},
+			want:  false,
+		},
+		{
+			name:  "supervisor of another doc marks it reviewed",
+			from:  entities.StatusSubmitted,
+			to:    entities.StatusReviewed,
+			actor: &entities.Actor{UserID: 5, Role: entities.RoleSupervisor},
+			want:  false,
+		},
+		{
+			name:  "reviewer of the doc marks it reviewed",
+			from:  entities.StatusSubmitted,
+			to:    entities.StatusReviewed,
+			actor: &entities.Actor{UserID: 3, Role: entities.RoleSupervisor},
+			want:  true,
+		},
+		{
+			name:  "student of the doc marks it reviewed",
+			from:  entities.StatusSubmitted,
+			to:    entities.StatusReviewed,
+			actor: &entities.Actor{UserID: 1, Role: entities.RoleStudent},
+			want:  false,
+		},
+		{
+			name:  "supervisor of the doc approves the topic",
+			from:  entities.StatusTopicProposed,
+			to:    entities.StatusTopicApproved,
+			actor: &entities.Actor{UserID: 2, Role: entities.RoleSupervisor},
+			want:  false,
+		},
+		{
+			name:  "secretary approves the topic of any doc",
+			from:  entities.StatusTopicProposed,
+			to:    entities.StatusTopicApproved,
+			actor: &entities.Actor{UserID: 6, Role: entities.RoleSecretary},
+			want:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, ok := transitions[tt.from][tt.to]
+			assert.True(t, ok)
+			assert.Equal(t, tt.want, rule.allows(doc, tt.actor))
+		})
+	}
+}
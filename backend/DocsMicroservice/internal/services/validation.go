@@ -0,0 +1,161 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+)
+
+var ErrInvalidDoc = errors.New("document is invalid")
+
+// Validation rules reported with field violations.
+const (
+	RuleRequired  = "required"
+	RuleMaxLength = "max_length"
+	RuleOneOf     = "one_of"
+	RuleRange     = "range"
+	RuleFIOFormat = "fio_format"
+)
+
+// FieldViolation is a rule a doc field breaks.
+type FieldViolation struct {
+	Field   string
+	Rule    string
+	Message string
+}
+
+// ValidationError lists every rule the doc breaks.
+type ValidationError struct {
+	Violations []*FieldViolation
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", v.Field, v.Message))
+	}
+	return fmt.Sprintf("%s: %s", ErrInvalidDoc, strings.Join(msgs, "; "))
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrInvalidDoc
+}
+
+// DocRules are the allowed doc types and years. Years run from MinYear
+// to YearsAhead years after the current one.
+type DocRules struct {
+	Types      []string
+	MinYear    int
+	YearsAhead int
+}
+
+// Max lengths of the docs table columns.
+const (
+	maxTypeLength = 50
+	maxTextLength = 250
+)
+
+// requiredDocFields can't be empty as the columns are NOT NULL. The order
+// is set later by the status workflow, so it may be empty.
+var requiredDocFields = []string{
+	entities.DocFieldType, entities.DocFieldGroup, entities.DocFieldFIO,
+	entities.DocFieldTheme, entities.DocFieldDirector, entities.DocFieldYear,
+}
+
+// fioPattern matches a full name of at least two words of letters, e.g.
+// "Иванов Иван Иванович" or "Иванов И. И.".
+var fioPattern = regexp.MustCompile(`^\p{L}[\p{L}'’.-]*(\s+\p{L}[\p{L}'’.-]*)+$`)
+
+type docValidator struct {
+	rules      DocRules
+	violations []*FieldViolation
+}
+
+func (v *docValidator) add(field, rule, format string, args ...any) {
+	v.violations = append(v.violations, &FieldViolation{Field: field, Rule: rule, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *docValidator) text(field, value string, maxLength int) {
+	if value == "" {
+		if slices.Contains(requiredDocFields, field) {
+			v.add(field, RuleRequired, "must be provided")
+		}
+		return
+	}
+	if utf8.RuneCountInString(value) > maxLength {
+		v.add(field, RuleMaxLength, "must be at most %d characters", maxLength)
+	}
+}
+
+func (v *docValidator) fio(field, value string) {
+	if value != "" && !fioPattern.MatchString(value) {
+		v.add(field, RuleFIOFormat, "must be a full name of letters, e.g. \"Иванов Иван Иванович\"")
+	}
+}
+
+// validateDoc trims the listed fields of the doc and checks them against
+// the rules. It returns *ValidationError with every broken rule.
+func (s *DocService) validateDoc(doc *entities.Doc, fields []string) error {
+	v := &docValidator{rules: s.rules}
+	for _, field := range fields {
+		switch field {
+		case entities.DocFieldType:
+			doc.Type = strings.TrimSpace(doc.Type)
+			v.text(field, doc.Type, maxTypeLength)
+			if doc.Type != "" && len(v.rules.Types) > 0 && !slices.ContainsFunc(v.rules.Types, func(t string) bool {
+				return strings.EqualFold(t, doc.Type)
+			}) {
+				v.add(field, RuleOneOf, "must be one of: %s", strings.Join(v.rules.Types, ", "))
+			}
+		case entities.DocFieldGroup:
+			doc.Group = strings.TrimSpace(doc.Group)
+			v.text(field, doc.Group, maxTextLength)
+		case entities.DocFieldFIO:
+			doc.FIO = strings.TrimSpace(doc.FIO)
+			v.text(field, doc.FIO, maxTextLength)
+			v.fio(field, doc.FIO)
+		case entities.DocFieldTheme:
+			doc.Theme = strings.TrimSpace(doc.Theme)
+			v.text(field, doc.Theme, maxTextLength)
+		case entities.DocFieldDirector:
+			doc.Director = strings.TrimSpace(doc.Director)
+			v.text(field, doc.Director, maxTextLength)
+		case entities.DocFieldYear:
+			maxYear := time.Now().Year() + v.rules.YearsAhead
+			if doc.Year == 0 {
+				v.add(field, RuleRequired, "must be provided")
+			} else if doc.Year < v.rules.MinYear || doc.Year > maxYear {
+				v.add(field, RuleRange, "must be from %d to %d", v.rules.MinYear, maxYear)
+			}
+		case entities.DocFieldOrder:
+			doc.Order = strings.TrimSpace(doc.Order)
+			v.text(field, doc.Order, maxOrderNumber)
+		case entities.DocFieldReviewer:
+			doc.Reviewer = strings.TrimSpace(doc.Reviewer)
+			v.text(field, doc.Reviewer, maxTextLength)
+		case entities.DocFieldDiscipline:
+			doc.Discipline = strings.TrimSpace(doc.Discipline)
+			v.text(field, doc.Discipline, maxTextLength)
+		case entities.DocFieldParticipants:
+			for i, p := range doc.Participants {
+				name := fmt.Sprintf("participants[%d].name", i)
+				v.text(name, p.Name, maxTextLength)
+				if p.Role == entities.ParticipantStudent {
+					v.fio(name, p.Name)
+				}
+			}
+		}
+	}
+
+	if len(v.violations) > 0 {
+		return &ValidationError{Violations: v.violations}
+	}
+
+	return nil
+}
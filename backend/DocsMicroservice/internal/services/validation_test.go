@@ -0,0 +1,172 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFIOPattern(t *testing.T) {
+	tests := []struct {
+		fio   string
+		valid bool
+	}{
+		{"Иванов Иван Иванович", true},
+		{"Иванов И. И.", true},
+		{"Иванов И.И.", true},
+		{"Салтыков-Щедрин Михаил", true},
+		{"O'Brien John", true},
+		{"Иванов  Иван", true},
+		{"Иванов", false},
+		{"Иванов 1", false},
+		{"Иванов_Иван Иванович", false},
+		{"-Иванов Иван", false},
+		{"Иванов Иван ", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fio, func(t *testing.T) {
+			assert.Equal(t, tt.valid, fioPattern.MatchString(tt.fio))
+		})
+	}
+}
+
+func violatedRules(err error) map[string]string {
+	rules := make(map[string]string)
+	if vErr, ok := err.(*ValidationError); ok {
+		for _, v := range vErr.Violations {
+			rules[v.Field] = v.Rule
+		}
+	}
+
+	return rules
+}
+
+func TestValidateDoc(t *testing.T) {
+	year := time.Now().Year()
+	rules := DocRules{Types: []string{"diploma", "coursework"}, MinYear: 2000, YearsAhead: 1}
+
+	valid := func() *entities.Doc {
+		return &entities.Doc{
+			Type:     "diploma",
+			Group:    "ИВТ-41",
+			FIO:      "Иванов Иван Иванович",
+			Theme:    "Разработка информационной системы",
+			Director: "Петров П. П.",
+			Year:     year,
+		}
+	}
+
+	tests := []struct {
+		name  string
+		rules DocRules
+		doc   func(doc *entities.Doc)
+		want  map[string]string
+	}{
+		{
+			name: "valid",
+			doc:  func(*entities.Doc) {},
+			want: map[string]string{},
+		},
+		{
+			name: "type is case-insensitive",
+			doc:  func(doc *entities.Doc) { doc.Type = "Coursework" },
+			want: map[string]string{},
+		},
+		{
+			name: "type outside the list",
+			doc:  func(doc *entities.Doc) { doc.Type = "essay" },
+			want: map[string]string{entities.DocFieldType: RuleOneOf},
+		},
+		{
+			name:  "any type without a list",
+			rules: DocRules{MinYear: 2000, YearsAhead: 1},
+			doc:   func(doc *entities.Doc) { doc.Type = "essay" },
+			want:  map[string]string{},
+		},
+		{
+			name: "blank required fields",
+			doc: func(doc *entities.Doc) {
+				doc.Group, doc.Theme, doc.Year = "  ", "", 0
+			},
+			want: map[string]string{
+				entities.DocFieldGroup: RuleRequired,
+				entities.DocFieldTheme: RuleRequired,
+				entities.DocFieldYear:  RuleRequired,
+			},
+		},
+		{
+			name: "year too far ahead",
+			doc:  func(doc *entities.Doc) { doc.Year = year + 2 },
+			want: map[string]string{entities.DocFieldYear: RuleRange},
+		},
+		{
+			name: "year before the min year",
+			doc:  func(doc *entities.Doc) { doc.Year = 1999 },
+			want: map[string]string{entities.DocFieldYear: RuleRange},
+		},
+		{
+			name: "bad fio",
+			doc:  func(doc *entities.Doc) { doc.FIO = "Иванов" },
+			want: map[string]string{entities.DocFieldFIO: RuleFIOFormat},
+		},
+		{
+			name: "too long theme",
+			doc: func(doc *entities.Doc) {
+				doc.Theme = string(make([]rune, maxTextLength+1))
+			},
+			want: map[string]string{entities.DocFieldTheme: RuleMaxLength},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := rules
+			if tt.rules.MinYear != 0 {
+				r = tt.rules
+			}
+			service := NewDocService(testLog, nil, nil, WorkloadLimits{}, r)
+
+			doc := valid()
+			tt.doc(doc)
+			err := service.validateDoc(doc, entities.DocFields)
+
+			assert.Equal(t, tt.want, violatedRules(err))
+			if len(tt.want) == 0 {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrInvalidDoc)
+			}
+		})
+	}
+}
+
+func TestValidateDocTrims(t *testing.T) {
+	service := NewDocService(testLog, nil, nil, WorkloadLimits{}, DocRules{MinYear: 2000})
+
+	doc := &entities.Doc{Type: " diploma ", Group: " ИВТ-41 ", FIO: " Иванов Иван ", Theme: " theme ", Director: " Петров П. П. ", Year: 2020}
+	err := service.validateDoc(doc, entities.DocFields)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "diploma", doc.Type)
+	assert.Equal(t, "ИВТ-41", doc.Group)
+	assert.Equal(t, "Иванов Иван", doc.FIO)
+	assert.Equal(t, "theme", doc.Theme)
+	assert.Equal(t, "Петров П. П.", doc.Director)
+}
+
+func TestValidateParticipants(t *testing.T) {
+	service := NewDocService(testLog, nil, nil, WorkloadLimits{}, DocRules{})
+
+	doc := &entities.Doc{Participants: []*entities.Participant{
+		{Role: entities.ParticipantStudent, Name: "Иванов Иван"},
+		{Role: entities.ParticipantStudent, Name: "Петров"},
+		{Role: entities.ParticipantConsultant, Name: "ООО Рога"},
+	}}
+	err := service.validateDoc(doc, []string{entities.DocFieldParticipants})
+
+	assert.Equal(t, map[string]string{"participants[1].name": RuleFIOFormat}, violatedRules(err))
+}
@@ -0,0 +1,256 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+)
+
+var (
+	ErrWorkloadExceeded      = errors.New("workload limit would be exceeded")
+	ErrUnknownWorkloadRole   = errors.New("workload role must be supervisor or reviewer")
+	ErrBadWorkloadLimit      = errors.New("workload limit must not be negative")
+	ErrMissingWorkloadPerson = errors.New("person is required")
+	ErrWorkloadForbidden     = errors.New("only secretaries and admins are allowed to change workload limits")
+)
+
+// WorkloadError lists the people whose limits the doc would exceed.
+type WorkloadError struct {
+	Exceeded []*entities.Workload
+}
+
+func (e *WorkloadError) Error() string {
+	msgs := make([]string, 0, len(e.Exceeded))
+	for _, w := range e.Exceeded {
+		msgs = append(msgs, w.String())
+	}
+	return fmt.Sprintf("%s: %s", ErrWorkloadExceeded, strings.Join(msgs, "; "))
+}
+
+func (e *WorkloadError) Is(target error) bool {
+	return target == ErrWorkloadExceeded
+}
+
+// WorkloadLimits are the limits for everyone without a personal one.
+// Without Enforce exceeding them only produces warnings.
+type WorkloadLimits struct {
+	Supervisor int
+	Reviewer   int
+	Enforce    bool
+}
+
+func (l WorkloadLimits) of(role string) int {
+	if role == entities.WorkloadReviewer {
+		return l.Reviewer
+	}
+	return l.Supervisor
+}
+
+type WorkloadRepo interface {
+	CountDocs(ctx context.Context, role, person string, year, excludeID int) (int, error)
+	GetPersonalLimits(ctx context.Context, person string) (map[string]int, error)
+	SetPersonalLimit(ctx context.Context, person, role string, limit int) error
+	DeletePersonalLimit(ctx context.Context, person, role string) error
+	GetLimits(ctx context.Context, role string) (map[string]int, error)
+}
+
+// workload counts the docs of the person in the role, leaving out the doc with excludeID.
+func (s *DocService) workload(ctx context.Context, person, role string, year, excludeID int) (*entities.Workload, error) {
+	personal, err := s.workloadRepo.GetPersonalLimits(ctx, person)
+	if err != nil {
+		return nil, err
+	}
+	count, err := s.workloadRepo.CountDocs(ctx, role, person, year, excludeID)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &entities.Workload{
+		Person: strings.ToLower(person),
+		Role:   role,
+		Year:   year,
+		Count:  count,
+		Limit:  s.limits.of(role),
+	}
+	if limit, ok := personal[role]; ok {
+		w.Limit, w.Personal = limit, true
+	}
+
+	return w, nil
+}
+
+// checkWorkload counts the doc for the people in the given workload roles.
+// The exceeded limits are returned as warnings, or as WorkloadError when they
+// are enforced.
+func (s *DocService) checkWorkload(ctx context.Context, doc *entities.Doc, persons map[string][]string) ([]*entities.Workload, error) {
+	warnings := make([]*entities.Workload, 0)
+	for _, role := range entities.WorkloadRoles {
+		for _, person := range persons[role] {
+			w, err := s.workload(ctx, person, role, doc.Year, doc.ID)
+			if err != nil {
+				return nil, err
+			}
+			w.Count++
+			if w.Exceeded() {
+				warnings = append(warnings, w)
+			}
+		}
+	}
+
+	if len(warnings) > 0 && s.limits.Enforce {
+		return nil, &WorkloadError{Exceeded: warnings}
+	}
+
+	return warnings, nil
+}
+
+// workloadPersons returns the participants of the doc by the workload role
+// they are counted in, each name once.
+func workloadPersons(doc *entities.Doc) map[string][]string {
+	persons := make(map[string][]string, len(entities.WorkloadRoles))
+	for _, role := range entities.WorkloadRoles {
+		for _, p := range doc.Participants {
+			name := strings.ToLower(strings.TrimSpace(p.Name))
+			if name != "" && slices.Contains(entities.WorkloadParticipants[role], p.Role) &&
+				!slices.Contains(persons[role], name) {
+				persons[role] = append(persons[role], name)
+			}
+		}
+	}
+
+	return persons
+}
+
+// addedWorkload returns the people whose docs count grows when the doc
+// becomes upd: all of its participants when the year changes, only the new
+// ones otherwise.
+func addedWorkload(doc, upd *entities.Doc) map[string][]string {
+	persons := workloadPersons(upd)
+	if upd.Year != doc.Year {
+		return persons
+	}
+
+	current := workloadPersons(doc)
+	for role, names := range persons {
+		persons[role] = slices.DeleteFunc(names, func(name string) bool {
+			return slices.Contains(current[role], name)
+		})
+	}
+
+	return persons
+}
+
+// GetWorkload returns the docs count of the person against the limits in both
+// roles. Zero year means the current one.
+func (s *DocService) GetWorkload(ctx context.Context, person string, year int) ([]*entities.Workload, error) {
+	const op = "Doc.GetWorkload"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("person", person),
+		slog.Int("year", year),
+	)
+
+	if strings.TrimSpace(person) == "" {
+		return nil, fmt.Errorf("%s: %w", op, ErrMissingWorkloadPerson)
+	}
+	if year == 0 {
+		year = time.Now().Year()
+	}
+
+	workload := make([]*entities.Workload, 0, len(entities.WorkloadRoles))
+	for _, role := range entities.WorkloadRoles {
+		w, err := s.workload(ctx, person, role, year, 0)
+		if err != nil {
+			log.Error(err.Error())
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		workload = append(workload, w)
+	}
+
+	return workload, nil
+}
+
+// SetWorkloadLimit sets the personal limit of the person in the role.
+// Only secretaries and admins may do it.
+func (s *DocService) SetWorkloadLimit(
+	ctx context.Context,
+	actor *entities.Actor,
+	person, role string,
+	limit int,
+) error {
+	const op = "Doc.SetWorkloadLimit"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("person", person),
+		slog.String("role", role),
+		slog.Int("limit", limit),
+		slog.String("actor", actor.String()),
+	)
+
+	err := s.checkWorkloadLimit(actor, person, role)
+	if err != nil {
+		log.Error(err.Error())
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if limit < 0 {
+		return fmt.Errorf("%s: %w", op, ErrBadWorkloadLimit)
+	}
+
+	err = s.workloadRepo.SetPersonalLimit(ctx, person, role, limit)
+	if err != nil {
+		log.Error(err.Error())
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	log.Info("workload limit has been set")
+
+	return nil
+}
+
+// ResetWorkloadLimit removes the personal limit, so the configured one applies.
+func (s *DocService) ResetWorkloadLimit(ctx context.Context, actor *entities.Actor, person, role string) error {
+	const op = "Doc.ResetWorkloadLimit"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("person", person),
+		slog.String("role", role),
+		slog.String("actor", actor.String()),
+	)
+
+	err := s.checkWorkloadLimit(actor, person, role)
+	if err != nil {
+		log.Error(err.Error())
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.workloadRepo.DeletePersonalLimit(ctx, person, role)
+	if err != nil {
+		log.Error(err.Error())
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	log.Info("workload limit has been reset")
+
+	return nil
+}
+
+func (s *DocService) checkWorkloadLimit(actor *entities.Actor, person, role string) error {
+	if actor.Role != entities.RoleSecretary && actor.Role != entities.RoleAdmin {
+		return ErrWorkloadForbidden
+	}
+	if strings.TrimSpace(person) == "" {
+		return ErrMissingWorkloadPerson
+	}
+	if !slices.Contains(entities.WorkloadRoles, role) {
+		return ErrUnknownWorkloadRole
+	}
+
+	return nil
+}
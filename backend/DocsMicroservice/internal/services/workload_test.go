@@ -0,0 +1,78 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/Homyakadze14/DocsMicroservice/internal/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkloadPersons(t *testing.T) {
+	doc := &entities.Doc{Participants: []*entities.Participant{
+		{Role: entities.ParticipantStudent, Name: "ivanov", Position: 1},
+		{Role: entities.ParticipantSupervisor, Name: "Petrov", Position: 1},
+		{Role: entities.ParticipantCoSupervisor, Name: "sidorov", Position: 1},
+		{Role: entities.ParticipantCoSupervisor, Name: "petrov", Position: 2},
+		{Role: entities.ParticipantConsultant, Name: "smirnov", Position: 1},
+		{Role: entities.ParticipantReviewer, Name: "kuznetsov", Position: 1},
+	}}
+
+	assert.Equal(t, map[string][]string{
+		entities.WorkloadSupervisor: {"petrov", "sidorov"},
+		entities.WorkloadReviewer:   {"kuznetsov"},
+	}, workloadPersons(doc))
+}
+
+func TestAddedWorkload(t *testing.T) {
+	current := &entities.Doc{Year: 2024, Participants: []*entities.Participant{
+		{Role: entities.ParticipantSupervisor, Name: "petrov", Position: 1},
+		{Role: entities.ParticipantReviewer, Name: "kuznetsov", Position: 1},
+	}}
+
+	tests := []struct {
+		name string
+		upd  func(doc *entities.Doc)
+		want map[string][]string
+	}{
+		{
+			name: "co-supervisor added",
+			upd: func(doc *entities.Doc) {
+				doc.Participants = append(doc.Participants,
+					&entities.Participant{Role: entities.ParticipantCoSupervisor, Name: "sidorov", Position: 1})
+			},
+			want: map[string][]string{entities.WorkloadSupervisor: {"sidorov"}, entities.WorkloadReviewer: {}},
+		},
+		{
+			name: "reviewer replaced",
+			upd: func(doc *entities.Doc) {
+				doc.Participants = withFirstParticipant(doc.Participants, entities.ParticipantReviewer, "smirnov")
+			},
+			want: map[string][]string{entities.WorkloadSupervisor: {}, entities.WorkloadReviewer: {"smirnov"}},
+		},
+		{
+			name: "reviewer removed",
+			upd: func(doc *entities.Doc) {
+				doc.Participants = withFirstParticipant(doc.Participants, entities.ParticipantReviewer, "")
+			},
+			want: map[string][]string{entities.WorkloadSupervisor: {}},
+		},
+		{
+			name: "year changed",
+			upd:  func(doc *entities.Doc) { doc.Year = 2025 },
+			want: map[string][]string{
+				entities.WorkloadSupervisor: {"petrov"},
+				entities.WorkloadReviewer:   {"kuznetsov"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := *current
+			tt.upd(&next)
+
+			assert.Equal(t, tt.want, addedWorkload(current, &next))
+			assert.Len(t, current.Participants, 2)
+		})
+	}
+}
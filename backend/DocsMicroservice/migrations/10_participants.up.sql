@@ -0,0 +1,24 @@
+-- Students, supervisors, co-supervisors, consultants and reviewers of a doc.
+-- fio, director and reviewer of docs keep the first student, supervisor and
+-- reviewer for older clients.
+CREATE TABLE IF NOT EXISTS doc_participant(
+    doc_id INT NOT NULL REFERENCES docs(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL,
+    position INT NOT NULL,
+    name VARCHAR(250) NOT NULL,
+    PRIMARY KEY (doc_id, role, position)
+);
+
+CREATE INDEX IF NOT EXISTS doc_participant_name_idx ON doc_participant(name);
+
+INSERT INTO doc_participant(doc_id, role, position, name)
+SELECT id, 'student', 1, fio FROM docs WHERE fio <> ''
+ON CONFLICT DO NOTHING;
+
+INSERT INTO doc_participant(doc_id, role, position, name)
+SELECT id, 'supervisor', 1, director FROM docs WHERE director <> ''
+ON CONFLICT DO NOTHING;
+
+INSERT INTO doc_participant(doc_id, role, position, name)
+SELECT id, 'reviewer', 1, reviewer FROM docs WHERE COALESCE(reviewer, '') <> ''
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS commission_member_user_idx;
+DROP INDEX IF EXISTS doc_participant_user_idx;
+
+ALTER TABLE commission_member DROP COLUMN IF EXISTS user_id;
+ALTER TABLE doc_participant DROP COLUMN IF EXISTS user_id;
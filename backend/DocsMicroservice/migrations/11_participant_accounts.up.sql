@@ -0,0 +1,5 @@
+ALTER TABLE doc_participant ADD COLUMN IF NOT EXISTS user_id INT;
+ALTER TABLE commission_member ADD COLUMN IF NOT EXISTS user_id INT;
+
+CREATE INDEX IF NOT EXISTS doc_participant_user_idx ON doc_participant(user_id);
+CREATE INDEX IF NOT EXISTS commission_member_user_idx ON commission_member(user_id);
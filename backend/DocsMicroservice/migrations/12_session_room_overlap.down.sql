@@ -0,0 +1 @@
+ALTER TABLE defense_session DROP CONSTRAINT IF EXISTS defense_session_room_overlap;
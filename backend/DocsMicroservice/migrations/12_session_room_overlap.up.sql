@@ -0,0 +1,6 @@
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+-- Conflicts are checked before a session is created, the constraint keeps
+-- two concurrent requests from booking the same room.
+ALTER TABLE defense_session ADD CONSTRAINT defense_session_room_overlap
+    EXCLUDE USING gist (lower(room) WITH =, tstzrange(starts_at, ends_at) WITH &&);
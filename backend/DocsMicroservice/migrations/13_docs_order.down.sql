@@ -0,0 +1,5 @@
+ALTER TABLE docs ADD COLUMN IF NOT EXISTS order_name VARCHAR(250) NOT NULL DEFAULT '';
+
+UPDATE docs d SET order_name = lower(o.number)
+FROM doc_order x JOIN orders o ON o.id = x.order_id AND o.type = 'theme_approval'
+WHERE x.doc_id = d.id;
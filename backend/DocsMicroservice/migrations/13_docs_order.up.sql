@@ -0,0 +1,21 @@
+-- Moves the free text order of docs to theme approval orders. Legacy names
+-- carry no date, the first approval of the docs stands in for it, or the
+-- start of the year if there was none.
+INSERT INTO orders(number, order_date, type, year, title, author_id, author_name, created_at)
+SELECT left(d.order_name, 100), COALESCE(min(t.created_at)::date, make_date(d.year, 1, 1)),
+    'theme_approval', d.year, min(d.order_name), 0, '', now()
+FROM docs d
+LEFT JOIN doc_transition t ON t.doc_id = d.id AND t.to_status = 'topic_approved'
+WHERE d.order_name <> ''
+-- Numbers are cut to their column, names sharing the first 100 characters
+-- become one order
+GROUP BY left(d.order_name, 100), d.year
+ON CONFLICT DO NOTHING;
+
+INSERT INTO doc_order(doc_id, order_id)
+SELECT d.id, o.id FROM docs d
+JOIN orders o ON o.type = 'theme_approval' AND o.year = d.year AND o.number = left(d.order_name, 100)
+WHERE d.order_name <> ''
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE docs DROP COLUMN IF EXISTS order_name;
@@ -0,0 +1,2 @@
+-- The linked accounts are kept, the column itself is dropped by 11.
+SELECT 1;
@@ -0,0 +1,8 @@
+-- Participants copied from the scalar doc fields have no account, link them
+-- to the only account whose username is their name.
+UPDATE doc_participant p SET user_id = a.id
+FROM (
+    SELECT lower(username) AS name, min(id) AS id FROM account
+    GROUP BY lower(username) HAVING count(*) = 1
+) a
+WHERE p.user_id IS NULL AND p.name = a.name;
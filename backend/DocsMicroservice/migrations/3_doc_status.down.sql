@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS doc_transition;
+
+DROP INDEX IF EXISTS docs_status_idx;
+
+ALTER TABLE docs
+    DROP COLUMN IF EXISTS status,
+    DROP COLUMN IF EXISTS grade,
+    DROP COLUMN IF EXISTS defense_date;
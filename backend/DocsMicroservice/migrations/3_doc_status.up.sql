@@ -0,0 +1,23 @@
+ALTER TABLE docs
+    ADD COLUMN IF NOT EXISTS status VARCHAR(30) NOT NULL DEFAULT 'topic_proposed',
+    ADD COLUMN IF NOT EXISTS grade SMALLINT,
+    ADD COLUMN IF NOT EXISTS defense_date DATE;
+
+-- Records with an order number already have an approved topic
+UPDATE docs SET status='topic_approved' WHERE order_name <> '';
+
+CREATE INDEX IF NOT EXISTS docs_status_idx ON docs(status);
+
+CREATE TABLE IF NOT EXISTS doc_transition(
+    id INT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    doc_id INT NOT NULL REFERENCES docs(id) ON DELETE CASCADE,
+    from_status VARCHAR(30) NOT NULL,
+    to_status VARCHAR(30) NOT NULL,
+    actor_id INT NOT NULL,
+    actor_name VARCHAR(250) NOT NULL,
+    actor_role VARCHAR(20) NOT NULL,
+    comment TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS doc_transition_doc_id_idx ON doc_transition(doc_id);
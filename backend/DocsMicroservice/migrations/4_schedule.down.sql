@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS defense_slot;
+DROP TABLE IF EXISTS defense_session;
+DROP TABLE IF EXISTS commission_member;
+DROP TABLE IF EXISTS commission;
@@ -0,0 +1,38 @@
+CREATE TABLE IF NOT EXISTS commission(
+    id INT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    name VARCHAR(250) NOT NULL,
+    year INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS commission_member(
+    id INT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    commission_id INT NOT NULL REFERENCES commission(id) ON DELETE CASCADE,
+    name VARCHAR(250) NOT NULL,
+    role VARCHAR(20) NOT NULL,
+    UNIQUE (commission_id, name)
+);
+
+CREATE INDEX IF NOT EXISTS commission_member_name_idx ON commission_member(name);
+
+CREATE TABLE IF NOT EXISTS defense_session(
+    id INT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    commission_id INT NOT NULL REFERENCES commission(id) ON DELETE CASCADE,
+    room VARCHAR(100) NOT NULL,
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    slot_minutes INTEGER NOT NULL,
+    CHECK (starts_at < ends_at)
+);
+
+CREATE INDEX IF NOT EXISTS defense_session_time_idx ON defense_session(starts_at, ends_at);
+
+CREATE TABLE IF NOT EXISTS defense_slot(
+    id INT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    session_id INT NOT NULL REFERENCES defense_session(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    doc_id INT UNIQUE REFERENCES docs(id) ON DELETE SET NULL,
+    UNIQUE (session_id, position)
+);
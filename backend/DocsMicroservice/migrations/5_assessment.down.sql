@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS defense_protocol;
+
+DROP TABLE IF EXISTS doc_feedback;
+
+DROP TABLE IF EXISTS doc_review;
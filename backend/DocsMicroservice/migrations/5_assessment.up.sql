@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS doc_review(
+    doc_id INT PRIMARY KEY REFERENCES docs(id) ON DELETE CASCADE,
+    author_id INT NOT NULL,
+    author_name VARCHAR(250) NOT NULL,
+    grade SMALLINT NOT NULL CHECK (grade BETWEEN 2 AND 5),
+    text TEXT NOT NULL DEFAULT '',
+    file_name VARCHAR(250) NOT NULL DEFAULT '',
+    file_type VARCHAR(100) NOT NULL DEFAULT '',
+    file BYTEA,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS doc_feedback(
+    doc_id INT PRIMARY KEY REFERENCES docs(id) ON DELETE CASCADE,
+    author_id INT NOT NULL,
+    author_name VARCHAR(250) NOT NULL,
+    text TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS defense_protocol(
+    doc_id INT PRIMARY KEY REFERENCES docs(id) ON DELETE CASCADE,
+    number VARCHAR(50) NOT NULL UNIQUE,
+    grade SMALLINT NOT NULL CHECK (grade BETWEEN 2 AND 5),
+    session_id INT REFERENCES defense_session(id) ON DELETE SET NULL,
+    questions TEXT NOT NULL DEFAULT '',
+    notes TEXT NOT NULL DEFAULT '',
+    author_id INT NOT NULL,
+    author_name VARCHAR(250) NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
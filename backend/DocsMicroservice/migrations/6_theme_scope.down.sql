@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS docs_theme_tokens_idx;
+
+ALTER TABLE docs DROP COLUMN IF EXISTS theme_tokens;
+
+ALTER TABLE docs DROP CONSTRAINT IF EXISTS docs_type_year_theme_key;
+
+ALTER TABLE docs ADD CONSTRAINT docs_theme_key UNIQUE (theme);
@@ -0,0 +1,8 @@
+ALTER TABLE docs DROP CONSTRAINT IF EXISTS docs_theme_key;
+
+ALTER TABLE docs ADD CONSTRAINT docs_type_year_theme_key UNIQUE (type, year, theme);
+
+-- Filled by the service on start for existing rows.
+ALTER TABLE docs ADD COLUMN IF NOT EXISTS theme_tokens TEXT[];
+
+CREATE INDEX IF NOT EXISTS docs_theme_tokens_idx ON docs USING GIN (theme_tokens);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS doc_attachment_text;
+
+DROP TABLE IF EXISTS doc_attachment;
@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS doc_attachment(
+    id SERIAL PRIMARY KEY,
+    doc_id INT NOT NULL REFERENCES docs(id) ON DELETE CASCADE,
+    name VARCHAR(250) NOT NULL,
+    type VARCHAR(100) NOT NULL DEFAULT '',
+    content BYTEA NOT NULL,
+    text_status VARCHAR(20) NOT NULL,
+    text_error TEXT NOT NULL DEFAULT '',
+    author_id INT NOT NULL,
+    author_name VARCHAR(250) NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS doc_attachment_doc_id_idx ON doc_attachment (doc_id);
+
+-- Pages of PDF files and paragraphs of DOCX and ODT files.
+CREATE TABLE IF NOT EXISTS doc_attachment_text(
+    attachment_id INT NOT NULL REFERENCES doc_attachment(id) ON DELETE CASCADE,
+    doc_id INT NOT NULL REFERENCES docs(id) ON DELETE CASCADE,
+    unit VARCHAR(20) NOT NULL,
+    number INT NOT NULL,
+    text TEXT NOT NULL,
+    tsv TSVECTOR NOT NULL,
+    PRIMARY KEY (attachment_id, unit, number)
+);
+
+CREATE INDEX IF NOT EXISTS doc_attachment_text_tsv_idx ON doc_attachment_text USING GIN (tsv);
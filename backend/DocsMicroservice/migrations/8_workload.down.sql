@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS docs_reviewer_year_idx;
+
+DROP INDEX IF EXISTS docs_director_year_idx;
+
+DROP TABLE IF EXISTS workload_limit;
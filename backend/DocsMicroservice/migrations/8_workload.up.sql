@@ -0,0 +1,11 @@
+-- Personal limits take precedence over the configured defaults.
+CREATE TABLE IF NOT EXISTS workload_limit(
+    person VARCHAR(250) NOT NULL,
+    role VARCHAR(20) NOT NULL,
+    max_docs INT NOT NULL CHECK (max_docs >= 0),
+    PRIMARY KEY (person, role)
+);
+
+CREATE INDEX IF NOT EXISTS docs_director_year_idx ON docs (director, year);
+
+CREATE INDEX IF NOT EXISTS docs_reviewer_year_idx ON docs (reviewer, year);
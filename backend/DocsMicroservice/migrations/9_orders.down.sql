@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS doc_order;
+
+DROP TABLE IF EXISTS orders;
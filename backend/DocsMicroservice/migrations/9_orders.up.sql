@@ -0,0 +1,27 @@
+-- University orders approving themes, assigning reviewers or forming commissions.
+-- year is the academic year the order belongs to, like docs.year.
+CREATE TABLE IF NOT EXISTS orders(
+    id INT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    number VARCHAR(100) NOT NULL,
+    order_date DATE NOT NULL,
+    type VARCHAR(30) NOT NULL,
+    year INTEGER NOT NULL,
+    title VARCHAR(500) NOT NULL DEFAULT '',
+    file_name VARCHAR(250) NOT NULL DEFAULT '',
+    file_type VARCHAR(100) NOT NULL DEFAULT '',
+    file_content BYTEA,
+    author_id INT NOT NULL,
+    author_name VARCHAR(250) NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    UNIQUE (number, order_date)
+);
+
+CREATE INDEX IF NOT EXISTS orders_year_idx ON orders(year);
+
+CREATE TABLE IF NOT EXISTS doc_order(
+    doc_id INT NOT NULL REFERENCES docs(id) ON DELETE CASCADE,
+    order_id INT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    PRIMARY KEY (doc_id, order_id)
+);
+
+CREATE INDEX IF NOT EXISTS doc_order_order_id_idx ON doc_order(order_id);
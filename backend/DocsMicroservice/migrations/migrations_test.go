@@ -0,0 +1,111 @@
+//go:build migrate
+
+package migrations
+
+import (
+	"database/sql"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// service is a set of migrations with the table keeping its version, as
+// its migrate.go runs them.
+type service struct {
+	name  string
+	dir   string
+	table string
+}
+
+var (
+	auth = service{"auth", "../../AuthMicroservice/migrations", "auth_migrations"}
+	docs = service{"docs", ".", "docs_migrations"}
+)
+
+// lastVersion returns the version of the newest migration in the dir.
+func lastVersion(t *testing.T, dir string) uint {
+	files, err := filepath.Glob(filepath.Join(dir, "*.up.sql"))
+	require.NoError(t, err)
+
+	var last uint
+	for _, f := range files {
+		v, err := strconv.ParseUint(strings.SplitN(filepath.Base(f), "_", 2)[0], 10, 64)
+		require.NoError(t, err)
+		last = max(last, uint(v))
+	}
+	return last
+}
+
+// up migrates the service in the schema and returns its version.
+func up(t *testing.T, databaseURL, schema string, s service) (uint, error) {
+	u, err := url.Parse(databaseURL)
+	require.NoError(t, err)
+
+	q := u.Query()
+	q.Set("x-migrations-table", s.table)
+	q.Set("search_path", schema+",public")
+	if !q.Has("sslmode") {
+		q.Set("sslmode", "disable")
+	}
+	u.RawQuery = q.Encode()
+
+	m, err := migrate.New("file://"+s.dir, u.String())
+	require.NoError(t, err)
+	defer m.Close()
+
+	err = m.Up()
+	version, _, verr := m.Version()
+	require.NoError(t, verr)
+
+	return version, err
+}
+
+// TestServicesShareDatabase runs Auth and Docs against one database in
+// either order, as they are deployed. Needs PG_URL and the migrate tag.
+func TestServicesShareDatabase(t *testing.T) {
+	databaseURL := os.Getenv("PG_URL")
+	if databaseURL == "" {
+		t.Skip("PG_URL is not set")
+	}
+
+	db, err := sql.Open("pgx", databaseURL)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// Extensions are per database, keep them out of the test schemas
+	_, err = db.Exec("CREATE EXTENSION IF NOT EXISTS btree_gist SCHEMA public")
+	require.NoError(t, err)
+
+	for _, order := range [][]service{{auth, docs}, {docs, auth}} {
+		name := order[0].name + "_first"
+		t.Run(name, func(t *testing.T) {
+			schema := "migrate_" + name
+			_, err := db.Exec("DROP SCHEMA IF EXISTS " + schema + " CASCADE; CREATE SCHEMA " + schema)
+			require.NoError(t, err)
+			defer db.Exec("DROP SCHEMA IF EXISTS " + schema + " CASCADE")
+
+			for _, s := range order {
+				version, err := up(t, databaseURL, schema, s)
+				assert.NoError(t, err, s.name)
+				assert.Equal(t, lastVersion(t, s.dir), version, s.name)
+			}
+
+			// Restarting either service finds nothing to do
+			for _, s := range order {
+				_, err := up(t, databaseURL, schema, s)
+				assert.True(t, errors.Is(err, migrate.ErrNoChange), "%s: %v", s.name, err)
+			}
+		})
+	}
+}
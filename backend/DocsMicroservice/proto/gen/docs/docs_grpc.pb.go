@@ -19,22 +19,86 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Docs_Create_FullMethodName      = "/Docs/Create"
-	Docs_Delete_FullMethodName      = "/Docs/Delete"
-	Docs_GetFiltered_FullMethodName = "/Docs/GetFiltered"
-	Docs_Search_FullMethodName      = "/Docs/Search"
-	Docs_Update_FullMethodName      = "/Docs/Update"
+	Docs_Create_FullMethodName                     = "/Docs/Create"
+	Docs_Delete_FullMethodName                     = "/Docs/Delete"
+	Docs_GetFiltered_FullMethodName                = "/Docs/GetFiltered"
+	Docs_Search_FullMethodName                     = "/Docs/Search"
+	Docs_Update_FullMethodName                     = "/Docs/Update"
+	Docs_GetByID_FullMethodName                    = "/Docs/GetByID"
+	Docs_TransitionDoc_FullMethodName              = "/Docs/TransitionDoc"
+	Docs_GetTransitions_FullMethodName             = "/Docs/GetTransitions"
+	Docs_CreateCommission_FullMethodName           = "/Docs/CreateCommission"
+	Docs_GetCommissions_FullMethodName             = "/Docs/GetCommissions"
+	Docs_CreateSession_FullMethodName              = "/Docs/CreateSession"
+	Docs_GetSession_FullMethodName                 = "/Docs/GetSession"
+	Docs_GetSessions_FullMethodName                = "/Docs/GetSessions"
+	Docs_DeleteSession_FullMethodName              = "/Docs/DeleteSession"
+	Docs_AssignSlot_FullMethodName                 = "/Docs/AssignSlot"
+	Docs_SubmitReview_FullMethodName               = "/Docs/SubmitReview"
+	Docs_SubmitFeedback_FullMethodName             = "/Docs/SubmitFeedback"
+	Docs_SubmitProtocol_FullMethodName             = "/Docs/SubmitProtocol"
+	Docs_GetAssessment_FullMethodName              = "/Docs/GetAssessment"
+	Docs_GetReviewFile_FullMethodName              = "/Docs/GetReviewFile"
+	Docs_GetProtocolPDF_FullMethodName             = "/Docs/GetProtocolPDF"
+	Docs_FindSimilarThemes_FullMethodName          = "/Docs/FindSimilarThemes"
+	Docs_UploadAttachment_FullMethodName           = "/Docs/UploadAttachment"
+	Docs_GetAttachments_FullMethodName             = "/Docs/GetAttachments"
+	Docs_GetAttachmentFile_FullMethodName          = "/Docs/GetAttachmentFile"
+	Docs_DeleteAttachment_FullMethodName           = "/Docs/DeleteAttachment"
+	Docs_GetStats_FullMethodName                   = "/Docs/GetStats"
+	Docs_GetWorkload_FullMethodName                = "/Docs/GetWorkload"
+	Docs_SetWorkloadLimit_FullMethodName           = "/Docs/SetWorkloadLimit"
+	Docs_SuggestReviewers_FullMethodName           = "/Docs/SuggestReviewers"
+	Docs_SuggestReviewerAssignments_FullMethodName = "/Docs/SuggestReviewerAssignments"
+	Docs_CreateOrder_FullMethodName                = "/Docs/CreateOrder"
+	Docs_GetOrder_FullMethodName                   = "/Docs/GetOrder"
+	Docs_GetOrders_FullMethodName                  = "/Docs/GetOrders"
+	Docs_GetOrderFile_FullMethodName               = "/Docs/GetOrderFile"
+	Docs_AttachDocsToOrder_FullMethodName          = "/Docs/AttachDocsToOrder"
+	Docs_DetachDocsFromOrder_FullMethodName        = "/Docs/DetachDocsFromOrder"
 )
 
 // DocsClient is the client API for Docs service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type DocsClient interface {
-	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*SuccessResponse, error)
+	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error)
 	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*SuccessResponse, error)
 	GetFiltered(ctx context.Context, in *GetFilteredRequest, opts ...grpc.CallOption) (*GetResponse, error)
 	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*GetResponse, error)
-	Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*SuccessResponse, error)
+	Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error)
+	GetByID(ctx context.Context, in *GetByIDRequest, opts ...grpc.CallOption) (*Doc, error)
+	TransitionDoc(ctx context.Context, in *TransitionDocRequest, opts ...grpc.CallOption) (*TransitionDocResponse, error)
+	GetTransitions(ctx context.Context, in *GetTransitionsRequest, opts ...grpc.CallOption) (*GetTransitionsResponse, error)
+	CreateCommission(ctx context.Context, in *CreateCommissionRequest, opts ...grpc.CallOption) (*Commission, error)
+	GetCommissions(ctx context.Context, in *GetCommissionsRequest, opts ...grpc.CallOption) (*GetCommissionsResponse, error)
+	CreateSession(ctx context.Context, in *CreateSessionRequest, opts ...grpc.CallOption) (*Session, error)
+	GetSession(ctx context.Context, in *GetSessionRequest, opts ...grpc.CallOption) (*Session, error)
+	GetSessions(ctx context.Context, in *GetSessionsRequest, opts ...grpc.CallOption) (*GetSessionsResponse, error)
+	DeleteSession(ctx context.Context, in *DeleteSessionRequest, opts ...grpc.CallOption) (*SuccessResponse, error)
+	AssignSlot(ctx context.Context, in *AssignSlotRequest, opts ...grpc.CallOption) (*Slot, error)
+	SubmitReview(ctx context.Context, in *SubmitReviewRequest, opts ...grpc.CallOption) (*Review, error)
+	SubmitFeedback(ctx context.Context, in *SubmitFeedbackRequest, opts ...grpc.CallOption) (*Feedback, error)
+	SubmitProtocol(ctx context.Context, in *SubmitProtocolRequest, opts ...grpc.CallOption) (*Protocol, error)
+	GetAssessment(ctx context.Context, in *GetAssessmentRequest, opts ...grpc.CallOption) (*Assessment, error)
+	GetReviewFile(ctx context.Context, in *GetReviewFileRequest, opts ...grpc.CallOption) (*File, error)
+	GetProtocolPDF(ctx context.Context, in *GetProtocolPDFRequest, opts ...grpc.CallOption) (*File, error)
+	FindSimilarThemes(ctx context.Context, in *FindSimilarThemesRequest, opts ...grpc.CallOption) (*FindSimilarThemesResponse, error)
+	UploadAttachment(ctx context.Context, in *UploadAttachmentRequest, opts ...grpc.CallOption) (*Attachment, error)
+	GetAttachments(ctx context.Context, in *GetAttachmentsRequest, opts ...grpc.CallOption) (*GetAttachmentsResponse, error)
+	GetAttachmentFile(ctx context.Context, in *AttachmentRequest, opts ...grpc.CallOption) (*File, error)
+	DeleteAttachment(ctx context.Context, in *AttachmentRequest, opts ...grpc.CallOption) (*SuccessResponse, error)
+	GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error)
+	GetWorkload(ctx context.Context, in *GetWorkloadRequest, opts ...grpc.CallOption) (*GetWorkloadResponse, error)
+	SetWorkloadLimit(ctx context.Context, in *SetWorkloadLimitRequest, opts ...grpc.CallOption) (*SuccessResponse, error)
+	SuggestReviewers(ctx context.Context, in *SuggestReviewersRequest, opts ...grpc.CallOption) (*SuggestReviewersResponse, error)
+	SuggestReviewerAssignments(ctx context.Context, in *SuggestReviewerAssignmentsRequest, opts ...grpc.CallOption) (*SuggestReviewerAssignmentsResponse, error)
+	CreateOrder(ctx context.Context, in *CreateOrderRequest, opts ...grpc.CallOption) (*Order, error)
+	GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*Order, error)
+	GetOrders(ctx context.Context, in *GetOrdersRequest, opts ...grpc.CallOption) (*GetOrdersResponse, error)
+	GetOrderFile(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*File, error)
+	AttachDocsToOrder(ctx context.Context, in *OrderDocsRequest, opts ...grpc.CallOption) (*OrderDocsResponse, error)
+	DetachDocsFromOrder(ctx context.Context, in *OrderDocsRequest, opts ...grpc.CallOption) (*OrderDocsResponse, error)
 }
 
 type docsClient struct {
@@ -45,197 +109,1221 @@ func NewDocsClient(cc grpc.ClientConnInterface) DocsClient {
 	return &docsClient{cc}
 }
 
-func (c *docsClient) Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*SuccessResponse, error) {
+func (c *docsClient) Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(SuccessResponse)
+	out := new(CreateResponse)
 	err := c.cc.Invoke(ctx, Docs_Create_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	return out, nil
+}
+
+func (c *docsClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*SuccessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SuccessResponse)
+	err := c.cc.Invoke(ctx, Docs_Delete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) GetFiltered(ctx context.Context, in *GetFilteredRequest, opts ...grpc.CallOption) (*GetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetResponse)
+	err := c.cc.Invoke(ctx, Docs_GetFiltered_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*GetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetResponse)
+	err := c.cc.Invoke(ctx, Docs_Search_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateResponse)
+	err := c.cc.Invoke(ctx, Docs_Update_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) GetByID(ctx context.Context, in *GetByIDRequest, opts ...grpc.CallOption) (*Doc, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Doc)
+	err := c.cc.Invoke(ctx, Docs_GetByID_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) TransitionDoc(ctx context.Context, in *TransitionDocRequest, opts ...grpc.CallOption) (*TransitionDocResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransitionDocResponse)
+	err := c.cc.Invoke(ctx, Docs_TransitionDoc_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) GetTransitions(ctx context.Context, in *GetTransitionsRequest, opts ...grpc.CallOption) (*GetTransitionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTransitionsResponse)
+	err := c.cc.Invoke(ctx, Docs_GetTransitions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) CreateCommission(ctx context.Context, in *CreateCommissionRequest, opts ...grpc.CallOption) (*Commission, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Commission)
+	err := c.cc.Invoke(ctx, Docs_CreateCommission_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) GetCommissions(ctx context.Context, in *GetCommissionsRequest, opts ...grpc.CallOption) (*GetCommissionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCommissionsResponse)
+	err := c.cc.Invoke(ctx, Docs_GetCommissions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) CreateSession(ctx context.Context, in *CreateSessionRequest, opts ...grpc.CallOption) (*Session, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Session)
+	err := c.cc.Invoke(ctx, Docs_CreateSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) GetSession(ctx context.Context, in *GetSessionRequest, opts ...grpc.CallOption) (*Session, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Session)
+	err := c.cc.Invoke(ctx, Docs_GetSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) GetSessions(ctx context.Context, in *GetSessionsRequest, opts ...grpc.CallOption) (*GetSessionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSessionsResponse)
+	err := c.cc.Invoke(ctx, Docs_GetSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) DeleteSession(ctx context.Context, in *DeleteSessionRequest, opts ...grpc.CallOption) (*SuccessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SuccessResponse)
+	err := c.cc.Invoke(ctx, Docs_DeleteSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) AssignSlot(ctx context.Context, in *AssignSlotRequest, opts ...grpc.CallOption) (*Slot, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Slot)
+	err := c.cc.Invoke(ctx, Docs_AssignSlot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) SubmitReview(ctx context.Context, in *SubmitReviewRequest, opts ...grpc.CallOption) (*Review, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Review)
+	err := c.cc.Invoke(ctx, Docs_SubmitReview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) SubmitFeedback(ctx context.Context, in *SubmitFeedbackRequest, opts ...grpc.CallOption) (*Feedback, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Feedback)
+	err := c.cc.Invoke(ctx, Docs_SubmitFeedback_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) SubmitProtocol(ctx context.Context, in *SubmitProtocolRequest, opts ...grpc.CallOption) (*Protocol, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Protocol)
+	err := c.cc.Invoke(ctx, Docs_SubmitProtocol_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) GetAssessment(ctx context.Context, in *GetAssessmentRequest, opts ...grpc.CallOption) (*Assessment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Assessment)
+	err := c.cc.Invoke(ctx, Docs_GetAssessment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) GetReviewFile(ctx context.Context, in *GetReviewFileRequest, opts ...grpc.CallOption) (*File, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(File)
+	err := c.cc.Invoke(ctx, Docs_GetReviewFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) GetProtocolPDF(ctx context.Context, in *GetProtocolPDFRequest, opts ...grpc.CallOption) (*File, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(File)
+	err := c.cc.Invoke(ctx, Docs_GetProtocolPDF_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) FindSimilarThemes(ctx context.Context, in *FindSimilarThemesRequest, opts ...grpc.CallOption) (*FindSimilarThemesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindSimilarThemesResponse)
+	err := c.cc.Invoke(ctx, Docs_FindSimilarThemes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) UploadAttachment(ctx context.Context, in *UploadAttachmentRequest, opts ...grpc.CallOption) (*Attachment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Attachment)
+	err := c.cc.Invoke(ctx, Docs_UploadAttachment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) GetAttachments(ctx context.Context, in *GetAttachmentsRequest, opts ...grpc.CallOption) (*GetAttachmentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAttachmentsResponse)
+	err := c.cc.Invoke(ctx, Docs_GetAttachments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) GetAttachmentFile(ctx context.Context, in *AttachmentRequest, opts ...grpc.CallOption) (*File, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(File)
+	err := c.cc.Invoke(ctx, Docs_GetAttachmentFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) DeleteAttachment(ctx context.Context, in *AttachmentRequest, opts ...grpc.CallOption) (*SuccessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SuccessResponse)
+	err := c.cc.Invoke(ctx, Docs_DeleteAttachment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStatsResponse)
+	err := c.cc.Invoke(ctx, Docs_GetStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) GetWorkload(ctx context.Context, in *GetWorkloadRequest, opts ...grpc.CallOption) (*GetWorkloadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetWorkloadResponse)
+	err := c.cc.Invoke(ctx, Docs_GetWorkload_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) SetWorkloadLimit(ctx context.Context, in *SetWorkloadLimitRequest, opts ...grpc.CallOption) (*SuccessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SuccessResponse)
+	err := c.cc.Invoke(ctx, Docs_SetWorkloadLimit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) SuggestReviewers(ctx context.Context, in *SuggestReviewersRequest, opts ...grpc.CallOption) (*SuggestReviewersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SuggestReviewersResponse)
+	err := c.cc.Invoke(ctx, Docs_SuggestReviewers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) SuggestReviewerAssignments(ctx context.Context, in *SuggestReviewerAssignmentsRequest, opts ...grpc.CallOption) (*SuggestReviewerAssignmentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SuggestReviewerAssignmentsResponse)
+	err := c.cc.Invoke(ctx, Docs_SuggestReviewerAssignments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) CreateOrder(ctx context.Context, in *CreateOrderRequest, opts ...grpc.CallOption) (*Order, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Order)
+	err := c.cc.Invoke(ctx, Docs_CreateOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*Order, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Order)
+	err := c.cc.Invoke(ctx, Docs_GetOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) GetOrders(ctx context.Context, in *GetOrdersRequest, opts ...grpc.CallOption) (*GetOrdersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetOrdersResponse)
+	err := c.cc.Invoke(ctx, Docs_GetOrders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) GetOrderFile(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*File, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(File)
+	err := c.cc.Invoke(ctx, Docs_GetOrderFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) AttachDocsToOrder(ctx context.Context, in *OrderDocsRequest, opts ...grpc.CallOption) (*OrderDocsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrderDocsResponse)
+	err := c.cc.Invoke(ctx, Docs_AttachDocsToOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *docsClient) DetachDocsFromOrder(ctx context.Context, in *OrderDocsRequest, opts ...grpc.CallOption) (*OrderDocsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrderDocsResponse)
+	err := c.cc.Invoke(ctx, Docs_DetachDocsFromOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DocsServer is the server API for Docs service.
+// All implementations must embed UnimplementedDocsServer
+// for forward compatibility.
+type DocsServer interface {
+	Create(context.Context, *CreateRequest) (*CreateResponse, error)
+	Delete(context.Context, *DeleteRequest) (*SuccessResponse, error)
+	GetFiltered(context.Context, *GetFilteredRequest) (*GetResponse, error)
+	Search(context.Context, *SearchRequest) (*GetResponse, error)
+	Update(context.Context, *UpdateRequest) (*UpdateResponse, error)
+	GetByID(context.Context, *GetByIDRequest) (*Doc, error)
+	TransitionDoc(context.Context, *TransitionDocRequest) (*TransitionDocResponse, error)
+	GetTransitions(context.Context, *GetTransitionsRequest) (*GetTransitionsResponse, error)
+	CreateCommission(context.Context, *CreateCommissionRequest) (*Commission, error)
+	GetCommissions(context.Context, *GetCommissionsRequest) (*GetCommissionsResponse, error)
+	CreateSession(context.Context, *CreateSessionRequest) (*Session, error)
+	GetSession(context.Context, *GetSessionRequest) (*Session, error)
+	GetSessions(context.Context, *GetSessionsRequest) (*GetSessionsResponse, error)
+	DeleteSession(context.Context, *DeleteSessionRequest) (*SuccessResponse, error)
+	AssignSlot(context.Context, *AssignSlotRequest) (*Slot, error)
+	SubmitReview(context.Context, *SubmitReviewRequest) (*Review, error)
+	SubmitFeedback(context.Context, *SubmitFeedbackRequest) (*Feedback, error)
+	SubmitProtocol(context.Context, *SubmitProtocolRequest) (*Protocol, error)
+	GetAssessment(context.Context, *GetAssessmentRequest) (*Assessment, error)
+	GetReviewFile(context.Context, *GetReviewFileRequest) (*File, error)
+	GetProtocolPDF(context.Context, *GetProtocolPDFRequest) (*File, error)
+	FindSimilarThemes(context.Context, *FindSimilarThemesRequest) (*FindSimilarThemesResponse, error)
+	UploadAttachment(context.Context, *UploadAttachmentRequest) (*Attachment, error)
+	GetAttachments(context.Context, *GetAttachmentsRequest) (*GetAttachmentsResponse, error)
+	GetAttachmentFile(context.Context, *AttachmentRequest) (*File, error)
+	DeleteAttachment(context.Context, *AttachmentRequest) (*SuccessResponse, error)
+	GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error)
+	GetWorkload(context.Context, *GetWorkloadRequest) (*GetWorkloadResponse, error)
+	SetWorkloadLimit(context.Context, *SetWorkloadLimitRequest) (*SuccessResponse, error)
+	SuggestReviewers(context.Context, *SuggestReviewersRequest) (*SuggestReviewersResponse, error)
+	SuggestReviewerAssignments(context.Context, *SuggestReviewerAssignmentsRequest) (*SuggestReviewerAssignmentsResponse, error)
+	CreateOrder(context.Context, *CreateOrderRequest) (*Order, error)
+	GetOrder(context.Context, *GetOrderRequest) (*Order, error)
+	GetOrders(context.Context, *GetOrdersRequest) (*GetOrdersResponse, error)
+	GetOrderFile(context.Context, *GetOrderRequest) (*File, error)
+	AttachDocsToOrder(context.Context, *OrderDocsRequest) (*OrderDocsResponse, error)
+	DetachDocsFromOrder(context.Context, *OrderDocsRequest) (*OrderDocsResponse, error)
+	mustEmbedUnimplementedDocsServer()
+}
+
+// UnimplementedDocsServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDocsServer struct{}
+
+func (UnimplementedDocsServer) Create(context.Context, *CreateRequest) (*CreateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedDocsServer) Delete(context.Context, *DeleteRequest) (*SuccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedDocsServer) GetFiltered(context.Context, *GetFilteredRequest) (*GetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFiltered not implemented")
+}
+func (UnimplementedDocsServer) Search(context.Context, *SearchRequest) (*GetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (UnimplementedDocsServer) Update(context.Context, *UpdateRequest) (*UpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedDocsServer) GetByID(context.Context, *GetByIDRequest) (*Doc, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetByID not implemented")
+}
+func (UnimplementedDocsServer) TransitionDoc(context.Context, *TransitionDocRequest) (*TransitionDocResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransitionDoc not implemented")
+}
+func (UnimplementedDocsServer) GetTransitions(context.Context, *GetTransitionsRequest) (*GetTransitionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransitions not implemented")
+}
+func (UnimplementedDocsServer) CreateCommission(context.Context, *CreateCommissionRequest) (*Commission, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCommission not implemented")
+}
+func (UnimplementedDocsServer) GetCommissions(context.Context, *GetCommissionsRequest) (*GetCommissionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCommissions not implemented")
+}
+func (UnimplementedDocsServer) CreateSession(context.Context, *CreateSessionRequest) (*Session, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSession not implemented")
+}
+func (UnimplementedDocsServer) GetSession(context.Context, *GetSessionRequest) (*Session, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSession not implemented")
+}
+func (UnimplementedDocsServer) GetSessions(context.Context, *GetSessionsRequest) (*GetSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSessions not implemented")
+}
+func (UnimplementedDocsServer) DeleteSession(context.Context, *DeleteSessionRequest) (*SuccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSession not implemented")
+}
+func (UnimplementedDocsServer) AssignSlot(context.Context, *AssignSlotRequest) (*Slot, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AssignSlot not implemented")
+}
+func (UnimplementedDocsServer) SubmitReview(context.Context, *SubmitReviewRequest) (*Review, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitReview not implemented")
+}
+func (UnimplementedDocsServer) SubmitFeedback(context.Context, *SubmitFeedbackRequest) (*Feedback, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitFeedback not implemented")
+}
+func (UnimplementedDocsServer) SubmitProtocol(context.Context, *SubmitProtocolRequest) (*Protocol, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitProtocol not implemented")
+}
+func (UnimplementedDocsServer) GetAssessment(context.Context, *GetAssessmentRequest) (*Assessment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAssessment not implemented")
+}
+func (UnimplementedDocsServer) GetReviewFile(context.Context, *GetReviewFileRequest) (*File, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReviewFile not implemented")
+}
+func (UnimplementedDocsServer) GetProtocolPDF(context.Context, *GetProtocolPDFRequest) (*File, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProtocolPDF not implemented")
+}
+func (UnimplementedDocsServer) FindSimilarThemes(context.Context, *FindSimilarThemesRequest) (*FindSimilarThemesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindSimilarThemes not implemented")
+}
+func (UnimplementedDocsServer) UploadAttachment(context.Context, *UploadAttachmentRequest) (*Attachment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UploadAttachment not implemented")
+}
+func (UnimplementedDocsServer) GetAttachments(context.Context, *GetAttachmentsRequest) (*GetAttachmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAttachments not implemented")
+}
+func (UnimplementedDocsServer) GetAttachmentFile(context.Context, *AttachmentRequest) (*File, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAttachmentFile not implemented")
+}
+func (UnimplementedDocsServer) DeleteAttachment(context.Context, *AttachmentRequest) (*SuccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAttachment not implemented")
+}
+func (UnimplementedDocsServer) GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
+}
+func (UnimplementedDocsServer) GetWorkload(context.Context, *GetWorkloadRequest) (*GetWorkloadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWorkload not implemented")
+}
+func (UnimplementedDocsServer) SetWorkloadLimit(context.Context, *SetWorkloadLimitRequest) (*SuccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetWorkloadLimit not implemented")
+}
+func (UnimplementedDocsServer) SuggestReviewers(context.Context, *SuggestReviewersRequest) (*SuggestReviewersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuggestReviewers not implemented")
+}
+func (UnimplementedDocsServer) SuggestReviewerAssignments(context.Context, *SuggestReviewerAssignmentsRequest) (*SuggestReviewerAssignmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuggestReviewerAssignments not implemented")
+}
+func (UnimplementedDocsServer) CreateOrder(context.Context, *CreateOrderRequest) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOrder not implemented")
+}
+func (UnimplementedDocsServer) GetOrder(context.Context, *GetOrderRequest) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrder not implemented")
+}
+func (UnimplementedDocsServer) GetOrders(context.Context, *GetOrdersRequest) (*GetOrdersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrders not implemented")
+}
+func (UnimplementedDocsServer) GetOrderFile(context.Context, *GetOrderRequest) (*File, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrderFile not implemented")
+}
+func (UnimplementedDocsServer) AttachDocsToOrder(context.Context, *OrderDocsRequest) (*OrderDocsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AttachDocsToOrder not implemented")
+}
+func (UnimplementedDocsServer) DetachDocsFromOrder(context.Context, *OrderDocsRequest) (*OrderDocsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DetachDocsFromOrder not implemented")
+}
+func (UnimplementedDocsServer) mustEmbedUnimplementedDocsServer() {}
+func (UnimplementedDocsServer) testEmbeddedByValue()              {}
+
+// UnsafeDocsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DocsServer will
+// result in compilation errors.
+type UnsafeDocsServer interface {
+	mustEmbedUnimplementedDocsServer()
+}
+
+func RegisterDocsServer(s grpc.ServiceRegistrar, srv DocsServer) {
+	// If the following call pancis, it indicates UnimplementedDocsServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Docs_ServiceDesc, srv)
+}
+
+func _Docs_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocsServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_Create_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).Create(ctx, req.(*CreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Docs_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocsServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Docs_GetFiltered_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFilteredRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocsServer).GetFiltered(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_GetFiltered_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).GetFiltered(ctx, req.(*GetFilteredRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Docs_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocsServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_Search_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).Search(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Docs_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocsServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_Update_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).Update(ctx, req.(*UpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Docs_GetByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocsServer).GetByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_GetByID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).GetByID(ctx, req.(*GetByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Docs_TransitionDoc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransitionDocRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocsServer).TransitionDoc(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_TransitionDoc_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).TransitionDoc(ctx, req.(*TransitionDocRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Docs_GetTransitions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransitionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocsServer).GetTransitions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_GetTransitions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).GetTransitions(ctx, req.(*GetTransitionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Docs_CreateCommission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCommissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocsServer).CreateCommission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_CreateCommission_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).CreateCommission(ctx, req.(*CreateCommissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Docs_GetCommissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCommissionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocsServer).GetCommissions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_GetCommissions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).GetCommissions(ctx, req.(*GetCommissionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Docs_CreateSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocsServer).CreateSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_CreateSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).CreateSession(ctx, req.(*CreateSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Docs_GetSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocsServer).GetSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_GetSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).GetSession(ctx, req.(*GetSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Docs_GetSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocsServer).GetSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_GetSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).GetSessions(ctx, req.(*GetSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Docs_DeleteSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocsServer).DeleteSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_DeleteSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).DeleteSession(ctx, req.(*DeleteSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Docs_AssignSlot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignSlotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocsServer).AssignSlot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_AssignSlot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).AssignSlot(ctx, req.(*AssignSlotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Docs_SubmitReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocsServer).SubmitReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_SubmitReview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).SubmitReview(ctx, req.(*SubmitReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Docs_SubmitFeedback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitFeedbackRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocsServer).SubmitFeedback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_SubmitFeedback_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).SubmitFeedback(ctx, req.(*SubmitFeedbackRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Docs_SubmitProtocol_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitProtocolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocsServer).SubmitProtocol(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_SubmitProtocol_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).SubmitProtocol(ctx, req.(*SubmitProtocolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Docs_GetAssessment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAssessmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocsServer).GetAssessment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_GetAssessment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).GetAssessment(ctx, req.(*GetAssessmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Docs_GetReviewFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReviewFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocsServer).GetReviewFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_GetReviewFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).GetReviewFile(ctx, req.(*GetReviewFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Docs_GetProtocolPDF_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProtocolPDFRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocsServer).GetProtocolPDF(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_GetProtocolPDF_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).GetProtocolPDF(ctx, req.(*GetProtocolPDFRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Docs_FindSimilarThemes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindSimilarThemesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocsServer).FindSimilarThemes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_FindSimilarThemes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).FindSimilarThemes(ctx, req.(*FindSimilarThemesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Docs_UploadAttachment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadAttachmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocsServer).UploadAttachment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_UploadAttachment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).UploadAttachment(ctx, req.(*UploadAttachmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *docsClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*SuccessResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(SuccessResponse)
-	err := c.cc.Invoke(ctx, Docs_Delete_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _Docs_GetAttachments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAttachmentsRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DocsServer).GetAttachments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_GetAttachments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).GetAttachments(ctx, req.(*GetAttachmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *docsClient) GetFiltered(ctx context.Context, in *GetFilteredRequest, opts ...grpc.CallOption) (*GetResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(GetResponse)
-	err := c.cc.Invoke(ctx, Docs_GetFiltered_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _Docs_GetAttachmentFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AttachmentRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DocsServer).GetAttachmentFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_GetAttachmentFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).GetAttachmentFile(ctx, req.(*AttachmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *docsClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*GetResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(GetResponse)
-	err := c.cc.Invoke(ctx, Docs_Search_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _Docs_DeleteAttachment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AttachmentRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DocsServer).DeleteAttachment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_DeleteAttachment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).DeleteAttachment(ctx, req.(*AttachmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *docsClient) Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*SuccessResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(SuccessResponse)
-	err := c.cc.Invoke(ctx, Docs_Update_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _Docs_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatsRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DocsServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_GetStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).GetStats(ctx, req.(*GetStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// DocsServer is the server API for Docs service.
-// All implementations must embed UnimplementedDocsServer
-// for forward compatibility.
-type DocsServer interface {
-	Create(context.Context, *CreateRequest) (*SuccessResponse, error)
-	Delete(context.Context, *DeleteRequest) (*SuccessResponse, error)
-	GetFiltered(context.Context, *GetFilteredRequest) (*GetResponse, error)
-	Search(context.Context, *SearchRequest) (*GetResponse, error)
-	Update(context.Context, *UpdateRequest) (*SuccessResponse, error)
-	mustEmbedUnimplementedDocsServer()
+func _Docs_GetWorkload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWorkloadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocsServer).GetWorkload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_GetWorkload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).GetWorkload(ctx, req.(*GetWorkloadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// UnimplementedDocsServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedDocsServer struct{}
-
-func (UnimplementedDocsServer) Create(context.Context, *CreateRequest) (*SuccessResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
-}
-func (UnimplementedDocsServer) Delete(context.Context, *DeleteRequest) (*SuccessResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
-}
-func (UnimplementedDocsServer) GetFiltered(context.Context, *GetFilteredRequest) (*GetResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetFiltered not implemented")
-}
-func (UnimplementedDocsServer) Search(context.Context, *SearchRequest) (*GetResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+func _Docs_SetWorkloadLimit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetWorkloadLimitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocsServer).SetWorkloadLimit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_SetWorkloadLimit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).SetWorkloadLimit(ctx, req.(*SetWorkloadLimitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedDocsServer) Update(context.Context, *UpdateRequest) (*SuccessResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+
+func _Docs_SuggestReviewers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestReviewersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocsServer).SuggestReviewers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_SuggestReviewers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).SuggestReviewers(ctx, req.(*SuggestReviewersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedDocsServer) mustEmbedUnimplementedDocsServer() {}
-func (UnimplementedDocsServer) testEmbeddedByValue()              {}
 
-// UnsafeDocsServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to DocsServer will
-// result in compilation errors.
-type UnsafeDocsServer interface {
-	mustEmbedUnimplementedDocsServer()
+func _Docs_SuggestReviewerAssignments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestReviewerAssignmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocsServer).SuggestReviewerAssignments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_SuggestReviewerAssignments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).SuggestReviewerAssignments(ctx, req.(*SuggestReviewerAssignmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterDocsServer(s grpc.ServiceRegistrar, srv DocsServer) {
-	// If the following call pancis, it indicates UnimplementedDocsServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _Docs_CreateOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&Docs_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(DocsServer).CreateOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Docs_CreateOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocsServer).CreateOrder(ctx, req.(*CreateOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Docs_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateRequest)
+func _Docs_GetOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DocsServer).Create(ctx, in)
+		return srv.(DocsServer).GetOrder(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Docs_Create_FullMethodName,
+		FullMethod: Docs_GetOrder_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DocsServer).Create(ctx, req.(*CreateRequest))
+		return srv.(DocsServer).GetOrder(ctx, req.(*GetOrderRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Docs_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteRequest)
+func _Docs_GetOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrdersRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DocsServer).Delete(ctx, in)
+		return srv.(DocsServer).GetOrders(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Docs_Delete_FullMethodName,
+		FullMethod: Docs_GetOrders_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DocsServer).Delete(ctx, req.(*DeleteRequest))
+		return srv.(DocsServer).GetOrders(ctx, req.(*GetOrdersRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Docs_GetFiltered_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetFilteredRequest)
+func _Docs_GetOrderFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DocsServer).GetFiltered(ctx, in)
+		return srv.(DocsServer).GetOrderFile(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Docs_GetFiltered_FullMethodName,
+		FullMethod: Docs_GetOrderFile_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DocsServer).GetFiltered(ctx, req.(*GetFilteredRequest))
+		return srv.(DocsServer).GetOrderFile(ctx, req.(*GetOrderRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Docs_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SearchRequest)
+func _Docs_AttachDocsToOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderDocsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DocsServer).Search(ctx, in)
+		return srv.(DocsServer).AttachDocsToOrder(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Docs_Search_FullMethodName,
+		FullMethod: Docs_AttachDocsToOrder_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DocsServer).Search(ctx, req.(*SearchRequest))
+		return srv.(DocsServer).AttachDocsToOrder(ctx, req.(*OrderDocsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Docs_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateRequest)
+func _Docs_DetachDocsFromOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderDocsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DocsServer).Update(ctx, in)
+		return srv.(DocsServer).DetachDocsFromOrder(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Docs_Update_FullMethodName,
+		FullMethod: Docs_DetachDocsFromOrder_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DocsServer).Update(ctx, req.(*UpdateRequest))
+		return srv.(DocsServer).DetachDocsFromOrder(ctx, req.(*OrderDocsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -267,6 +1355,134 @@ var Docs_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Update",
 			Handler:    _Docs_Update_Handler,
 		},
+		{
+			MethodName: "GetByID",
+			Handler:    _Docs_GetByID_Handler,
+		},
+		{
+			MethodName: "TransitionDoc",
+			Handler:    _Docs_TransitionDoc_Handler,
+		},
+		{
+			MethodName: "GetTransitions",
+			Handler:    _Docs_GetTransitions_Handler,
+		},
+		{
+			MethodName: "CreateCommission",
+			Handler:    _Docs_CreateCommission_Handler,
+		},
+		{
+			MethodName: "GetCommissions",
+			Handler:    _Docs_GetCommissions_Handler,
+		},
+		{
+			MethodName: "CreateSession",
+			Handler:    _Docs_CreateSession_Handler,
+		},
+		{
+			MethodName: "GetSession",
+			Handler:    _Docs_GetSession_Handler,
+		},
+		{
+			MethodName: "GetSessions",
+			Handler:    _Docs_GetSessions_Handler,
+		},
+		{
+			MethodName: "DeleteSession",
+			Handler:    _Docs_DeleteSession_Handler,
+		},
+		{
+			MethodName: "AssignSlot",
+			Handler:    _Docs_AssignSlot_Handler,
+		},
+		{
+			MethodName: "SubmitReview",
+			Handler:    _Docs_SubmitReview_Handler,
+		},
+		{
+			MethodName: "SubmitFeedback",
+			Handler:    _Docs_SubmitFeedback_Handler,
+		},
+		{
+			MethodName: "SubmitProtocol",
+			Handler:    _Docs_SubmitProtocol_Handler,
+		},
+		{
+			MethodName: "GetAssessment",
+			Handler:    _Docs_GetAssessment_Handler,
+		},
+		{
+			MethodName: "GetReviewFile",
+			Handler:    _Docs_GetReviewFile_Handler,
+		},
+		{
+			MethodName: "GetProtocolPDF",
+			Handler:    _Docs_GetProtocolPDF_Handler,
+		},
+		{
+			MethodName: "FindSimilarThemes",
+			Handler:    _Docs_FindSimilarThemes_Handler,
+		},
+		{
+			MethodName: "UploadAttachment",
+			Handler:    _Docs_UploadAttachment_Handler,
+		},
+		{
+			MethodName: "GetAttachments",
+			Handler:    _Docs_GetAttachments_Handler,
+		},
+		{
+			MethodName: "GetAttachmentFile",
+			Handler:    _Docs_GetAttachmentFile_Handler,
+		},
+		{
+			MethodName: "DeleteAttachment",
+			Handler:    _Docs_DeleteAttachment_Handler,
+		},
+		{
+			MethodName: "GetStats",
+			Handler:    _Docs_GetStats_Handler,
+		},
+		{
+			MethodName: "GetWorkload",
+			Handler:    _Docs_GetWorkload_Handler,
+		},
+		{
+			MethodName: "SetWorkloadLimit",
+			Handler:    _Docs_SetWorkloadLimit_Handler,
+		},
+		{
+			MethodName: "SuggestReviewers",
+			Handler:    _Docs_SuggestReviewers_Handler,
+		},
+		{
+			MethodName: "SuggestReviewerAssignments",
+			Handler:    _Docs_SuggestReviewerAssignments_Handler,
+		},
+		{
+			MethodName: "CreateOrder",
+			Handler:    _Docs_CreateOrder_Handler,
+		},
+		{
+			MethodName: "GetOrder",
+			Handler:    _Docs_GetOrder_Handler,
+		},
+		{
+			MethodName: "GetOrders",
+			Handler:    _Docs_GetOrders_Handler,
+		},
+		{
+			MethodName: "GetOrderFile",
+			Handler:    _Docs_GetOrderFile_Handler,
+		},
+		{
+			MethodName: "AttachDocsToOrder",
+			Handler:    _Docs_AttachDocsToOrder_Handler,
+		},
+		{
+			MethodName: "DetachDocsFromOrder",
+			Handler:    _Docs_DetachDocsFromOrder_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "docs/docs.proto",
@@ -0,0 +1,3 @@
+// API_URL is the gateway address. It's set with VITE_API_URL at build
+// time, see .env.production, and defaults to the local gateway.
+export const API_URL: string = import.meta.env.VITE_API_URL ?? "http://localhost:8080";
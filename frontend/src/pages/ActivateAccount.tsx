@@ -1,6 +1,7 @@
 import React, { useEffect, useState } from "react";
 import { useParams, useNavigate } from "react-router-dom";
 import axios from "axios";
+import { API_URL } from "../api";
 
 function ActivateAccount() {
   const navigate = useNavigate();
@@ -19,7 +20,7 @@ function ActivateAccount() {
       setStatus("loading");
       try {
         const response = await axios.post(
-          "http://77.51.223.54:8080/api/v1/auth/activate_account",
+          `${API_URL}/api/v1/auth/activate_account`,
           { link },
           { headers: { "Content-Type": "application/json" } }
         );
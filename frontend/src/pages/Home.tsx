@@ -4,6 +4,7 @@ import { useState, useEffect, useRef } from "react";
 import LoadingScreen from "../components/LoadingScreen";
 import AppBackground from "../components/AppBackground";
 import Cookies from "js-cookie";
+import { API_URL } from "../api";
 
 export default function Home() {
   const navigate = useNavigate();
@@ -78,7 +79,7 @@ export default function Home() {
       navigate("/login", { replace: true });
       return;
     }
-    fetch("http://77.51.223.54:8080/api/v1/auth/logout", {
+    fetch(`${API_URL}/api/v1/auth/logout`, {
       method: "POST",
       headers: {
         "Content-Type": "application/json",
@@ -110,7 +111,7 @@ export default function Home() {
     setDocsError(null);
     const accessToken = Cookies.get("access_token");
     try {
-      const resp = await fetch("http://77.51.223.54:8080/api/v1/docs/search", {
+      const resp = await fetch(`${API_URL}/api/v1/docs/search`, {
         method: "POST",
         headers: {
           "Content-Type": "application/json",
@@ -139,7 +140,7 @@ export default function Home() {
     setDocsError(null);
     const accessToken = Cookies.get("access_token");
     try {
-      const resp = await fetch("http://77.51.223.54:8080/api/v1/docs/filtered", {
+      const resp = await fetch(`${API_URL}/api/v1/docs/filtered`, {
         method: "POST",
         headers: {
           "Content-Type": "application/json",
@@ -215,7 +216,7 @@ export default function Home() {
     }
 
     try {
-      const resp = await fetch("http://77.51.223.54:8080/api/v1/docs/delete", {
+      const resp = await fetch(`${API_URL}/api/v1/docs/delete`, {
         method: "POST",
         headers: {
           "Content-Type": "application/json",
@@ -286,7 +287,7 @@ export default function Home() {
     
     const accessToken = Cookies.get("access_token");
     try {
-      const resp = await fetch("http://77.51.223.54:8080/api/v1/docs/update", {
+      const resp = await fetch(`${API_URL}/api/v1/docs/update`, {
         method: "POST",
         headers: {
           "Content-Type": "application/json",
@@ -295,14 +296,16 @@ export default function Home() {
         body: JSON.stringify({
           ...editForm,
           id: editingDoc.id,
+          version: editingDoc.version,
           type: editForm.type.toLowerCase()
         }),
       });
       if (resp.ok) {
-        // Обновляем документ в списке
+        const data = await resp.json();
+        // Обновляем документ в списке, новая версия нужна для следующего изменения
         setDocs(prev => prev.map((doc: any) => 
           doc.id === editingDoc.id 
-            ? { ...doc, ...editForm, type: editForm.type.toLowerCase() }
+            ? { ...doc, ...editForm, type: editForm.type.toLowerCase(), version: data.doc?.version ?? doc.version }
             : doc
         ));
         closeEditModal();
@@ -319,7 +322,7 @@ export default function Home() {
     e.preventDefault();
     const accessToken = Cookies.get("access_token");
     try {
-      const resp = await fetch("http://77.51.223.54:8080/api/v1/docs/create", {
+      const resp = await fetch(`${API_URL}/api/v1/docs/create`, {
         method: "POST",
         headers: {
           "Content-Type": "application/json",
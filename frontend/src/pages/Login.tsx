@@ -4,6 +4,7 @@ import AuthLayout from "../components/AuthLayout";
 import TextField from "../components/TextField";
 import styles from "./AuthForm.module.css";
 import Cookies from "js-cookie";
+import { API_URL } from "../api";
 
 export default function Login() {
   const [email, setEmail] = useState("");
@@ -36,7 +37,7 @@ export default function Login() {
     setIsLoading(true);
 
     try {
-      const response = await fetch("http://77.51.223.54:8080/api/v1/auth/login", {
+      const response = await fetch(`${API_URL}/api/v1/auth/login`, {
         method: "POST",
         headers: {
           "Content-Type": "application/json",
@@ -4,6 +4,7 @@ import AuthLayout from "../components/AuthLayout";
 import TextField from "../components/TextField";
 import styles from "./AuthForm.module.css";
 import Cookies from "js-cookie";
+import { API_URL } from "../api";
 
 export default function Registration() {
   const [name, setName] = useState("");
@@ -45,7 +46,7 @@ export default function Registration() {
     setIsLoading(true);
 
     try {
-      const response = await fetch("http://77.51.223.54:8080/api/v1/auth/register", {
+      const response = await fetch(`${API_URL}/api/v1/auth/register`, {
         method: "POST",
         headers: {
           "Content-Type": "application/json",